@@ -0,0 +1,30 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"os"
+
+	"woocommerce-mcp/internal/testutil/fakestore"
+)
+
+// AddrEnv is the environment variable holding the fake store's listen address
+const AddrEnv = "FAKE_STORE_ADDR"
+
+// DefaultAddr is the listen address used when AddrEnv is not set
+const DefaultAddr = ":8091"
+
+// main serves the fake WooCommerce/WordPress store, so the bridge can be
+// exercised end to end without a real store
+func main() {
+	addr := os.Getenv(AddrEnv)
+	if addr == "" {
+		addr = DefaultAddr
+	}
+
+	log.Printf("Fake store listening on %s (consumer_key=%s, consumer_secret=%s)",
+		addr, fakestore.ConsumerKey, fakestore.ConsumerSecret)
+	if err := http.ListenAndServe(addr, fakestore.New().Handler()); err != nil {
+		log.Fatalf("Fake store failed: %v", err)
+	}
+}
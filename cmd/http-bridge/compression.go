@@ -0,0 +1,134 @@
+package main
+
+import (
+	"compress/gzip"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CompressionEnv is the environment variable that turns gzip compression of
+// responses off when set to false
+const CompressionEnv = "HTTP_GZIP_ENABLED"
+
+// compressionEnabled reports whether response compression is enabled. It is
+// on when CompressionEnv is unset; otherwise only a true value keeps it on,
+// and an invalid value such as "off" is logged and turns it off.
+func compressionEnabled() bool {
+	value := os.Getenv(CompressionEnv)
+	if value == "" {
+		return true
+	}
+	enabled, err := strconv.ParseBool(strings.TrimSpace(value))
+	if err != nil {
+		log.Printf("Ignoring invalid %s %q; response compression is off", CompressionEnv, value)
+		return false
+	}
+	return enabled
+}
+
+var gzipWriters = sync.Pool{
+	New: func() interface{} {
+		return gzip.NewWriter(nil)
+	},
+}
+
+// gzipMiddleware compresses JSON responses for clients that accept gzip.
+// Server-Sent Events are passed through untouched so events are not held
+// back in the compressor's buffer.
+func gzipMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !acceptsGzip(c.GetHeader("Accept-Encoding")) || c.Request.Method == "HEAD" {
+			c.Next()
+			return
+		}
+
+		writer := &gzipResponseWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Header("Vary", "Accept-Encoding")
+		defer writer.close()
+
+		c.Next()
+	}
+}
+
+// acceptsGzip reports whether an Accept-Encoding header allows gzip
+func acceptsGzip(acceptEncoding string) bool {
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if !strings.EqualFold(strings.TrimSpace(coding), "gzip") {
+			continue
+		}
+		// gzip;q=0 explicitly refuses the encoding
+		if q, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			weight, err := strconv.ParseFloat(strings.TrimSpace(q), 64)
+			return err == nil && weight > 0
+		}
+		return true
+	}
+	return false
+}
+
+// gzipResponseWriter compresses the body once the handler has set a JSON
+// content type; other responses are written as is
+type gzipResponseWriter struct {
+	gin.ResponseWriter
+	gz      *gzip.Writer
+	decided bool
+}
+
+// decide picks compression on the first body write, once headers are final
+func (w *gzipResponseWriter) decide() {
+	if w.decided {
+		return
+	}
+	w.decided = true
+
+	header := w.ResponseWriter.Header()
+	if header.Get("Content-Encoding") != "" || !strings.Contains(header.Get("Content-Type"), "application/json") {
+		return
+	}
+
+	header.Set("Content-Encoding", "gzip")
+	header.Del("Content-Length")
+	w.gz = gzipWriters.Get().(*gzip.Writer)
+	w.gz.Reset(w.ResponseWriter)
+}
+
+// Write compresses the body when compression was chosen
+func (w *gzipResponseWriter) Write(data []byte) (int, error) {
+	w.decide()
+	if w.gz == nil {
+		return w.ResponseWriter.Write(data)
+	}
+	w.ResponseWriter.WriteHeaderNow()
+	return w.gz.Write(data)
+}
+
+// WriteString compresses the body when compression was chosen
+func (w *gzipResponseWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush flushes compressed data before flushing the connection
+func (w *gzipResponseWriter) Flush() {
+	if w.gz != nil {
+		w.gz.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// close finishes the gzip stream and returns the writer to the pool
+func (w *gzipResponseWriter) close() {
+	if w.gz == nil {
+		return
+	}
+	w.gz.Close()
+	w.gz.Reset(nil)
+	gzipWriters.Put(w.gz)
+	w.gz = nil
+}
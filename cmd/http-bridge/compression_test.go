@@ -0,0 +1,188 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"woocommerce-mcp/internal/testutil/fakestore"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCompressionEnabled(t *testing.T) {
+	cases := map[string]bool{
+		"":      true,
+		"true":  true,
+		"1":     true,
+		"false": false,
+		"0":     false,
+		"off":   false,
+		"no":    false,
+	}
+	for value, want := range cases {
+		t.Setenv(CompressionEnv, value)
+		if got := compressionEnabled(); got != want {
+			t.Errorf("%s=%q: compressionEnabled() = %v, want %v", CompressionEnv, value, got, want)
+		}
+	}
+}
+
+// postGzipCall sends a legacy /call_tool request that accepts gzip and returns
+// the response without letting the client decompress it
+func postGzipCall(t *testing.T, bridgeURL, name string, arguments map[string]interface{}) *http.Response {
+	t.Helper()
+
+	body, err := json.Marshal(map[string]interface{}{"name": name, "arguments": arguments})
+	if err != nil {
+		t.Fatalf("encode call: %v", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, bridgeURL+"/call_tool", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("build request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	client := &http.Client{Transport: &http.Transport{DisableCompression: true}}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("call %s: %v", name, err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestCallToolCompressesJSON(t *testing.T) {
+	t.Setenv(CompressionEnv, "")
+	store := fakestore.New()
+	storeServer := store.Start()
+	defer storeServer.Close()
+	bridge := startTestBridge(t)
+
+	resp := postGzipCall(t, bridge.URL, "search_products", map[string]interface{}{
+		"base_url":        storeServer.URL,
+		"consumer_key":    fakestore.ConsumerKey,
+		"consumer_secret": fakestore.ConsumerSecret,
+		"search":          "Sneakers",
+	})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	if got := resp.Header.Get("Content-Encoding"); got != "gzip" {
+		t.Fatalf("Content-Encoding = %q, want gzip", got)
+	}
+
+	reader, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		t.Fatalf("open gzip body: %v", err)
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("decode gzip body: %v", err)
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("decoded body is not JSON: %v\n%s", err, data)
+	}
+	if !strings.Contains(string(data), "Sneakers") {
+		t.Errorf("decoded body = %s, want the matching product", data)
+	}
+}
+
+func TestCallToolSkipsCompressionWhenDisabled(t *testing.T) {
+	t.Setenv(CompressionEnv, "off")
+	store := fakestore.New()
+	storeServer := store.Start()
+	defer storeServer.Close()
+	bridge := startTestBridge(t)
+
+	resp := postGzipCall(t, bridge.URL, "search_products", map[string]interface{}{
+		"base_url":        storeServer.URL,
+		"consumer_key":    fakestore.ConsumerKey,
+		"consumer_secret": fakestore.ConsumerSecret,
+		"search":          "Sneakers",
+	})
+	if got := resp.Header.Get("Content-Encoding"); got != "" {
+		t.Errorf("Content-Encoding = %q with %s=off, want none", got, CompressionEnv)
+	}
+	data, _ := io.ReadAll(resp.Body)
+	if !json.Valid(data) {
+		t.Errorf("body is not plain JSON: %s", data)
+	}
+}
+
+func TestAcceptsGzip(t *testing.T) {
+	cases := map[string]bool{
+		"":                      false,
+		"gzip":                  true,
+		"deflate, GZIP":         true,
+		"br;q=1.0, gzip;q=0.5":  true,
+		"gzip;q=0":              false,
+		"gzip; q=0.0, deflate":  false,
+		"identity":              false,
+		"x-gzip-but-not-really": false,
+	}
+	for header, want := range cases {
+		if got := acceptsGzip(header); got != want {
+			t.Errorf("acceptsGzip(%q) = %v, want %v", header, got, want)
+		}
+	}
+}
+
+func TestEventStreamIsNotCompressed(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(gzipMiddleware())
+	router.GET("/events", func(c *gin.Context) {
+		c.Header("Content-Type", "text/event-stream")
+		c.String(http.StatusOK, "data: {\"ok\":true}\n\n")
+		c.Writer.Flush()
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/events", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	if got := recorder.Header().Get("Content-Encoding"); got != "" {
+		t.Errorf("Content-Encoding = %q for an event stream, want none", got)
+	}
+	if body := recorder.Body.String(); body != "data: {\"ok\":true}\n\n" {
+		t.Errorf("body = %q, want the event passed through", body)
+	}
+}
+
+func TestListToolsIsCompressed(t *testing.T) {
+	t.Setenv(CompressionEnv, "")
+	bridge := startTestBridge(t)
+
+	req, err := http.NewRequest(http.MethodGet, bridge.URL+"/list_tools", nil)
+	if err != nil {
+		t.Fatalf("build request: %v", err)
+	}
+	req.Header.Set("Accept-Encoding", "gzip")
+	client := &http.Client{Transport: &http.Transport{DisableCompression: true}}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("list tools: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if got := resp.Header.Get("Content-Encoding"); got != "gzip" {
+		t.Fatalf("Content-Encoding = %q, want gzip", got)
+	}
+	reader, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		t.Fatalf("open gzip body: %v", err)
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil || !json.Valid(data) {
+		t.Errorf("decoded body is not JSON (%v): %s", err, data)
+	}
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"os"
+	"strconv"
+
+	customer_presentation "woocommerce-mcp/internal/customer/presentation"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// CustomerToolsEnv enables the customer tools. They expose personal data
+// (names and email addresses), so they are off unless explicitly enabled.
+const CustomerToolsEnv = "ENABLE_CUSTOMER_TOOLS"
+
+// customerToolsEnabled reports whether the customer tools are enabled; an
+// unset or invalid value keeps them disabled
+func customerToolsEnabled() bool {
+	enabled, err := strconv.ParseBool(os.Getenv(CustomerToolsEnv))
+	return err == nil && enabled
+}
+
+// registerCustomerTools adds the customer tools to the MCP server and the
+// bridge's tool registry
+func registerCustomerTools(mcpServer *mcp.Server, tools *toolRegistry, searchHandler *customer_presentation.SearchCustomersHandler, getHandler *customer_presentation.GetCustomerHandler) {
+	addTool(mcpServer, tools, searchHandler, searchHandler.ExecuteMCPTool)
+	addTool(mcpServer, tools, getHandler, getHandler.ExecuteMCPTool)
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"woocommerce-mcp/internal/testutil/fakestore"
+)
+
+// listedToolNames returns the tool names of the legacy /list_tools endpoint
+func listedToolNames(t *testing.T, bridgeURL string) map[string]bool {
+	t.Helper()
+
+	resp, err := http.Get(bridgeURL + "/list_tools")
+	if err != nil {
+		t.Fatalf("list tools: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	var listed struct {
+		Tools []struct {
+			Name string `json:"name"`
+		} `json:"tools"`
+	}
+	if err := json.Unmarshal(body, &listed); err != nil {
+		t.Fatalf("decode tools %s: %v", body, err)
+	}
+	names := make(map[string]bool)
+	for _, tool := range listed.Tools {
+		names[tool.Name] = true
+	}
+	return names
+}
+
+func TestCustomerToolsDisabledByDefault(t *testing.T) {
+	t.Setenv(CustomerToolsEnv, "")
+	bridge := startTestBridge(t)
+
+	names := listedToolNames(t, bridge.URL)
+	if names["search_customers"] || names["get_customer"] {
+		t.Errorf("customer tools listed without %s: %v", CustomerToolsEnv, names)
+	}
+
+	status, body := postLegacyCall(t, bridge.URL, "search_customers", map[string]interface{}{"email": "jane.doe@example.com"})
+	if status != http.StatusBadRequest || !strings.Contains(body, "UNKNOWN_TOOL") {
+		t.Errorf("disabled search_customers answered %d %s", status, body)
+	}
+
+	response := postJSONRPC(t, bridge.URL, "", toolsCall(1, "get_customer", map[string]interface{}{"customer_id": "201"}))
+	if !strings.Contains(response, "Unknown tool") {
+		t.Errorf("disabled get_customer answered %s", response)
+	}
+}
+
+func TestSearchCustomersByEmail(t *testing.T) {
+	t.Setenv(CustomerToolsEnv, "true")
+	storeServer := fakestore.New().Start()
+	defer storeServer.Close()
+	bridge := startTestBridge(t)
+
+	if names := listedToolNames(t, bridge.URL); !names["search_customers"] || !names["get_customer"] {
+		t.Fatalf("customer tools not listed with %s=true: %v", CustomerToolsEnv, names)
+	}
+
+	status, body := postLegacyCall(t, bridge.URL, "search_customers", map[string]interface{}{
+		"base_url":        storeServer.URL,
+		"consumer_key":    fakestore.ConsumerKey,
+		"consumer_secret": fakestore.ConsumerSecret,
+		"email":           "jane.doe@example.com",
+	})
+	if status != http.StatusOK {
+		t.Fatalf("search_customers answered %d %s", status, body)
+	}
+	if !strings.Contains(body, "jane.doe@example.com") || strings.Contains(body, "john.smith@example.com") {
+		t.Errorf("search by email returned other customers: %s", body)
+	}
+	if !strings.Contains(body, "Found 1 customer(s)") {
+		t.Errorf("search by email did not find exactly one customer: %s", body)
+	}
+}
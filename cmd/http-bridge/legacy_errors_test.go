@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"woocommerce-mcp/internal/testutil/fakestore"
+	kitDomain "woocommerce-mcp/kit/domain"
+	"woocommerce-mcp/kit/retry"
+)
+
+// legacyErrorDetail decodes the structured error of a failed legacy call,
+// checking the human-readable text is still there
+func legacyErrorDetail(t *testing.T, body string) kitDomain.ErrorDetail {
+	t.Helper()
+
+	var result struct {
+		Content []struct {
+			Text string `json:"text"`
+		} `json:"content"`
+		IsError bool                   `json:"isError"`
+		Error   *kitDomain.ErrorDetail `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(body), &result); err != nil {
+		t.Fatalf("decode result: %v\n%s", err, body)
+	}
+	if !result.IsError || result.Error == nil {
+		t.Fatalf("result = %s, want a failed result with a structured error", body)
+	}
+	if len(result.Content) != 1 || result.Content[0].Text == "" {
+		t.Errorf("result = %s, want the text content kept", body)
+	}
+	return *result.Error
+}
+
+func TestLegacyValidationErrorIsStructured(t *testing.T) {
+	server := fakestore.New().Start()
+	defer server.Close()
+	bridge := startTestBridge(t)
+
+	status, body := postLegacyCall(t, bridge.URL, "search_products", map[string]interface{}{
+		"base_url":        server.URL,
+		"consumer_key":    fakestore.ConsumerKey,
+		"consumer_secret": fakestore.ConsumerSecret,
+		"per_page":        "lots",
+	})
+	if status != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", status)
+	}
+	detail := legacyErrorDetail(t, body)
+	if detail.Code != "VALIDATION_ERROR" || detail.Type != "ValidationError" || detail.Status != 0 {
+		t.Errorf("error = %+v, want a VALIDATION_ERROR without an upstream status", detail)
+	}
+	if !strings.Contains(detail.Message, "per_page") {
+		t.Errorf("message = %q, want the invalid field", detail.Message)
+	}
+}
+
+func TestLegacyUpstreamErrorIsStructured(t *testing.T) {
+	t.Setenv(retry.MaxRetriesEnv, "0")
+	store := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"code":"woocommerce_rest_cannot_view","message":"Sorry, you cannot list resources.","data":{"status":403}}`))
+	}))
+	defer store.Close()
+	bridge := startTestBridge(t)
+
+	_, body := postLegacyCall(t, bridge.URL, "search_products", map[string]interface{}{
+		"base_url":        store.URL,
+		"consumer_key":    "ck_read_only",
+		"consumer_secret": "cs_read_only",
+		"search":          "Sneakers",
+	})
+	detail := legacyErrorDetail(t, body)
+	if detail.Code != "woocommerce_rest_cannot_view" || detail.Type != "WooCommerceAPIError" || detail.Status != http.StatusForbidden {
+		t.Errorf("error = %+v, want the store's code with status 403", detail)
+	}
+}
+
+func TestLegacyUnknownToolIsStructured(t *testing.T) {
+	bridge := startTestBridge(t)
+
+	status, body := postLegacyCall(t, bridge.URL, "delete_store", nil)
+	if status != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", status)
+	}
+	if detail := legacyErrorDetail(t, body); detail.Code != "UNKNOWN_TOOL" || detail.Type != "NotFoundError" {
+		t.Errorf("error = %+v, want UNKNOWN_TOOL", detail)
+	}
+}
@@ -5,14 +5,26 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
+	brand_presentation "woocommerce-mcp/internal/brand/presentation"
+	customer_presentation "woocommerce-mcp/internal/customer/presentation"
 	post_presentation "woocommerce-mcp/internal/post/presentation"
+	product_woocommerce "woocommerce-mcp/internal/product/infrastructure/woocommerce"
 	product_presentation "woocommerce-mcp/internal/product/presentation"
+	search_presentation "woocommerce-mcp/internal/search/presentation"
+	server_presentation "woocommerce-mcp/internal/server/presentation"
+	store_presentation "woocommerce-mcp/internal/store/presentation"
+	kitDomain "woocommerce-mcp/kit/domain"
+	"woocommerce-mcp/kit/requestid"
+	"woocommerce-mcp/kit/storeconfig"
+	"woocommerce-mcp/kit/tlsconfig"
 
 	"github.com/gin-gonic/gin"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -20,10 +32,99 @@ import (
 
 // HTTPBridge provides HTTP endpoints that internally use MCP protocol
 type HTTPBridge struct {
-	mcpServer      *mcp.Server
-	router         *gin.Engine
-	productHandler *product_presentation.SearchProductsHandler
-	postHandler    *post_presentation.SearchPostsHandler
+	mcpServer *mcp.Server
+	router    *gin.Engine
+	// tools holds every tool registered with mcpServer; customer tools are
+	// only among them when ENABLE_CUSTOMER_TOOLS is on
+	tools       *toolRegistry
+	inFlight    *inFlightRequests
+	resultCache *toolResultCache
+	closeOnce   sync.Once
+}
+
+// inFlightRequests tracks running tool calls by caller and JSON-RPC request
+// ID so they can be aborted by a notifications/cancelled message. Request IDs
+// are only unique per caller, so a caller can only cancel its own calls.
+type inFlightRequests struct {
+	mu    sync.Mutex
+	next  uint64
+	calls map[string]map[uint64]context.CancelFunc
+}
+
+// newInFlightRequests creates an empty in-flight request registry
+func newInFlightRequests() *inFlightRequests {
+	return &inFlightRequests{
+		calls: make(map[string]map[uint64]context.CancelFunc),
+	}
+}
+
+// callerKey identifies the client of a request: its MCP session when it
+// sends one, otherwise its remote host. The host rather than the address is
+// used since a cancellation may arrive on another connection.
+func callerKey(c *gin.Context) string {
+	if session := c.GetHeader("Mcp-Session-Id"); session != "" {
+		return "session:" + session
+	}
+	host, _, err := net.SplitHostPort(c.Request.RemoteAddr)
+	if err != nil {
+		host = c.Request.RemoteAddr
+	}
+	return "host:" + host
+}
+
+// requestKey combines a caller and a JSON-RPC ID (string or number) into a
+// map key. The ID type is kept so that "1" and 1 stay distinct requests.
+func requestKey(caller string, id interface{}) string {
+	return fmt.Sprintf("%s|%T:%v", caller, id, id)
+}
+
+// add registers the cancel function of a running request and returns the
+// token that removes this registration only
+func (r *inFlightRequests) add(caller string, id interface{}, cancel context.CancelFunc) uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := requestKey(caller, id)
+	if r.calls[key] == nil {
+		r.calls[key] = make(map[uint64]context.CancelFunc)
+	}
+	r.next++
+	r.calls[key][r.next] = cancel
+	return r.next
+}
+
+// remove unregisters a finished request, leaving other calls that reuse its
+// ID registered
+func (r *inFlightRequests) remove(caller string, id interface{}, token uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := requestKey(caller, id)
+	delete(r.calls[key], token)
+	if len(r.calls[key]) == 0 {
+		delete(r.calls, key)
+	}
+}
+
+// cancel aborts the caller's running requests with the given ID, reporting
+// whether any was found
+func (r *inFlightRequests) cancel(caller string, id interface{}) bool {
+	r.mu.Lock()
+	key := requestKey(caller, id)
+	cancels := r.calls[key]
+	delete(r.calls, key)
+	r.mu.Unlock()
+
+	for _, cancel := range cancels {
+		cancel()
+	}
+	return len(cancels) > 0
+}
+
+// CancelledNotificationParams represents the params of a notifications/cancelled message
+type CancelledNotificationParams struct {
+	RequestID interface{} `json:"requestId"`
+	Reason    string      `json:"reason,omitempty"`
 }
 
 // JsonRpcRequest represents a JSON-RPC 2.0 request (compatible with chatbot-service)
@@ -44,9 +145,9 @@ type JsonRpcResponse struct {
 
 // JsonRpcError represents a JSON-RPC 2.0 error
 type JsonRpcError struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
-	Data    string `json:"data,omitempty"`
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
 }
 
 // CallToolRequest represents the call tool request format
@@ -66,30 +167,75 @@ func NewHTTPBridge() *HTTPBridge {
 	// Create handlers
 	productHandler := product_presentation.NewSearchProductsHandler()
 	postHandler := post_presentation.NewSearchPostsHandler()
+	brandHandler := brand_presentation.NewListBrandsHandler()
+	storeHandler := store_presentation.NewStoreInfoHandler()
+	storeConfigHandler := store_presentation.NewStoreConfigHandler()
+	variationHandler := product_presentation.NewGetVariationHandler()
+	credentialsHandler := store_presentation.NewVerifyCredentialsHandler()
+	relatedHandler := product_presentation.NewGetRelatedProductsHandler()
+	productsHandler := product_presentation.NewGetProductsHandler()
+	breadcrumbHandler := product_presentation.NewGetProductBreadcrumbHandler()
+	categoryMenuHandler := product_presentation.NewCategoryMenuHandler()
+	postCategoriesHandler := post_presentation.NewListPostCategoriesHandler()
+	postTagsHandler := post_presentation.NewListPostTagsHandler()
+	resolvePostURLHandler := post_presentation.NewResolvePostURLHandler()
+	trendingHandler := product_presentation.NewTrendingProductsHandler()
+	searchAllHandler := search_presentation.NewSearchAllHandler()
+	searchCustomersHandler := customer_presentation.NewSearchCustomersHandler()
+	getCustomerHandler := customer_presentation.NewGetCustomerHandler()
 
 	// Create MCP server
-	mcpServer := mcp.NewServer(&mcp.Implementation{
+	implementation := &mcp.Implementation{
 		Name:    "woocommerce-mcp",
 		Version: "1.0.0",
-	}, nil)
+	}
+	mcpServer := mcp.NewServer(implementation, nil)
 
-	// Register tools using handlers
-	mcp.AddTool(mcpServer, productHandler.GetToolDefinition(), func(ctx context.Context, req *mcp.CallToolRequest, input product_presentation.SearchProductsInput) (*mcp.CallToolResult, product_presentation.SearchProductsOutput, error) {
-		return productHandler.ExecuteMCPTool(ctx, req, input)
+	// The status tool reports the tools the bridge ends up exposing
+	var bridge *HTTPBridge
+	serverStatusHandler := server_presentation.NewServerStatusHandler(implementation, func() []string {
+		return bridge.toolNames()
 	})
 
-	mcp.AddTool(mcpServer, postHandler.GetToolDefinition(), func(ctx context.Context, req *mcp.CallToolRequest, input post_presentation.SearchPostsInput) (*mcp.CallToolResult, post_presentation.SearchPostsOutput, error) {
-		return postHandler.ExecuteMCPTool(ctx, req, input)
-	})
+	// Register tools using handlers. The registry records them for listing
+	// and dispatch, so every registered tool is listed and callable.
+	tools := newToolRegistry()
+	addTool(mcpServer, tools, productHandler, productHandler.ExecuteMCPTool)
+	addTool(mcpServer, tools, postHandler, postHandler.ExecuteMCPTool)
+	addTool(mcpServer, tools, brandHandler, brandHandler.ExecuteMCPTool)
+	addTool(mcpServer, tools, storeHandler, storeHandler.ExecuteMCPTool)
+	addTool(mcpServer, tools, storeConfigHandler, storeConfigHandler.ExecuteMCPTool)
+	addTool(mcpServer, tools, variationHandler, variationHandler.ExecuteMCPTool)
+	addTool(mcpServer, tools, credentialsHandler, credentialsHandler.ExecuteMCPTool)
+	addTool(mcpServer, tools, relatedHandler, relatedHandler.ExecuteMCPTool)
+	addTool(mcpServer, tools, productsHandler, productsHandler.ExecuteMCPTool)
+	addTool(mcpServer, tools, breadcrumbHandler, breadcrumbHandler.ExecuteMCPTool)
+	addTool(mcpServer, tools, categoryMenuHandler, categoryMenuHandler.ExecuteMCPTool)
+	addTool(mcpServer, tools, postCategoriesHandler, postCategoriesHandler.ExecuteMCPTool)
+	addTool(mcpServer, tools, postTagsHandler, postTagsHandler.ExecuteMCPTool)
+	addTool(mcpServer, tools, resolvePostURLHandler, resolvePostURLHandler.ExecuteMCPTool)
+	addTool(mcpServer, tools, trendingHandler, trendingHandler.ExecuteMCPTool)
+	addTool(mcpServer, tools, searchAllHandler, searchAllHandler.ExecuteMCPTool)
+	addTool(mcpServer, tools, serverStatusHandler, serverStatusHandler.ExecuteMCPTool)
+
+	// Customer tools expose personal data and must be enabled explicitly
+	if customerToolsEnabled() {
+		registerCustomerTools(mcpServer, tools, searchCustomersHandler, getCustomerHandler)
+	}
 
 	// Create HTTP router
-	router := gin.Default()
+	router := gin.New()
+	router.Use(gin.LoggerWithFormatter(logFormatter), gin.Recovery(), requestIDMiddleware())
+	if compressionEnabled() {
+		router.Use(gzipMiddleware())
+	}
 
-	bridge := &HTTPBridge{
-		mcpServer:      mcpServer,
-		router:         router,
-		productHandler: productHandler,
-		postHandler:    postHandler,
+	bridge = &HTTPBridge{
+		mcpServer:   mcpServer,
+		router:      router,
+		tools:       tools,
+		inFlight:    newInFlightRequests(),
+		resultCache: newToolResultCacheFromEnv(),
 	}
 
 	bridge.setupRoutes()
@@ -115,7 +261,13 @@ func (b *HTTPBridge) setupRoutes() {
 func (b *HTTPBridge) handleJsonRpc(c *gin.Context) {
 	var request JsonRpcRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
-		b.sendJsonRpcError(c, request.ID, -32700, "Parse error", err.Error())
+		sendJsonRpcError(c, request.ID, -32700, "Parse error", err.Error())
+		return
+	}
+
+	// Notifications carry no ID and expect no response
+	if request.Method == "notifications/cancelled" {
+		b.handleCancelledNotification(c, request)
 		return
 	}
 
@@ -131,24 +283,34 @@ func (b *HTTPBridge) handleJsonRpc(c *gin.Context) {
 	case "tools/call":
 		b.handleToolsCall(c, request)
 	default:
-		b.sendJsonRpcError(c, request.ID, -32601, "Method not found", fmt.Sprintf("Unknown method: %s", request.Method))
+		sendJsonRpcError(c, request.ID, -32601, "Method not found", fmt.Sprintf("Unknown method: %s", request.Method))
+	}
+}
+
+// handleCancelledNotification aborts the in-flight tool call named by the notification
+func (b *HTTPBridge) handleCancelledNotification(c *gin.Context, request JsonRpcRequest) {
+	paramsJSON, err := json.Marshal(request.Params)
+	if err == nil {
+		var params CancelledNotificationParams
+		if err := json.Unmarshal(paramsJSON, &params); err == nil && params.RequestID != nil {
+			if b.inFlight.cancel(callerKey(c), params.RequestID) {
+				requestid.Logf(c.Request.Context(), "Cancelled request %v: %s", params.RequestID, params.Reason)
+			}
+		}
 	}
+
+	// Unknown or already finished requests are ignored, as the spec requires
+	c.Status(http.StatusAccepted)
+}
+
+// toolNames returns the names of every tool the bridge serves
+func (b *HTTPBridge) toolNames() []string {
+	return b.tools.names()
 }
 
 // handleToolsList handles the tools/list JSON-RPC method
 func (b *HTTPBridge) handleToolsList(c *gin.Context, request JsonRpcRequest) {
-	tools := []map[string]interface{}{
-		{
-			"name":        "search_products",
-			"description": "Search for products in WooCommerce store. Supports various filters like search terms, categories, tags, status, and more.",
-			"inputSchema": b.productHandler.GetInputSchema(),
-		},
-		{
-			"name":        "search_posts",
-			"description": "Search for blog posts in WordPress sites. Supports various filters like search terms, categories, tags, author, status, and more.",
-			"inputSchema": b.postHandler.GetInputSchema(),
-		},
-	}
+	tools := b.tools.listed()
 
 	response := JsonRpcResponse{
 		JsonRpc: "2.0",
@@ -156,7 +318,7 @@ func (b *HTTPBridge) handleToolsList(c *gin.Context, request JsonRpcRequest) {
 		ID:      request.ID,
 	}
 
-	b.sendSSEResponse(c, response)
+	sendSSEResponse(c, response)
 }
 
 // handleToolsCall handles the tools/call JSON-RPC method
@@ -164,32 +326,60 @@ func (b *HTTPBridge) handleToolsCall(c *gin.Context, request JsonRpcRequest) {
 	// Parse params
 	paramsJSON, err := json.Marshal(request.Params)
 	if err != nil {
-		b.sendJsonRpcError(c, request.ID, -32602, "Invalid params", err.Error())
+		sendJsonRpcError(c, request.ID, -32602, "Invalid params", err.Error())
 		return
 	}
 
 	var callRequest CallToolRequest
 	if err := json.Unmarshal(paramsJSON, &callRequest); err != nil {
-		b.sendJsonRpcError(c, request.ID, -32602, "Invalid params", err.Error())
+		sendJsonRpcError(c, request.ID, -32602, "Invalid params", err.Error())
 		return
 	}
 
-	// Handle different tools using handlers
-	switch callRequest.Name {
-	case "search_products":
-		b.productHandler.HandleJSONRPC(c, request.ID, callRequest.Arguments)
-	case "search_posts":
-		b.postHandler.HandleJSONRPC(c, request.ID, callRequest.Arguments)
-	default:
-		b.sendJsonRpcError(c, request.ID, -32601, "Unknown tool", fmt.Sprintf("Tool '%s' not found", callRequest.Name))
+	// Answer a repeated identical call from the result cache when enabled
+	if b.resultCache != nil {
+		if key, ok := b.resultCache.key(callRequest.Name, callRequest.Arguments); ok {
+			if result, hit := b.resultCache.get(key, time.Now()); hit {
+				sendSSEResponse(c, JsonRpcResponse{JsonRpc: "2.0", Result: result, ID: request.ID})
+				return
+			}
+
+			// Only successful results are cached
+			recorder := recordResponse(c)
+			defer func() {
+				if result, ok := recorder.jsonRPCResult(); ok {
+					b.resultCache.put(key, result, time.Now())
+				}
+			}()
+		}
+	}
+
+	// Make the call cancellable by a notifications/cancelled message
+	if request.ID != nil {
+		ctx, cancel := context.WithCancel(c.Request.Context())
+		caller := callerKey(c)
+		token := b.inFlight.add(caller, request.ID, cancel)
+		defer func() {
+			b.inFlight.remove(caller, request.ID, token)
+			cancel()
+		}()
+		c.Request = c.Request.WithContext(ctx)
+	}
+
+	tool, ok := b.tools.lookup(callRequest.Name)
+	if !ok {
+		sendJsonRpcError(c, request.ID, -32601, "Unknown tool", fmt.Sprintf("Tool '%s' not found", callRequest.Name))
+		return
 	}
+	tool.HandleJSONRPC(c, request.ID, callRequest.Arguments)
+
 }
 
 // sendSSEResponse sends a JSON-RPC response as Server-Sent Event
-func (b *HTTPBridge) sendSSEResponse(c *gin.Context, response JsonRpcResponse) {
+func sendSSEResponse(c *gin.Context, response JsonRpcResponse) {
 	responseData, err := json.Marshal(response)
 	if err != nil {
-		b.sendJsonRpcError(c, response.ID, -32603, "Internal error", err.Error())
+		sendJsonRpcError(c, response.ID, -32603, "Internal error", err.Error())
 		return
 	}
 
@@ -198,7 +388,7 @@ func (b *HTTPBridge) sendSSEResponse(c *gin.Context, response JsonRpcResponse) {
 }
 
 // sendJsonRpcError sends a JSON-RPC error response as SSE
-func (b *HTTPBridge) sendJsonRpcError(c *gin.Context, id interface{}, code int, message, data string) {
+func sendJsonRpcError(c *gin.Context, id interface{}, code int, message string, data interface{}) {
 	errorResponse := JsonRpcResponse{
 		JsonRpc: "2.0",
 		Error: JsonRpcError{
@@ -215,18 +405,7 @@ func (b *HTTPBridge) sendJsonRpcError(c *gin.Context, id interface{}, code int,
 
 // handleLegacyListTools provides backward compatibility
 func (b *HTTPBridge) handleLegacyListTools(c *gin.Context) {
-	tools := []map[string]interface{}{
-		{
-			"name":        "search_products",
-			"description": "Search for products in WooCommerce store. Supports various filters like search terms, categories, tags, status, and more.",
-			"inputSchema": b.productHandler.GetInputSchema(),
-		},
-		{
-			"name":        "search_posts",
-			"description": "Search for blog posts in WordPress sites. Supports various filters like search terms, categories, tags, author, status, and more.",
-			"inputSchema": b.postHandler.GetInputSchema(),
-		},
-	}
+	tools := b.tools.listed()
 	c.JSON(http.StatusOK, map[string]interface{}{"tools": tools})
 }
 
@@ -237,22 +416,41 @@ func (b *HTTPBridge) handleLegacyCallTool(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, map[string]interface{}{
 			"content": []map[string]interface{}{{"type": "text", "text": fmt.Sprintf("Invalid request format: %v", err)}},
 			"isError": true,
+			"error":   kitDomain.NewErrorDetail("INVALID_REQUEST", "ValidationError", err.Error()),
 		})
 		return
 	}
 
-	// Handle different tools using handlers
-	switch toolCall.Name {
-	case "search_products":
-		b.productHandler.HandleLegacyHTTP(c, toolCall.Arguments)
-	case "search_posts":
-		b.postHandler.HandleLegacyHTTP(c, toolCall.Arguments)
-	default:
+	// Answer a repeated identical call from the result cache when enabled;
+	// streamed exports are never buffered for it
+	if b.resultCache != nil && !isStreamingCall(toolCall.Arguments) {
+		if key, ok := b.resultCache.key(toolCall.Name, toolCall.Arguments); ok {
+			if result, hit := b.resultCache.get(key, time.Now()); hit {
+				c.Data(http.StatusOK, "application/json; charset=utf-8", result)
+				return
+			}
+
+			// Only successful results are cached
+			recorder := recordResponse(c)
+			defer func() {
+				if result, ok := recorder.legacyResult(); ok {
+					b.resultCache.put(key, result, time.Now())
+				}
+			}()
+		}
+	}
+
+	tool, ok := b.tools.lookup(toolCall.Name)
+	if !ok {
 		c.JSON(http.StatusBadRequest, map[string]interface{}{
 			"content": []map[string]interface{}{{"type": "text", "text": fmt.Sprintf("Unknown tool: %s", toolCall.Name)}},
 			"isError": true,
+			"error":   kitDomain.NewErrorDetail("UNKNOWN_TOOL", "NotFoundError", fmt.Sprintf("tool '%s' not found", toolCall.Name)),
 		})
+		return
 	}
+	tool.HandleLegacyHTTP(c, toolCall.Arguments)
+
 }
 
 // Start starts the HTTP bridge server
@@ -267,6 +465,12 @@ func (b *HTTPBridge) Start() error {
 		Handler: b.router,
 	}
 
+	// Only the base URL is logged; the default store's credentials never are
+	if store := storeconfig.Default(); store.BaseURL != "" {
+		log.Printf("Default store: %s", store.BaseURL)
+	}
+	tlsconfig.WarnIfInsecure()
+
 	// Start server in a goroutine
 	go func() {
 		log.Printf("Starting WooCommerce MCP HTTP Bridge on port %s", port)
@@ -287,13 +491,28 @@ func (b *HTTPBridge) Start() error {
 	defer cancel()
 
 	if err := server.Shutdown(ctx); err != nil {
+		b.Close()
 		log.Fatalf("Server forced to shutdown: %v", err)
 	}
+	b.Close()
 
 	log.Println("Server exited")
 	return nil
 }
 
+// Close releases what the bridge holds on to between requests: the cached
+// store clients and their idle keep-alive connections, and the tool result
+// cache. It is called on graceful shutdown, once requests have finished;
+// calling it again is a no-op.
+func (b *HTTPBridge) Close() {
+	b.closeOnce.Do(func() {
+		product_woocommerce.CloseCachedClients()
+		if b.resultCache != nil {
+			b.resultCache.clear()
+		}
+	})
+}
+
 // Run starts the HTTP bridge
 func Run() error {
 	bridge := NewHTTPBridge()
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"woocommerce-mcp/internal/testutil/fakestore"
+
+	"github.com/gin-gonic/gin"
+)
+
+// startTestBridge serves a new bridge on a local test server
+func startTestBridge(t *testing.T) *httptest.Server {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	bridge := NewHTTPBridge()
+	server := httptest.NewServer(bridge.router)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// postJSONRPC sends a JSON-RPC message to the bridge as the given MCP
+// session and returns the response body
+func postJSONRPC(t *testing.T, bridgeURL, session string, message map[string]interface{}) string {
+	t.Helper()
+
+	body, err := json.Marshal(message)
+	if err != nil {
+		t.Fatalf("encode message: %v", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, bridgeURL+"/", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("build request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if session != "" {
+		req.Header.Set("Mcp-Session-Id", session)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Errorf("post %v: %v", message["method"], err)
+		return ""
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+	return string(data)
+}
+
+// toolsCall builds a tools/call message
+func toolsCall(id interface{}, name string, arguments map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  "tools/call",
+		"id":      id,
+		"params":  map[string]interface{}{"name": name, "arguments": arguments},
+	}
+}
+
+// cancelled builds a notifications/cancelled message
+func cancelled(id interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  "notifications/cancelled",
+		"params":  map[string]interface{}{"requestId": id, "reason": "test"},
+	}
+}
+
+// startSlowStore serves the fake store, holding product requests until
+// they are aborted. started receives a value whenever one arrives.
+func startSlowStore(t *testing.T) (string, <-chan struct{}) {
+	t.Helper()
+
+	started := make(chan struct{}, 8)
+	store := fakestore.New().Handler()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/wp-json/wc/v3/products") {
+			started <- struct{}{}
+			<-r.Context().Done()
+			return
+		}
+		store.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server.URL, started
+}
+
+func TestCancelledNotificationAbortsOnlyTheCallersCall(t *testing.T) {
+	storeURL, started := startSlowStore(t)
+	bridge := startTestBridge(t)
+
+	done := make(chan string, 1)
+	go func() {
+		done <- postJSONRPC(t, bridge.URL, "session-a", toolsCall(7, "search_products", map[string]interface{}{
+			"base_url":        storeURL,
+			"consumer_key":    fakestore.ConsumerKey,
+			"consumer_secret": fakestore.ConsumerSecret,
+			"search":          "Sneakers",
+		}))
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the call never reached the store")
+	}
+
+	// Another session reusing the request ID cannot cancel the call
+	postJSONRPC(t, bridge.URL, "session-b", cancelled(7))
+	select {
+	case response := <-done:
+		t.Fatalf("another session cancelled the call: %s", response)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	postJSONRPC(t, bridge.URL, "session-a", cancelled(7))
+	select {
+	case response := <-done:
+		if !strings.Contains(response, `"id":7`) || !strings.Contains(strings.ToLower(response), "cancel") {
+			t.Errorf("cancelled call answered %s", response)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the call was not cancelled")
+	}
+}
+
+func TestInFlightRequestsKeepCallsReusingAnID(t *testing.T) {
+	inFlight := newInFlightRequests()
+	first, cancelFirst := context.WithCancel(context.Background())
+	defer cancelFirst()
+	second, cancelSecond := context.WithCancel(context.Background())
+	defer cancelSecond()
+
+	firstToken := inFlight.add("session:a", 1, cancelFirst)
+	inFlight.add("session:a", 1, cancelSecond)
+
+	// The first call finishing leaves the second cancellable
+	inFlight.remove("session:a", 1, firstToken)
+	if inFlight.cancel("session:a", "1") {
+		t.Error("the string ID \"1\" cancelled the call with the number ID 1")
+	}
+	if inFlight.cancel("session:b", 1) {
+		t.Error("another session cancelled the call")
+	}
+	if !inFlight.cancel("session:a", 1) {
+		t.Fatal("the second call was no longer registered")
+	}
+	if second.Err() == nil {
+		t.Error("the second call was not cancelled")
+	}
+	if first.Err() != nil {
+		t.Error("the finished first call was cancelled again")
+	}
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPostToolsAreRegistered(t *testing.T) {
+	bridge := startTestBridge(t)
+
+	names := listedToolNames(t, bridge.URL)
+	response := postJSONRPC(t, bridge.URL, "", map[string]interface{}{"jsonrpc": "2.0", "method": "tools/list", "id": 1})
+	for _, name := range []string{"list_post_categories", "list_post_tags"} {
+		if !names[name] {
+			t.Errorf("/list_tools does not list %s: %v", name, names)
+		}
+		if !strings.Contains(response, `"name":"`+name+`"`) {
+			t.Errorf("tools/list does not list %s", name)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"woocommerce-mcp/internal/testutil/fakestore"
+)
+
+func TestProductToolsAreRegistered(t *testing.T) {
+	bridge := startTestBridge(t)
+
+	names := listedToolNames(t, bridge.URL)
+	response := postJSONRPC(t, bridge.URL, "", map[string]interface{}{"jsonrpc": "2.0", "method": "tools/list", "id": 1})
+	for _, name := range []string{"get_variation", "get_related_products", "trending_products", "get_products"} {
+		if !names[name] {
+			t.Errorf("/list_tools does not list %s: %v", name, names)
+		}
+		if !strings.Contains(response, `"name":"`+name+`"`) {
+			t.Errorf("tools/list does not list %s", name)
+		}
+	}
+}
+
+func TestGetProductsReportsMissingIDs(t *testing.T) {
+	store := fakestore.New().Start()
+	defer store.Close()
+	bridge := startTestBridge(t)
+
+	status, body := postLegacyCall(t, bridge.URL, "get_products", map[string]interface{}{
+		"base_url":        store.URL,
+		"consumer_key":    fakestore.ConsumerKey,
+		"consumer_secret": fakestore.ConsumerSecret,
+		"product_ids":     []int{3, 99, 1},
+	})
+	if status != 200 {
+		t.Fatalf("get_products answered %d %s", status, body)
+	}
+	for _, want := range []string{`\"id\": 3`, `\"id\": 1`, `\"missing_ids\": [\n    99\n  ]`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("get_products answered %s, want it to contain %s", body, want)
+		}
+	}
+}
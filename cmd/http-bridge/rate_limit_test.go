@@ -0,0 +1,38 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"woocommerce-mcp/kit/retry"
+)
+
+func TestRateLimitSurfacesRetryAfterSeconds(t *testing.T) {
+	t.Setenv(retry.MaxRetriesEnv, "0")
+	store := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"code":"rate_limited","message":"Too many requests"}`))
+	}))
+	defer store.Close()
+	bridge := startTestBridge(t)
+	arguments := map[string]interface{}{
+		"base_url":        store.URL,
+		"consumer_key":    "ck_test",
+		"consumer_secret": "cs_test",
+		"search":          "Sneakers",
+	}
+
+	response := postJSONRPC(t, bridge.URL, "", toolsCall(1, "search_products", arguments))
+	if !strings.Contains(response, `"retry_after_seconds":7`) {
+		t.Errorf("tools/call answered %s, want retry_after_seconds 7 in the error data", response)
+	}
+
+	_, body := postLegacyCall(t, bridge.URL, "search_products", arguments)
+	if detail := legacyErrorDetail(t, body); detail.Status != http.StatusTooManyRequests || detail.RetryAfterSeconds != 7 {
+		t.Errorf("error = %+v, want status 429 with retry_after_seconds 7", detail)
+	}
+}
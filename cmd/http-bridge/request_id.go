@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"woocommerce-mcp/kit/requestid"
+
+	"github.com/gin-gonic/gin"
+)
+
+// requestIDKey is the gin context key holding the request ID of a call
+const requestIDKey = "request_id"
+
+// requestIDMiddleware gives every call a request ID: the caller's
+// X-Request-ID when it is usable, or a generated one. The ID is echoed in the
+// response, carried in the request context so the store requests of the call
+// send it upstream, and included in the call's log lines.
+func requestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestid.Header)
+		if !requestid.Valid(id) {
+			id = requestid.New()
+		}
+
+		c.Set(requestIDKey, id)
+		c.Request = c.Request.WithContext(requestid.WithID(c.Request.Context(), id))
+		c.Header(requestid.Header, id)
+
+		c.Next()
+	}
+}
+
+// logFormatter formats gin's access log like its default formatter, followed
+// by the request ID of the call
+func logFormatter(param gin.LogFormatterParams) string {
+	if param.Latency > time.Minute {
+		param.Latency = param.Latency.Truncate(time.Second)
+	}
+	id, _ := param.Keys[requestIDKey].(string)
+	return fmt.Sprintf("[GIN] %v | %3d | %13v | %15s | %-7s %#v | request_id=%s\n%s",
+		param.TimeStamp.Format("2006/01/02 - 15:04:05"),
+		param.StatusCode,
+		param.Latency,
+		param.ClientIP,
+		param.Method,
+		param.Path,
+		id,
+		param.ErrorMessage,
+	)
+}
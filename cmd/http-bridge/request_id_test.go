@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"woocommerce-mcp/internal/testutil/fakestore"
+	"woocommerce-mcp/kit/requestid"
+)
+
+// callWithRequestID makes a legacy search_products call against the store,
+// sending the request ID given unless it is empty, and returns the ID the
+// bridge echoed
+func callWithRequestID(t *testing.T, bridgeURL, storeURL, search, id string) string {
+	t.Helper()
+	body, _ := json.Marshal(map[string]interface{}{"name": "search_products", "arguments": map[string]interface{}{
+		"base_url":        storeURL,
+		"consumer_key":    fakestore.ConsumerKey,
+		"consumer_secret": fakestore.ConsumerSecret,
+		"search":          search,
+	}})
+	req, _ := http.NewRequest(http.MethodPost, bridgeURL+"/call_tool", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if id != "" {
+		req.Header.Set(requestid.Header, id)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("call: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("call answered %d", resp.StatusCode)
+	}
+	return resp.Header.Get(requestid.Header)
+}
+
+func TestRequestIDIsPropagatedUpstream(t *testing.T) {
+	var mu sync.Mutex
+	upstream := map[string]bool{}
+	store := fakestore.New().Handler()
+	storeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		upstream[r.Header.Get(requestid.Header)] = true
+		mu.Unlock()
+		store.ServeHTTP(w, r)
+	}))
+	defer storeServer.Close()
+	bridge := startTestBridge(t)
+
+	if got := callWithRequestID(t, bridge.URL, storeServer.URL, "boots", "trace-123"); got != "trace-123" {
+		t.Errorf("echoed request ID %q, want the caller's trace-123", got)
+	}
+
+	generated := callWithRequestID(t, bridge.URL, storeServer.URL, "socks", "")
+	if len(generated) != 36 {
+		t.Errorf("echoed request ID %q, want a generated UUID", generated)
+	}
+
+	// an unusable ID is replaced rather than echoed
+	if got := callWithRequestID(t, bridge.URL, storeServer.URL, "sandals", "has spaces"); got == "has spaces" || !requestid.Valid(got) {
+		t.Errorf("echoed request ID %q, want a generated one", got)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !upstream["trace-123"] || !upstream[generated] {
+		t.Errorf("store saw request IDs %v, want trace-123 and %s", upstream, generated)
+	}
+	if upstream[""] {
+		t.Error("a store request was sent without a request ID")
+	}
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Environment variables configuring the tool result cache
+const (
+	ResultCacheTTLEnv  = "TOOL_RESULT_CACHE_TTL"
+	ResultCacheSizeEnv = "TOOL_RESULT_CACHE_SIZE"
+)
+
+// DefaultResultCacheSize caps the number of cached tool results
+const DefaultResultCacheSize = 500
+
+// resultCacheEntry holds a cached tool result and its expiry
+type resultCacheEntry struct {
+	result    json.RawMessage
+	expiresAt time.Time
+}
+
+// toolResultCache memoizes successful tool results for identical calls (same
+// tool name and arguments) for a short time, so a client retrying a call does
+// not hit the store again. Keys are salted hashes, so credentials passed as
+// arguments are never stored in plaintext.
+type toolResultCache struct {
+	mu      sync.Mutex
+	salt    []byte
+	ttl     time.Duration
+	maxSize int
+	entries map[string]*resultCacheEntry
+}
+
+// newToolResultCacheFromEnv creates the result cache configured by the
+// environment. The cache is opt-in: it returns nil unless a positive TTL is set.
+func newToolResultCacheFromEnv() *toolResultCache {
+	value := os.Getenv(ResultCacheTTLEnv)
+	if value == "" {
+		return nil
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl <= 0 {
+		if err != nil {
+			log.Printf("Ignoring invalid %s %q: %v", ResultCacheTTLEnv, value, err)
+		}
+		return nil
+	}
+
+	maxSize := DefaultResultCacheSize
+	if value := os.Getenv(ResultCacheSizeEnv); value != "" {
+		if size, err := strconv.Atoi(value); err == nil && size > 0 {
+			maxSize = size
+		} else {
+			log.Printf("Ignoring invalid %s %q", ResultCacheSizeEnv, value)
+		}
+	}
+
+	return newToolResultCache(ttl, maxSize)
+}
+
+// newToolResultCache creates a result cache with a random per-process salt
+func newToolResultCache(ttl time.Duration, maxSize int) *toolResultCache {
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		panic("failed to generate result cache salt: " + err.Error())
+	}
+
+	return &toolResultCache{
+		salt:    salt,
+		ttl:     ttl,
+		maxSize: maxSize,
+		entries: make(map[string]*resultCacheEntry),
+	}
+}
+
+// key derives the cache key of a tool call. Arguments are marshalled with
+// sorted map keys, so argument order does not matter.
+func (rc *toolResultCache) key(name string, arguments map[string]interface{}) (string, bool) {
+	argsJSON, err := json.Marshal(arguments)
+	if err != nil {
+		return "", false
+	}
+
+	mac := hmac.New(sha256.New, rc.salt)
+	mac.Write([]byte(name))
+	mac.Write([]byte{0})
+	mac.Write(argsJSON)
+	return hex.EncodeToString(mac.Sum(nil)), true
+}
+
+// get returns the cached result for a key, if present and not expired
+func (rc *toolResultCache) get(key string, now time.Time) (json.RawMessage, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	entry, ok := rc.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !now.Before(entry.expiresAt) {
+		delete(rc.entries, key)
+		return nil, false
+	}
+	return entry.result, true
+}
+
+// put stores a result, evicting expired entries and then the entry closest
+// to expiry when the cache is full
+func (rc *toolResultCache) put(key string, result json.RawMessage, now time.Time) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if _, ok := rc.entries[key]; !ok && len(rc.entries) >= rc.maxSize {
+		rc.evictExpired(now)
+		if len(rc.entries) >= rc.maxSize {
+			rc.evictOldest()
+		}
+	}
+
+	rc.entries[key] = &resultCacheEntry{
+		result:    result,
+		expiresAt: now.Add(rc.ttl),
+	}
+}
+
+// evictExpired drops expired entries. The caller must hold the lock.
+func (rc *toolResultCache) evictExpired(now time.Time) {
+	for key, entry := range rc.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(rc.entries, key)
+		}
+	}
+}
+
+// evictOldest drops the entry that expires first. The caller must hold the lock.
+func (rc *toolResultCache) evictOldest() {
+	var oldestKey string
+	var oldest time.Time
+	for key, entry := range rc.entries {
+		if oldestKey == "" || entry.expiresAt.Before(oldest) {
+			oldestKey, oldest = key, entry.expiresAt
+		}
+	}
+	delete(rc.entries, oldestKey)
+}
+
+// recordingWriter passes a response through while keeping a copy of its body
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+// recordResponse replaces the context's writer with a recording one
+func recordResponse(c *gin.Context) *recordingWriter {
+	writer := &recordingWriter{ResponseWriter: c.Writer}
+	c.Writer = writer
+	return writer
+}
+
+// Write records and writes the body
+func (w *recordingWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString records and writes the body
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// jsonRPCResult extracts the result of a successful JSON-RPC SSE response
+func (w *recordingWriter) jsonRPCResult() (json.RawMessage, bool) {
+	data := strings.TrimSpace(strings.TrimPrefix(w.body.String(), "data: "))
+
+	var response struct {
+		Result json.RawMessage `json:"result"`
+		Error  json.RawMessage `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(data), &response); err != nil {
+		return nil, false
+	}
+	if len(response.Error) > 0 || len(response.Result) == 0 {
+		return nil, false
+	}
+	return response.Result, isSuccessfulResult(response.Result)
+}
+
+// legacyResult extracts the result of a successful legacy HTTP response
+func (w *recordingWriter) legacyResult() (json.RawMessage, bool) {
+	if w.Status() != http.StatusOK {
+		return nil, false
+	}
+	result := json.RawMessage(bytes.Clone(w.body.Bytes()))
+	return result, isSuccessfulResult(result)
+}
+
+// isStreamingCall reports whether a legacy call asks for a streamed export,
+// whose response is written page by page and must not be recorded. Like the
+// tool's own arguments, stream may be given as a string, boolean or number.
+func isStreamingCall(arguments map[string]interface{}) bool {
+	var stream string
+	switch value := arguments["stream"].(type) {
+	case string:
+		stream = value
+	case bool:
+		return value
+	case float64:
+		stream = strconv.FormatFloat(value, 'f', -1, 64)
+	case json.Number:
+		stream = value.String()
+	default:
+		return false
+	}
+	streaming, err := strconv.ParseBool(strings.TrimSpace(stream))
+	return err == nil && streaming
+}
+
+// isSuccessfulResult reports whether a tool result is valid and not flagged as an error
+func isSuccessfulResult(result json.RawMessage) bool {
+	var parsed struct {
+		IsError bool `json:"isError"`
+	}
+	if err := json.Unmarshal(result, &parsed); err != nil {
+		return false
+	}
+	return !parsed.IsError
+}
+
+// clear drops every entry
+func (rc *toolResultCache) clear() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	clear(rc.entries)
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"woocommerce-mcp/internal/testutil/fakestore"
+)
+
+// postLegacyCall sends a legacy /call_tool request and returns the status
+// and body of the response
+func postLegacyCall(t *testing.T, bridgeURL, name string, arguments map[string]interface{}) (int, string) {
+	t.Helper()
+
+	body, err := json.Marshal(map[string]interface{}{"name": name, "arguments": arguments})
+	if err != nil {
+		t.Fatalf("encode call: %v", err)
+	}
+	resp, err := http.Post(bridgeURL+"/call_tool", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("call %s: %v", name, err)
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(data)
+}
+
+// productRequests counts the product listings the store served
+func productRequests(store *fakestore.Store) int {
+	count := 0
+	for _, request := range store.Requests() {
+		if strings.HasPrefix(request, "GET /wp-json/wc/v3/products?") {
+			count++
+		}
+	}
+	return count
+}
+
+func TestResultCacheAnswersRepeatedCalls(t *testing.T) {
+	t.Setenv(ResultCacheTTLEnv, "1m")
+	store := fakestore.New()
+	storeServer := store.Start()
+	defer storeServer.Close()
+	bridge := startTestBridge(t)
+
+	arguments := map[string]interface{}{
+		"base_url":        storeServer.URL,
+		"consumer_key":    fakestore.ConsumerKey,
+		"consumer_secret": fakestore.ConsumerSecret,
+		"search":          "Sneakers",
+	}
+	_, first := postLegacyCall(t, bridge.URL, "search_products", arguments)
+	_, second := postLegacyCall(t, bridge.URL, "search_products", arguments)
+	if first != second {
+		t.Errorf("cached result differs:\n%s\n%s", first, second)
+	}
+	if got := productRequests(store); got != 1 {
+		t.Errorf("store served %d product listings, want 1 for a cache hit", got)
+	}
+
+	// Other arguments miss the cache
+	arguments["search"] = "Boots"
+	postLegacyCall(t, bridge.URL, "search_products", arguments)
+	if got := productRequests(store); got != 2 {
+		t.Errorf("store served %d product listings, want 2 after a cache miss", got)
+	}
+
+	// The JSON-RPC endpoint shares the cache, both answering the same result
+	arguments["search"] = "Sneakers"
+	response := postJSONRPC(t, bridge.URL, "", toolsCall(1, "search_products", arguments))
+	if !strings.Contains(response, "Sneakers") || !strings.Contains(response, `"id":1`) {
+		t.Errorf("JSON-RPC call answered %s", response)
+	}
+	if got := productRequests(store); got != 2 {
+		t.Errorf("store served %d product listings, want 2 with the JSON-RPC call cached", got)
+	}
+}
+
+func TestResultCacheSkipsErrors(t *testing.T) {
+	t.Setenv(ResultCacheTTLEnv, "1m")
+	store := fakestore.New()
+	storeServer := store.Start()
+	defer storeServer.Close()
+	bridge := startTestBridge(t)
+
+	arguments := map[string]interface{}{
+		"base_url":        storeServer.URL,
+		"consumer_key":    fakestore.ConsumerKey,
+		"consumer_secret": "cs_wrong",
+		"search":          "Sneakers",
+	}
+	for i := 0; i < 2; i++ {
+		if _, body := postLegacyCall(t, bridge.URL, "search_products", arguments); !strings.Contains(body, `"isError":true`) {
+			t.Fatalf("call with wrong credentials answered %s", body)
+		}
+	}
+	if got := productRequests(store); got != 2 {
+		t.Errorf("store served %d product listings, want 2 since errors are not cached", got)
+	}
+}
+
+func TestResultCacheExpiresAndEvicts(t *testing.T) {
+	cache := newToolResultCache(time.Minute, 2)
+	now := time.Now()
+
+	cache.put("a", json.RawMessage(`{"a":1}`), now)
+	if _, hit := cache.get("a", now.Add(time.Minute)); hit {
+		t.Error("entry served after its TTL")
+	}
+
+	cache.put("b", json.RawMessage(`{"b":1}`), now)
+	cache.put("c", json.RawMessage(`{"c":1}`), now.Add(time.Second))
+	cache.put("d", json.RawMessage(`{"d":1}`), now.Add(2*time.Second))
+	if _, hit := cache.get("b", now.Add(3*time.Second)); hit {
+		t.Error("the entry closest to expiry was not evicted from a full cache")
+	}
+	if _, hit := cache.get("d", now.Add(3*time.Second)); !hit {
+		t.Error("the newest entry is missing")
+	}
+
+	key, _ := cache.key("search_products", map[string]interface{}{"consumer_secret": "cs_secret"})
+	if strings.Contains(key, "cs_secret") {
+		t.Error("the cache key holds the credentials in plaintext")
+	}
+}
+
+func TestIsStreamingCallAcceptsScalars(t *testing.T) {
+	for _, tc := range []struct {
+		stream interface{}
+		want   bool
+	}{
+		{"true", true},
+		{" 1 ", true},
+		{true, true},
+		{float64(1), true},
+		{json.Number("1"), true},
+		{"false", false},
+		{false, false},
+		{float64(0), false},
+		{float64(2), false},
+		{"yes", false},
+		{nil, false},
+	} {
+		if got := isStreamingCall(map[string]interface{}{"stream": tc.stream}); got != tc.want {
+			t.Errorf("isStreamingCall(stream=%#v) = %v, want %v", tc.stream, got, tc.want)
+		}
+	}
+}
+
+func TestResultCacheSkipsStreamedExports(t *testing.T) {
+	t.Setenv(ResultCacheTTLEnv, "1m")
+	store := fakestore.New()
+	storeServer := store.Start()
+	defer storeServer.Close()
+	bridge := startTestBridge(t)
+
+	arguments := map[string]interface{}{
+		"base_url":        storeServer.URL,
+		"consumer_key":    fakestore.ConsumerKey,
+		"consumer_secret": fakestore.ConsumerSecret,
+		"search":          "Sneakers",
+		"stream":          true,
+	}
+	for i := 0; i < 2; i++ {
+		_, body := postLegacyCall(t, bridge.URL, "search_products", arguments)
+		var products []map[string]interface{}
+		if err := json.Unmarshal([]byte(body), &products); err != nil || len(products) != 3 {
+			t.Fatalf("stream=true answered %s", body)
+		}
+	}
+	if got := productRequests(store); got != 2 {
+		t.Errorf("store served %d product listings, want 2 since streamed exports are not cached", got)
+	}
+}
+
+func TestBridgeCloseClearsTheResultCacheOnce(t *testing.T) {
+	t.Setenv(ResultCacheTTLEnv, "1m")
+	bridge := NewHTTPBridge()
+	if bridge.resultCache == nil {
+		t.Fatal("result cache not enabled")
+	}
+	now := time.Now()
+	bridge.resultCache.put("a", json.RawMessage(`{"a":1}`), now)
+
+	bridge.Close()
+	if _, hit := bridge.resultCache.get("a", now); hit {
+		t.Error("a result is still cached after Close")
+	}
+
+	// Closing again is a no-op
+	bridge.resultCache.put("b", json.RawMessage(`{"b":1}`), now)
+	bridge.Close()
+	if _, hit := bridge.resultCache.get("b", now); !hit {
+		t.Error("a second Close cleared the cache again")
+	}
+}
@@ -0,0 +1,18 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSearchAllIsRegistered(t *testing.T) {
+	bridge := startTestBridge(t)
+
+	if names := listedToolNames(t, bridge.URL); !names["search_all"] {
+		t.Errorf("/list_tools does not list search_all: %v", names)
+	}
+	response := postJSONRPC(t, bridge.URL, "", map[string]interface{}{"jsonrpc": "2.0", "method": "tools/list", "id": 1})
+	if !strings.Contains(response, `"name":"search_all"`) {
+		t.Error("tools/list does not list search_all")
+	}
+}
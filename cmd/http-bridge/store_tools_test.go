@@ -0,0 +1,70 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"woocommerce-mcp/internal/testutil/fakestore"
+	"woocommerce-mcp/kit/retry"
+)
+
+func TestStoreToolsAreRegistered(t *testing.T) {
+	bridge := startTestBridge(t)
+
+	names := listedToolNames(t, bridge.URL)
+	response := postJSONRPC(t, bridge.URL, "", map[string]interface{}{"jsonrpc": "2.0", "method": "tools/list", "id": 1})
+	for _, name := range []string{"store_info", "verify_credentials", "store_config"} {
+		if !names[name] {
+			t.Errorf("/list_tools does not list %s: %v", name, names)
+		}
+		if !strings.Contains(response, `"name":"`+name+`"`) {
+			t.Errorf("tools/list does not list %s", name)
+		}
+	}
+}
+
+func TestStoreConfigListsEnabledGateways(t *testing.T) {
+	store := fakestore.New().Start()
+	defer store.Close()
+	bridge := startTestBridge(t)
+
+	status, body := postLegacyCall(t, bridge.URL, "store_config", map[string]interface{}{
+		"base_url":        store.URL,
+		"consumer_key":    fakestore.ConsumerKey,
+		"consumer_secret": fakestore.ConsumerSecret,
+	})
+	if status != http.StatusOK {
+		t.Fatalf("store_config answered %d %s", status, body)
+	}
+	for _, want := range []string{"Direct bank transfer", "Cash on delivery", "Flat rate", "Free shipping"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("store_config answered %s, want it to list %s", body, want)
+		}
+	}
+	if strings.Contains(body, "Check payments") {
+		t.Errorf("store_config lists the disabled check payments: %s", body)
+	}
+}
+
+func TestStoreConfigForbiddenIsStructured(t *testing.T) {
+	t.Setenv(retry.MaxRetriesEnv, "0")
+	store := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"code":"woocommerce_rest_cannot_view","message":"Sorry, you cannot list resources.","data":{"status":403}}`))
+	}))
+	defer store.Close()
+	bridge := startTestBridge(t)
+
+	_, body := postLegacyCall(t, bridge.URL, "store_config", map[string]interface{}{
+		"base_url":        store.URL,
+		"consumer_key":    "ck_read_only",
+		"consumer_secret": "cs_read_only",
+	})
+	detail := legacyErrorDetail(t, body)
+	if detail.Code != "FORBIDDEN" || detail.Type != "ForbiddenError" || !strings.Contains(detail.Message, "administrator") {
+		t.Errorf("error = %+v, want FORBIDDEN asking for an administrator key", detail)
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	kitDomain "woocommerce-mcp/kit/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// toolHandler is what the bridge needs of a tool handler besides its execute
+// function: the MCP definition and the input schema of tools/list
+type toolHandler interface {
+	GetToolDefinition() *mcp.Tool
+	GetInputSchema() map[string]interface{}
+}
+
+// legacyStreamer is implemented by tool handlers that can answer a legacy
+// call by streaming their result. StreamLegacyHTTP reports whether it
+// answered the call; otherwise the call is executed as usual.
+type legacyStreamer[In any] interface {
+	StreamLegacyHTTP(c *gin.Context, input In) bool
+}
+
+// toolResult holds the fields every tool output carries
+type toolResult struct {
+	Message string `json:"message"`
+	Data    string `json:"data"`
+}
+
+// text returns the result as the text content sent to callers
+func (r toolResult) text() string {
+	return fmt.Sprintf("%s\n\n%s", r.Message, r.Data)
+}
+
+// toolAdapter serves the typed execute function of a tool on the JSON-RPC
+// and legacy HTTP endpoints
+type toolAdapter[In, Out any] struct {
+	toolHandler
+	execute mcp.ToolHandlerFor[In, Out]
+}
+
+// decode converts the call arguments to the tool input. On failure it also
+// returns the reason reported to the caller.
+func (a *toolAdapter[In, Out]) decode(arguments map[string]interface{}) (In, string, error) {
+	var input In
+	argsJSON, err := json.Marshal(arguments)
+	if err != nil {
+		return input, "Invalid arguments", err
+	}
+	if err := json.Unmarshal(argsJSON, &input); err != nil {
+		return input, "Invalid input format", err
+	}
+	return input, "", nil
+}
+
+// run executes the tool and returns its message and data
+func (a *toolAdapter[In, Out]) run(ctx context.Context, input In) (toolResult, error) {
+	_, output, err := a.execute(ctx, nil, input)
+	if err != nil {
+		return toolResult{}, err
+	}
+
+	outputJSON, err := json.Marshal(output)
+	if err != nil {
+		return toolResult{}, fmt.Errorf("failed to serialize response: %w", err)
+	}
+	var result toolResult
+	if err := json.Unmarshal(outputJSON, &result); err != nil {
+		return toolResult{}, fmt.Errorf("failed to serialize response: %w", err)
+	}
+	return result, nil
+}
+
+// HandleJSONRPC handles JSON-RPC tool calls
+func (a *toolAdapter[In, Out]) HandleJSONRPC(c *gin.Context, requestID interface{}, arguments map[string]interface{}) {
+	input, reason, err := a.decode(arguments)
+	if err != nil {
+		sendJsonRpcError(c, requestID, -32602, reason, err.Error())
+		return
+	}
+
+	result, err := a.run(c.Request.Context(), input)
+	if err != nil {
+		sendJsonRpcError(c, requestID, -32603, "Tool execution failed", kitDomain.JSONRPCErrorData(err))
+		return
+	}
+
+	// Format response as expected by the message API
+	content := []map[string]interface{}{{"type": "text", "text": result.text()}}
+	sendSSEResponse(c, JsonRpcResponse{
+		JsonRpc: "2.0",
+		Result:  map[string]interface{}{"content": content},
+		ID:      requestID,
+	})
+}
+
+// HandleLegacyHTTP handles legacy HTTP tool calls
+func (a *toolAdapter[In, Out]) HandleLegacyHTTP(c *gin.Context, arguments map[string]interface{}) {
+	input, reason, err := a.decode(arguments)
+	if err != nil {
+		sendLegacyError(c, http.StatusBadRequest, reason, err,
+			kitDomain.NewErrorDetail("INVALID_ARGUMENTS", "ValidationError", err.Error()))
+		return
+	}
+
+	if streamer, ok := a.toolHandler.(legacyStreamer[In]); ok && streamer.StreamLegacyHTTP(c, input) {
+		return
+	}
+
+	result, err := a.run(c.Request.Context(), input)
+	if err != nil {
+		sendLegacyError(c, http.StatusInternalServerError, "Tool execution failed", err, kitDomain.DescribeError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"content": []map[string]interface{}{{"type": "text", "text": result.text()}},
+	})
+}
+
+// sendLegacyError sends a failed tool result to a legacy HTTP caller
+func sendLegacyError(c *gin.Context, status int, message string, err error, detail *kitDomain.ErrorDetail) {
+	c.JSON(status, map[string]interface{}{
+		"content": []map[string]interface{}{{"type": "text", "text": fmt.Sprintf("%s: %v", message, err)}},
+		"isError": true,
+		"error":   detail,
+	})
+}
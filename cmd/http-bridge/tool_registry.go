@@ -0,0 +1,73 @@
+package main
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// bridgeTool is a registered tool as the bridge lists and dispatches it
+type bridgeTool interface {
+	toolHandler
+	HandleJSONRPC(c *gin.Context, requestID interface{}, arguments map[string]interface{})
+	HandleLegacyHTTP(c *gin.Context, arguments map[string]interface{})
+}
+
+// toolRegistry records the tools registered with the MCP server, so the
+// tools/list answers, the legacy list and the dispatch of calls all follow
+// what is actually registered
+type toolRegistry struct {
+	tools  []bridgeTool
+	byName map[string]bridgeTool
+}
+
+// newToolRegistry creates an empty tool registry
+func newToolRegistry() *toolRegistry {
+	return &toolRegistry{
+		byName: make(map[string]bridgeTool),
+	}
+}
+
+// addTool registers a tool with the MCP server and records it in the
+// registry, served on the bridge endpoints by a toolAdapter. execute is the
+// typed MCP handler of the tool, usually its ExecuteMCPTool method.
+func addTool[In, Out any](server *mcp.Server, registry *toolRegistry, handler toolHandler, execute mcp.ToolHandlerFor[In, Out]) {
+	definition := handler.GetToolDefinition()
+	mcp.AddTool(server, definition, execute)
+
+	tool := &toolAdapter[In, Out]{toolHandler: handler, execute: execute}
+
+	if _, seen := registry.byName[definition.Name]; !seen {
+		registry.tools = append(registry.tools, tool)
+	}
+	registry.byName[definition.Name] = tool
+}
+
+// lookup returns the registered tool with the given name
+func (r *toolRegistry) lookup(name string) (bridgeTool, bool) {
+	tool, ok := r.byName[name]
+	return tool, ok
+}
+
+// listed returns the tools/list entries of the registered tools, in
+// registration order
+func (r *toolRegistry) listed() []map[string]interface{} {
+	tools := make([]map[string]interface{}, 0, len(r.tools))
+	for _, tool := range r.tools {
+		definition := tool.GetToolDefinition()
+		tools = append(tools, map[string]interface{}{
+			"name":        definition.Name,
+			"description": definition.Description,
+			"inputSchema": tool.GetInputSchema(),
+		})
+	}
+	return tools
+}
+
+// names returns the names of the registered tools, in registration order
+func (r *toolRegistry) names() []string {
+	names := make([]string, 0, len(r.tools))
+	for _, tool := range r.tools {
+		names = append(names, tool.GetToolDefinition().Name)
+	}
+	return names
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"woocommerce-mcp/kit/toolargs"
+
+	"github.com/gin-gonic/gin"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// echoInput is the input of the echo test tool
+type echoInput struct {
+	Text string `json:"text,omitempty" jsonschema:"Text to echo"`
+}
+
+// echoOutput is the output of the echo test tool
+type echoOutput struct {
+	Message string `json:"message"`
+	Data    string `json:"data"`
+}
+
+// echoHandler is a tool registered only by the tests
+type echoHandler struct{}
+
+func (h *echoHandler) GetToolDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "echo",
+		Description: "Echo the text back",
+		InputSchema: toolargs.InputSchema[echoInput](),
+	}
+}
+
+func (h *echoHandler) GetInputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{"text": map[string]string{"type": "string"}},
+	}
+}
+
+func (h *echoHandler) ExecuteMCPTool(ctx context.Context, req *mcp.CallToolRequest, input echoInput) (*mcp.CallToolResult, echoOutput, error) {
+	return nil, echoOutput{Message: "Echoed", Data: `{"text":"` + input.Text + `"}`}, nil
+}
+
+// startBridgeWithEcho starts a bridge that also registers the echo tool
+func startBridgeWithEcho(t *testing.T) *httptest.Server {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	bridge := NewHTTPBridge()
+	echo := &echoHandler{}
+	addTool(bridge.mcpServer, bridge.tools, echo, echo.ExecuteMCPTool)
+
+	server := httptest.NewServer(bridge.router)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRegisteredToolIsListed(t *testing.T) {
+	bridge := startBridgeWithEcho(t)
+
+	if names := listedToolNames(t, bridge.URL); !names["echo"] || !names["search_products"] {
+		t.Errorf("/list_tools listed %v, want echo besides the built-in tools", names)
+	}
+
+	response := postJSONRPC(t, bridge.URL, "", map[string]interface{}{"jsonrpc": "2.0", "method": "tools/list", "id": 1})
+	if !strings.Contains(response, `"name":"echo"`) || !strings.Contains(response, `"description":"Echo the text back"`) {
+		t.Errorf("tools/list answered %s, want the echo tool", response)
+	}
+}
+
+func TestRegisteredToolIsCallable(t *testing.T) {
+	bridge := startBridgeWithEcho(t)
+
+	response := postJSONRPC(t, bridge.URL, "", toolsCall(1, "echo", map[string]interface{}{"text": "hello"}))
+	if !strings.Contains(response, `Echoed\n\n{\"text\":\"hello\"}`) {
+		t.Errorf("tools/call answered %s", response)
+	}
+
+	status, body := postLegacyCall(t, bridge.URL, "echo", map[string]interface{}{"text": "hello"})
+	if status != 200 || !strings.Contains(body, `Echoed\n\n{\"text\":\"hello\"}`) {
+		t.Errorf("/call_tool answered %d %s", status, body)
+	}
+
+	// Arguments that do not fit the input are rejected before the tool runs
+	status, body = postLegacyCall(t, bridge.URL, "echo", map[string]interface{}{"text": []int{1}})
+	if status != 400 || !strings.Contains(body, "INVALID_ARGUMENTS") {
+		t.Errorf("/call_tool with a bad argument answered %d %s", status, body)
+	}
+}
+
+func TestServerStatusListsRegisteredTools(t *testing.T) {
+	bridge := startBridgeWithEcho(t)
+
+	status, body := postLegacyCall(t, bridge.URL, "server_status", map[string]interface{}{})
+	if status != 200 || !strings.Contains(body, "echo") {
+		t.Errorf("server_status answered %d %s, want the echo tool listed", status, body)
+	}
+}
+
+func TestServerStatusReportsTheVersionAndCoreTools(t *testing.T) {
+	bridge := startTestBridge(t)
+
+	status, body := postLegacyCall(t, bridge.URL, "server_status", map[string]interface{}{})
+	if status != 200 {
+		t.Fatalf("server_status answered %d %s", status, body)
+	}
+	for _, want := range []string{`\"version\": \"1.0.0\"`, `\"search_products\"`, `\"search_posts\"`, `\"server_status\"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("server_status answered %s, want it to contain %s", body, want)
+		}
+	}
+}
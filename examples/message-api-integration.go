@@ -5,26 +5,19 @@ import (
 	"fmt"
 	"log"
 	"os/exec"
+	"strings"
 	"time"
 
+	"woocommerce-mcp/internal/product/application/search_products"
+	"woocommerce-mcp/pkg/client"
+
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
 // MessageAPI demonstrates how your message API would use MCP client
 type MessageAPI struct {
 	mcpClient *mcp.Client
-	session   *mcp.Session
-}
-
-// WooCommerceRequest represents a request to search WooCommerce products
-type WooCommerceRequest struct {
-	BaseURL        string `json:"base_url"`
-	ConsumerKey    string `json:"consumer_key"`
-	ConsumerSecret string `json:"consumer_secret"`
-	Search         string `json:"search,omitempty"`
-	Category       string `json:"category,omitempty"`
-	PerPage        string `json:"per_page,omitempty"`
-	Page           string `json:"page,omitempty"`
+	woo       *client.Client
 }
 
 // NewMessageAPI creates a new message API with MCP client
@@ -54,67 +47,31 @@ func (api *MessageAPI) ConnectToWooCommerceMCP(ctx context.Context) error {
 	// Option 3: Connect via stdio if you spawn the server differently
 	// transport := &mcp.StdioTransport{}
 
-	var err error
-	api.session, err = api.mcpClient.Connect(ctx, transport, nil)
+	session, err := api.mcpClient.Connect(ctx, transport, nil)
 	if err != nil {
 		return fmt.Errorf("failed to connect to WooCommerce MCP: %w", err)
 	}
 
+	// Wrap the session in the typed client
+	api.woo = client.New(session)
+
 	log.Println("Successfully connected to WooCommerce MCP server")
 	return nil
 }
 
 // SearchProducts searches for products using the MCP server
-func (api *MessageAPI) SearchProducts(ctx context.Context, req WooCommerceRequest) (string, error) {
-	if api.session == nil {
-		return "", fmt.Errorf("not connected to MCP server")
-	}
-
-	// Convert request to MCP tool call parameters
-	arguments := map[string]any{
-		"base_url":        req.BaseURL,
-		"consumer_key":    req.ConsumerKey,
-		"consumer_secret": req.ConsumerSecret,
+func (api *MessageAPI) SearchProducts(ctx context.Context, input client.SearchProductsInput) (*search_products.SearchResponse, error) {
+	if api.woo == nil {
+		return nil, fmt.Errorf("not connected to MCP server")
 	}
 
-	// Add optional parameters
-	if req.Search != "" {
-		arguments["search"] = req.Search
-	}
-	if req.Category != "" {
-		arguments["category"] = req.Category
-	}
-	if req.PerPage != "" {
-		arguments["per_page"] = req.PerPage
-	}
-	if req.Page != "" {
-		arguments["page"] = req.Page
-	}
-
-	// Call the search_products tool
-	params := &mcp.CallToolParams{
-		Name:      "search_products",
-		Arguments: arguments,
-	}
-
-	result, err := api.session.CallTool(ctx, params)
+	// The typed client marshals the arguments and decodes the result DTOs
+	response, err := api.woo.SearchProducts(ctx, input)
 	if err != nil {
-		return "", fmt.Errorf("failed to call search_products tool: %w", err)
-	}
-
-	if result.IsError {
-		return "", fmt.Errorf("tool returned error")
+		return nil, fmt.Errorf("failed to call search_products tool: %w", err)
 	}
 
-	// Extract result text
-	var resultText string
-	for _, content := range result.Content {
-		if textContent, ok := content.(*mcp.TextContent); ok {
-			resultText += textContent.Text
-		}
-	}
-
-	return resultText, nil
+	return response, nil
 }
 
 // ProcessMessage simulates processing a user message that requires product search
@@ -123,7 +80,7 @@ func (api *MessageAPI) ProcessMessage(ctx context.Context, userMessage string) (
 
 	// Example: Parse user intent and extract search parameters
 	// In a real implementation, you'd use NLP or pattern matching
-	searchReq := WooCommerceRequest{
+	searchInput := client.SearchProductsInput{
 		BaseURL:        "https://mystore.com", // These would come from config
 		ConsumerKey:    "ck_1234567890abcdef", // or be passed by user
 		ConsumerSecret: "cs_1234567890abcdef", // securely
@@ -132,20 +89,24 @@ func (api *MessageAPI) ProcessMessage(ctx context.Context, userMessage string) (
 	}
 
 	// Search for products
-	searchResult, err := api.SearchProducts(ctx, searchReq)
+	searchResult, err := api.SearchProducts(ctx, searchInput)
 	if err != nil {
 		return "", fmt.Errorf("failed to search products: %w", err)
 	}
 
 	// Format response for user
-	response := fmt.Sprintf("I found these products for you:\n\n%s", searchResult)
+	var lines []string
+	for _, product := range searchResult.Products {
+		lines = append(lines, fmt.Sprintf("- %s (%s)", product.Name, product.Price))
+	}
+	response := fmt.Sprintf("I found these products for you:\n\n%s", strings.Join(lines, "\n"))
 	return response, nil
 }
 
 // Close closes the MCP connection
 func (api *MessageAPI) Close() error {
-	if api.session != nil {
-		return api.session.Close()
+	if api.woo != nil {
+		return api.woo.Close()
 	}
 	return nil
 }
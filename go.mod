@@ -4,8 +4,11 @@ go 1.25
 
 require (
 	github.com/gin-gonic/gin v1.10.1
+	github.com/google/jsonschema-go v0.2.3
+	github.com/google/uuid v1.6.0
 	github.com/jperdior/chatbot-kit v0.1.0
 	github.com/modelcontextprotocol/go-sdk v0.5.0
+	golang.org/x/text v0.16.0
 )
 
 require (
@@ -19,8 +22,6 @@ require (
 	github.com/go-playground/universal-translator v0.18.1 // indirect
 	github.com/go-playground/validator/v10 v10.20.0 // indirect
 	github.com/goccy/go-json v0.10.2 // indirect
-	github.com/google/jsonschema-go v0.2.3 // indirect
-	github.com/google/uuid v1.6.0 // indirect
 	github.com/json-iterator/go v1.1.12 // indirect
 	github.com/klauspost/cpuid/v2 v2.2.7 // indirect
 	github.com/leodido/go-urn v1.4.0 // indirect
@@ -35,7 +36,6 @@ require (
 	golang.org/x/crypto v0.23.0 // indirect
 	golang.org/x/net v0.25.0 // indirect
 	golang.org/x/sys v0.20.0 // indirect
-	golang.org/x/text v0.16.0 // indirect
 	google.golang.org/protobuf v1.34.1 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,20 @@
+package list_brands
+
+// ListRequest represents a request to list product brands
+type ListRequest struct {
+	BaseURL        string `json:"base_url"`
+	ConsumerKey    string `json:"consumer_key"`
+	ConsumerSecret string `json:"consumer_secret"`
+
+	// Filtering
+	Search    string `json:"search,omitempty"`
+	HideEmpty string `json:"hide_empty,omitempty"`
+
+	// Pagination
+	Page    string `json:"page,omitempty"`
+	PerPage string `json:"per_page,omitempty"`
+
+	// Sorting
+	OrderBy string `json:"orderby,omitempty"`
+	Order   string `json:"order,omitempty"`
+}
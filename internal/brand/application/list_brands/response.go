@@ -0,0 +1,68 @@
+package list_brands
+
+import (
+	"encoding/json"
+	"woocommerce-mcp/internal/brand/domain"
+)
+
+// ListResponse represents a response from listing brands
+type ListResponse struct {
+	Brands      []BrandDTO `json:"brands"`
+	TotalCount  int64      `json:"total_count"`
+	CurrentPage int        `json:"current_page"`
+	PerPage     int        `json:"per_page"`
+	TotalPages  int        `json:"total_pages"`
+	HasNext     bool       `json:"has_next"`
+	HasPrev     bool       `json:"has_prev"`
+}
+
+// BrandDTO represents a brand data transfer object
+type BrandDTO struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	Description string `json:"description"`
+	Parent      int64  `json:"parent"`
+	Count       int    `json:"count"`
+	ImageURL    string `json:"image_url,omitempty"`
+}
+
+// ToJSON converts the response to JSON string
+func (r *ListResponse) ToJSON() (string, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// FromDomainBrands converts domain brands to response DTOs
+func FromDomainBrands(brands []*domain.Brand, totalCount int64, currentPage, perPage int) *ListResponse {
+	brandDTOs := make([]BrandDTO, len(brands))
+	for i, brand := range brands {
+		brandDTOs[i] = BrandDTO{
+			ID:          brand.ID.Value(),
+			Name:        brand.Name,
+			Slug:        brand.Slug,
+			Description: brand.Description,
+			Parent:      brand.Parent,
+			Count:       brand.Count,
+			ImageURL:    brand.ImageURL,
+		}
+	}
+
+	totalPages := int(totalCount) / perPage
+	if int(totalCount)%perPage != 0 {
+		totalPages++
+	}
+
+	return &ListResponse{
+		Brands:      brandDTOs,
+		TotalCount:  totalCount,
+		CurrentPage: currentPage,
+		PerPage:     perPage,
+		TotalPages:  totalPages,
+		HasNext:     currentPage < totalPages,
+		HasPrev:     currentPage > 1,
+	}
+}
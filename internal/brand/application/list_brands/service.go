@@ -0,0 +1,102 @@
+package list_brands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"woocommerce-mcp/internal/brand/domain"
+	"woocommerce-mcp/kit/pagination"
+)
+
+// BrandLister handles brand listing operations
+type BrandLister struct {
+	repository domain.BrandRepository
+}
+
+// NewBrandLister creates a new BrandLister
+func NewBrandLister(repository domain.BrandRepository) *BrandLister {
+	return &BrandLister{
+		repository: repository,
+	}
+}
+
+// Execute lists brands based on the provided request
+func (l *BrandLister) Execute(ctx context.Context, req *ListRequest) (*ListResponse, error) {
+	criteria, err := requestToCriteria(req)
+	if err != nil {
+		return nil, err
+	}
+
+	brands, err := l.repository.ListBrands(ctx, criteria)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list brands: %w", err)
+	}
+
+	totalCount, err := l.repository.CountBrands(ctx, criteria)
+	if err != nil {
+		// If count fails, fall back to the number of brands on this page
+		totalCount = int64(len(brands))
+	}
+
+	return FromDomainBrands(brands, totalCount, criteria.Page, criteria.PerPage), nil
+}
+
+// requestToCriteria converts a ListRequest to domain ListCriteria
+func requestToCriteria(req *ListRequest) (*domain.ListCriteria, error) {
+	if req.BaseURL == "" {
+		return nil, domain.NewValidationError("base_url is required")
+	}
+	if req.ConsumerKey == "" {
+		return nil, domain.NewValidationError("consumer_key is required")
+	}
+	if req.ConsumerSecret == "" {
+		return nil, domain.NewValidationError("consumer_secret is required")
+	}
+
+	criteria := &domain.ListCriteria{
+		Search:  req.Search,
+		Page:    1,
+		PerPage: 10,
+		OrderBy: "name",
+		Order:   "asc",
+	}
+
+	if req.HideEmpty != "" {
+		hideEmpty, err := strconv.ParseBool(req.HideEmpty)
+		if err != nil {
+			return nil, domain.NewValidationError("hide_empty must be true or false")
+		}
+		criteria.HideEmpty = hideEmpty
+	}
+
+	if req.Page != "" {
+		page, err := pagination.ParsePage(req.Page)
+		if err != nil {
+			return nil, domain.NewValidationError("page " + err.Error())
+		}
+		criteria.Page = page
+	}
+
+	if req.PerPage != "" {
+		perPage, err := pagination.ParsePerPage(req.PerPage)
+		if err != nil {
+			return nil, domain.NewValidationError("per_page " + err.Error())
+		}
+		if perPage > pagination.MaxPerPage {
+			perPage = pagination.MaxPerPage
+		}
+		criteria.PerPage = perPage
+	}
+
+	if req.OrderBy != "" {
+		criteria.OrderBy = req.OrderBy
+	}
+	if req.Order != "" {
+		if req.Order != "asc" && req.Order != "desc" {
+			return nil, domain.NewValidationError("order must be 'asc' or 'desc'")
+		}
+		criteria.Order = req.Order
+	}
+
+	return criteria, nil
+}
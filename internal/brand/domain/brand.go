@@ -0,0 +1,37 @@
+package domain
+
+// BrandID represents a unique identifier for a brand
+type BrandID int64
+
+// NewBrandID creates a new BrandID
+func NewBrandID(id int64) (BrandID, error) {
+	if id <= 0 {
+		return 0, NewValidationError("brand ID must be positive")
+	}
+	return BrandID(id), nil
+}
+
+// Value returns the underlying int64 value
+func (id BrandID) Value() int64 {
+	return int64(id)
+}
+
+// Brand represents a term of the WooCommerce product_brand taxonomy
+type Brand struct {
+	ID          BrandID
+	Name        string
+	Slug        string
+	Description string
+	Parent      int64
+	Count       int
+	ImageURL    string
+}
+
+// NewBrand creates a new Brand
+func NewBrand(id BrandID, name, slug string) *Brand {
+	return &Brand{
+		ID:   id,
+		Name: name,
+		Slug: slug,
+	}
+}
@@ -0,0 +1,68 @@
+package domain
+
+import "fmt"
+
+// BrandError represents a domain error for brands
+type BrandError struct {
+	Code    string
+	Message string
+	Type    string
+	// StatusCode is the upstream HTTP status of API errors
+	StatusCode int
+}
+
+func (e *BrandError) Error() string {
+	return fmt.Sprintf("[%s] %s: %s", e.Type, e.Code, e.Message)
+}
+
+// ErrorCode returns the machine-readable error code
+func (e *BrandError) ErrorCode() string {
+	return e.Code
+}
+
+// ErrorType returns the error type
+func (e *BrandError) ErrorType() string {
+	return e.Type
+}
+
+// HTTPStatus returns the upstream HTTP status, or 0 when there is none
+func (e *BrandError) HTTPStatus() int {
+	return e.StatusCode
+}
+
+// NewValidationError creates a new validation error
+func NewValidationError(message string) *BrandError {
+	return &BrandError{
+		Code:    "VALIDATION_ERROR",
+		Message: message,
+		Type:    "ValidationError",
+	}
+}
+
+// NewWooCommerceAPIError creates a new WooCommerce API error
+func NewWooCommerceAPIError(statusCode int, message, code string) *BrandError {
+	return &BrandError{
+		Code:       fmt.Sprintf("WOOCOMMERCE_API_ERROR_%d", statusCode),
+		Message:    fmt.Sprintf("WooCommerce API error (status %d): %s", statusCode, message),
+		Type:       "WooCommerceAPIError",
+		StatusCode: statusCode,
+	}
+}
+
+// NewConnectionError creates a new connection error
+func NewConnectionError(url, message string) *BrandError {
+	return &BrandError{
+		Code:    "CONNECTION_ERROR",
+		Message: fmt.Sprintf("connection error to %s: %s", url, message),
+		Type:    "ConnectionError",
+	}
+}
+
+// NewBrandsNotSupportedError creates an error for stores without the brands endpoint
+func NewBrandsNotSupportedError() *BrandError {
+	return &BrandError{
+		Code:    "BRANDS_NOT_SUPPORTED",
+		Message: "this store does not expose product brands; the brands endpoint requires WooCommerce 9.6 or later",
+		Type:    "NotSupportedError",
+	}
+}
@@ -0,0 +1,29 @@
+package domain
+
+import "context"
+
+// BrandRepository defines the interface for brand data access
+type BrandRepository interface {
+	// ListBrands lists brands matching the criteria
+	ListBrands(ctx context.Context, criteria *ListCriteria) ([]*Brand, error)
+
+	// CountBrands returns the total count of brands matching the criteria
+	CountBrands(ctx context.Context, criteria *ListCriteria) (int64, error)
+}
+
+// ListCriteria represents list parameters for brands
+type ListCriteria struct {
+	// Basic search
+	Search string
+
+	// Filtering
+	HideEmpty bool
+
+	// Pagination
+	Page    int
+	PerPage int
+
+	// Sorting
+	OrderBy string // id, include, name, slug, term_group, description, count
+	Order   string // asc, desc
+}
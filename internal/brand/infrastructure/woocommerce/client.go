@@ -0,0 +1,211 @@
+package woocommerce
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+	"woocommerce-mcp/internal/brand/domain"
+	"woocommerce-mcp/kit/contentencoding"
+	"woocommerce-mcp/kit/httpjson"
+	"woocommerce-mcp/kit/requestid"
+	"woocommerce-mcp/kit/storehttp"
+)
+
+// brandsRoute is the REST route of the product_brand taxonomy
+const brandsRoute = "wc/v3/products/brands"
+
+// Config represents WooCommerce API configuration
+type Config struct {
+	BaseURL        string
+	ConsumerKey    string
+	ConsumerSecret string
+	Timeout        time.Duration
+	// ProxyURL routes outbound requests through an HTTP proxy. When empty,
+	// the standard HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment is used.
+	ProxyURL string
+}
+
+// NewConfig creates a new WooCommerce configuration
+func NewConfig(baseURL, consumerKey, consumerSecret string) *Config {
+	return &Config{
+		BaseURL:        storehttp.NormalizeBaseURL(baseURL),
+		ConsumerKey:    consumerKey,
+		ConsumerSecret: consumerSecret,
+		Timeout:        30 * time.Second,
+		ProxyURL:       storehttp.ProxyURL(),
+	}
+}
+
+// Client represents a WooCommerce API client for product brands
+type Client struct {
+	config     *Config
+	httpClient *http.Client
+}
+
+// NewClient creates a new WooCommerce brands client
+func NewClient(config *Config) *Client {
+	return &Client{
+		config: config,
+		httpClient: &http.Client{
+			Timeout:   config.Timeout,
+			Transport: requestid.NewTransport(contentencoding.NewTransport(storehttp.NewTransport(config.ProxyURL))),
+		},
+	}
+}
+
+// ListBrands lists product brands using the WooCommerce API
+func (c *Client) ListBrands(ctx context.Context, criteria *domain.ListCriteria) ([]*domain.Brand, error) {
+	// Build the API endpoint URL
+	u, err := c.buildURL(brandsRoute)
+	if err != nil {
+		return nil, err
+	}
+
+	// Build query parameters
+	query := u.Query()
+	c.addAuthParams(query)
+	c.addListParams(query, criteria)
+
+	u.RawQuery = query.Encode()
+
+	var apiBrands []APIBrand
+	if _, err := c.api().DoJSON(ctx, "GET", u.String(), &apiBrands); err != nil {
+		return nil, err
+	}
+
+	// Convert API brands to domain brands
+	brands := make([]*domain.Brand, 0, len(apiBrands))
+	for _, apiBrand := range apiBrands {
+		brand, err := c.apiBrandToDomain(&apiBrand)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert brand %d: %w", apiBrand.ID, err)
+		}
+		brands = append(brands, brand)
+	}
+
+	return brands, nil
+}
+
+// CountBrands counts brands matching the criteria using the X-WP-Total header
+func (c *Client) CountBrands(ctx context.Context, criteria *domain.ListCriteria) (int64, error) {
+	u, err := c.buildURL(brandsRoute)
+	if err != nil {
+		return 0, err
+	}
+
+	// Build query parameters (same as list but we only need the count)
+	query := u.Query()
+	c.addAuthParams(query)
+	c.addListParams(query, criteria)
+
+	// Set per_page to 1 to minimize data transfer when we only need the count
+	query.Set("per_page", "1")
+
+	u.RawQuery = query.Encode()
+
+	header, err := c.api().DoJSON(ctx, "HEAD", u.String(), nil)
+	if err != nil {
+		return 0, err
+	}
+
+	// Get total count from header
+	totalHeader := header.Get("X-WP-Total")
+	if totalHeader == "" {
+		return 0, nil
+	}
+
+	total, err := strconv.ParseInt(totalHeader, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse total count: %w", err)
+	}
+
+	return total, nil
+}
+
+// buildURL resolves a REST route against the base URL, appending it under
+// wp-json relative to any path prefix of the store
+func (c *Client) buildURL(route string) (*url.URL, error) {
+	u, err := storehttp.RouteURL(c.config.BaseURL, route)
+	if err != nil {
+		return nil, domain.NewConnectionError(c.config.BaseURL, err.Error())
+	}
+	return u, nil
+}
+
+// addAuthParams adds authentication parameters to the query
+func (c *Client) addAuthParams(query url.Values) {
+	query.Set("consumer_key", c.config.ConsumerKey)
+	query.Set("consumer_secret", c.config.ConsumerSecret)
+}
+
+// addListParams adds list parameters to the query
+func (c *Client) addListParams(query url.Values, criteria *domain.ListCriteria) {
+	if criteria.Search != "" {
+		query.Set("search", criteria.Search)
+	}
+	if criteria.HideEmpty {
+		query.Set("hide_empty", "true")
+	}
+
+	// Pagination
+	if criteria.PerPage > 0 {
+		query.Set("per_page", strconv.Itoa(criteria.PerPage))
+	}
+	if criteria.Page > 0 {
+		query.Set("page", strconv.Itoa(criteria.Page))
+	}
+
+	// Sorting
+	if criteria.OrderBy != "" {
+		query.Set("orderby", criteria.OrderBy)
+	}
+	if criteria.Order != "" {
+		query.Set("order", criteria.Order)
+	}
+}
+
+// api returns the JSON request helper of the client. Errors name the
+// endpoint without its query string, which holds the credentials.
+func (c *Client) api() httpjson.Client {
+	return httpjson.Client{
+		Send: c.httpClient.Do,
+		ConnectionError: func(url string, err error) error {
+			return domain.NewConnectionError(url, fmt.Sprintf("HTTP request failed: %v", err))
+		},
+		StatusError: func(statusCode int, _ http.Header, body []byte) error {
+			return c.handleAPIError(statusCode, body)
+		},
+	}
+}
+
+// handleAPIError handles API errors and converts them to domain errors
+func (c *Client) handleAPIError(statusCode int, body []byte) error {
+	// Stores before WooCommerce 9.6 have no brands route at all
+	if statusCode == http.StatusNotFound {
+		return domain.NewBrandsNotSupportedError()
+	}
+
+	message, code := httpjson.ErrorMessage(statusCode, body)
+	return domain.NewWooCommerceAPIError(statusCode, message, code)
+}
+
+// apiBrandToDomain converts an API brand to a domain brand
+func (c *Client) apiBrandToDomain(apiBrand *APIBrand) (*domain.Brand, error) {
+	brandID, err := domain.NewBrandID(apiBrand.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	brand := domain.NewBrand(brandID, apiBrand.Name, apiBrand.Slug)
+	brand.Description = apiBrand.Description
+	brand.Parent = apiBrand.Parent
+	brand.Count = apiBrand.Count
+	if apiBrand.Image != nil {
+		brand.ImageURL = apiBrand.Image.Src
+	}
+
+	return brand, nil
+}
@@ -0,0 +1,30 @@
+package woocommerce
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"woocommerce-mcp/internal/brand/domain"
+)
+
+func TestConnectionErrorsHideTheCredentials(t *testing.T) {
+	// A closed server refuses the connection
+	server := httptest.NewServer(nil)
+	server.Close()
+
+	client := NewClient(NewConfig(server.URL, "ck_visible", "cs_secret"))
+	_, err := client.ListBrands(context.Background(), &domain.ListCriteria{})
+	if err == nil {
+		t.Fatal("listing brands of a closed server succeeded")
+	}
+	if strings.Contains(err.Error(), "cs_secret") || strings.Contains(err.Error(), "consumer_key") {
+		t.Errorf("connection error leaks the credentials: %v", err)
+	}
+
+	_, err = client.CountBrands(context.Background(), &domain.ListCriteria{})
+	if err == nil || strings.Contains(err.Error(), "cs_secret") {
+		t.Errorf("count error = %v, want one without the credentials", err)
+	}
+}
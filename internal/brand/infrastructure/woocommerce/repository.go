@@ -0,0 +1,28 @@
+package woocommerce
+
+import (
+	"context"
+	"woocommerce-mcp/internal/brand/domain"
+)
+
+// Repository implements the domain BrandRepository interface
+type Repository struct {
+	client *Client
+}
+
+// NewRepository creates a new WooCommerce brand repository
+func NewRepository(client *Client) *Repository {
+	return &Repository{
+		client: client,
+	}
+}
+
+// ListBrands lists brands using the WooCommerce API
+func (r *Repository) ListBrands(ctx context.Context, criteria *domain.ListCriteria) ([]*domain.Brand, error) {
+	return r.client.ListBrands(ctx, criteria)
+}
+
+// CountBrands returns the total count of brands matching the criteria
+func (r *Repository) CountBrands(ctx context.Context, criteria *domain.ListCriteria) (int64, error) {
+	return r.client.CountBrands(ctx, criteria)
+}
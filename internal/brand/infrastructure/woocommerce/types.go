@@ -0,0 +1,19 @@
+package woocommerce
+
+// APIBrand represents a product brand term from the WooCommerce API
+type APIBrand struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Slug        string    `json:"slug"`
+	Parent      int64     `json:"parent"`
+	Description string    `json:"description"`
+	Count       int       `json:"count"`
+	Image       *APIImage `json:"image"`
+}
+
+// APIImage represents a brand image from the WooCommerce API
+type APIImage struct {
+	ID  int64  `json:"id"`
+	Src string `json:"src"`
+	Alt string `json:"alt"`
+}
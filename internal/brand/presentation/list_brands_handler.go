@@ -0,0 +1,143 @@
+package presentation
+
+import (
+	"context"
+	"fmt"
+
+	"woocommerce-mcp/internal/brand/application/list_brands"
+	"woocommerce-mcp/internal/brand/infrastructure/woocommerce"
+	kitDomain "woocommerce-mcp/kit/domain"
+	"woocommerce-mcp/kit/storeconfig"
+	"woocommerce-mcp/kit/toolargs"
+	"woocommerce-mcp/kit/tooltimeout"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ListBrandsInput defines the input structure for the list_brands tool
+type ListBrandsInput struct {
+	BaseURL        string `json:"base_url,omitempty" jsonschema:"WooCommerce store base URL (e.g., https://example.com); defaults to WC_BASE_URL"`
+	ConsumerKey    string `json:"consumer_key,omitempty" jsonschema:"WooCommerce REST API consumer key; defaults to WC_CONSUMER_KEY for the default store"`
+	ConsumerSecret string `json:"consumer_secret,omitempty" jsonschema:"WooCommerce REST API consumer secret; defaults to WC_CONSUMER_SECRET for the default store"`
+	Search         string `json:"search,omitempty" jsonschema:"Search term to filter brands by name"`
+	HideEmpty      string `json:"hide_empty,omitempty" jsonschema:"Hide brands without products (true/false)"`
+	Page           string `json:"page,omitempty" jsonschema:"Page number for pagination (default: 1)"`
+	PerPage        string `json:"per_page,omitempty" jsonschema:"Number of brands per page (1-100, default: 10)"`
+	OrderBy        string `json:"orderby,omitempty" jsonschema:"Sort by field (id, include, name, slug, term_group, description, count)"`
+	Order          string `json:"order,omitempty" jsonschema:"Sort order (asc, desc)"`
+}
+
+// UnmarshalJSON decodes the arguments, also accepting numbers and booleans
+// for the string fields
+func (in *ListBrandsInput) UnmarshalJSON(data []byte) error {
+	type plain ListBrandsInput
+	return toolargs.Unmarshal(data, (*plain)(in))
+}
+
+// ListBrandsOutput defines the output structure for the list_brands tool
+type ListBrandsOutput struct {
+	Message string `json:"message" jsonschema:"Human-readable message about the listed brands"`
+	Data    string `json:"data" jsonschema:"JSON-formatted brand data"`
+}
+
+// ListBrandsHandler handles list_brands tool calls
+type ListBrandsHandler struct{}
+
+// NewListBrandsHandler creates a new ListBrandsHandler
+func NewListBrandsHandler() *ListBrandsHandler {
+	return &ListBrandsHandler{}
+}
+
+// GetToolDefinition returns the MCP tool definition for list_brands
+func (h *ListBrandsHandler) GetToolDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "list_brands",
+		Description: "List product brands in a WooCommerce store. Brand IDs can be used with the brand filter of search_products.",
+		InputSchema: toolargs.InputSchema[ListBrandsInput](),
+	}
+}
+
+// GetInputSchema returns the input schema for the JSON-RPC tools/list endpoint
+func (h *ListBrandsHandler) GetInputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"base_url":        map[string]string{"type": "string", "description": "WooCommerce store base URL"},
+			"consumer_key":    map[string]string{"type": "string", "description": "WooCommerce REST API consumer key"},
+			"consumer_secret": map[string]string{"type": "string", "description": "WooCommerce REST API consumer secret"},
+			"search":          map[string]string{"type": "string", "description": "Search term to filter brands"},
+			"hide_empty":      map[string]string{"type": "string", "description": "Hide brands without products"},
+			"per_page":        map[string]string{"type": "string", "description": "Items per page"},
+			"page":            map[string]string{"type": "string", "description": "Page number"},
+			"order":           map[string]string{"type": "string", "description": "Sort order"},
+			"orderby":         map[string]string{"type": "string", "description": "Sort field"},
+		},
+		"required": storeconfig.RequiredFields("base_url", "consumer_key", "consumer_secret"),
+	}
+}
+
+// ExecuteMCPTool implements the MCP tool execution
+func (h *ListBrandsHandler) ExecuteMCPTool(ctx context.Context, req *mcp.CallToolRequest, input ListBrandsInput) (*mcp.CallToolResult, ListBrandsOutput, error) {
+	// Fall back to the default store configured through the environment
+	storeconfig.ApplyDefaults(&input.BaseURL, &input.ConsumerKey, &input.ConsumerSecret)
+
+	// Validate required fields
+	if input.BaseURL == "" {
+		return nil, ListBrandsOutput{}, kitDomain.NewValidationError("base_url is required")
+	}
+	if input.ConsumerKey == "" {
+		return nil, ListBrandsOutput{}, kitDomain.NewValidationError("consumer_key is required")
+	}
+	if input.ConsumerSecret == "" {
+		return nil, ListBrandsOutput{}, kitDomain.NewValidationError("consumer_secret is required")
+	}
+
+	// Create WooCommerce client
+	config := woocommerce.NewConfig(input.BaseURL, input.ConsumerKey, input.ConsumerSecret)
+	client := woocommerce.NewClient(config)
+	repo := woocommerce.NewRepository(client)
+
+	// Create list request
+	request := &list_brands.ListRequest{
+		BaseURL:        input.BaseURL,
+		ConsumerKey:    input.ConsumerKey,
+		ConsumerSecret: input.ConsumerSecret,
+		Search:         input.Search,
+		HideEmpty:      input.HideEmpty,
+		Page:           input.Page,
+		PerPage:        input.PerPage,
+		OrderBy:        input.OrderBy,
+		Order:          input.Order,
+	}
+
+	// Bound the whole tool call, across all of its API requests
+	ctx, cancel := tooltimeout.WithTimeout(ctx)
+	defer cancel()
+
+	// Execute listing
+	lister := list_brands.NewBrandLister(repo)
+	response, err := lister.Execute(ctx, request)
+	if err != nil {
+		return nil, ListBrandsOutput{}, tooltimeout.Err(ctx, "list_brands", fmt.Errorf("failed to list brands: %w", err))
+	}
+
+	// Convert response to JSON
+	jsonData, err := response.ToJSON()
+	if err != nil {
+		return nil, ListBrandsOutput{}, fmt.Errorf("failed to serialize response: %w", err)
+	}
+
+	// Create human-readable message
+	var message string
+	if len(response.Brands) == 0 {
+		message = "No brands found"
+	} else {
+		message = fmt.Sprintf("Found %d brand(s) out of %d total (page %d of %d)",
+			len(response.Brands), response.TotalCount, response.CurrentPage, response.TotalPages)
+	}
+
+	return nil, ListBrandsOutput{
+		Message: message,
+		Data:    jsonData,
+	}, nil
+}
@@ -0,0 +1,9 @@
+package get_customer
+
+// GetRequest represents a request to fetch a customer by ID
+type GetRequest struct {
+	BaseURL        string `json:"base_url"`
+	ConsumerKey    string `json:"consumer_key"`
+	ConsumerSecret string `json:"consumer_secret"`
+	CustomerID     string `json:"customer_id"`
+}
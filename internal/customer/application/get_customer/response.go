@@ -0,0 +1,28 @@
+package get_customer
+
+import (
+	"encoding/json"
+	"woocommerce-mcp/internal/customer/application/search_customers"
+	"woocommerce-mcp/internal/customer/domain"
+)
+
+// GetResponse represents a response from fetching a customer
+type GetResponse struct {
+	Customer search_customers.CustomerDTO `json:"customer"`
+}
+
+// ToJSON converts the response to JSON string
+func (r *GetResponse) ToJSON() (string, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// FromDomainCustomer converts a domain customer to a response
+func FromDomainCustomer(customer *domain.Customer) *GetResponse {
+	return &GetResponse{
+		Customer: search_customers.FromDomainCustomer(customer),
+	}
+}
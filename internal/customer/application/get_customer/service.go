@@ -0,0 +1,50 @@
+package get_customer
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"woocommerce-mcp/internal/customer/domain"
+)
+
+// CustomerGetter handles fetching a single customer
+type CustomerGetter struct {
+	repository domain.CustomerRepository
+}
+
+// NewCustomerGetter creates a new CustomerGetter
+func NewCustomerGetter(repository domain.CustomerRepository) *CustomerGetter {
+	return &CustomerGetter{
+		repository: repository,
+	}
+}
+
+// Execute fetches the customer of the request
+func (g *CustomerGetter) Execute(ctx context.Context, req *GetRequest) (*GetResponse, error) {
+	if req.BaseURL == "" {
+		return nil, domain.NewValidationError("base_url is required")
+	}
+	if req.ConsumerKey == "" {
+		return nil, domain.NewValidationError("consumer_key is required")
+	}
+	if req.ConsumerSecret == "" {
+		return nil, domain.NewValidationError("consumer_secret is required")
+	}
+
+	rawID, err := strconv.ParseInt(strings.TrimSpace(req.CustomerID), 10, 64)
+	if err != nil {
+		return nil, domain.NewValidationError("customer_id must be a positive integer")
+	}
+	customerID, err := domain.NewCustomerID(rawID)
+	if err != nil {
+		return nil, err
+	}
+
+	customer, err := g.repository.GetCustomer(ctx, customerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get customer: %w", err)
+	}
+
+	return FromDomainCustomer(customer), nil
+}
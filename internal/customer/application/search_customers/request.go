@@ -0,0 +1,17 @@
+package search_customers
+
+// SearchRequest represents a request to search customers
+type SearchRequest struct {
+	BaseURL        string `json:"base_url"`
+	ConsumerKey    string `json:"consumer_key"`
+	ConsumerSecret string `json:"consumer_secret"`
+
+	// Filtering
+	Search string `json:"search,omitempty"`
+	Email  string `json:"email,omitempty"`
+	Role   string `json:"role,omitempty"`
+
+	// Pagination
+	Page    string `json:"page,omitempty"`
+	PerPage string `json:"per_page,omitempty"`
+}
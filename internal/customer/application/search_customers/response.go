@@ -0,0 +1,67 @@
+package search_customers
+
+import (
+	"encoding/json"
+	"woocommerce-mcp/internal/customer/domain"
+)
+
+// SearchResponse represents a response from searching customers
+type SearchResponse struct {
+	Customers   []CustomerDTO `json:"customers"`
+	TotalCount  int64         `json:"total_count"`
+	CurrentPage int           `json:"current_page"`
+	PerPage     int           `json:"per_page"`
+	TotalPages  int           `json:"total_pages"`
+	HasNext     bool          `json:"has_next"`
+	HasPrev     bool          `json:"has_prev"`
+}
+
+// CustomerDTO represents a customer data transfer object
+type CustomerDTO struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Email       string `json:"email"`
+	OrdersCount int64  `json:"orders_count"`
+}
+
+// ToJSON converts the response to JSON string
+func (r *SearchResponse) ToJSON() (string, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// FromDomainCustomer converts a domain customer to a DTO
+func FromDomainCustomer(customer *domain.Customer) CustomerDTO {
+	return CustomerDTO{
+		ID:          customer.ID.Value(),
+		Name:        customer.Name(),
+		Email:       customer.Email,
+		OrdersCount: customer.OrdersCount,
+	}
+}
+
+// FromDomainCustomers converts domain customers to response DTOs
+func FromDomainCustomers(customers []*domain.Customer, totalCount int64, currentPage, perPage int) *SearchResponse {
+	customerDTOs := make([]CustomerDTO, len(customers))
+	for i, customer := range customers {
+		customerDTOs[i] = FromDomainCustomer(customer)
+	}
+
+	totalPages := int(totalCount) / perPage
+	if int(totalCount)%perPage != 0 {
+		totalPages++
+	}
+
+	return &SearchResponse{
+		Customers:   customerDTOs,
+		TotalCount:  totalCount,
+		CurrentPage: currentPage,
+		PerPage:     perPage,
+		TotalPages:  totalPages,
+		HasNext:     currentPage < totalPages,
+		HasPrev:     currentPage > 1,
+	}
+}
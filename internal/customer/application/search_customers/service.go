@@ -0,0 +1,92 @@
+package search_customers
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"strings"
+	"woocommerce-mcp/internal/customer/domain"
+	"woocommerce-mcp/kit/pagination"
+)
+
+// CustomerSearcher handles customer search operations
+type CustomerSearcher struct {
+	repository domain.CustomerRepository
+}
+
+// NewCustomerSearcher creates a new CustomerSearcher
+func NewCustomerSearcher(repository domain.CustomerRepository) *CustomerSearcher {
+	return &CustomerSearcher{
+		repository: repository,
+	}
+}
+
+// Execute searches customers based on the provided request
+func (s *CustomerSearcher) Execute(ctx context.Context, req *SearchRequest) (*SearchResponse, error) {
+	criteria, err := requestToCriteria(req)
+	if err != nil {
+		return nil, err
+	}
+
+	customers, err := s.repository.SearchCustomers(ctx, criteria)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search customers: %w", err)
+	}
+
+	totalCount, err := s.repository.CountCustomers(ctx, criteria)
+	if err != nil {
+		// If count fails, fall back to the number of customers on this page
+		totalCount = int64(len(customers))
+	}
+
+	return FromDomainCustomers(customers, totalCount, criteria.Page, criteria.PerPage), nil
+}
+
+// requestToCriteria converts a SearchRequest to domain SearchCriteria
+func requestToCriteria(req *SearchRequest) (*domain.SearchCriteria, error) {
+	if req.BaseURL == "" {
+		return nil, domain.NewValidationError("base_url is required")
+	}
+	if req.ConsumerKey == "" {
+		return nil, domain.NewValidationError("consumer_key is required")
+	}
+	if req.ConsumerSecret == "" {
+		return nil, domain.NewValidationError("consumer_secret is required")
+	}
+
+	criteria := &domain.SearchCriteria{
+		Search:  strings.TrimSpace(req.Search),
+		Role:    strings.TrimSpace(req.Role),
+		Page:    1,
+		PerPage: 10,
+	}
+
+	if email := strings.TrimSpace(req.Email); email != "" {
+		// The address itself is left out of the message to keep it out of logs
+		if _, err := mail.ParseAddress(email); err != nil {
+			return nil, domain.NewValidationError("email must be a valid email address")
+		}
+		criteria.Email = email
+	}
+
+	if req.Page != "" {
+		page, err := pagination.ParsePage(req.Page)
+		if err != nil {
+			return nil, domain.NewValidationError("page " + err.Error())
+		}
+		criteria.Page = page
+	}
+
+	if req.PerPage != "" {
+		perPage, err := pagination.ParsePerPage(req.PerPage)
+		if err != nil {
+			return nil, domain.NewValidationError("per_page " + err.Error())
+		}
+		if perPage > pagination.MaxPerPage {
+			perPage = pagination.MaxPerPage
+		}
+		criteria.PerPage = perPage
+	}
+
+	return criteria, nil
+}
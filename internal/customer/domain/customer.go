@@ -0,0 +1,47 @@
+package domain
+
+// CustomerID represents a unique identifier for a customer
+type CustomerID int64
+
+// NewCustomerID creates a new CustomerID
+func NewCustomerID(id int64) (CustomerID, error) {
+	if id <= 0 {
+		return 0, NewValidationError("customer ID must be positive")
+	}
+	return CustomerID(id), nil
+}
+
+// Value returns the underlying int64 value
+func (id CustomerID) Value() int64 {
+	return int64(id)
+}
+
+// Customer represents a WooCommerce customer. Only the fields support
+// assistants need are kept, to limit the personal data handled.
+type Customer struct {
+	ID          CustomerID
+	FirstName   string
+	LastName    string
+	Email       string
+	OrdersCount int64
+}
+
+// NewCustomer creates a new Customer
+func NewCustomer(id CustomerID, email string) *Customer {
+	return &Customer{
+		ID:    id,
+		Email: email,
+	}
+}
+
+// Name returns the full name of the customer
+func (c *Customer) Name() string {
+	switch {
+	case c.FirstName == "":
+		return c.LastName
+	case c.LastName == "":
+		return c.FirstName
+	default:
+		return c.FirstName + " " + c.LastName
+	}
+}
@@ -0,0 +1,79 @@
+package domain
+
+import "fmt"
+
+// CustomerError represents a domain error for customers
+type CustomerError struct {
+	Code    string
+	Message string
+	Type    string
+	// StatusCode is the upstream HTTP status of API errors
+	StatusCode int
+}
+
+func (e *CustomerError) Error() string {
+	return fmt.Sprintf("[%s] %s: %s", e.Type, e.Code, e.Message)
+}
+
+// ErrorCode returns the machine-readable error code
+func (e *CustomerError) ErrorCode() string {
+	return e.Code
+}
+
+// ErrorType returns the error type
+func (e *CustomerError) ErrorType() string {
+	return e.Type
+}
+
+// HTTPStatus returns the upstream HTTP status, or 0 when there is none
+func (e *CustomerError) HTTPStatus() int {
+	return e.StatusCode
+}
+
+// NewValidationError creates a new validation error
+func NewValidationError(message string) *CustomerError {
+	return &CustomerError{
+		Code:    "VALIDATION_ERROR",
+		Message: message,
+		Type:    "ValidationError",
+	}
+}
+
+// NewWooCommerceAPIError creates a new WooCommerce API error
+func NewWooCommerceAPIError(statusCode int, message, code string) *CustomerError {
+	return &CustomerError{
+		Code:       fmt.Sprintf("WOOCOMMERCE_API_ERROR_%d", statusCode),
+		Message:    fmt.Sprintf("WooCommerce API error (status %d): %s", statusCode, message),
+		Type:       "WooCommerceAPIError",
+		StatusCode: statusCode,
+	}
+}
+
+// NewConnectionError creates a new connection error
+func NewConnectionError(url, message string) *CustomerError {
+	return &CustomerError{
+		Code:    "CONNECTION_ERROR",
+		Message: fmt.Sprintf("connection error to %s: %s", url, message),
+		Type:    "ConnectionError",
+	}
+}
+
+// NewScopeError creates an error for API keys not allowed to read customers
+func NewScopeError() *CustomerError {
+	return &CustomerError{
+		Code:       "INSUFFICIENT_SCOPE",
+		Message:    "the API key is not allowed to read customers; it needs read access and must belong to a user who can manage customers",
+		Type:       "ScopeError",
+		StatusCode: 403,
+	}
+}
+
+// NewNotFoundError creates a new not found error
+func NewNotFoundError(customerID CustomerID) *CustomerError {
+	return &CustomerError{
+		Code:       "CUSTOMER_NOT_FOUND",
+		Message:    fmt.Sprintf("customer with ID %d not found", customerID.Value()),
+		Type:       "NotFoundError",
+		StatusCode: 404,
+	}
+}
@@ -0,0 +1,29 @@
+package domain
+
+import "context"
+
+// CustomerRepository defines the interface for customer data access
+type CustomerRepository interface {
+	// SearchCustomers lists customers matching the criteria
+	SearchCustomers(ctx context.Context, criteria *SearchCriteria) ([]*Customer, error)
+
+	// CountCustomers returns the total count of customers matching the criteria
+	CountCustomers(ctx context.Context, criteria *SearchCriteria) (int64, error)
+
+	// GetCustomer fetches a single customer by ID
+	GetCustomer(ctx context.Context, id CustomerID) (*Customer, error)
+}
+
+// SearchCriteria represents search parameters for customers
+type SearchCriteria struct {
+	// Basic search
+	Search string
+
+	// Filtering
+	Email string
+	Role  string // all, customer, administrator, shop_manager, ...
+
+	// Pagination
+	Page    int
+	PerPage int
+}
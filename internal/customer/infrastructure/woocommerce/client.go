@@ -0,0 +1,265 @@
+package woocommerce
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+	"woocommerce-mcp/internal/customer/domain"
+	"woocommerce-mcp/kit/contentencoding"
+	"woocommerce-mcp/kit/httpjson"
+	"woocommerce-mcp/kit/requestid"
+	"woocommerce-mcp/kit/storehttp"
+)
+
+// REST routes of the customer endpoints
+const (
+	customersRoute = "wc/v3/customers"
+	ordersRoute    = "wc/v3/orders"
+)
+
+// Config represents WooCommerce API configuration
+type Config struct {
+	BaseURL        string
+	ConsumerKey    string
+	ConsumerSecret string
+	Timeout        time.Duration
+	// ProxyURL routes outbound requests through an HTTP proxy. When empty,
+	// the standard HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment is used.
+	ProxyURL string
+}
+
+// NewConfig creates a new WooCommerce configuration
+func NewConfig(baseURL, consumerKey, consumerSecret string) *Config {
+	return &Config{
+		BaseURL:        storehttp.NormalizeBaseURL(baseURL),
+		ConsumerKey:    consumerKey,
+		ConsumerSecret: consumerSecret,
+		Timeout:        30 * time.Second,
+		ProxyURL:       storehttp.ProxyURL(),
+	}
+}
+
+// Client represents a WooCommerce API client for customers. Customer data
+// is personal data: request URLs may carry an email filter, so errors only
+// ever mention the endpoint without its query string, and nothing is logged.
+type Client struct {
+	config     *Config
+	httpClient *http.Client
+}
+
+// NewClient creates a new WooCommerce customers client
+func NewClient(config *Config) *Client {
+	return &Client{
+		config: config,
+		httpClient: &http.Client{
+			Timeout:   config.Timeout,
+			Transport: requestid.NewTransport(contentencoding.NewTransport(storehttp.NewTransport(config.ProxyURL))),
+		},
+	}
+}
+
+// SearchCustomers lists customers using the WooCommerce API
+func (c *Client) SearchCustomers(ctx context.Context, criteria *domain.SearchCriteria) ([]*domain.Customer, error) {
+	// Build the API endpoint URL
+	u, err := c.buildURL(customersRoute)
+	if err != nil {
+		return nil, err
+	}
+
+	// Build query parameters
+	query := u.Query()
+	c.addAuthParams(query)
+	c.addSearchParams(query, criteria)
+
+	u.RawQuery = query.Encode()
+
+	var apiCustomers []APICustomer
+	if _, err := c.api().DoJSON(ctx, "GET", u.String(), &apiCustomers); err != nil {
+		return nil, err
+	}
+
+	// Convert API customers to domain customers
+	customers := make([]*domain.Customer, 0, len(apiCustomers))
+	for _, apiCustomer := range apiCustomers {
+		customer, err := c.apiCustomerToDomain(ctx, &apiCustomer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert customer %d: %w", apiCustomer.ID, err)
+		}
+		customers = append(customers, customer)
+	}
+
+	return customers, nil
+}
+
+// CountCustomers counts customers matching the criteria using the X-WP-Total header
+func (c *Client) CountCustomers(ctx context.Context, criteria *domain.SearchCriteria) (int64, error) {
+	u, err := c.buildURL(customersRoute)
+	if err != nil {
+		return 0, err
+	}
+
+	// Build query parameters (same as search but we only need the count)
+	query := u.Query()
+	c.addAuthParams(query)
+	c.addSearchParams(query, criteria)
+
+	// Set per_page to 1 to minimize data transfer when we only need the count
+	query.Set("per_page", "1")
+
+	u.RawQuery = query.Encode()
+
+	header, err := c.api().DoJSON(ctx, "HEAD", u.String(), nil)
+	if err != nil {
+		return 0, err
+	}
+	return parseTotal(header)
+}
+
+// GetCustomer fetches a single customer by ID
+func (c *Client) GetCustomer(ctx context.Context, id domain.CustomerID) (*domain.Customer, error) {
+	u, err := c.buildURL(customersRoute + "/" + strconv.FormatInt(id.Value(), 10))
+	if err != nil {
+		return nil, err
+	}
+
+	query := u.Query()
+	c.addAuthParams(query)
+	u.RawQuery = query.Encode()
+
+	var apiCustomer APICustomer
+	if _, err := c.api().DoJSON(ctx, "GET", u.String(), &apiCustomer); err != nil {
+		var customerErr *domain.CustomerError
+		if errors.As(err, &customerErr) && customerErr.StatusCode == http.StatusNotFound {
+			return nil, domain.NewNotFoundError(id)
+		}
+		return nil, err
+	}
+
+	return c.apiCustomerToDomain(ctx, &apiCustomer)
+}
+
+// countOrders counts the orders of a customer using the X-WP-Total header
+func (c *Client) countOrders(ctx context.Context, id domain.CustomerID) (int64, error) {
+	u, err := c.buildURL(ordersRoute)
+	if err != nil {
+		return 0, err
+	}
+
+	query := u.Query()
+	c.addAuthParams(query)
+	query.Set("customer", strconv.FormatInt(id.Value(), 10))
+	query.Set("per_page", "1")
+	u.RawQuery = query.Encode()
+
+	header, err := c.api().DoJSON(ctx, "HEAD", u.String(), nil)
+	if err != nil {
+		return 0, err
+	}
+	return parseTotal(header)
+}
+
+// api returns the JSON request helper of the client. Errors name the
+// endpoint without its query string, which holds the credentials and
+// possibly an email address.
+func (c *Client) api() httpjson.Client {
+	return httpjson.Client{
+		Send: c.httpClient.Do,
+		ConnectionError: func(url string, err error) error {
+			return domain.NewConnectionError(url, fmt.Sprintf("HTTP request failed: %v", err))
+		},
+		StatusError: func(statusCode int, _ http.Header, body []byte) error {
+			return c.handleAPIError(statusCode, body)
+		},
+	}
+}
+
+// buildURL resolves a REST route against the base URL, appending it under
+// wp-json relative to any path prefix of the store
+func (c *Client) buildURL(route string) (*url.URL, error) {
+	u, err := storehttp.RouteURL(c.config.BaseURL, route)
+	if err != nil {
+		return nil, domain.NewConnectionError(c.config.BaseURL, err.Error())
+	}
+	return u, nil
+}
+
+// addAuthParams adds authentication parameters to the query
+func (c *Client) addAuthParams(query url.Values) {
+	query.Set("consumer_key", c.config.ConsumerKey)
+	query.Set("consumer_secret", c.config.ConsumerSecret)
+}
+
+// addSearchParams adds search parameters to the query
+func (c *Client) addSearchParams(query url.Values, criteria *domain.SearchCriteria) {
+	if criteria.Search != "" {
+		query.Set("search", criteria.Search)
+	}
+	if criteria.Email != "" {
+		query.Set("email", criteria.Email)
+	}
+	if criteria.Role != "" {
+		query.Set("role", criteria.Role)
+	}
+
+	// Pagination
+	if criteria.PerPage > 0 {
+		query.Set("per_page", strconv.Itoa(criteria.PerPage))
+	}
+	if criteria.Page > 0 {
+		query.Set("page", strconv.Itoa(criteria.Page))
+	}
+}
+
+// handleAPIError handles API errors and converts them to domain errors
+func (c *Client) handleAPIError(statusCode int, body []byte) error {
+	// Reading customers needs an administrator key with read access
+	if statusCode == http.StatusForbidden {
+		return domain.NewScopeError()
+	}
+
+	message, code := httpjson.ErrorMessage(statusCode, body)
+	return domain.NewWooCommerceAPIError(statusCode, message, code)
+}
+
+// apiCustomerToDomain converts an API customer to a domain customer,
+// counting its orders when the API does not report them
+func (c *Client) apiCustomerToDomain(ctx context.Context, apiCustomer *APICustomer) (*domain.Customer, error) {
+	customerID, err := domain.NewCustomerID(apiCustomer.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	customer := domain.NewCustomer(customerID, apiCustomer.Email)
+	customer.FirstName = apiCustomer.FirstName
+	customer.LastName = apiCustomer.LastName
+
+	if apiCustomer.OrdersCount != nil {
+		customer.OrdersCount = *apiCustomer.OrdersCount
+	} else {
+		ordersCount, err := c.countOrders(ctx, customerID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count orders: %w", err)
+		}
+		customer.OrdersCount = ordersCount
+	}
+
+	return customer, nil
+}
+
+// parseTotal reads the X-WP-Total header, treating a missing header as 0
+func parseTotal(header http.Header) (int64, error) {
+	totalHeader := header.Get("X-WP-Total")
+	if totalHeader == "" {
+		return 0, nil
+	}
+
+	total, err := strconv.ParseInt(totalHeader, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse total count: %w", err)
+	}
+	return total, nil
+}
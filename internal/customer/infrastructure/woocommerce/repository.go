@@ -0,0 +1,33 @@
+package woocommerce
+
+import (
+	"context"
+	"woocommerce-mcp/internal/customer/domain"
+)
+
+// Repository implements the domain CustomerRepository interface
+type Repository struct {
+	client *Client
+}
+
+// NewRepository creates a new WooCommerce customer repository
+func NewRepository(client *Client) *Repository {
+	return &Repository{
+		client: client,
+	}
+}
+
+// SearchCustomers lists customers using the WooCommerce API
+func (r *Repository) SearchCustomers(ctx context.Context, criteria *domain.SearchCriteria) ([]*domain.Customer, error) {
+	return r.client.SearchCustomers(ctx, criteria)
+}
+
+// CountCustomers returns the total count of customers matching the criteria
+func (r *Repository) CountCustomers(ctx context.Context, criteria *domain.SearchCriteria) (int64, error) {
+	return r.client.CountCustomers(ctx, criteria)
+}
+
+// GetCustomer fetches a single customer by ID
+func (r *Repository) GetCustomer(ctx context.Context, id domain.CustomerID) (*domain.Customer, error) {
+	return r.client.GetCustomer(ctx, id)
+}
@@ -0,0 +1,14 @@
+package woocommerce
+
+// APICustomer represents a customer from the WooCommerce API. Only the
+// fields the tools return are decoded; addresses and other personal data
+// are left out on purpose.
+type APICustomer struct {
+	ID        int64  `json:"id"`
+	Email     string `json:"email"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	// OrdersCount is only sent by older API versions; the orders are
+	// counted separately when it is missing
+	OrdersCount *int64 `json:"orders_count"`
+}
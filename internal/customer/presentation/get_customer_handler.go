@@ -0,0 +1,122 @@
+package presentation
+
+import (
+	"context"
+	"fmt"
+
+	"woocommerce-mcp/internal/customer/application/get_customer"
+	"woocommerce-mcp/internal/customer/infrastructure/woocommerce"
+	kitDomain "woocommerce-mcp/kit/domain"
+	"woocommerce-mcp/kit/storeconfig"
+	"woocommerce-mcp/kit/toolargs"
+	"woocommerce-mcp/kit/tooltimeout"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// GetCustomerInput defines the input structure for the get_customer tool
+type GetCustomerInput struct {
+	BaseURL        string `json:"base_url,omitempty" jsonschema:"WooCommerce store base URL (e.g., https://example.com); defaults to WC_BASE_URL"`
+	ConsumerKey    string `json:"consumer_key,omitempty" jsonschema:"WooCommerce REST API consumer key; defaults to WC_CONSUMER_KEY for the default store"`
+	ConsumerSecret string `json:"consumer_secret,omitempty" jsonschema:"WooCommerce REST API consumer secret; defaults to WC_CONSUMER_SECRET for the default store"`
+	CustomerID     string `json:"customer_id" jsonschema:"ID of the customer"`
+}
+
+// UnmarshalJSON decodes the arguments, also accepting numbers and booleans
+// for the string fields
+func (in *GetCustomerInput) UnmarshalJSON(data []byte) error {
+	type plain GetCustomerInput
+	return toolargs.Unmarshal(data, (*plain)(in))
+}
+
+// GetCustomerOutput defines the output structure for the get_customer tool
+type GetCustomerOutput struct {
+	Message string `json:"message" jsonschema:"Human-readable message about the customer"`
+	Data    string `json:"data" jsonschema:"JSON-formatted customer data"`
+}
+
+// GetCustomerHandler handles get_customer tool calls
+type GetCustomerHandler struct{}
+
+// NewGetCustomerHandler creates a new GetCustomerHandler
+func NewGetCustomerHandler() *GetCustomerHandler {
+	return &GetCustomerHandler{}
+}
+
+// GetCustomerDescription describes the get_customer tool
+const GetCustomerDescription = "Get a WooCommerce customer by ID: name, email and order count. The API key must belong to an administrator."
+
+// GetToolDefinition returns the MCP tool definition for get_customer
+func (h *GetCustomerHandler) GetToolDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "get_customer",
+		Description: GetCustomerDescription,
+		InputSchema: toolargs.InputSchema[GetCustomerInput](),
+	}
+}
+
+// GetInputSchema returns the input schema for the JSON-RPC tools/list endpoint
+func (h *GetCustomerHandler) GetInputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"base_url":        map[string]string{"type": "string", "description": "WooCommerce store base URL"},
+			"consumer_key":    map[string]string{"type": "string", "description": "WooCommerce REST API consumer key"},
+			"consumer_secret": map[string]string{"type": "string", "description": "WooCommerce REST API consumer secret"},
+			"customer_id":     map[string]string{"type": "string", "description": "ID of the customer"},
+		},
+		"required": storeconfig.RequiredFields("base_url", "consumer_key", "consumer_secret", "customer_id"),
+	}
+}
+
+// ExecuteMCPTool implements the MCP tool execution
+func (h *GetCustomerHandler) ExecuteMCPTool(ctx context.Context, req *mcp.CallToolRequest, input GetCustomerInput) (*mcp.CallToolResult, GetCustomerOutput, error) {
+	// Fall back to the default store configured through the environment
+	storeconfig.ApplyDefaults(&input.BaseURL, &input.ConsumerKey, &input.ConsumerSecret)
+
+	// Validate required fields
+	if input.BaseURL == "" {
+		return nil, GetCustomerOutput{}, kitDomain.NewValidationError("base_url is required")
+	}
+	if input.ConsumerKey == "" {
+		return nil, GetCustomerOutput{}, kitDomain.NewValidationError("consumer_key is required")
+	}
+	if input.ConsumerSecret == "" {
+		return nil, GetCustomerOutput{}, kitDomain.NewValidationError("consumer_secret is required")
+	}
+	if input.CustomerID == "" {
+		return nil, GetCustomerOutput{}, kitDomain.NewValidationError("customer_id is required")
+	}
+
+	// Create WooCommerce client
+	config := woocommerce.NewConfig(input.BaseURL, input.ConsumerKey, input.ConsumerSecret)
+	client := woocommerce.NewClient(config)
+	repo := woocommerce.NewRepository(client)
+
+	// Bound the whole tool call, across all of its API requests
+	ctx, cancel := tooltimeout.WithTimeout(ctx)
+	defer cancel()
+
+	// Execute lookup
+	getter := get_customer.NewCustomerGetter(repo)
+	response, err := getter.Execute(ctx, &get_customer.GetRequest{
+		BaseURL:        input.BaseURL,
+		ConsumerKey:    input.ConsumerKey,
+		ConsumerSecret: input.ConsumerSecret,
+		CustomerID:     input.CustomerID,
+	})
+	if err != nil {
+		return nil, GetCustomerOutput{}, tooltimeout.Err(ctx, "get_customer", fmt.Errorf("failed to get customer: %w", err))
+	}
+
+	// Convert response to JSON
+	jsonData, err := response.ToJSON()
+	if err != nil {
+		return nil, GetCustomerOutput{}, fmt.Errorf("failed to serialize response: %w", err)
+	}
+
+	return nil, GetCustomerOutput{
+		Message: fmt.Sprintf("Customer %d has %d order(s)", response.Customer.ID, response.Customer.OrdersCount),
+		Data:    jsonData,
+	}, nil
+}
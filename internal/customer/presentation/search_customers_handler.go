@@ -0,0 +1,143 @@
+package presentation
+
+import (
+	"context"
+	"fmt"
+
+	"woocommerce-mcp/internal/customer/application/search_customers"
+	"woocommerce-mcp/internal/customer/infrastructure/woocommerce"
+	kitDomain "woocommerce-mcp/kit/domain"
+	"woocommerce-mcp/kit/storeconfig"
+	"woocommerce-mcp/kit/toolargs"
+	"woocommerce-mcp/kit/tooltimeout"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// SearchCustomersInput defines the input structure for the search_customers tool
+type SearchCustomersInput struct {
+	BaseURL        string `json:"base_url,omitempty" jsonschema:"WooCommerce store base URL (e.g., https://example.com); defaults to WC_BASE_URL"`
+	ConsumerKey    string `json:"consumer_key,omitempty" jsonschema:"WooCommerce REST API consumer key; defaults to WC_CONSUMER_KEY for the default store"`
+	ConsumerSecret string `json:"consumer_secret,omitempty" jsonschema:"WooCommerce REST API consumer secret; defaults to WC_CONSUMER_SECRET for the default store"`
+	Email          string `json:"email,omitempty" jsonschema:"Exact email address of the customer"`
+	Search         string `json:"search,omitempty" jsonschema:"Search term matched against customer names, usernames and emails"`
+	Role           string `json:"role,omitempty" jsonschema:"User role to filter by (customer, administrator, shop_manager, all; default: customer)"`
+	Page           string `json:"page,omitempty" jsonschema:"Page number for pagination (default: 1)"`
+	PerPage        string `json:"per_page,omitempty" jsonschema:"Number of customers per page (1-100, default: 10)"`
+}
+
+// UnmarshalJSON decodes the arguments, also accepting numbers and booleans
+// for the string fields
+func (in *SearchCustomersInput) UnmarshalJSON(data []byte) error {
+	type plain SearchCustomersInput
+	return toolargs.Unmarshal(data, (*plain)(in))
+}
+
+// SearchCustomersOutput defines the output structure for the search_customers tool
+type SearchCustomersOutput struct {
+	Message string `json:"message" jsonschema:"Human-readable message about the found customers"`
+	Data    string `json:"data" jsonschema:"JSON-formatted customer data"`
+}
+
+// SearchCustomersHandler handles search_customers tool calls
+type SearchCustomersHandler struct{}
+
+// NewSearchCustomersHandler creates a new SearchCustomersHandler
+func NewSearchCustomersHandler() *SearchCustomersHandler {
+	return &SearchCustomersHandler{}
+}
+
+// SearchCustomersDescription describes the search_customers tool
+const SearchCustomersDescription = "Look up WooCommerce customers by email, search term or role. Returns each customer's ID, name, email and order count. The API key must belong to an administrator."
+
+// GetToolDefinition returns the MCP tool definition for search_customers
+func (h *SearchCustomersHandler) GetToolDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "search_customers",
+		Description: SearchCustomersDescription,
+		InputSchema: toolargs.InputSchema[SearchCustomersInput](),
+	}
+}
+
+// GetInputSchema returns the input schema for the JSON-RPC tools/list endpoint
+func (h *SearchCustomersHandler) GetInputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"base_url":        map[string]string{"type": "string", "description": "WooCommerce store base URL"},
+			"consumer_key":    map[string]string{"type": "string", "description": "WooCommerce REST API consumer key"},
+			"consumer_secret": map[string]string{"type": "string", "description": "WooCommerce REST API consumer secret"},
+			"email":           map[string]string{"type": "string", "description": "Exact email address of the customer"},
+			"search":          map[string]string{"type": "string", "description": "Search term"},
+			"role":            map[string]string{"type": "string", "description": "User role (default: customer)"},
+			"per_page":        map[string]string{"type": "string", "description": "Items per page"},
+			"page":            map[string]string{"type": "string", "description": "Page number"},
+		},
+		"required": storeconfig.RequiredFields("base_url", "consumer_key", "consumer_secret"),
+	}
+}
+
+// ExecuteMCPTool implements the MCP tool execution
+func (h *SearchCustomersHandler) ExecuteMCPTool(ctx context.Context, req *mcp.CallToolRequest, input SearchCustomersInput) (*mcp.CallToolResult, SearchCustomersOutput, error) {
+	// Fall back to the default store configured through the environment
+	storeconfig.ApplyDefaults(&input.BaseURL, &input.ConsumerKey, &input.ConsumerSecret)
+
+	// Validate required fields
+	if input.BaseURL == "" {
+		return nil, SearchCustomersOutput{}, kitDomain.NewValidationError("base_url is required")
+	}
+	if input.ConsumerKey == "" {
+		return nil, SearchCustomersOutput{}, kitDomain.NewValidationError("consumer_key is required")
+	}
+	if input.ConsumerSecret == "" {
+		return nil, SearchCustomersOutput{}, kitDomain.NewValidationError("consumer_secret is required")
+	}
+
+	// Create WooCommerce client
+	config := woocommerce.NewConfig(input.BaseURL, input.ConsumerKey, input.ConsumerSecret)
+	client := woocommerce.NewClient(config)
+	repo := woocommerce.NewRepository(client)
+
+	// Create search request
+	request := &search_customers.SearchRequest{
+		BaseURL:        input.BaseURL,
+		ConsumerKey:    input.ConsumerKey,
+		ConsumerSecret: input.ConsumerSecret,
+		Search:         input.Search,
+		Email:          input.Email,
+		Role:           input.Role,
+		Page:           input.Page,
+		PerPage:        input.PerPage,
+	}
+
+	// Bound the whole tool call, across all of its API requests
+	ctx, cancel := tooltimeout.WithTimeout(ctx)
+	defer cancel()
+
+	// Execute search
+	searcher := search_customers.NewCustomerSearcher(repo)
+	response, err := searcher.Execute(ctx, request)
+	if err != nil {
+		return nil, SearchCustomersOutput{}, tooltimeout.Err(ctx, "search_customers", fmt.Errorf("failed to search customers: %w", err))
+	}
+
+	// Convert response to JSON
+	jsonData, err := response.ToJSON()
+	if err != nil {
+		return nil, SearchCustomersOutput{}, fmt.Errorf("failed to serialize response: %w", err)
+	}
+
+	// Create human-readable message
+	var message string
+	if len(response.Customers) == 0 {
+		message = "No customers found"
+	} else {
+		message = fmt.Sprintf("Found %d customer(s) out of %d total (page %d of %d)",
+			len(response.Customers), response.TotalCount, response.CurrentPage, response.TotalPages)
+	}
+
+	return nil, SearchCustomersOutput{
+		Message: message,
+		Data:    jsonData,
+	}, nil
+}
@@ -0,0 +1,22 @@
+package list_terms
+
+import "woocommerce-mcp/internal/post/domain"
+
+// ListRequest represents a request to list post categories or tags
+type ListRequest struct {
+	BaseURL  string          `json:"base_url"`
+	Taxonomy domain.Taxonomy `json:"taxonomy"`
+
+	// Filtering
+	Search    string `json:"search,omitempty"`
+	Parent    string `json:"parent,omitempty"`
+	HideEmpty string `json:"hide_empty,omitempty"`
+
+	// Pagination
+	Page    string `json:"page,omitempty"`
+	PerPage string `json:"per_page,omitempty"`
+
+	// Sorting
+	OrderBy string `json:"orderby,omitempty"`
+	Order   string `json:"order,omitempty"`
+}
@@ -0,0 +1,77 @@
+package list_terms
+
+import (
+	"encoding/json"
+	"woocommerce-mcp/internal/post/domain"
+	"woocommerce-mcp/kit/pagination"
+)
+
+// ListResponse represents a response from listing post categories or tags
+type ListResponse struct {
+	Taxonomy    string    `json:"taxonomy"`
+	Terms       []TermDTO `json:"terms"`
+	TotalCount  int64     `json:"total_count"`
+	CurrentPage int       `json:"current_page"`
+	PerPage     int       `json:"per_page"`
+	TotalPages  int       `json:"total_pages"`
+	HasNext     bool      `json:"has_next"`
+	HasPrev     bool      `json:"has_prev"`
+
+	// Pagination repeats the flat fields above with the adjacent page numbers
+	Pagination *pagination.Pagination `json:"pagination"`
+}
+
+// TermDTO represents a category or tag data transfer object.
+// Parent is only set for categories, where 0 marks a top-level category.
+type TermDTO struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	Description string `json:"description,omitempty"`
+	Count       int    `json:"count"`
+	Parent      *int64 `json:"parent,omitempty"`
+}
+
+// ToJSON converts the response to JSON string
+func (r *ListResponse) ToJSON() (string, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// FromDomainTerms converts domain terms to response DTOs
+func FromDomainTerms(taxonomy domain.Taxonomy, terms []*domain.Term, totalCount int64, currentPage, perPage int) *ListResponse {
+	termDTOs := make([]TermDTO, len(terms))
+	for i, term := range terms {
+		termDTOs[i] = TermDTO{
+			ID:          term.ID,
+			Name:        term.Name,
+			Slug:        term.Slug,
+			Description: term.Description,
+			Count:       term.Count,
+		}
+		if taxonomy.IsHierarchical() {
+			parent := term.Parent
+			termDTOs[i].Parent = &parent
+		}
+	}
+
+	totalPages := int(totalCount) / perPage
+	if int(totalCount)%perPage != 0 {
+		totalPages++
+	}
+
+	return &ListResponse{
+		Taxonomy:    string(taxonomy),
+		Terms:       termDTOs,
+		TotalCount:  totalCount,
+		CurrentPage: currentPage,
+		PerPage:     perPage,
+		TotalPages:  totalPages,
+		HasNext:     currentPage < totalPages,
+		HasPrev:     currentPage > 1,
+		Pagination:  pagination.New(totalCount, currentPage, perPage, totalPages),
+	}
+}
@@ -0,0 +1,111 @@
+package list_terms
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"woocommerce-mcp/internal/post/domain"
+	"woocommerce-mcp/kit/pagination"
+)
+
+// TermLister handles category and tag listing operations
+type TermLister struct {
+	repository domain.TermRepository
+}
+
+// NewTermLister creates a new TermLister
+func NewTermLister(repository domain.TermRepository) *TermLister {
+	return &TermLister{
+		repository: repository,
+	}
+}
+
+// Execute lists categories or tags based on the provided request
+func (l *TermLister) Execute(ctx context.Context, req *ListRequest) (*ListResponse, error) {
+	criteria, err := requestToCriteria(req)
+	if err != nil {
+		return nil, err
+	}
+
+	terms, err := l.repository.ListTerms(ctx, criteria)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list terms: %w", err)
+	}
+
+	totalCount, err := l.repository.CountTerms(ctx, criteria)
+	if err != nil {
+		// If count fails, fall back to the number of terms on this page
+		totalCount = int64(len(terms))
+	}
+
+	return FromDomainTerms(criteria.Taxonomy, terms, totalCount, criteria.Page, criteria.PerPage), nil
+}
+
+// requestToCriteria converts a ListRequest to domain TermCriteria
+func requestToCriteria(req *ListRequest) (*domain.TermCriteria, error) {
+	if req.BaseURL == "" {
+		return nil, domain.NewValidationError("base_url is required")
+	}
+	if !req.Taxonomy.IsValid() {
+		return nil, domain.NewValidationError(fmt.Sprintf("unsupported taxonomy %q", req.Taxonomy))
+	}
+
+	criteria := &domain.TermCriteria{
+		Taxonomy: req.Taxonomy,
+		Search:   req.Search,
+		Page:     1,
+		PerPage:  10,
+		OrderBy:  "name",
+		Order:    "asc",
+	}
+
+	if req.Parent != "" {
+		if !req.Taxonomy.IsHierarchical() {
+			return nil, domain.NewValidationError("parent is only supported for categories")
+		}
+		parent, err := strconv.ParseInt(req.Parent, 10, 64)
+		if err != nil || parent < 0 {
+			return nil, domain.NewValidationError("parent must be a non-negative integer")
+		}
+		criteria.Parent = &parent
+	}
+
+	if req.HideEmpty != "" {
+		hideEmpty, err := strconv.ParseBool(req.HideEmpty)
+		if err != nil {
+			return nil, domain.NewValidationError("hide_empty must be true or false")
+		}
+		criteria.HideEmpty = hideEmpty
+	}
+
+	if req.Page != "" {
+		page, err := pagination.ParsePage(req.Page)
+		if err != nil {
+			return nil, domain.NewValidationError("page " + err.Error())
+		}
+		criteria.Page = page
+	}
+
+	if req.PerPage != "" {
+		perPage, err := pagination.ParsePerPage(req.PerPage)
+		if err != nil {
+			return nil, domain.NewValidationError("per_page " + err.Error())
+		}
+		if perPage > pagination.MaxPerPage {
+			perPage = pagination.MaxPerPage
+		}
+		criteria.PerPage = perPage
+	}
+
+	if req.OrderBy != "" {
+		criteria.OrderBy = req.OrderBy
+	}
+	if req.Order != "" {
+		if req.Order != "asc" && req.Order != "desc" {
+			return nil, domain.NewValidationError("order must be 'asc' or 'desc'")
+		}
+		criteria.Order = req.Order
+	}
+
+	return criteria, nil
+}
@@ -0,0 +1,113 @@
+package list_terms
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"woocommerce-mcp/internal/post/domain"
+)
+
+// stubTermRepository answers with its terms and records the criteria
+type stubTermRepository struct {
+	terms    []*domain.Term
+	countErr error
+	criteria []*domain.TermCriteria
+}
+
+func (r *stubTermRepository) ListTerms(ctx context.Context, criteria *domain.TermCriteria) ([]*domain.Term, error) {
+	r.criteria = append(r.criteria, criteria)
+	return r.terms, nil
+}
+
+func (r *stubTermRepository) CountTerms(ctx context.Context, criteria *domain.TermCriteria) (int64, error) {
+	if r.countErr != nil {
+		return 0, r.countErr
+	}
+	return 25, nil
+}
+
+// nestedTerms returns News > Releases > Beta in the given taxonomy
+func nestedTerms(taxonomy domain.Taxonomy) []*domain.Term {
+	return []*domain.Term{
+		{ID: 5, Taxonomy: taxonomy, Name: "News", Parent: 0},
+		{ID: 6, Taxonomy: taxonomy, Name: "Releases", Parent: 5},
+		{ID: 7, Taxonomy: taxonomy, Name: "Beta", Parent: 6},
+	}
+}
+
+func TestCategoriesExposeTheirParent(t *testing.T) {
+	repository := &stubTermRepository{terms: nestedTerms(domain.TaxonomyCategory)}
+	response, err := NewTermLister(repository).Execute(context.Background(), &ListRequest{
+		BaseURL:  "https://blog.example",
+		Taxonomy: domain.TaxonomyCategory,
+		Parent:   "0",
+	})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	for i, want := range []int64{0, 5, 6} {
+		if parent := response.Terms[i].Parent; parent == nil || *parent != want {
+			t.Errorf("term %d parent = %v, want %d", response.Terms[i].ID, parent, want)
+		}
+	}
+	if parent := repository.criteria[0].Parent; parent == nil || *parent != 0 {
+		t.Errorf("criteria parent = %v, want 0 for top-level categories", parent)
+	}
+	if response.TotalCount != 25 || response.TotalPages != 3 || !response.HasNext {
+		t.Errorf("got total %d over %d pages, want 25 over 3", response.TotalCount, response.TotalPages)
+	}
+}
+
+func TestTagsHaveNoParent(t *testing.T) {
+	repository := &stubTermRepository{terms: nestedTerms(domain.TaxonomyTag)}
+	response, err := NewTermLister(repository).Execute(context.Background(), &ListRequest{
+		BaseURL:  "https://blog.example",
+		Taxonomy: domain.TaxonomyTag,
+	})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	for _, term := range response.Terms {
+		if term.Parent != nil {
+			t.Errorf("tag %d has parent %d, want none", term.ID, *term.Parent)
+		}
+	}
+
+	_, err = NewTermLister(repository).Execute(context.Background(), &ListRequest{
+		BaseURL:  "https://blog.example",
+		Taxonomy: domain.TaxonomyTag,
+		Parent:   "5",
+	})
+	if err == nil {
+		t.Error("listing tags by parent succeeded, want a validation error")
+	}
+}
+
+func TestFailedCountFallsBackToThePage(t *testing.T) {
+	repository := &stubTermRepository{terms: nestedTerms(domain.TaxonomyCategory), countErr: errors.New("HEAD not allowed")}
+	response, err := NewTermLister(repository).Execute(context.Background(), &ListRequest{
+		BaseURL:  "https://blog.example",
+		Taxonomy: domain.TaxonomyCategory,
+	})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if response.TotalCount != 3 {
+		t.Errorf("total_count = %d, want the 3 terms of the page", response.TotalCount)
+	}
+}
+
+func TestListRequestIsValidated(t *testing.T) {
+	for name, request := range map[string]*ListRequest{
+		"unknown taxonomy": {BaseURL: "https://blog.example", Taxonomy: "product_cat"},
+		"negative parent":  {BaseURL: "https://blog.example", Taxonomy: domain.TaxonomyCategory, Parent: "-1"},
+		"hide_empty":       {BaseURL: "https://blog.example", Taxonomy: domain.TaxonomyCategory, HideEmpty: "maybe"},
+		"order":            {BaseURL: "https://blog.example", Taxonomy: domain.TaxonomyCategory, Order: "up"},
+	} {
+		if _, err := NewTermLister(&stubTermRepository{}).Execute(context.Background(), request); err == nil {
+			t.Errorf("%s: succeeded, want a validation error", name)
+		}
+	}
+}
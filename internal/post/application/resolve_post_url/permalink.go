@@ -0,0 +1,93 @@
+package resolve_post_url
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"woocommerce-mcp/internal/post/domain"
+)
+
+// Permalink is what identifies a post in its URL: the ID of a plain
+// permalink such as ?p=123, or the slug ending a pretty permalink such as
+// /2023/05/my-post/
+type Permalink struct {
+	ID   domain.PostID
+	Slug string
+
+	// Page is set for ?page_id= permalinks, which only link pages
+	Page bool
+
+	// Path is the path of the URL without trailing slashes, to tell apart
+	// pages sharing a slug under different parents
+	Path string
+}
+
+// ParsePermalink extracts the post ID or slug from a pasted URL. A missing
+// scheme is taken to be https. Trailing slashes, query strings and
+// fragments are ignored, as are a trailing page number of a paginated
+// post (/my-post/2/) and an .html suffix.
+func ParsePermalink(rawURL string) (*Permalink, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" {
+		return nil, domain.NewValidationError("url is required")
+	}
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return nil, domain.NewValidationError(fmt.Sprintf("url: %q is not a valid URL", rawURL))
+	}
+
+	// Plain permalinks carry the ID in the query string
+	for _, param := range []string{"p", "page_id"} {
+		value := strings.TrimSpace(u.Query().Get(param))
+		if value == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || id <= 0 {
+			return nil, domain.NewValidationError(fmt.Sprintf("url: %s=%s is not a post ID", param, value))
+		}
+		return &Permalink{ID: domain.PostID(id), Page: param == "page_id"}, nil
+	}
+
+	// WordPress does not give posts numeric slugs, so trailing numbers are
+	// page numbers or date archive parts
+	segments := strings.FieldsFunc(u.Path, func(r rune) bool { return r == '/' })
+	for len(segments) > 0 && isNumber(segments[len(segments)-1]) {
+		segments = segments[:len(segments)-1]
+	}
+	if len(segments) == 0 {
+		return nil, domain.NewValidationError(fmt.Sprintf("url: %q has no post slug or ?p= post ID", rawURL))
+	}
+
+	slug := strings.ToLower(segments[len(segments)-1])
+	slug = strings.TrimSuffix(strings.TrimSuffix(slug, ".html"), ".htm")
+	return &Permalink{
+		Slug: slug,
+		Path: "/" + strings.Join(segments, "/"),
+	}, nil
+}
+
+// SiteURL returns the scheme and host of a pasted URL, or "" when it has
+// none. It stands in for the site base URL when none is given, which is
+// right unless WordPress is installed in a subdirectory.
+func SiteURL(rawURL string) string {
+	rawURL = strings.TrimSpace(rawURL)
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+// isNumber reports whether a path segment is all digits
+func isNumber(segment string) bool {
+	_, err := strconv.ParseUint(segment, 10, 64)
+	return err == nil
+}
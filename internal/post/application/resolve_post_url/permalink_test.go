@@ -0,0 +1,60 @@
+package resolve_post_url
+
+import (
+	"errors"
+	"testing"
+
+	"woocommerce-mcp/internal/post/domain"
+)
+
+func TestParsePermalink(t *testing.T) {
+	for rawURL, want := range map[string]Permalink{
+		"https://blog.example/2023/05/my-post/":       {Slug: "my-post", Path: "/2023/05/my-post"},
+		"https://blog.example/my-post?utm_source=x#c": {Slug: "my-post", Path: "/my-post"},
+		"blog.example/My-Post.html":                   {Slug: "my-post", Path: "/My-Post.html"},
+		"https://blog.example/my-post/2/":             {Slug: "my-post", Path: "/my-post"},
+		"https://blog.example/about/team/":            {Slug: "team", Path: "/about/team"},
+		"https://blog.example/?p=123":                 {ID: 123},
+		"https://blog.example/?page_id=7":             {ID: 7, Page: true},
+		" https://blog.example/2023/05/my-post ":      {Slug: "my-post", Path: "/2023/05/my-post"},
+	} {
+		got, err := ParsePermalink(rawURL)
+		if err != nil {
+			t.Errorf("%q: %v", rawURL, err)
+			continue
+		}
+		if *got != want {
+			t.Errorf("%q = %+v, want %+v", rawURL, *got, want)
+		}
+	}
+}
+
+func TestParsePermalinkRejectsURLsWithoutAPost(t *testing.T) {
+	for _, rawURL := range []string{
+		"",
+		"https://blog.example/",
+		"https://blog.example/2023/05/",
+		"https://blog.example/?p=abc",
+		"https://blog.example/?p=-1",
+		"https://",
+	} {
+		_, err := ParsePermalink(rawURL)
+		var postErr *domain.PostError
+		if !errors.As(err, &postErr) || postErr.Type != "ValidationError" {
+			t.Errorf("%q: got error %v, want a ValidationError", rawURL, err)
+		}
+	}
+}
+
+func TestSiteURL(t *testing.T) {
+	for rawURL, want := range map[string]string{
+		"https://blog.example/2023/05/my-post/": "https://blog.example",
+		"http://blog.example:8080/?p=1":         "http://blog.example:8080",
+		"blog.example/my-post":                  "https://blog.example",
+		"https://":                              "",
+	} {
+		if got := SiteURL(rawURL); got != want {
+			t.Errorf("SiteURL(%q) = %q, want %q", rawURL, got, want)
+		}
+	}
+}
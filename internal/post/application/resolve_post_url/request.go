@@ -0,0 +1,14 @@
+package resolve_post_url
+
+// ResolveRequest represents a request for the post a pasted permalink
+// points to. The site travels in the repository the resolver is built
+// with, not in the request.
+type ResolveRequest struct {
+	// URL is the permalink, e.g. https://example.com/2023/05/my-post/ or
+	// https://example.com/?p=123
+	URL string `json:"url"`
+
+	// PostType is the REST base of the post type the permalink belongs to;
+	// empty means regular posts, or pages for ?page_id= links
+	PostType string `json:"post_type,omitempty"`
+}
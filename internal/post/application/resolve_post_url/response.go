@@ -0,0 +1,25 @@
+package resolve_post_url
+
+import (
+	"woocommerce-mcp/internal/post/application/search_posts"
+	"woocommerce-mcp/kit/jsonformat"
+)
+
+// ResolveResponse represents the post a permalink points to
+type ResolveResponse struct {
+	URL      string `json:"url"`
+	PostType string `json:"post_type"`
+
+	// ID is set for plain permalinks such as ?p=123, Slug for pretty ones
+	ID   int64  `json:"id,omitempty"`
+	Slug string `json:"slug,omitempty"`
+
+	// Found tells whether a post matched; Post is only set when one did
+	Found bool                  `json:"found"`
+	Post  *search_posts.PostDTO `json:"post,omitempty"`
+}
+
+// ToJSON converts the response to a compact JSON string, or an indented one when pretty is set
+func (r *ResolveResponse) ToJSON(pretty bool) (string, error) {
+	return jsonformat.Marshal(r, pretty)
+}
@@ -0,0 +1,87 @@
+package resolve_post_url
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"woocommerce-mcp/internal/post/application/search_posts"
+	"woocommerce-mcp/internal/post/domain"
+)
+
+// maxSlugMatches bounds the posts fetched for a slug. Only pages can share
+// a slug, under different parents.
+const maxSlugMatches = 10
+
+// PostResolver finds the post a pasted permalink points to
+type PostResolver struct {
+	repository domain.PostRepository
+}
+
+// NewPostResolver creates a new PostResolver
+func NewPostResolver(repository domain.PostRepository) *PostResolver {
+	return &PostResolver{
+		repository: repository,
+	}
+}
+
+// Execute looks the post of a permalink up by ID or slug in one request.
+// A permalink matching no post is not an error; the response says so.
+func (r *PostResolver) Execute(ctx context.Context, req *ResolveRequest) (*ResolveResponse, error) {
+	permalink, err := ParsePermalink(req.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	postType, err := search_posts.ParsePostType(req.PostType)
+	if err != nil {
+		return nil, err
+	}
+	if permalink.Page && strings.TrimSpace(req.PostType) == "" {
+		postType = "pages"
+	}
+
+	criteria := &domain.SearchCriteria{
+		Type:    postType,
+		Page:    1,
+		PerPage: 1,
+	}
+	if permalink.ID != 0 {
+		criteria.Include = []domain.PostID{permalink.ID}
+	} else {
+		criteria.Slug = permalink.Slug
+		criteria.PerPage = maxSlugMatches
+	}
+
+	posts, err := r.repository.SearchPosts(ctx, criteria)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find post: %w", err)
+	}
+
+	response := &ResolveResponse{
+		URL:      strings.TrimSpace(req.URL),
+		PostType: string(postType),
+		ID:       permalink.ID.Value(),
+		Slug:     permalink.Slug,
+	}
+	if post := pickPost(posts, permalink); post != nil {
+		dto := search_posts.FromDomainPosts([]*domain.Post{post}, 1, 1, 1).Posts[0]
+		response.Found = true
+		response.Post = &dto
+	}
+	return response, nil
+}
+
+// pickPost returns the post whose permalink has the pasted path, or the
+// first post when none has, e.g. because the site moved
+func pickPost(posts []*domain.Post, permalink *Permalink) *domain.Post {
+	if len(posts) == 0 {
+		return nil
+	}
+	for _, post := range posts {
+		if u, err := url.Parse(post.Permalink); err == nil && strings.TrimRight(u.Path, "/") == permalink.Path {
+			return post
+		}
+	}
+	return posts[0]
+}
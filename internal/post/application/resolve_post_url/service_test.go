@@ -0,0 +1,85 @@
+package resolve_post_url
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"woocommerce-mcp/internal/post/domain"
+)
+
+// stubRepository answers every search with its posts and records the
+// criteria it was asked for
+type stubRepository struct {
+	domain.PostRepository
+	posts    []*domain.Post
+	err      error
+	searches []*domain.SearchCriteria
+}
+
+func (r *stubRepository) SearchPosts(ctx context.Context, criteria *domain.SearchCriteria) ([]*domain.Post, error) {
+	r.searches = append(r.searches, criteria)
+	return r.posts, r.err
+}
+
+func TestPlainPermalinksAreLookedUpByID(t *testing.T) {
+	for rawURL, wantType := range map[string]domain.PostType{
+		"https://blog.example/?p=123":       domain.DefaultPostType,
+		"https://blog.example/?page_id=123": "pages",
+	} {
+		repository := &stubRepository{posts: []*domain.Post{{ID: 123, Title: "Hello"}}}
+		response, err := NewPostResolver(repository).Execute(context.Background(), &ResolveRequest{URL: rawURL})
+		if err != nil {
+			t.Fatalf("%s: %v", rawURL, err)
+		}
+		criteria := repository.searches[0]
+		if len(criteria.Include) != 1 || criteria.Include[0] != 123 || criteria.Slug != "" || criteria.Type != wantType {
+			t.Errorf("%s: searched %+v, want post 123 of type %s", rawURL, criteria, wantType)
+		}
+		if !response.Found || response.Post == nil || response.Post.ID != 123 || response.ID != 123 {
+			t.Errorf("%s: response %+v, want post 123 found", rawURL, response)
+		}
+	}
+}
+
+func TestPagesSharingASlugArePickedByPath(t *testing.T) {
+	repository := &stubRepository{posts: []*domain.Post{
+		{ID: 10, Slug: "team", Permalink: "https://blog.example/careers/team/"},
+		{ID: 11, Slug: "team", Permalink: "https://blog.example/about/team/"},
+	}}
+	response, err := NewPostResolver(repository).Execute(context.Background(), &ResolveRequest{
+		URL:      "https://blog.example/about/team/",
+		PostType: "page",
+	})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if criteria := repository.searches[0]; criteria.Slug != "team" || criteria.Type != "pages" || criteria.PerPage != maxSlugMatches {
+		t.Errorf("searched %+v, want up to %d pages with slug team", criteria, maxSlugMatches)
+	}
+	if !response.Found || response.Post.ID != 11 {
+		t.Errorf("response %+v, want page 11 under /about", response)
+	}
+}
+
+func TestUnmatchedPermalinksAreNotFound(t *testing.T) {
+	response, err := NewPostResolver(&stubRepository{}).Execute(context.Background(), &ResolveRequest{URL: "https://blog.example/gone/"})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if response.Found || response.Post != nil || response.Slug != "gone" {
+		t.Errorf("response %+v, want slug gone not found", response)
+	}
+}
+
+func TestLookupErrorsAreReturned(t *testing.T) {
+	failure := errors.New("connection refused")
+	_, err := NewPostResolver(&stubRepository{err: failure}).Execute(context.Background(), &ResolveRequest{URL: "https://blog.example/my-post/"})
+	if !errors.Is(err, failure) {
+		t.Errorf("error = %v, want the lookup error wrapped", err)
+	}
+
+	if _, err := NewPostResolver(&stubRepository{}).Execute(context.Background(), &ResolveRequest{URL: "https://blog.example/my-post/", PostType: "users"}); err == nil {
+		t.Error("post_type users was accepted")
+	}
+}
@@ -1,41 +1,102 @@
 package search_posts
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 	"woocommerce-mcp/internal/post/domain"
+	"woocommerce-mcp/kit/pagination"
+	"woocommerce-mcp/kit/safemode"
+	"woocommerce-mcp/kit/searchterm"
 )
 
 // Query represents a search posts query
 type Query struct {
 	BaseURL    string
+	PostType   domain.PostType
 	Search     string
-	Status     domain.PostStatus
+	Statuses   []domain.PostStatus
 	Author     int64
 	Categories []int64
 	Tags       []int64
+	Sticky     *bool
 	Before     string
 	After      string
 	Page       int
 	PerPage    int
 	OrderBy    string
 	Order      string
+
+	// SearchColumns limits the columns the search term matches in
+	SearchColumns []domain.SearchColumn
+
+	// PerPageCapped is set when the requested per_page was clamped
+	PerPageCapped bool
+
+	// IncludeCommentCount requests the comment count of each post
+	IncludeCommentCount bool
+
+	// Embed requests the author, featured image and terms of each post
+	Embed bool
+
+	// ContentFormat selects rendered or raw post content
+	ContentFormat domain.ContentFormat
+
+	// Username and ApplicationPassword authenticate the requests; both are
+	// empty for anonymous requests
+	Username            string
+	ApplicationPassword string
 }
 
 // NewQueryFromRequest creates a new Query from a SearchRequest
 func NewQueryFromRequest(req *SearchRequest) (*Query, error) {
 	query := &Query{
 		BaseURL: req.BaseURL,
-		Search:  req.Search,
+		Search:  searchterm.Sanitize(req.Search),
 		Before:  req.Before,
 		After:   req.After,
 		OrderBy: req.OrderBy,
 		Order:   req.Order,
+
+		Username:            strings.TrimSpace(req.Username),
+		ApplicationPassword: strings.TrimSpace(req.ApplicationPassword),
 	}
 
-	// Parse status
-	if req.Status != "" {
-		query.Status = domain.PostStatus(req.Status)
+	// Safe mode only ever returns published posts, so it also drops the
+	// credentials that would unlock any others
+	if safemode.Enabled() {
+		if status := safemode.UnpublishedStatus(req.Status); status != "" {
+			return nil, domain.NewValidationError("status: " + safemode.Rejection(fmt.Sprintf("status '%s'", status)))
+		}
+		query.Statuses = []domain.PostStatus{domain.PostStatusPublish}
+		query.Username = ""
+		query.ApplicationPassword = ""
+	}
+	if (query.Username == "") != (query.ApplicationPassword == "") {
+		return nil, domain.NewValidationError("username and application_password must be given together")
+	}
+
+	// Parse post type
+	postType, err := ParsePostType(req.PostType)
+	if err != nil {
+		return nil, err
+	}
+	query.PostType = postType
+
+	// Parse search columns
+	searchColumns, err := parseSearchColumns(req.SearchColumns)
+	if err != nil {
+		return nil, err
+	}
+	query.SearchColumns = searchColumns
+
+	// Parse statuses
+	if !safemode.Enabled() {
+		statuses, err := parseStatuses(req.Status)
+		if err != nil {
+			return nil, err
+		}
+		query.Statuses = statuses
 	}
 
 	// Parse author
@@ -65,49 +126,191 @@ func NewQueryFromRequest(req *SearchRequest) (*Query, error) {
 		}
 	}
 
+	// Parse sticky
+	if sticky := strings.TrimSpace(req.Sticky); sticky != "" {
+		value, err := strconv.ParseBool(sticky)
+		if err != nil {
+			return nil, domain.NewValidationError("sticky must be true or false")
+		}
+		query.Sticky = &value
+	}
+
 	// Parse pagination
 	if req.Page != "" {
-		if page, err := strconv.Atoi(req.Page); err == nil && page > 0 {
-			query.Page = page
+		page, err := pagination.ParsePage(req.Page)
+		if err != nil {
+			return nil, domain.NewValidationError("page " + err.Error())
 		}
+		query.Page = page
 	}
 	if query.Page == 0 {
 		query.Page = 1 // Default
 	}
 
 	if req.PerPage != "" {
-		if perPage, err := strconv.Atoi(req.PerPage); err == nil && perPage > 0 {
-			query.PerPage = perPage
+		perPage, err := pagination.ParsePerPage(req.PerPage)
+		if err != nil {
+			return nil, domain.NewValidationError("per_page " + err.Error())
 		}
+		query.PerPage = perPage
 	}
 	if query.PerPage == 0 {
 		query.PerPage = 10 // Default
 	}
+	if query.PerPage > pagination.MaxPerPage {
+		query.PerPage = pagination.MaxPerPage
+		query.PerPageCapped = true
+	}
 
-	// Set defaults for sorting
+	// Set defaults for sorting. WordPress only accepts relevance ordering
+	// alongside a search term, so prefer it whenever one is given.
 	if query.OrderBy == "" {
-		query.OrderBy = "date"
+		if query.Search != "" {
+			query.OrderBy = "relevance"
+		} else {
+			query.OrderBy = "date"
+		}
+	}
+	if query.OrderBy == "relevance" && strings.TrimSpace(query.Search) == "" {
+		return nil, domain.NewValidationError("orderby: 'relevance' requires a non-empty search term")
 	}
 	if query.Order == "" {
 		query.Order = "desc"
 	}
 
+	if req.IncludeCommentCount != "" {
+		includeCommentCount, err := strconv.ParseBool(req.IncludeCommentCount)
+		if err != nil {
+			return nil, domain.NewValidationError("include_comment_count must be true or false")
+		}
+		query.IncludeCommentCount = includeCommentCount
+	}
+
+	if req.Embed != "" {
+		embed, err := strconv.ParseBool(req.Embed)
+		if err != nil {
+			return nil, domain.NewValidationError("embed must be true or false")
+		}
+		query.Embed = embed
+	}
+
+	contentFormat, err := parseContentFormat(req.ContentFormat)
+	if err != nil {
+		return nil, err
+	}
+	if contentFormat == domain.ContentFormatRaw && safemode.Enabled() {
+		return nil, domain.NewValidationError(fmt.Sprintf("content_format: raw content needs credentials, which the server ignores in safe mode (%s)", safemode.Env))
+	}
+	if contentFormat == domain.ContentFormatRaw && query.Username == "" {
+		return nil, domain.NewValidationError("content_format: raw content is only returned to authenticated requests; pass username and application_password (a WordPress application password of a user who can edit the posts)")
+	}
+	query.ContentFormat = contentFormat
+
 	return query, nil
 }
 
 // ToSearchCriteria converts the query to domain search criteria
 func (q *Query) ToSearchCriteria() *domain.SearchCriteria {
 	return &domain.SearchCriteria{
-		Search:     q.Search,
-		Status:     q.Status,
-		Author:     q.Author,
-		Categories: q.Categories,
-		Tags:       q.Tags,
-		Before:     q.Before,
-		After:      q.After,
-		Page:       q.Page,
-		PerPage:    q.PerPage,
-		OrderBy:    q.OrderBy,
-		Order:      q.Order,
+		Type:          q.PostType,
+		Search:        q.Search,
+		SearchColumns: q.SearchColumns,
+		Statuses:      q.Statuses,
+		Author:        q.Author,
+		Categories:    q.Categories,
+		Tags:          q.Tags,
+		Sticky:        q.Sticky,
+		Before:        q.Before,
+		After:         q.After,
+		Page:          q.Page,
+		PerPage:       q.PerPage,
+		OrderBy:       q.OrderBy,
+		Order:         q.Order,
+		ContentFormat: q.ContentFormat,
+		Embed:         q.Embed,
+	}
+}
+
+// postTypeAliases maps post type names to the REST base WordPress serves
+// them under, as callers often pass the name instead
+var postTypeAliases = map[string]domain.PostType{
+	"post":       "posts",
+	"page":       "pages",
+	"attachment": "media",
+}
+
+// ParsePostType parses the post type to search; empty means regular posts.
+// Type names such as page are accepted for their REST base.
+func ParsePostType(value string) (domain.PostType, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return domain.DefaultPostType, nil
+	}
+	if alias, ok := postTypeAliases[value]; ok {
+		return alias, nil
+	}
+
+	postType := domain.PostType(value)
+	if !postType.IsValid() {
+		return "", domain.NewValidationError(fmt.Sprintf("post_type: %q does not name a post type; use the REST base of the type, e.g. posts, pages or portfolio", value))
+	}
+	return postType, nil
+}
+
+// parseContentFormat parses the content format; empty means rendered
+func parseContentFormat(value string) (domain.ContentFormat, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return domain.ContentFormatRendered, nil
+	}
+
+	format := domain.ContentFormat(value)
+	if !format.IsValid() {
+		return "", domain.NewValidationError(fmt.Sprintf("content_format: unsupported format %q; must be rendered or raw", value))
+	}
+	return format, nil
+}
+
+// parseStatuses parses a comma-separated list of post statuses, dropping
+// repeats; an empty list means no status filter
+func parseStatuses(value string) ([]domain.PostStatus, error) {
+	var statuses []domain.PostStatus
+	seen := make(map[domain.PostStatus]bool)
+	for _, part := range strings.Split(value, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" {
+			continue
+		}
+		status := domain.PostStatus(part)
+		if !status.IsValid() {
+			return nil, domain.NewValidationError(fmt.Sprintf("status: unsupported post status %q; must be publish, future, draft, pending, private or trash", part))
+		}
+		if !seen[status] {
+			seen[status] = true
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses, nil
+}
+
+// parseSearchColumns parses a comma-separated list of search columns,
+// dropping repeats; an empty list searches every column
+func parseSearchColumns(value string) ([]domain.SearchColumn, error) {
+	var columns []domain.SearchColumn
+	seen := make(map[domain.SearchColumn]bool)
+	for _, part := range strings.Split(value, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" {
+			continue
+		}
+		column := domain.SearchColumn(part)
+		if !column.IsValid() {
+			return nil, domain.NewValidationError(fmt.Sprintf("search_columns: unsupported column %q; must be post_title, post_content or post_excerpt", part))
+		}
+		if !seen[column] {
+			seen[column] = true
+			columns = append(columns, column)
+		}
 	}
+	return columns, nil
 }
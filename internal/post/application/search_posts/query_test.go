@@ -0,0 +1,274 @@
+package search_posts
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"woocommerce-mcp/internal/post/domain"
+	"woocommerce-mcp/kit/safemode"
+)
+
+func TestSafeModeRejectsUnpublishedStatuses(t *testing.T) {
+	t.Setenv(safemode.Env, "true")
+
+	for _, status := range []string{"draft", "publish,private", "future"} {
+		_, err := NewQueryFromRequest(&SearchRequest{BaseURL: "https://blog.example", Status: status})
+		if err == nil || !strings.Contains(err.Error(), "safe mode") {
+			t.Errorf("status=%s: got error %v, want a safe mode rejection", status, err)
+		}
+	}
+}
+
+func TestSafeModeForcesPublishAndDropsCredentials(t *testing.T) {
+	t.Setenv(safemode.Env, "true")
+
+	query, err := NewQueryFromRequest(&SearchRequest{
+		BaseURL:             "https://blog.example",
+		Username:            "editor",
+		ApplicationPassword: "abcd efgh",
+	})
+	if err != nil {
+		t.Fatalf("NewQueryFromRequest: %v", err)
+	}
+	if len(query.Statuses) != 1 || query.Statuses[0] != domain.PostStatusPublish {
+		t.Errorf("statuses = %v, want [publish]", query.Statuses)
+	}
+	if query.Username != "" || query.ApplicationPassword != "" {
+		t.Errorf("credentials kept in safe mode: %q/%q", query.Username, query.ApplicationPassword)
+	}
+}
+
+func TestSafeModeRejectsRawContent(t *testing.T) {
+	t.Setenv(safemode.Env, "true")
+
+	_, err := NewQueryFromRequest(&SearchRequest{
+		BaseURL:             "https://blog.example",
+		ContentFormat:       "raw",
+		Username:            "editor",
+		ApplicationPassword: "abcd efgh",
+	})
+	if err == nil || !strings.Contains(err.Error(), "safe mode") {
+		t.Errorf("content_format=raw: got error %v, want a safe mode rejection", err)
+	}
+}
+
+func TestDraftStatusAllowedOutsideSafeMode(t *testing.T) {
+	t.Setenv(safemode.Env, "")
+
+	query, err := NewQueryFromRequest(&SearchRequest{
+		BaseURL:             "https://blog.example",
+		Status:              "draft",
+		Username:            "editor",
+		ApplicationPassword: "abcd efgh",
+	})
+	if err != nil {
+		t.Fatalf("NewQueryFromRequest: %v", err)
+	}
+	if len(query.Statuses) != 1 || query.Statuses[0] != domain.PostStatusDraft {
+		t.Errorf("statuses = %v, want [draft]", query.Statuses)
+	}
+}
+
+func TestRelevanceOrderRequiresSearch(t *testing.T) {
+	_, err := NewQueryFromRequest(&SearchRequest{BaseURL: "https://blog.example", OrderBy: "relevance"})
+	if err == nil || !strings.Contains(err.Error(), "orderby") {
+		t.Errorf("orderby=relevance without search: got error %v, want an orderby error", err)
+	}
+}
+
+func TestOrderByDefaults(t *testing.T) {
+	tests := []struct {
+		search, orderBy, want string
+	}{
+		{"running", "", "relevance"},
+		{"", "", "date"},
+		{"running", "title", "title"},
+		{"running", "relevance", "relevance"},
+	}
+	for _, tt := range tests {
+		query, err := NewQueryFromRequest(&SearchRequest{BaseURL: "https://blog.example", Search: tt.search, OrderBy: tt.orderBy})
+		if err != nil {
+			t.Fatalf("search=%q orderby=%q: %v", tt.search, tt.orderBy, err)
+		}
+		if query.OrderBy != tt.want {
+			t.Errorf("search=%q orderby=%q: got orderby %q, want %q", tt.search, tt.orderBy, query.OrderBy, tt.want)
+		}
+	}
+}
+
+func TestStickyIsParsed(t *testing.T) {
+	for value, want := range map[string]string{"": "unset", " true ": "true", "false": "false", "0": "false"} {
+		query, err := NewQueryFromRequest(&SearchRequest{BaseURL: "https://blog.example", Sticky: value})
+		if err != nil {
+			t.Fatalf("sticky=%q: %v", value, err)
+		}
+		got := "unset"
+		if query.Sticky != nil {
+			got = strconv.FormatBool(*query.Sticky)
+		}
+		if got != want {
+			t.Errorf("sticky=%q parsed as %s, want %s", value, got, want)
+		}
+		if criteria := query.ToSearchCriteria(); criteria.Sticky != query.Sticky {
+			t.Errorf("sticky=%q is not passed to the criteria", value)
+		}
+	}
+
+	if _, err := NewQueryFromRequest(&SearchRequest{BaseURL: "https://blog.example", Sticky: "pinned"}); err == nil {
+		t.Error("sticky=pinned: succeeded, want a validation error")
+	}
+}
+
+func TestStatusesAreParsed(t *testing.T) {
+	t.Setenv(safemode.Env, "")
+
+	tests := []struct {
+		status string
+		want   []domain.PostStatus
+	}{
+		{"", nil},
+		{"publish", []domain.PostStatus{domain.PostStatusPublish}},
+		{" Publish , future,publish ", []domain.PostStatus{domain.PostStatusPublish, domain.PostStatusFuture}},
+		{"draft,pending,private", []domain.PostStatus{domain.PostStatusDraft, domain.PostStatusPending, domain.PostStatusPrivate}},
+	}
+	for _, tt := range tests {
+		query, err := NewQueryFromRequest(&SearchRequest{BaseURL: "https://blog.example", Status: tt.status})
+		if err != nil {
+			t.Fatalf("status=%q: %v", tt.status, err)
+		}
+		if len(query.Statuses) != len(tt.want) {
+			t.Errorf("status=%q: statuses = %v, want %v", tt.status, query.Statuses, tt.want)
+			continue
+		}
+		for i := range tt.want {
+			if query.Statuses[i] != tt.want[i] {
+				t.Errorf("status=%q: statuses = %v, want %v", tt.status, query.Statuses, tt.want)
+				break
+			}
+		}
+	}
+
+	_, err := NewQueryFromRequest(&SearchRequest{BaseURL: "https://blog.example", Status: "publish,archived"})
+	if err == nil || !strings.Contains(err.Error(), `"archived"`) {
+		t.Errorf("status=publish,archived: got error %v, want the invalid member named", err)
+	}
+}
+
+func TestSearchColumnsAreParsed(t *testing.T) {
+	query, err := NewQueryFromRequest(&SearchRequest{BaseURL: "https://blog.example", Search: "leather", SearchColumns: " Post_Title, post_excerpt,post_title"})
+	if err != nil {
+		t.Fatalf("NewQueryFromRequest: %v", err)
+	}
+	want := []domain.SearchColumn{domain.SearchColumnTitle, domain.SearchColumnExcerpt}
+	if len(query.SearchColumns) != 2 || query.SearchColumns[0] != want[0] || query.SearchColumns[1] != want[1] {
+		t.Errorf("search columns = %v, want %v", query.SearchColumns, want)
+	}
+	if criteria := query.ToSearchCriteria(); len(criteria.SearchColumns) != 2 {
+		t.Errorf("criteria search columns = %v, want the parsed columns", criteria.SearchColumns)
+	}
+
+	_, err = NewQueryFromRequest(&SearchRequest{BaseURL: "https://blog.example", Search: "leather", SearchColumns: "post_title,post_author"})
+	if err == nil || !strings.Contains(err.Error(), `"post_author"`) {
+		t.Errorf("search_columns=post_title,post_author: got error %v, want the invalid column named", err)
+	}
+}
+
+func TestSearchTermIsSanitized(t *testing.T) {
+	query, err := NewQueryFromRequest(&SearchRequest{BaseURL: "https://blog.example", Search: "  leather\x07\t\t& suede \n"})
+	if err != nil {
+		t.Fatalf("NewQueryFromRequest: %v", err)
+	}
+	if got := query.ToSearchCriteria().Search; got != "leather & suede" {
+		t.Errorf("search = %q, want the sanitized term", got)
+	}
+}
+
+func TestPostTypeIsParsed(t *testing.T) {
+	for value, want := range map[string]domain.PostType{
+		"":            domain.DefaultPostType,
+		"post":        "posts",
+		"Page":        "pages",
+		"attachment":  "media",
+		" portfolio":  "portfolio",
+		"product_faq": "product_faq",
+	} {
+		query, err := NewQueryFromRequest(&SearchRequest{BaseURL: "https://blog.example", PostType: value})
+		if err != nil {
+			t.Errorf("post_type %q: %v", value, err)
+			continue
+		}
+		if got := query.ToSearchCriteria().Type; got != want {
+			t.Errorf("post_type %q = %q, want %q", value, got, want)
+		}
+	}
+
+	for _, value := range []string{"users", "tags", "../users", "posts?context=edit", "my type"} {
+		if _, err := NewQueryFromRequest(&SearchRequest{BaseURL: "https://blog.example", PostType: value}); err == nil {
+			t.Errorf("post_type %q was accepted", value)
+		}
+	}
+}
+
+func TestContentFormatIsParsed(t *testing.T) {
+	for value, want := range map[string]domain.ContentFormat{
+		"":         domain.ContentFormatRendered,
+		"rendered": domain.ContentFormatRendered,
+		" RAW ":    domain.ContentFormatRaw,
+	} {
+		query, err := NewQueryFromRequest(&SearchRequest{
+			BaseURL:             "https://blog.example",
+			ContentFormat:       value,
+			Username:            "editor",
+			ApplicationPassword: "abcd efgh",
+		})
+		if err != nil {
+			t.Errorf("content_format %q: %v", value, err)
+			continue
+		}
+		if got := query.ToSearchCriteria().ContentFormat; got != want {
+			t.Errorf("content_format %q = %q, want %q", value, got, want)
+		}
+	}
+
+	_, err := NewQueryFromRequest(&SearchRequest{BaseURL: "https://blog.example", ContentFormat: "markdown"})
+	if err == nil || !strings.Contains(err.Error(), "content_format") {
+		t.Errorf("content_format=markdown: got error %v, want a content_format error", err)
+	}
+}
+
+func TestRawContentRequiresCredentials(t *testing.T) {
+	_, err := NewQueryFromRequest(&SearchRequest{BaseURL: "https://blog.example", ContentFormat: "raw"})
+	if err == nil || !strings.Contains(err.Error(), "application_password") {
+		t.Errorf("content_format=raw without credentials: got error %v, want a credentials error", err)
+	}
+}
+
+func TestCredentialsMustBeGivenTogether(t *testing.T) {
+	for _, req := range []*SearchRequest{
+		{BaseURL: "https://blog.example", Username: "editor"},
+		{BaseURL: "https://blog.example", ApplicationPassword: "abcd efgh"},
+	} {
+		if _, err := NewQueryFromRequest(req); err == nil {
+			t.Errorf("username %q with application_password %q was accepted", req.Username, req.ApplicationPassword)
+		}
+	}
+}
+
+func TestEmbedIsParsed(t *testing.T) {
+	for value, want := range map[string]bool{"": false, "false": false, "true": true, "1": true} {
+		query, err := NewQueryFromRequest(&SearchRequest{BaseURL: "https://blog.example", Embed: value})
+		if err != nil {
+			t.Errorf("embed %q: %v", value, err)
+			continue
+		}
+		if got := query.ToSearchCriteria().Embed; got != want {
+			t.Errorf("embed %q = %v, want %v", value, got, want)
+		}
+	}
+
+	_, err := NewQueryFromRequest(&SearchRequest{BaseURL: "https://blog.example", Embed: "authors"})
+	if err == nil || !strings.Contains(err.Error(), "embed") {
+		t.Errorf("embed=authors: got error %v, want an embed error", err)
+	}
+}
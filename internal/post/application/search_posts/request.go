@@ -1,15 +1,27 @@
 package search_posts
 
+import (
+	"fmt"
+	"strings"
+	"woocommerce-mcp/internal/post/domain"
+	"woocommerce-mcp/kit/searchterm"
+)
+
 // SearchRequest represents a request to search for posts
 type SearchRequest struct {
 	BaseURL string `json:"base_url"`
 
+	// PostType is the REST base of the post type to search, e.g. pages or a
+	// custom type; empty searches regular posts
+	PostType string `json:"post_type,omitempty"`
+
 	// Search parameters
 	Search     string `json:"search,omitempty"`
 	Status     string `json:"status,omitempty"`
 	Author     string `json:"author,omitempty"`
 	Categories string `json:"categories,omitempty"`
 	Tags       string `json:"tags,omitempty"`
+	Sticky     string `json:"sticky,omitempty"`
 	Before     string `json:"before,omitempty"`
 	After      string `json:"after,omitempty"`
 
@@ -20,4 +32,58 @@ type SearchRequest struct {
 	// Sorting
 	OrderBy string `json:"orderby,omitempty"`
 	Order   string `json:"order,omitempty"`
+
+	// SearchColumns limits where the search term matches, e.g. post_title
+	SearchColumns string `json:"search_columns,omitempty"`
+
+	// IncludeCommentCount adds the approved comment count to each post
+	IncludeCommentCount string `json:"include_comment_count,omitempty"`
+
+	// Embed adds the author name, featured image URL and category and tag
+	// names to each post, fetched in the same request
+	Embed string `json:"embed,omitempty"`
+
+	// ContentFormat is rendered (the default) for the HTML visitors see, or
+	// raw for the stored source, which needs credentials
+	ContentFormat string `json:"content_format,omitempty"`
+
+	// Username and ApplicationPassword authenticate the requests with a
+	// WordPress application password
+	Username            string `json:"username,omitempty"`
+	ApplicationPassword string `json:"application_password,omitempty"`
+}
+
+// FilterSummary describes the effective filters in a short human-readable
+// form, e.g. "search='xyz', categories='3,7'". Pagination is not included.
+func (r *SearchRequest) FilterSummary() string {
+	var parts []string
+	addValue := func(name, value string) {
+		if value = strings.TrimSpace(value); value != "" {
+			parts = append(parts, fmt.Sprintf("%s='%s'", name, value))
+		}
+	}
+
+	if postType, err := ParsePostType(r.PostType); err == nil && postType != domain.DefaultPostType {
+		addValue("post_type", string(postType))
+	}
+	addValue("search", searchterm.Sanitize(r.Search))
+	addValue("search_columns", r.SearchColumns)
+	addValue("status", r.Status)
+	addValue("author", r.Author)
+	addValue("categories", r.Categories)
+	addValue("tags", r.Tags)
+	switch strings.ToLower(strings.TrimSpace(r.Sticky)) {
+	case "true":
+		parts = append(parts, "sticky only")
+	case "false":
+		parts = append(parts, "excluding sticky")
+	}
+	if before := strings.TrimSpace(r.Before); before != "" {
+		parts = append(parts, fmt.Sprintf("published before %s", before))
+	}
+	if after := strings.TrimSpace(r.After); after != "" {
+		parts = append(parts, fmt.Sprintf("published after %s", after))
+	}
+
+	return strings.Join(parts, ", ")
 }
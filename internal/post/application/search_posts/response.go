@@ -1,8 +1,10 @@
 package search_posts
 
 import (
-	"encoding/json"
+	"html"
 	"woocommerce-mcp/internal/post/domain"
+	"woocommerce-mcp/kit/jsonformat"
+	"woocommerce-mcp/kit/pagination"
 )
 
 // SearchResponse represents a response from searching posts
@@ -14,29 +16,43 @@ type SearchResponse struct {
 	TotalPages  int       `json:"total_pages"`
 	HasNext     bool      `json:"has_next"`
 	HasPrev     bool      `json:"has_prev"`
+
+	// Pagination repeats the flat fields above with the adjacent page numbers
+	Pagination *pagination.Pagination `json:"pagination"`
+
+	// PerPageCapped is set when the requested per_page exceeded the API cap
+	PerPageCapped bool `json:"per_page_capped"`
 }
 
-// PostDTO represents a post data transfer object
+// PostDTO represents a post data transfer object.
+// Optional fields are omitted when empty to keep the serialized post compact.
 type PostDTO struct {
 	ID              int64         `json:"id"`
 	Title           string        `json:"title"`
 	Content         string        `json:"content"`
-	Excerpt         string        `json:"excerpt"`
+	Excerpt         string        `json:"excerpt,omitempty"`
 	Slug            string        `json:"slug"`
 	Status          string        `json:"status"`
-	Format          string        `json:"format"`
+	Format          string        `json:"format,omitempty"`
 	Type            string        `json:"type"`
 	Permalink       string        `json:"permalink"`
-	FeaturedMediaID int64         `json:"featured_media_id"`
+	FeaturedMediaID int64         `json:"featured_media_id,omitempty"`
 	AuthorID        int64         `json:"author_id"`
 	DateCreated     string        `json:"date_created"`
 	DateModified    string        `json:"date_modified"`
 	CommentStatus   string        `json:"comment_status"`
 	PingStatus      string        `json:"ping_status"`
 	Sticky          bool          `json:"sticky"`
-	Tags            []TagDTO      `json:"tags"`
-	Categories      []CategoryDTO `json:"categories"`
-	MetaData        []MetaDataDTO `json:"meta_data"`
+	Tags            []TagDTO      `json:"tags,omitempty"`
+	Categories      []CategoryDTO `json:"categories,omitempty"`
+	MetaData        []MetaDataDTO `json:"meta_data,omitempty"`
+
+	// CommentCount is only set when include_comment_count is requested
+	CommentCount *int64 `json:"comment_count,omitempty"`
+
+	// AuthorName and FeaturedImageURL are only set when embed is requested
+	AuthorName       string `json:"author_name,omitempty"`
+	FeaturedImageURL string `json:"featured_image_url,omitempty"`
 }
 
 // TagDTO represents a tag data transfer object
@@ -62,13 +78,9 @@ type MetaDataDTO struct {
 	Value interface{} `json:"value"`
 }
 
-// ToJSON converts the response to JSON string
-func (r *SearchResponse) ToJSON() (string, error) {
-	data, err := json.MarshalIndent(r, "", "  ")
-	if err != nil {
-		return "", err
-	}
-	return string(data), nil
+// ToJSON converts the response to a compact JSON string, or an indented one when pretty is set
+func (r *SearchResponse) ToJSON(pretty bool) (string, error) {
+	return jsonformat.Marshal(r, pretty)
 }
 
 // FromDomainPosts converts domain posts to response DTOs
@@ -76,29 +88,31 @@ func FromDomainPosts(posts []*domain.Post, totalCount int64, currentPage, perPag
 	postDTOs := make([]PostDTO, len(posts))
 	for i, post := range posts {
 		postDTOs[i] = PostDTO{
-			ID:              post.ID.Value(),
-			Title:           post.Title,
-			Content:         post.Content,
-			Excerpt:         post.Excerpt,
-			Slug:            post.Slug,
-			Status:          string(post.Status),
-			Format:          string(post.Format),
-			Type:            post.Type,
-			Permalink:       post.Permalink,
-			FeaturedMediaID: post.FeaturedMediaID,
-			AuthorID:        post.AuthorID,
-			DateCreated:     post.DateCreated.Format("2006-01-02T15:04:05"),
-			DateModified:    post.DateModified.Format("2006-01-02T15:04:05"),
-			CommentStatus:   post.CommentStatus,
-			PingStatus:      post.PingStatus,
-			Sticky:          post.Sticky,
+			ID:               post.ID.Value(),
+			Title:            post.Title,
+			Content:          post.Content,
+			Excerpt:          post.Excerpt,
+			Slug:             post.Slug,
+			Status:           string(post.Status),
+			Format:           string(post.Format),
+			Type:             post.Type,
+			Permalink:        post.Permalink,
+			FeaturedMediaID:  post.FeaturedMediaID,
+			AuthorID:         post.AuthorID,
+			DateCreated:      post.DateCreated.Format("2006-01-02T15:04:05"),
+			DateModified:     post.DateModified.Format("2006-01-02T15:04:05"),
+			CommentStatus:    post.CommentStatus,
+			PingStatus:       post.PingStatus,
+			Sticky:           post.Sticky,
+			AuthorName:       post.AuthorName,
+			FeaturedImageURL: post.FeaturedImageURL,
 		}
 
 		// Convert tags
 		for _, tag := range post.Tags {
 			postDTOs[i].Tags = append(postDTOs[i].Tags, TagDTO{
 				ID:   tag.ID,
-				Name: tag.Name,
+				Name: html.UnescapeString(tag.Name),
 				Slug: tag.Slug,
 				Link: tag.Link,
 			})
@@ -108,7 +122,7 @@ func FromDomainPosts(posts []*domain.Post, totalCount int64, currentPage, perPag
 		for _, category := range post.Categories {
 			postDTOs[i].Categories = append(postDTOs[i].Categories, CategoryDTO{
 				ID:   category.ID,
-				Name: category.Name,
+				Name: html.UnescapeString(category.Name),
 				Slug: category.Slug,
 				Link: category.Link,
 			})
@@ -137,5 +151,6 @@ func FromDomainPosts(posts []*domain.Post, totalCount int64, currentPage, perPag
 		TotalPages:  totalPages,
 		HasNext:     currentPage < totalPages,
 		HasPrev:     currentPage > 1,
+		Pagination:  pagination.New(totalCount, currentPage, perPage, totalPages),
 	}
 }
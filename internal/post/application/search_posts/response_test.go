@@ -0,0 +1,73 @@
+package search_posts
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"woocommerce-mcp/internal/post/domain"
+)
+
+func TestSparsePostSerializesCompactly(t *testing.T) {
+	id, _ := domain.NewPostID(101)
+	post := domain.NewPost(id, "Spring Collection")
+	post.Status = domain.PostStatusPublish
+	post.Type = "post"
+	post.DateCreated = time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	post.DateModified = post.DateCreated
+
+	response := FromDomainPosts([]*domain.Post{post}, 1, 1, 10)
+	data, err := json.Marshal(response.Posts[0])
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	// Empty optional fields are left out; sticky and the author stay
+	const golden = `{"id":101,"title":"Spring Collection","content":"","slug":"","status":"publish","format":"standard","type":"post","permalink":"",` +
+		`"author_id":0,"date_created":"2024-03-01T08:00:00","date_modified":"2024-03-01T08:00:00","comment_status":"","ping_status":"","sticky":false}`
+	if string(data) != golden {
+		t.Errorf("sparse post serialized as\n%s\nwant\n%s", data, golden)
+	}
+}
+
+func TestPaginationIsNullAtTheBoundaries(t *testing.T) {
+	only, _ := json.Marshal(FromDomainPosts(nil, 4, 1, 10).Pagination)
+	if want := `{"current_page":1,"per_page":10,"total":4,"total_pages":1,"next_page":null,"prev_page":null}`; string(only) != want {
+		t.Errorf("single page pagination = %s, want %s", only, want)
+	}
+
+	middle := FromDomainPosts(nil, 25, 2, 10)
+	if middle.Pagination.NextPage == nil || *middle.Pagination.NextPage != 3 || middle.Pagination.PrevPage == nil || *middle.Pagination.PrevPage != 1 {
+		t.Errorf("middle page pagination = %+v, want next 3 and prev 1", middle.Pagination)
+	}
+	if !middle.HasNext || !middle.HasPrev {
+		t.Errorf("has_next = %v, has_prev = %v, want the flat fields kept", middle.HasNext, middle.HasPrev)
+	}
+}
+
+func TestTermNamesAreDecoded(t *testing.T) {
+	id, _ := domain.NewPostID(101)
+	post := domain.NewPost(id, "Spring Collection")
+	post.Categories = []domain.Category{{ID: 3, Name: "Tips &amp; Tricks"}}
+	post.Tags = []domain.Tag{{ID: 4, Name: "Editor&#8217;s &quot;Pick&quot;"}}
+
+	dto := FromDomainPosts([]*domain.Post{post}, 1, 1, 10).Posts[0]
+	if got := dto.Categories[0].Name; got != "Tips & Tricks" {
+		t.Errorf("category name = %q, want %q", got, "Tips & Tricks")
+	}
+	if got, want := dto.Tags[0].Name, "Editor\u2019s \"Pick\""; got != want {
+		t.Errorf("tag name = %q, want %q", got, want)
+	}
+}
+
+func TestEmbeddedAuthorAndImageAreExposed(t *testing.T) {
+	id, _ := domain.NewPostID(101)
+	post := domain.NewPost(id, "Spring Collection")
+	post.AuthorName = "Ana"
+	post.FeaturedImageURL = "https://blog.example/spring.jpg"
+
+	dto := FromDomainPosts([]*domain.Post{post}, 1, 1, 10).Posts[0]
+	if dto.AuthorName != "Ana" || dto.FeaturedImageURL != "https://blog.example/spring.jpg" {
+		t.Errorf("author %q, image %q; want the embedded ones", dto.AuthorName, dto.FeaturedImageURL)
+	}
+}
@@ -3,25 +3,51 @@ package search_posts
 import (
 	"context"
 	"fmt"
+	"strings"
 	"woocommerce-mcp/internal/post/domain"
 	"woocommerce-mcp/internal/post/infrastructure/wordpress"
+	"woocommerce-mcp/kit/searchobserver"
 )
 
 // PostSearcher handles post search operations
 type PostSearcher struct {
 	repository domain.PostRepository
+	observer   searchobserver.SearchObserver
 }
 
-// NewPostSearcher creates a new PostSearcher
+// NewPostSearcher creates a new PostSearcher notifying the default search
+// observer
 func NewPostSearcher(repository domain.PostRepository) *PostSearcher {
 	return &PostSearcher{
 		repository: repository,
+		observer:   searchobserver.Default(),
 	}
 }
 
-// SearchPosts searches for posts based on the provided request
+// SetObserver replaces the search observer; nil disables observation
+func (s *PostSearcher) SetObserver(observer searchobserver.SearchObserver) *PostSearcher {
+	if observer == nil {
+		observer = searchobserver.Nop{}
+	}
+	s.observer = observer
+	return s
+}
+
+// SearchPosts searches for posts based on the provided request, notifying
+// the search observer
 func (s *PostSearcher) SearchPosts(ctx context.Context, req *SearchRequest) (*SearchResponse, error) {
+	search := searchobserver.Search{Kind: searchobserver.KindPosts, Summary: req.FilterSummary()}
+	return searchobserver.Observe(ctx, s.observer, search, func() (*SearchResponse, error) {
+		return s.searchPosts(ctx, req)
+	}, func(response *SearchResponse) (int, int) {
+		return len(response.Posts), int(response.TotalCount)
+	})
+}
+
+// searchPosts searches for posts based on the provided request
+func (s *PostSearcher) searchPosts(ctx context.Context, req *SearchRequest) (*SearchResponse, error) {
 	// Validate request
+	req.BaseURL = strings.TrimSpace(req.BaseURL)
 	if req.BaseURL == "" {
 		return nil, fmt.Errorf("base_url is required")
 	}
@@ -34,6 +60,8 @@ func (s *PostSearcher) SearchPosts(ctx context.Context, req *SearchRequest) (*Se
 
 	// Create WordPress client and repository for this request
 	config := wordpress.NewConfig(query.BaseURL)
+	config.Username = query.Username
+	config.ApplicationPassword = query.ApplicationPassword
 	client := wordpress.NewClient(config)
 	repository := wordpress.NewRepository(client)
 
@@ -52,6 +80,13 @@ func (s *PostSearcher) SearchPosts(ctx context.Context, req *SearchRequest) (*Se
 
 	// Convert to response
 	response := FromDomainPosts(posts, totalCount, query.Page, query.PerPage)
+	response.PerPageCapped = query.PerPageCapped
+
+	if query.IncludeCommentCount {
+		if err := addCommentCounts(ctx, repository, posts, response.Posts); err != nil {
+			return nil, fmt.Errorf("failed to count comments: %w", err)
+		}
+	}
 
 	return response, nil
 }
@@ -60,3 +95,23 @@ func (s *PostSearcher) SearchPosts(ctx context.Context, req *SearchRequest) (*Se
 func (s *PostSearcher) Execute(ctx context.Context, req *SearchRequest) (*SearchResponse, error) {
 	return s.SearchPosts(ctx, req)
 }
+
+// addCommentCounts looks up the comment counts of a page of posts in one batch
+// and sets them on the matching DTOs
+func addCommentCounts(ctx context.Context, repository domain.PostRepository, posts []*domain.Post, dtos []PostDTO) error {
+	postIDs := make([]domain.PostID, len(posts))
+	for i, post := range posts {
+		postIDs[i] = post.ID
+	}
+
+	counts, err := repository.CountComments(ctx, postIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range dtos {
+		count := counts[domain.PostID(dtos[i].ID)]
+		dtos[i].CommentCount = &count
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package search_posts
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"woocommerce-mcp/internal/post/domain"
+	"woocommerce-mcp/internal/testutil/fakestore"
+	"woocommerce-mcp/kit/searchobserver"
+)
+
+// commentCountRepository answers comment counts from its counts and records
+// the batches it was asked for
+type commentCountRepository struct {
+	domain.PostRepository
+	counts  map[domain.PostID]int64
+	batches [][]domain.PostID
+}
+
+func (r *commentCountRepository) CountComments(ctx context.Context, postIDs []domain.PostID) (map[domain.PostID]int64, error) {
+	r.batches = append(r.batches, postIDs)
+	counts := make(map[domain.PostID]int64, len(postIDs))
+	for _, id := range postIDs {
+		counts[id] = r.counts[id]
+	}
+	return counts, nil
+}
+
+func TestCommentCountsArePopulatedInOneBatch(t *testing.T) {
+	var posts []*domain.Post
+	for _, id := range []int64{101, 102} {
+		postID, _ := domain.NewPostID(id)
+		post := domain.NewPost(postID, "Post")
+		posts = append(posts, post)
+	}
+	// Post 102 has comments disabled, so it has none
+	posts[1].CommentStatus = "closed"
+	repository := &commentCountRepository{counts: map[domain.PostID]int64{101: 4}}
+	response := FromDomainPosts(posts, 2, 1, 10)
+
+	if err := addCommentCounts(context.Background(), repository, posts, response.Posts); err != nil {
+		t.Fatalf("addCommentCounts: %v", err)
+	}
+
+	if len(repository.batches) != 1 || len(repository.batches[0]) != 2 {
+		t.Errorf("counted batches %v, want the whole page at once", repository.batches)
+	}
+	for i, want := range []int64{4, 0} {
+		if got := response.Posts[i].CommentCount; got == nil || *got != want {
+			t.Errorf("post %d comment_count = %v, want %d", response.Posts[i].ID, got, want)
+		}
+	}
+}
+
+func TestCommentCountIsOmittedUnlessRequested(t *testing.T) {
+	postID, _ := domain.NewPostID(101)
+	response := FromDomainPosts([]*domain.Post{domain.NewPost(postID, "Post")}, 1, 1, 10)
+
+	if response.Posts[0].CommentCount != nil {
+		t.Errorf("comment_count = %d without include_comment_count, want it omitted", *response.Posts[0].CommentCount)
+	}
+}
+
+// recordingObserver records the search observer callbacks it receives
+type recordingObserver struct {
+	searches []searchobserver.Search
+	results  []searchobserver.Result
+}
+
+func (o *recordingObserver) OnSearch(ctx context.Context, search searchobserver.Search) {
+	o.searches = append(o.searches, search)
+}
+
+func (o *recordingObserver) OnResult(ctx context.Context, search searchobserver.Search, result searchobserver.Result) {
+	o.results = append(o.results, result)
+}
+
+func TestObserverReceivesThePostSearchWithoutCredentials(t *testing.T) {
+	server := fakestore.New().Start()
+	defer server.Close()
+	observer := &recordingObserver{}
+
+	_, err := NewPostSearcher(nil).SetObserver(observer).SearchPosts(context.Background(), &SearchRequest{
+		BaseURL:             server.URL,
+		Search:              "running",
+		Username:            fakestore.Username,
+		ApplicationPassword: fakestore.ApplicationPassword,
+	})
+	if err != nil {
+		t.Fatalf("SearchPosts: %v", err)
+	}
+
+	if len(observer.searches) != 1 || observer.searches[0].Kind != searchobserver.KindPosts {
+		t.Fatalf("searches = %+v, want one posts search", observer.searches)
+	}
+	summary := observer.searches[0].Summary
+	if !strings.Contains(summary, "running") {
+		t.Errorf("summary = %q, want the search term", summary)
+	}
+	for _, secret := range []string{server.URL, fakestore.Username, fakestore.ApplicationPassword} {
+		if strings.Contains(summary, secret) {
+			t.Errorf("summary %q reveals %q", summary, secret)
+		}
+	}
+	if len(observer.results) != 1 || observer.results[0].Err != nil || observer.results[0].Count != 1 {
+		t.Errorf("results = %+v, want the one running post", observer.results)
+	}
+}
+
+func TestWhitespaceBaseURLIsReportedAsMissing(t *testing.T) {
+	for _, baseURL := range []string{"   ", "\t\n"} {
+		_, err := NewPostSearcher(nil).SearchPosts(context.Background(), &SearchRequest{BaseURL: baseURL})
+		if err == nil || err.Error() != "base_url is required" {
+			t.Errorf("base_url %q: error = %v, want base_url is required", baseURL, err)
+		}
+	}
+}
+
+func TestPaddedBaseURLReachesTheStore(t *testing.T) {
+	server := fakestore.New().Start()
+	defer server.Close()
+
+	response, err := NewPostSearcher(nil).SearchPosts(context.Background(), &SearchRequest{BaseURL: "\t" + server.URL + "\n"})
+	if err != nil {
+		t.Fatalf("SearchPosts with a padded base_url: %v", err)
+	}
+	if len(response.Posts) == 0 {
+		t.Error("no posts found with a padded base_url")
+	}
+}
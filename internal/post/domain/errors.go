@@ -7,12 +7,29 @@ type PostError struct {
 	Code    string
 	Message string
 	Type    string
+	// StatusCode is the upstream HTTP status of API errors
+	StatusCode int
 }
 
 func (e *PostError) Error() string {
 	return fmt.Sprintf("[%s] %s: %s", e.Type, e.Code, e.Message)
 }
 
+// ErrorCode returns the machine-readable error code
+func (e *PostError) ErrorCode() string {
+	return e.Code
+}
+
+// ErrorType returns the error type
+func (e *PostError) ErrorType() string {
+	return e.Type
+}
+
+// HTTPStatus returns the upstream HTTP status, or 0 when there is none
+func (e *PostError) HTTPStatus() int {
+	return e.StatusCode
+}
+
 // NewValidationError creates a new validation error
 func NewValidationError(message string) *PostError {
 	return &PostError{
@@ -25,9 +42,10 @@ func NewValidationError(message string) *PostError {
 // NewWordPressAPIError creates a new WordPress API error
 func NewWordPressAPIError(statusCode int, message, code string) *PostError {
 	return &PostError{
-		Code:    fmt.Sprintf("WORDPRESS_API_ERROR_%d", statusCode),
-		Message: fmt.Sprintf("WordPress API error (status %d): %s", statusCode, message),
-		Type:    "WordPressAPIError",
+		Code:       fmt.Sprintf("WORDPRESS_API_ERROR_%d", statusCode),
+		Message:    fmt.Sprintf("WordPress API error (status %d): %s", statusCode, message),
+		Type:       "WordPressAPIError",
+		StatusCode: statusCode,
 	}
 }
 
@@ -48,3 +66,14 @@ func NewNotFoundError(postID PostID) *PostError {
 		Type:    "NotFoundError",
 	}
 }
+
+// NewPostTypeNotFoundError creates an error for a post type the site does not
+// expose through its REST API
+func NewPostTypeNotFoundError(postType PostType) *PostError {
+	return &PostError{
+		Code:       "POST_TYPE_NOT_FOUND",
+		Message:    fmt.Sprintf("post type %q is not available on this site; check that it is registered with show_in_rest and use its REST base (e.g. posts, pages)", postType),
+		Type:       "NotFoundError",
+		StatusCode: 404,
+	}
+}
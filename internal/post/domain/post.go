@@ -29,18 +29,91 @@ const (
 	PostStatusPrivate PostStatus = "private"
 	PostStatusPending PostStatus = "pending"
 	PostStatusTrash   PostStatus = "trash"
+	// PostStatusFuture is a post scheduled for publication
+	PostStatusFuture PostStatus = "future"
 )
 
 // IsValid checks if the post status is valid
 func (s PostStatus) IsValid() bool {
 	switch s {
-	case PostStatusPublish, PostStatusDraft, PostStatusPrivate, PostStatusPending, PostStatusTrash:
+	case PostStatusPublish, PostStatusDraft, PostStatusPrivate, PostStatusPending, PostStatusTrash, PostStatusFuture:
 		return true
 	default:
 		return false
 	}
 }
 
+// SearchColumn is a post column a search term can be matched in
+type SearchColumn string
+
+const (
+	SearchColumnTitle   SearchColumn = "post_title"
+	SearchColumnContent SearchColumn = "post_content"
+	SearchColumnExcerpt SearchColumn = "post_excerpt"
+)
+
+// IsValid checks if the search column is one WordPress can search
+func (c SearchColumn) IsValid() bool {
+	switch c {
+	case SearchColumnTitle, SearchColumnContent, SearchColumnExcerpt:
+		return true
+	default:
+		return false
+	}
+}
+
+// ContentFormat is the form post content is returned in
+type ContentFormat string
+
+const (
+	// ContentFormatRendered is the HTML WordPress renders for visitors
+	ContentFormatRendered ContentFormat = "rendered"
+	// ContentFormatRaw is the stored source, e.g. block markup, which
+	// WordPress only returns to authenticated requests
+	ContentFormatRaw ContentFormat = "raw"
+)
+
+// IsValid checks if the content format is one WordPress can return
+func (f ContentFormat) IsValid() bool {
+	switch f {
+	case ContentFormatRendered, ContentFormatRaw:
+		return true
+	default:
+		return false
+	}
+}
+
+// PostType is the REST base of a post type, the last segment of its
+// /wp/v2 route, e.g. posts, pages or a custom type like portfolio
+type PostType string
+
+// DefaultPostType is the REST base of regular blog posts
+const DefaultPostType PostType = "posts"
+
+// nonPostRoutes are /wp/v2 routes that do not list posts of any type
+var nonPostRoutes = map[PostType]bool{
+	"categories": true, "tags": true, "comments": true, "users": true,
+	"taxonomies": true, "types": true, "statuses": true, "settings": true,
+	"search": true, "themes": true, "plugins": true, "block-types": true,
+	"menus": true, "menu-locations": true, "sidebars": true, "widgets": true,
+	"widget-types": true,
+}
+
+// IsValid checks if the post type is a plain slug naming a posts route:
+// lowercase letters, digits, hyphens and underscores only, so it cannot
+// reach outside /wp/v2
+func (t PostType) IsValid() bool {
+	if t == "" || nonPostRoutes[t] {
+		return false
+	}
+	for _, r := range t {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' && r != '_' {
+			return false
+		}
+	}
+	return true
+}
+
 // PostFormat represents the format of a post
 type PostFormat string
 
@@ -80,6 +153,11 @@ type Post struct {
 	Tags            []Tag
 	Categories      []Category
 	MetaData        []MetaData
+
+	// AuthorName and FeaturedImageURL are only known when the linked
+	// resources were embedded in the response
+	AuthorName       string
+	FeaturedImageURL string
 }
 
 // NewPost creates a new Post
@@ -12,18 +12,34 @@ type PostRepository interface {
 
 	// GetPostByID retrieves a post by its ID
 	GetPostByID(ctx context.Context, id PostID) (*Post, error)
+
+	// CountComments returns the approved comment count of each post; posts
+	// without comments are reported with 0
+	CountComments(ctx context.Context, postIDs []PostID) (map[PostID]int64, error)
 }
 
 // SearchCriteria represents search parameters for posts
 type SearchCriteria struct {
+	// Type is the post type to search; empty searches DefaultPostType
+	Type PostType
+
 	// Basic search
 	Search string
 
+	// SearchColumns limits the columns the search term matches in; empty
+	// matches all of them
+	SearchColumns []SearchColumn
+
+	// Lookup of known posts by slug or ID
+	Slug    string
+	Include []PostID
+
 	// Filtering
-	Status     PostStatus
+	Statuses   []PostStatus // any of these statuses; empty leaves WordPress's default
 	Author     int64
 	Categories []int64
 	Tags       []int64
+	Sticky     *bool // nil includes sticky and regular posts
 
 	// Date filtering
 	Before string // ISO 8601 format
@@ -36,4 +52,40 @@ type SearchCriteria struct {
 	// Sorting
 	OrderBy string // date, relevance, id, include, title, slug
 	Order   string // asc, desc
+
+	// ContentFormat selects the content returned; empty means rendered
+	ContentFormat ContentFormat
+
+	// Embed asks for the author, featured image and terms of each post in
+	// the same response
+	Embed bool
+}
+
+// TermRepository defines the interface for category and tag data access
+type TermRepository interface {
+	// ListTerms lists terms matching the criteria
+	ListTerms(ctx context.Context, criteria *TermCriteria) ([]*Term, error)
+
+	// CountTerms returns the total count of terms matching the criteria
+	CountTerms(ctx context.Context, criteria *TermCriteria) (int64, error)
+}
+
+// TermCriteria represents list parameters for categories and tags
+type TermCriteria struct {
+	Taxonomy Taxonomy
+
+	// Basic search
+	Search string
+
+	// Filtering
+	Parent    *int64 // categories only; 0 lists top-level categories
+	HideEmpty bool
+
+	// Pagination
+	Page    int
+	PerPage int
+
+	// Sorting
+	OrderBy string // id, include, name, slug, term_group, description, count
+	Order   string // asc, desc
 }
@@ -0,0 +1,36 @@
+package domain
+
+// Taxonomy identifies a WordPress post taxonomy
+type Taxonomy string
+
+const (
+	TaxonomyCategory Taxonomy = "category"
+	TaxonomyTag      Taxonomy = "post_tag"
+)
+
+// IsValid checks if the taxonomy is supported
+func (t Taxonomy) IsValid() bool {
+	switch t {
+	case TaxonomyCategory, TaxonomyTag:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsHierarchical reports whether terms of the taxonomy can have a parent
+func (t Taxonomy) IsHierarchical() bool {
+	return t == TaxonomyCategory
+}
+
+// Term represents a category or tag attached to posts
+type Term struct {
+	ID          int64
+	Taxonomy    Taxonomy
+	Name        string
+	Slug        string
+	Description string
+	Count       int
+	// Parent is the parent category ID, 0 for top-level categories and tags
+	Parent int64
+}
@@ -2,28 +2,43 @@ package wordpress
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
+	"html"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
 	"time"
 	"woocommerce-mcp/internal/post/domain"
+	"woocommerce-mcp/kit/contentencoding"
+	"woocommerce-mcp/kit/httpjson"
+	"woocommerce-mcp/kit/requestid"
+	"woocommerce-mcp/kit/retry"
+	"woocommerce-mcp/kit/storehttp"
 )
 
 // Config represents WordPress API configuration
 type Config struct {
 	BaseURL string
 	Timeout time.Duration
+	// ProxyURL routes outbound requests through an HTTP proxy. When empty,
+	// the standard HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment is used.
+	ProxyURL string
+
+	// Username and ApplicationPassword authenticate post requests with a
+	// WordPress application password; requests are anonymous when either
+	// is empty
+	Username            string
+	ApplicationPassword string
 }
 
 // NewConfig creates a new WordPress configuration
 func NewConfig(baseURL string) *Config {
 	return &Config{
-		BaseURL: strings.TrimSuffix(baseURL, "/"),
-		Timeout: 30 * time.Second,
+		BaseURL:  storehttp.NormalizeBaseURL(baseURL),
+		Timeout:  30 * time.Second,
+		ProxyURL: storehttp.ProxyURL(),
 	}
 }
 
@@ -38,20 +53,52 @@ func NewClient(config *Config) *Client {
 	return &Client{
 		config: config,
 		httpClient: &http.Client{
-			Timeout: config.Timeout,
+			Timeout:   config.Timeout,
+			Transport: requestid.NewTransport(retry.NewTransport(contentencoding.NewTransport(storehttp.NewTransport(config.ProxyURL)))),
 		},
 	}
 }
 
+// HasCredentials reports whether post requests are authenticated
+func (c *Config) HasCredentials() bool {
+	return c.Username != "" && c.ApplicationPassword != ""
+}
+
+// authenticate adds the configured credentials, if any, to a request
+func (c *Client) authenticate(req *http.Request) {
+	if c.config.HasCredentials() {
+		req.SetBasicAuth(c.config.Username, c.config.ApplicationPassword)
+	}
+}
+
+// api returns the JSON client for anonymous API requests, which fail with
+// domain errors
+func (c *Client) api() httpjson.Client {
+	return httpjson.Client{
+		Send: c.httpClient.Do,
+		ConnectionError: func(url string, err error) error {
+			return domain.NewConnectionError(url, fmt.Sprintf("HTTP request failed: %v", err))
+		},
+		StatusError: func(statusCode int, _ http.Header, body []byte) error {
+			return c.handleAPIError(statusCode, body)
+		},
+	}
+}
+
+// postsAPI returns the JSON client for post requests, authenticated with the
+// configured credentials, if any
+func (c *Client) postsAPI() httpjson.Client {
+	api := c.api()
+	api.Prepare = c.authenticate
+	return api
+}
+
 // SearchPosts searches for posts using the WordPress API
 func (c *Client) SearchPosts(ctx context.Context, criteria *domain.SearchCriteria) ([]*domain.Post, error) {
 	// Build the API endpoint URL
-	endpoint := fmt.Sprintf("%s/wp-json/wp/v2/posts", c.config.BaseURL)
-
-	// Parse base URL
-	u, err := url.Parse(endpoint)
+	u, err := c.buildURL(postsRoute(criteria))
 	if err != nil {
-		return nil, domain.NewConnectionError(endpoint, fmt.Sprintf("invalid base URL: %v", err))
+		return nil, err
 	}
 
 	// Build query parameters
@@ -60,40 +107,15 @@ func (c *Client) SearchPosts(ctx context.Context, criteria *domain.SearchCriteri
 
 	u.RawQuery = query.Encode()
 
-	// Create HTTP request
-	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Make HTTP request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, domain.NewConnectionError(u.String(), fmt.Sprintf("HTTP request failed: %v", err))
-	}
-	defer resp.Body.Close()
-
-	// Read response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	// Check for HTTP errors
-	if resp.StatusCode != http.StatusOK {
-		return nil, c.handleAPIError(resp.StatusCode, body)
-	}
-
-	// Parse JSON response
 	var apiPosts []APIPost
-	if err := json.Unmarshal(body, &apiPosts); err != nil {
-		return nil, fmt.Errorf("failed to parse JSON response: %w", err)
+	if _, err := c.postsAPI().DoJSON(ctx, "GET", u.String(), &apiPosts); err != nil {
+		return nil, postsError(criteria, err)
 	}
 
 	// Convert API posts to domain posts
 	posts := make([]*domain.Post, len(apiPosts))
 	for i, apiPost := range apiPosts {
-		domainPost, err := c.apiPostToDomain(&apiPost)
+		domainPost, err := c.apiPostToDomain(&apiPost, criteria.ContentFormat)
 		if err != nil {
 			return nil, fmt.Errorf("failed to convert post %d: %w", apiPost.ID, err)
 		}
@@ -107,42 +129,29 @@ func (c *Client) SearchPosts(ctx context.Context, criteria *domain.SearchCriteri
 func (c *Client) CountPosts(ctx context.Context, criteria *domain.SearchCriteria) (int64, error) {
 	// For WordPress API, we need to make a HEAD request or parse headers
 	// Since WordPress doesn't provide a direct count endpoint, we'll use the X-WP-Total header
-	endpoint := fmt.Sprintf("%s/wp-json/wp/v2/posts", c.config.BaseURL)
-
-	u, err := url.Parse(endpoint)
+	u, err := c.buildURL(postsRoute(criteria))
 	if err != nil {
-		return 0, domain.NewConnectionError(endpoint, fmt.Sprintf("invalid base URL: %v", err))
+		return 0, err
 	}
 
 	// Build query parameters (same as search but we only need the count)
 	query := u.Query()
 	c.addSearchParams(query, criteria)
 
-	// Set per_page to 1 to minimize data transfer when we only need the count
+	// Set per_page to 1 and skip embedding to minimize the work when we only
+	// need the count
 	query.Set("per_page", "1")
+	query.Del("_embed")
 
 	u.RawQuery = query.Encode()
 
-	// Create HTTP request
-	req, err := http.NewRequestWithContext(ctx, "HEAD", u.String(), nil)
+	header, err := c.postsAPI().DoJSON(ctx, "HEAD", u.String(), nil)
 	if err != nil {
-		return 0, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Make HTTP request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return 0, domain.NewConnectionError(u.String(), fmt.Sprintf("HTTP request failed: %v", err))
-	}
-	defer resp.Body.Close()
-
-	// Check for HTTP errors
-	if resp.StatusCode != http.StatusOK {
-		return 0, c.handleAPIError(resp.StatusCode, nil)
+		return 0, postsError(criteria, err)
 	}
 
 	// Get total count from header
-	totalHeader := resp.Header.Get("X-WP-Total")
+	totalHeader := header.Get("X-WP-Total")
 	if totalHeader == "" {
 		// Fallback: return 0 if header is not available
 		return 0, nil
@@ -156,13 +165,66 @@ func (c *Client) CountPosts(ctx context.Context, criteria *domain.SearchCriteria
 	return total, nil
 }
 
+// postsRoute returns the REST route listing posts of the criteria's type
+func postsRoute(criteria *domain.SearchCriteria) string {
+	postType := criteria.Type
+	if postType == "" {
+		postType = domain.DefaultPostType
+	}
+	return "wp/v2/" + string(postType)
+}
+
+// postsError explains a 404 for a custom post type: WordPress has no route
+// for types that do not exist or are not shown in the REST API
+func postsError(criteria *domain.SearchCriteria, err error) error {
+	if criteria.Type == "" || criteria.Type == domain.DefaultPostType {
+		return err
+	}
+	var postErr *domain.PostError
+	if errors.As(err, &postErr) && postErr.StatusCode == http.StatusNotFound {
+		return domain.NewPostTypeNotFoundError(criteria.Type)
+	}
+	return err
+}
+
+// buildURL resolves a REST route (e.g. "wp/v2/posts") against the base URL,
+// appending it under wp-json relative to any path prefix of the site
+func (c *Client) buildURL(route string) (*url.URL, error) {
+	u, err := storehttp.RouteURL(c.config.BaseURL, route)
+	if err != nil {
+		return nil, domain.NewConnectionError(c.config.BaseURL, err.Error())
+	}
+	return u, nil
+}
+
 // addSearchParams adds search parameters to the query
 func (c *Client) addSearchParams(query url.Values, criteria *domain.SearchCriteria) {
 	if criteria.Search != "" {
 		query.Set("search", criteria.Search)
 	}
-	if criteria.Status != "" {
-		query.Set("status", string(criteria.Status))
+	if len(criteria.SearchColumns) > 0 {
+		columnStrs := make([]string, len(criteria.SearchColumns))
+		for i, column := range criteria.SearchColumns {
+			columnStrs[i] = string(column)
+		}
+		query.Set("search_columns", strings.Join(columnStrs, ","))
+	}
+	if criteria.Slug != "" {
+		query.Set("slug", criteria.Slug)
+	}
+	if len(criteria.Include) > 0 {
+		includeStrs := make([]string, len(criteria.Include))
+		for i, id := range criteria.Include {
+			includeStrs[i] = strconv.FormatInt(id.Value(), 10)
+		}
+		query.Set("include", strings.Join(includeStrs, ","))
+	}
+	if len(criteria.Statuses) > 0 {
+		statusStrs := make([]string, len(criteria.Statuses))
+		for i, status := range criteria.Statuses {
+			statusStrs[i] = string(status)
+		}
+		query.Set("status", strings.Join(statusStrs, ","))
 	}
 	if criteria.Author != 0 {
 		query.Set("author", strconv.FormatInt(criteria.Author, 10))
@@ -181,6 +243,9 @@ func (c *Client) addSearchParams(query url.Values, criteria *domain.SearchCriter
 		}
 		query.Set("tags", strings.Join(tagStrs, ","))
 	}
+	if criteria.Sticky != nil {
+		query.Set("sticky", strconv.FormatBool(*criteria.Sticky))
+	}
 	if criteria.Before != "" {
 		query.Set("before", criteria.Before)
 	}
@@ -211,44 +276,45 @@ func (c *Client) addSearchParams(query url.Values, criteria *domain.SearchCriter
 	} else {
 		query.Set("order", "desc") // Default
 	}
-}
 
-// handleAPIError handles API errors and converts them to domain errors
-func (c *Client) handleAPIError(statusCode int, body []byte) error {
-	message := string(body)
-	if len(body) == 0 {
-		message = http.StatusText(statusCode)
+	// The raw content is only part of the edit context, which WordPress
+	// serves to authenticated users allowed to edit the posts
+	if criteria.ContentFormat == domain.ContentFormatRaw {
+		query.Set("context", "edit")
 	}
 
-	// Try to parse error response for more details
-	var apiError struct {
-		Code    string `json:"code"`
-		Message string `json:"message"`
-	}
-	if len(body) > 0 {
-		if err := json.Unmarshal(body, &apiError); err == nil {
-			if apiError.Message != "" {
-				message = apiError.Message
-			}
-		}
+	// Only the links the posts are converted from are embedded; sites older
+	// than WordPress 5.4 ignore the list and embed every link
+	if criteria.Embed {
+		query.Set("_embed", "author,wp:featuredmedia,wp:term")
 	}
+}
+
+// handleAPIError handles API errors and converts them to domain errors
+func (c *Client) handleAPIError(statusCode int, body []byte) error {
+	message, code := httpjson.ErrorMessage(statusCode, body)
 
-	return domain.NewWordPressAPIError(statusCode, message, apiError.Code)
+	return domain.NewWordPressAPIError(statusCode, message, code)
 }
 
-// apiPostToDomain converts an API post to a domain post
-func (c *Client) apiPostToDomain(apiPost *APIPost) (*domain.Post, error) {
+// apiPostToDomain converts an API post to a domain post, taking its content
+// in the given format
+func (c *Client) apiPostToDomain(apiPost *APIPost, format domain.ContentFormat) (*domain.Post, error) {
 	// Create post ID
 	postID, err := domain.NewPostID(apiPost.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Create domain post
-	post := domain.NewPost(postID, apiPost.Title.Rendered)
+	// Create domain post. Rendered titles carry HTML entities (e.g. &amp;,
+	// &#8217;) that must not reach users literally; content is left as is.
+	post := domain.NewPost(postID, html.UnescapeString(apiPost.Title.Rendered))
 
 	// Set basic fields
 	post.Content = apiPost.Content.Rendered
+	if format == domain.ContentFormatRaw {
+		post.Content = apiPost.Content.Raw
+	}
 	post.Excerpt = apiPost.Excerpt.Rendered
 	post.Slug = apiPost.Slug
 	post.Permalink = apiPost.Link
@@ -300,5 +366,42 @@ func (c *Client) apiPostToDomain(apiPost *APIPost) (*domain.Post, error) {
 		post.MetaData = append(post.MetaData, *metaData)
 	}
 
+	if apiPost.Embedded != nil {
+		addEmbedded(post, apiPost.Embedded)
+	}
+
 	return post, nil
 }
+
+// addEmbedded fills the author name, featured image and terms of a post from
+// the resources embedded with it. Links the request may not see are
+// embedded as error objects and leave the fields empty.
+func addEmbedded(post *domain.Post, embedded *APIEmbedded) {
+	if len(embedded.Author) > 0 {
+		post.AuthorName = html.UnescapeString(embedded.Author[0].Name)
+	}
+	if len(embedded.FeaturedMedia) > 0 {
+		post.FeaturedImageURL = embedded.FeaturedMedia[0].SourceURL
+	}
+
+	for _, terms := range embedded.Terms {
+		for _, term := range terms {
+			switch term.Taxonomy {
+			case "category":
+				post.Categories = append(post.Categories, domain.Category{
+					ID:   term.ID,
+					Name: term.Name,
+					Slug: term.Slug,
+					Link: term.Link,
+				})
+			case "post_tag":
+				post.Tags = append(post.Tags, domain.Tag{
+					ID:   term.ID,
+					Name: term.Name,
+					Slug: term.Slug,
+					Link: term.Link,
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,303 @@
+package wordpress
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"woocommerce-mcp/internal/post/domain"
+)
+
+// startStub starts a server answering every request with the handler given,
+// or with an empty list for a nil one, and returns the requests it received
+func startStub(t *testing.T, handler http.HandlerFunc) (string, func() []*http.Request) {
+	t.Helper()
+	if handler == nil {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`[]`))
+		}
+	}
+	var mu sync.Mutex
+	var requests []*http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r)
+		mu.Unlock()
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server.URL, func() []*http.Request {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]*http.Request(nil), requests...)
+	}
+}
+
+func TestRoutesKeepTheBaseURLPrefix(t *testing.T) {
+	for suffix, want := range map[string]string{
+		"":               "/wp-json/wp/v2/posts",
+		"/":              "/wp-json/wp/v2/posts",
+		"/blog":          "/blog/wp-json/wp/v2/posts",
+		"/blog/":         "/blog/wp-json/wp/v2/posts",
+		"/blog/wp-json/": "/blog/wp-json/wp/v2/posts",
+	} {
+		baseURL, requests := startStub(t, nil)
+		client := NewClient(NewConfig(baseURL + suffix))
+		if _, err := client.SearchPosts(context.Background(), &domain.SearchCriteria{}); err != nil {
+			t.Fatalf("base URL %q: %v", suffix, err)
+		}
+		if got := requests(); len(got) != 1 || got[0].URL.Path != want {
+			t.Errorf("base URL %q requested %v, want %s", suffix, got, want)
+		}
+	}
+}
+
+func TestInvalidBaseURLIsAConnectionError(t *testing.T) {
+	client := NewClient(NewConfig("example.com/blog"))
+	_, err := client.SearchPosts(context.Background(), &domain.SearchCriteria{})
+	var postErr *domain.PostError
+	if !errors.As(err, &postErr) || postErr.Type != "ConnectionError" {
+		t.Errorf("base URL without scheme: got error %v, want a ConnectionError", err)
+	}
+}
+
+func TestRenderedTitlesAreDecoded(t *testing.T) {
+	baseURL, _ := startStub(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"id":7,"status":"publish","type":"post",` +
+			`"title":{"rendered":"Ben &amp; Jerry&#8217;s &quot;Best&quot; Flavours"},` +
+			`"content":{"rendered":"<p>Salt &amp; pepper</p>"}}]`))
+	})
+	client := NewClient(NewConfig(baseURL))
+
+	posts, err := client.SearchPosts(context.Background(), &domain.SearchCriteria{})
+	if err != nil {
+		t.Fatalf("SearchPosts: %v", err)
+	}
+	if len(posts) != 1 {
+		t.Fatalf("got %d posts, want 1", len(posts))
+	}
+	if want := "Ben & Jerry\u2019s \"Best\" Flavours"; posts[0].Title != want {
+		t.Errorf("title = %q, want %q", posts[0].Title, want)
+	}
+	if !strings.Contains(posts[0].Content, "Salt &amp; pepper") {
+		t.Errorf("content = %q, want the entities left alone", posts[0].Content)
+	}
+}
+
+func TestStickyIsSentOnlyWhenSet(t *testing.T) {
+	pinned, unpinned := true, false
+	for name, tt := range map[string]struct {
+		sticky *bool
+		want   string
+	}{
+		"sticky only":      {sticky: &pinned, want: "true"},
+		"excluding sticky": {sticky: &unpinned, want: "false"},
+		"unset":            {},
+	} {
+		baseURL, requests := startStub(t, nil)
+		client := NewClient(NewConfig(baseURL))
+		if _, err := client.SearchPosts(context.Background(), &domain.SearchCriteria{Sticky: tt.sticky}); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		query := requests()[0].URL.Query()
+		if got, sent := query.Get("sticky"), query.Has("sticky"); got != tt.want || sent != (tt.sticky != nil) {
+			t.Errorf("%s: sticky = %q (sent %v), want %q", name, got, sent, tt.want)
+		}
+	}
+}
+
+func TestStatusesAreSentJoined(t *testing.T) {
+	for _, tt := range []struct {
+		statuses []domain.PostStatus
+		want     string
+	}{
+		{[]domain.PostStatus{domain.PostStatusPublish}, "publish"},
+		{[]domain.PostStatus{domain.PostStatusPublish, domain.PostStatusFuture}, "publish,future"},
+		{nil, ""},
+	} {
+		baseURL, requests := startStub(t, nil)
+		if _, err := NewClient(NewConfig(baseURL)).SearchPosts(context.Background(), &domain.SearchCriteria{Statuses: tt.statuses}); err != nil {
+			t.Fatalf("statuses %v: %v", tt.statuses, err)
+		}
+		if got := requests()[0].URL.Query().Get("status"); got != tt.want {
+			t.Errorf("statuses %v: sent status=%q, want %q", tt.statuses, got, tt.want)
+		}
+	}
+}
+
+func TestSearchColumnsAreSentJoined(t *testing.T) {
+	baseURL, requests := startStub(t, nil)
+	criteria := &domain.SearchCriteria{Search: "leather", SearchColumns: []domain.SearchColumn{domain.SearchColumnTitle, domain.SearchColumnExcerpt}}
+	if _, err := NewClient(NewConfig(baseURL)).SearchPosts(context.Background(), criteria); err != nil {
+		t.Fatalf("SearchPosts: %v", err)
+	}
+	if got := requests()[0].URL.Query().Get("search_columns"); got != "post_title,post_excerpt" {
+		t.Errorf("search_columns = %q, want post_title,post_excerpt", got)
+	}
+
+	baseURL, requests = startStub(t, nil)
+	if _, err := NewClient(NewConfig(baseURL)).SearchPosts(context.Background(), &domain.SearchCriteria{Search: "leather"}); err != nil {
+		t.Fatalf("SearchPosts: %v", err)
+	}
+	if requests()[0].URL.Query().Has("search_columns") {
+		t.Error("search_columns sent without columns")
+	}
+}
+
+func TestLatin1ResponsesAreTranscoded(t *testing.T) {
+	baseURL, _ := startStub(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=ISO-8859-1")
+		w.Write([]byte("[{\"id\":7,\"status\":\"publish\",\"type\":\"post\"," +
+			"\"title\":{\"rendered\":\"Caf\xe9 cr\xe8me br\xfbl\xe9e\"},\"content\":{\"rendered\":\"<p>D\xe9j\xe0 vu</p>\"}}]"))
+	})
+
+	posts, err := NewClient(NewConfig(baseURL)).SearchPosts(context.Background(), &domain.SearchCriteria{})
+	if err != nil {
+		t.Fatalf("SearchPosts: %v", err)
+	}
+	if len(posts) != 1 || posts[0].Title != "Café crème brûlée" {
+		t.Fatalf("got %+v, want the title decoded from ISO-8859-1", posts)
+	}
+	if !strings.Contains(posts[0].Content, "Déjà vu") {
+		t.Errorf("content = %q, want it decoded from ISO-8859-1", posts[0].Content)
+	}
+}
+
+func TestRawContentIsRequestedInTheEditContext(t *testing.T) {
+	baseURL, requests := startStub(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"id":7,"status":"publish","type":"post","title":{"rendered":"Hello"},` +
+			`"content":{"rendered":"<p>Hello</p>","raw":"<!-- wp:paragraph --><p>Hello</p><!-- /wp:paragraph -->"}}]`))
+	})
+	config := NewConfig(baseURL)
+	config.Username = "editor"
+	config.ApplicationPassword = "abcd efgh"
+	client := NewClient(config)
+
+	for format, want := range map[domain.ContentFormat]string{
+		"":                           "<p>Hello</p>",
+		domain.ContentFormatRendered: "<p>Hello</p>",
+		domain.ContentFormatRaw:      "<!-- wp:paragraph --><p>Hello</p><!-- /wp:paragraph -->",
+	} {
+		posts, err := client.SearchPosts(context.Background(), &domain.SearchCriteria{ContentFormat: format})
+		if err != nil {
+			t.Fatalf("format %q: %v", format, err)
+		}
+		if len(posts) != 1 || posts[0].Content != want {
+			t.Errorf("format %q: got %v, want content %q", format, posts, want)
+		}
+		sent := requests()
+		query := sent[len(sent)-1].URL.Query()
+		wantContext := ""
+		if format == domain.ContentFormatRaw {
+			wantContext = "edit"
+		}
+		if got := query.Get("context"); got != wantContext {
+			t.Errorf("format %q: sent context=%q, want %q", format, got, wantContext)
+		}
+	}
+}
+
+func TestCredentialsAreSentOnlyWhenConfigured(t *testing.T) {
+	baseURL, requests := startStub(t, nil)
+	if _, err := NewClient(NewConfig(baseURL)).SearchPosts(context.Background(), &domain.SearchCriteria{}); err != nil {
+		t.Fatalf("anonymous SearchPosts: %v", err)
+	}
+	if _, _, ok := requests()[0].BasicAuth(); ok {
+		t.Error("anonymous request sent basic auth")
+	}
+
+	config := NewConfig(baseURL)
+	config.Username = "editor"
+	config.ApplicationPassword = "abcd efgh"
+	if _, err := NewClient(config).SearchPosts(context.Background(), &domain.SearchCriteria{}); err != nil {
+		t.Fatalf("authenticated SearchPosts: %v", err)
+	}
+	username, password, ok := requests()[1].BasicAuth()
+	if !ok || username != "editor" || password != "abcd efgh" {
+		t.Errorf("basic auth = %q/%q (sent %v), want editor/abcd efgh", username, password, ok)
+	}
+}
+
+func TestSlugAndIncludeAreSentOnlyWhenSet(t *testing.T) {
+	baseURL, requests := startStub(t, nil)
+	client := NewClient(NewConfig(baseURL))
+	for _, criteria := range []*domain.SearchCriteria{
+		{},
+		{Slug: "my-post"},
+		{Include: []domain.PostID{7, 12}},
+	} {
+		if _, err := client.SearchPosts(context.Background(), criteria); err != nil {
+			t.Fatalf("criteria %+v: %v", criteria, err)
+		}
+	}
+
+	sent := requests()
+	for i, want := range []struct{ slug, include string }{{}, {slug: "my-post"}, {include: "7,12"}} {
+		query := sent[i].URL.Query()
+		if query.Get("slug") != want.slug || query.Get("include") != want.include || query.Has("slug") != (want.slug != "") || query.Has("include") != (want.include != "") {
+			t.Errorf("request %d sent slug=%q include=%q, want %q and %q", i, query.Get("slug"), query.Get("include"), want.slug, want.include)
+		}
+	}
+}
+
+func TestEmbeddedResourcesAreRead(t *testing.T) {
+	baseURL, requests := startStub(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-WP-Total", "2")
+		w.Write([]byte(`[{"id":7,"status":"publish","type":"post","title":{"rendered":"Hello"},"_embedded":{` +
+			`"author":[{"id":2,"name":"Ana &amp; Co"}],` +
+			`"wp:featuredmedia":[{"id":9,"source_url":"https://blog.example/hello.jpg"}],` +
+			`"wp:term":[[{"id":4,"name":"News","slug":"news","taxonomy":"category"}],[{"id":5,"name":"tips","slug":"tips","taxonomy":"post_tag"}]]}},` +
+			`{"id":8,"status":"publish","type":"post","title":{"rendered":"Private"},"_embedded":{` +
+			`"author":[{"code":"rest_user_invalid_id","message":"Invalid user ID."}],` +
+			`"wp:featuredmedia":[{"code":"rest_forbidden","message":"Sorry, you are not allowed to do that."}]}}]`))
+	})
+	client := NewClient(NewConfig(baseURL))
+
+	posts, err := client.SearchPosts(context.Background(), &domain.SearchCriteria{Embed: true})
+	if err != nil {
+		t.Fatalf("SearchPosts: %v", err)
+	}
+	if got := requests()[0].URL.Query().Get("_embed"); got != "author,wp:featuredmedia,wp:term" {
+		t.Errorf("sent _embed=%q, want the links the posts are converted from", got)
+	}
+	if len(posts) != 2 {
+		t.Fatalf("got %d posts, want 2", len(posts))
+	}
+	embedded := posts[0]
+	if embedded.AuthorName != "Ana & Co" || embedded.FeaturedImageURL != "https://blog.example/hello.jpg" {
+		t.Errorf("author %q, image %q; want the embedded ones", embedded.AuthorName, embedded.FeaturedImageURL)
+	}
+	if len(embedded.Categories) != 1 || embedded.Categories[0].Name != "News" || len(embedded.Tags) != 1 || embedded.Tags[0].Name != "tips" {
+		t.Errorf("categories %+v, tags %+v; want News and tips", embedded.Categories, embedded.Tags)
+	}
+	if hidden := posts[1]; hidden.AuthorName != "" || hidden.FeaturedImageURL != "" {
+		t.Errorf("error objects read as author %q, image %q; want them empty", hidden.AuthorName, hidden.FeaturedImageURL)
+	}
+
+	// Counting does not embed
+	if _, err := client.CountPosts(context.Background(), &domain.SearchCriteria{Embed: true}); err != nil {
+		t.Fatalf("CountPosts: %v", err)
+	}
+	sent := requests()
+	if query := sent[len(sent)-1].URL.Query(); query.Has("_embed") {
+		t.Errorf("count sent _embed=%q", query.Get("_embed"))
+	}
+}
+
+func TestEmbedIsSentOnlyWhenSet(t *testing.T) {
+	baseURL, requests := startStub(t, nil)
+	if _, err := NewClient(NewConfig(baseURL)).SearchPosts(context.Background(), &domain.SearchCriteria{}); err != nil {
+		t.Fatalf("SearchPosts: %v", err)
+	}
+	if query := requests()[0].URL.Query(); query.Has("_embed") {
+		t.Errorf("sent _embed=%q without embed", query.Get("_embed"))
+	}
+}
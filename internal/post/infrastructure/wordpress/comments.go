@@ -0,0 +1,125 @@
+package wordpress
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+	"woocommerce-mcp/internal/post/domain"
+)
+
+// maxCommentPages bounds the comment pages tallied for one batch; busier
+// batches are counted per post from the X-WP-Total header instead
+const maxCommentPages = 10
+
+// CountComments counts the approved comments of each post. The comments of
+// the whole batch are listed in one request filtered by post IDs (paginating
+// if needed) and tallied per post; posts without comments, including those
+// with comments disabled, get 0.
+func (c *Client) CountComments(ctx context.Context, postIDs []domain.PostID) (map[domain.PostID]int64, error) {
+	counts := make(map[domain.PostID]int64, len(postIDs))
+	if len(postIDs) == 0 {
+		return counts, nil
+	}
+
+	ids := make([]string, len(postIDs))
+	for i, id := range postIDs {
+		counts[id] = 0
+		ids[i] = strconv.FormatInt(id.Value(), 10)
+	}
+
+	for page := 1; ; page++ {
+		comments, totalPages, err := c.listCommentPosts(ctx, strings.Join(ids, ","), page)
+		if err != nil {
+			return nil, err
+		}
+		if totalPages > maxCommentPages {
+			return c.countCommentsPerPost(ctx, postIDs)
+		}
+
+		for _, comment := range comments {
+			postID, err := domain.NewPostID(comment.Post)
+			if err != nil {
+				continue
+			}
+			if _, ok := counts[postID]; ok {
+				counts[postID]++
+			}
+		}
+
+		if page >= totalPages || len(comments) == 0 {
+			return counts, nil
+		}
+	}
+}
+
+// listCommentPosts fetches one page of comments for the given posts, keeping
+// only the post each comment belongs to, and returns the total page count
+func (c *Client) listCommentPosts(ctx context.Context, postIDs string, page int) ([]APIComment, int, error) {
+	u, err := c.buildCommentsURL(postIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := u.Query()
+	query.Set("per_page", "100")
+	query.Set("page", strconv.Itoa(page))
+	query.Set("_fields", "post")
+	u.RawQuery = query.Encode()
+
+	var comments []APIComment
+	header, err := c.api().DoJSON(ctx, "GET", u.String(), &comments)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	totalPages, err := strconv.Atoi(header.Get("X-WP-TotalPages"))
+	if err != nil {
+		totalPages = page
+	}
+
+	return comments, totalPages, nil
+}
+
+// countCommentsPerPost counts the comments of each post from the X-WP-Total
+// header, for batches with too many comments to list
+func (c *Client) countCommentsPerPost(ctx context.Context, postIDs []domain.PostID) (map[domain.PostID]int64, error) {
+	counts := make(map[domain.PostID]int64, len(postIDs))
+	for _, id := range postIDs {
+		u, err := c.buildCommentsURL(strconv.FormatInt(id.Value(), 10))
+		if err != nil {
+			return nil, err
+		}
+
+		// Set per_page to 1 to minimize data transfer when we only need the count
+		query := u.Query()
+		query.Set("per_page", "1")
+		u.RawQuery = query.Encode()
+
+		header, err := c.api().DoJSON(ctx, "HEAD", u.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		total, err := strconv.ParseInt(header.Get("X-WP-Total"), 10, 64)
+		if err != nil {
+			total = 0
+		}
+		counts[id] = total
+	}
+
+	return counts, nil
+}
+
+// buildCommentsURL builds the comments endpoint URL filtered by post IDs
+func (c *Client) buildCommentsURL(postIDs string) (*url.URL, error) {
+	u, err := c.buildURL("wp/v2/comments")
+	if err != nil {
+		return nil, err
+	}
+
+	query := u.Query()
+	query.Set("post", postIDs)
+	u.RawQuery = query.Encode()
+	return u, nil
+}
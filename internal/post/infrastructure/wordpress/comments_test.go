@@ -0,0 +1,104 @@
+package wordpress
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"woocommerce-mcp/internal/post/domain"
+)
+
+// postIDs creates post IDs
+func postIDs(ids ...int64) []domain.PostID {
+	postIDs := make([]domain.PostID, len(ids))
+	for i, id := range ids {
+		postIDs[i], _ = domain.NewPostID(id)
+	}
+	return postIDs
+}
+
+// commentPages answers comment listings with the pages given, each a list
+// of the post IDs the comments belong to
+func commentPages(pages ...[]int64) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var page int
+		fmt.Sscan(r.URL.Query().Get("page"), &page)
+		comments := make([]string, 0)
+		if page >= 1 && page <= len(pages) {
+			for _, post := range pages[page-1] {
+				comments = append(comments, fmt.Sprintf(`{"post":%d}`, post))
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-WP-TotalPages", fmt.Sprint(len(pages)))
+		w.Write([]byte("[" + strings.Join(comments, ",") + "]"))
+	}
+}
+
+func TestCommentCountsAreBatched(t *testing.T) {
+	// Post 103 has comments disabled and none listed
+	baseURL, requests := startStub(t, commentPages([]int64{101, 102, 101}, []int64{101}))
+	client := NewClient(NewConfig(baseURL))
+
+	counts, err := client.CountComments(context.Background(), postIDs(101, 102, 103))
+	if err != nil {
+		t.Fatalf("CountComments: %v", err)
+	}
+
+	want := map[int64]int64{101: 3, 102: 1, 103: 0}
+	if len(counts) != len(want) {
+		t.Errorf("counts = %v, want %v", counts, want)
+	}
+	for id, count := range counts {
+		if count != want[id.Value()] {
+			t.Errorf("post %d has %d comments, want %d", id.Value(), count, want[id.Value()])
+		}
+	}
+
+	got := requests()
+	if len(got) != 2 {
+		t.Fatalf("sent %d requests, want one per comment page", len(got))
+	}
+	for _, request := range got {
+		if request.URL.Path != "/wp-json/wp/v2/comments" || request.URL.Query().Get("post") != "101,102,103" {
+			t.Errorf("requested %s, want the comments of the whole batch", request.URL)
+		}
+	}
+}
+
+func TestBusyBatchesAreCountedPerPost(t *testing.T) {
+	baseURL, requests := startStub(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodHead {
+			w.Header().Set("X-WP-Total", map[string]string{"101": "250", "102": "0"}[r.URL.Query().Get("post")])
+			return
+		}
+		w.Header().Set("X-WP-TotalPages", fmt.Sprint(maxCommentPages+1))
+		w.Write([]byte(`[{"post":101}]`))
+	})
+	client := NewClient(NewConfig(baseURL))
+
+	counts, err := client.CountComments(context.Background(), postIDs(101, 102))
+	if err != nil {
+		t.Fatalf("CountComments: %v", err)
+	}
+	ids := postIDs(101, 102)
+	if counts[ids[0]] != 250 || counts[ids[1]] != 0 {
+		t.Errorf("counts = %v, want 250 and 0 from the totals", counts)
+	}
+	if got := len(requests()); got != 3 {
+		t.Errorf("sent %d requests, want the listing then one count per post", got)
+	}
+}
+
+func TestNoPostsNeedNoRequest(t *testing.T) {
+	baseURL, requests := startStub(t, nil)
+	client := NewClient(NewConfig(baseURL))
+
+	counts, err := client.CountComments(context.Background(), nil)
+	if err != nil || len(counts) != 0 || len(requests()) != 0 {
+		t.Errorf("got %v, %v after %d requests, want no counts and no request", counts, err, len(requests()))
+	}
+}
@@ -49,3 +49,18 @@ func (r *Repository) GetPostByID(ctx context.Context, id domain.PostID) (*domain
 
 	return nil, domain.NewNotFoundError(id)
 }
+
+// ListTerms lists categories or tags using the WordPress API
+func (r *Repository) ListTerms(ctx context.Context, criteria *domain.TermCriteria) ([]*domain.Term, error) {
+	return r.client.ListTerms(ctx, criteria)
+}
+
+// CountTerms returns the total count of categories or tags matching the criteria
+func (r *Repository) CountTerms(ctx context.Context, criteria *domain.TermCriteria) (int64, error) {
+	return r.client.CountTerms(ctx, criteria)
+}
+
+// CountComments returns the approved comment count of each post
+func (r *Repository) CountComments(ctx context.Context, postIDs []domain.PostID) (map[domain.PostID]int64, error) {
+	return r.client.CountComments(ctx, postIDs)
+}
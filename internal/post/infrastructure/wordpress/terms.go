@@ -0,0 +1,126 @@
+package wordpress
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/url"
+	"strconv"
+	"woocommerce-mcp/internal/post/domain"
+)
+
+// termRoutes maps each taxonomy to its WordPress REST route
+var termRoutes = map[domain.Taxonomy]string{
+	domain.TaxonomyCategory: "wp/v2/categories",
+	domain.TaxonomyTag:      "wp/v2/tags",
+}
+
+// ListTerms lists categories or tags using the WordPress API
+func (c *Client) ListTerms(ctx context.Context, criteria *domain.TermCriteria) ([]*domain.Term, error) {
+	u, err := c.buildTermsURL(criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiTerms []APITerm
+	if _, err := c.api().DoJSON(ctx, "GET", u.String(), &apiTerms); err != nil {
+		return nil, err
+	}
+
+	terms := make([]*domain.Term, len(apiTerms))
+	for i := range apiTerms {
+		terms[i] = apiTermToDomain(&apiTerms[i], criteria.Taxonomy)
+	}
+
+	return terms, nil
+}
+
+// CountTerms counts categories or tags matching the criteria using the X-WP-Total header
+func (c *Client) CountTerms(ctx context.Context, criteria *domain.TermCriteria) (int64, error) {
+	u, err := c.buildTermsURL(criteria)
+	if err != nil {
+		return 0, err
+	}
+
+	// Set per_page to 1 to minimize data transfer when we only need the count
+	query := u.Query()
+	query.Set("per_page", "1")
+	query.Set("page", "1")
+	u.RawQuery = query.Encode()
+
+	header, err := c.api().DoJSON(ctx, "HEAD", u.String(), nil)
+	if err != nil {
+		return 0, err
+	}
+
+	// Get total count from header
+	totalHeader := header.Get("X-WP-Total")
+	if totalHeader == "" {
+		return 0, nil
+	}
+
+	total, err := strconv.ParseInt(totalHeader, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse total count: %w", err)
+	}
+
+	return total, nil
+}
+
+// buildTermsURL builds the taxonomy endpoint URL with the list parameters
+func (c *Client) buildTermsURL(criteria *domain.TermCriteria) (*url.URL, error) {
+	route, ok := termRoutes[criteria.Taxonomy]
+	if !ok {
+		return nil, domain.NewValidationError(fmt.Sprintf("unsupported taxonomy %q", criteria.Taxonomy))
+	}
+
+	u, err := c.buildURL(route)
+	if err != nil {
+		return nil, err
+	}
+
+	query := u.Query()
+	if criteria.Search != "" {
+		query.Set("search", criteria.Search)
+	}
+	// Tags are flat; WordPress rejects the parent parameter on their route
+	if criteria.Parent != nil && criteria.Taxonomy.IsHierarchical() {
+		query.Set("parent", strconv.FormatInt(*criteria.Parent, 10))
+	}
+	if criteria.HideEmpty {
+		query.Set("hide_empty", "true")
+	}
+
+	// Pagination
+	if criteria.PerPage > 0 {
+		query.Set("per_page", strconv.Itoa(criteria.PerPage))
+	}
+	if criteria.Page > 0 {
+		query.Set("page", strconv.Itoa(criteria.Page))
+	}
+
+	// Sorting
+	if criteria.OrderBy != "" {
+		query.Set("orderby", criteria.OrderBy)
+	}
+	if criteria.Order != "" {
+		query.Set("order", criteria.Order)
+	}
+
+	u.RawQuery = query.Encode()
+	return u, nil
+}
+
+// apiTermToDomain converts an API term to a domain term
+func apiTermToDomain(apiTerm *APITerm, taxonomy domain.Taxonomy) *domain.Term {
+	// Term names are returned with HTML entities (e.g. &amp;) encoded
+	return &domain.Term{
+		ID:          apiTerm.ID,
+		Taxonomy:    taxonomy,
+		Name:        html.UnescapeString(apiTerm.Name),
+		Slug:        apiTerm.Slug,
+		Description: apiTerm.Description,
+		Count:       apiTerm.Count,
+		Parent:      apiTerm.Parent,
+	}
+}
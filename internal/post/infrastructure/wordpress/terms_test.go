@@ -0,0 +1,90 @@
+package wordpress
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"woocommerce-mcp/internal/post/domain"
+)
+
+// nestedCategories answers with News > Releases > Beta and a total of 3
+func nestedCategories(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-WP-Total", "3")
+	w.Write([]byte(`[
+		{"id":5,"name":"News","slug":"news","count":4,"parent":0},
+		{"id":6,"name":"Releases &amp; Updates","slug":"releases","count":2,"parent":5},
+		{"id":7,"name":"Beta","slug":"beta","count":0,"parent":6}
+	]`))
+}
+
+func TestListCategoriesKeepsTheHierarchy(t *testing.T) {
+	baseURL, requests := startStub(t, nestedCategories)
+	client := NewClient(NewConfig(baseURL))
+
+	parent := int64(5)
+	terms, err := client.ListTerms(context.Background(), &domain.TermCriteria{
+		Taxonomy:  domain.TaxonomyCategory,
+		Search:    "rel",
+		Parent:    &parent,
+		HideEmpty: true,
+		Page:      2,
+		PerPage:   20,
+	})
+	if err != nil {
+		t.Fatalf("ListTerms: %v", err)
+	}
+
+	request := requests()[0]
+	if request.URL.Path != "/wp-json/wp/v2/categories" {
+		t.Errorf("requested %s, want the categories route", request.URL.Path)
+	}
+	query := request.URL.Query()
+	for param, want := range map[string]string{"search": "rel", "parent": "5", "hide_empty": "true", "page": "2", "per_page": "20"} {
+		if got := query.Get(param); got != want {
+			t.Errorf("%s = %q, want %q", param, got, want)
+		}
+	}
+
+	wantParents := map[int64]int64{5: 0, 6: 5, 7: 6}
+	for _, term := range terms {
+		if term.Parent != wantParents[term.ID] || term.Taxonomy != domain.TaxonomyCategory {
+			t.Errorf("term %d has parent %d in %s, want parent %d", term.ID, term.Parent, term.Taxonomy, wantParents[term.ID])
+		}
+	}
+	if terms[1].Name != "Releases & Updates" {
+		t.Errorf("name = %q, want the entities decoded", terms[1].Name)
+	}
+}
+
+func TestListTagsNeverSendsParent(t *testing.T) {
+	baseURL, requests := startStub(t, nil)
+	client := NewClient(NewConfig(baseURL))
+
+	parent := int64(5)
+	if _, err := client.ListTerms(context.Background(), &domain.TermCriteria{Taxonomy: domain.TaxonomyTag, Parent: &parent}); err != nil {
+		t.Fatalf("ListTerms: %v", err)
+	}
+
+	request := requests()[0]
+	if request.URL.Path != "/wp-json/wp/v2/tags" || request.URL.Query().Has("parent") {
+		t.Errorf("requested %s, want the tags route without parent", request.URL)
+	}
+}
+
+func TestCountTermsReadsTheTotalHeader(t *testing.T) {
+	baseURL, requests := startStub(t, nestedCategories)
+	client := NewClient(NewConfig(baseURL))
+
+	count, err := client.CountTerms(context.Background(), &domain.TermCriteria{Taxonomy: domain.TaxonomyCategory, PerPage: 50})
+	if err != nil {
+		t.Fatalf("CountTerms: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("count = %d, want 3", count)
+	}
+	if request := requests()[0]; request.Method != http.MethodHead || request.URL.Query().Get("per_page") != "1" {
+		t.Errorf("counted with %s %s, want a single-item HEAD request", request.Method, request.URL)
+	}
+}
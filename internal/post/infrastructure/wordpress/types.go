@@ -25,6 +25,36 @@ type APIPost struct {
 	MetaFields    map[string]interface{} `json:"meta"`
 	Categories    []int64                `json:"categories"`
 	Tags          []int64                `json:"tags"`
+
+	// Embedded holds the linked resources WordPress adds when _embed is
+	// requested
+	Embedded *APIEmbedded `json:"_embedded,omitempty"`
+}
+
+// APIEmbedded represents the _embedded field of a post requested with _embed
+type APIEmbedded struct {
+	Author        []APIEmbeddedAuthor `json:"author"`
+	FeaturedMedia []APIEmbeddedMedia  `json:"wp:featuredmedia"`
+
+	// Terms holds one list per taxonomy of the post, e.g. its categories
+	// and its tags
+	Terms [][]APITerm `json:"wp:term"`
+}
+
+// APIEmbeddedAuthor represents an embedded post author. An author the
+// request may not see is embedded as an error object with no name.
+type APIEmbeddedAuthor struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Link string `json:"link"`
+}
+
+// APIEmbeddedMedia represents an embedded featured image. Media the request
+// may not see is embedded as an error object with no source URL.
+type APIEmbeddedMedia struct {
+	ID        int64  `json:"id"`
+	SourceURL string `json:"source_url"`
+	AltText   string `json:"alt_text"`
 }
 
 // GUID represents the GUID field from WordPress API
@@ -41,6 +71,10 @@ type Title struct {
 type Content struct {
 	Rendered  string `json:"rendered"`
 	Protected bool   `json:"protected"`
+
+	// Raw is the stored source of the content, only returned in the edit
+	// context
+	Raw string `json:"raw,omitempty"`
 }
 
 // Excerpt represents the excerpt field from WordPress API
@@ -77,3 +111,21 @@ type APITag struct {
 	Slug        string `json:"slug"`
 	Taxonomy    string `json:"taxonomy"`
 }
+
+// APITerm represents a category or tag from the WordPress REST API
+type APITerm struct {
+	ID          int64  `json:"id"`
+	Count       int    `json:"count"`
+	Description string `json:"description"`
+	Link        string `json:"link"`
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	Taxonomy    string `json:"taxonomy"`
+	Parent      int64  `json:"parent"`
+}
+
+// APIComment represents a comment from the WordPress REST API, trimmed to the
+// fields requested
+type APIComment struct {
+	Post int64 `json:"post"`
+}
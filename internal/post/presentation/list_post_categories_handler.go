@@ -0,0 +1,135 @@
+package presentation
+
+import (
+	"context"
+	"fmt"
+
+	"woocommerce-mcp/internal/post/application/list_terms"
+	"woocommerce-mcp/internal/post/domain"
+	"woocommerce-mcp/internal/post/infrastructure/wordpress"
+	kitDomain "woocommerce-mcp/kit/domain"
+	"woocommerce-mcp/kit/storeconfig"
+	"woocommerce-mcp/kit/toolargs"
+	"woocommerce-mcp/kit/tooltimeout"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ListPostCategoriesInput defines the input structure for the list_post_categories tool
+type ListPostCategoriesInput struct {
+	BaseURL   string `json:"base_url,omitempty" jsonschema:"WordPress site base URL (e.g., https://example.com); defaults to WC_BASE_URL"`
+	Search    string `json:"search,omitempty" jsonschema:"Search term to filter categories by name"`
+	Parent    string `json:"parent,omitempty" jsonschema:"Only list direct children of this category ID (0 for top-level categories)"`
+	HideEmpty string `json:"hide_empty,omitempty" jsonschema:"Hide categories without posts (true/false)"`
+	Page      string `json:"page,omitempty" jsonschema:"Page number for pagination (default: 1)"`
+	PerPage   string `json:"per_page,omitempty" jsonschema:"Number of categories per page (1-100, default: 10)"`
+	OrderBy   string `json:"orderby,omitempty" jsonschema:"Sort by field (id, include, name, slug, term_group, description, count)"`
+	Order     string `json:"order,omitempty" jsonschema:"Sort order (asc, desc)"`
+}
+
+// UnmarshalJSON decodes the arguments, also accepting numbers and booleans
+// for the string fields
+func (in *ListPostCategoriesInput) UnmarshalJSON(data []byte) error {
+	type plain ListPostCategoriesInput
+	return toolargs.Unmarshal(data, (*plain)(in))
+}
+
+// ListPostCategoriesOutput defines the output structure for the list_post_categories tool
+type ListPostCategoriesOutput struct {
+	Message string `json:"message" jsonschema:"Human-readable message about the listed categories"`
+	Data    string `json:"data" jsonschema:"JSON-formatted category data"`
+}
+
+// ListPostCategoriesHandler handles list_post_categories tool calls
+type ListPostCategoriesHandler struct{}
+
+// NewListPostCategoriesHandler creates a new ListPostCategoriesHandler
+func NewListPostCategoriesHandler() *ListPostCategoriesHandler {
+	return &ListPostCategoriesHandler{}
+}
+
+// GetToolDefinition returns the MCP tool definition for list_post_categories
+func (h *ListPostCategoriesHandler) GetToolDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "list_post_categories",
+		Description: "List WordPress post categories with their parent category, so they can be shown as a hierarchy. Category IDs can be used with the categories filter of search_posts.",
+		InputSchema: toolargs.InputSchema[ListPostCategoriesInput](),
+	}
+}
+
+// GetInputSchema returns the input schema for the JSON-RPC tools/list endpoint
+func (h *ListPostCategoriesHandler) GetInputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"base_url":   map[string]string{"type": "string", "description": "WordPress site base URL"},
+			"search":     map[string]string{"type": "string", "description": "Search term to filter categories"},
+			"parent":     map[string]string{"type": "string", "description": "Parent category ID (0 for top-level categories)"},
+			"hide_empty": map[string]string{"type": "string", "description": "Hide categories without posts"},
+			"per_page":   map[string]string{"type": "string", "description": "Number of categories per page"},
+			"page":       map[string]string{"type": "string", "description": "Page number"},
+			"order":      map[string]string{"type": "string", "description": "Sort order"},
+			"orderby":    map[string]string{"type": "string", "description": "Sort field"},
+		},
+		"required": storeconfig.RequiredFields("base_url"),
+	}
+}
+
+// ExecuteMCPTool implements the MCP tool execution
+func (h *ListPostCategoriesHandler) ExecuteMCPTool(ctx context.Context, req *mcp.CallToolRequest, input ListPostCategoriesInput) (*mcp.CallToolResult, ListPostCategoriesOutput, error) {
+	// Fall back to the default store configured through the environment
+	storeconfig.ApplyDefaultBaseURL(&input.BaseURL)
+
+	// Validate required fields
+	if input.BaseURL == "" {
+		return nil, ListPostCategoriesOutput{}, kitDomain.NewValidationError("base_url is required")
+	}
+
+	// Create WordPress client
+	config := wordpress.NewConfig(input.BaseURL)
+	client := wordpress.NewClient(config)
+	repo := wordpress.NewRepository(client)
+
+	request := &list_terms.ListRequest{
+		BaseURL:   input.BaseURL,
+		Taxonomy:  domain.TaxonomyCategory,
+		Search:    input.Search,
+		Parent:    input.Parent,
+		HideEmpty: input.HideEmpty,
+		Page:      input.Page,
+		PerPage:   input.PerPage,
+		OrderBy:   input.OrderBy,
+		Order:     input.Order,
+	}
+
+	// Bound the whole tool call, across all of its API requests
+	ctx, cancel := tooltimeout.WithTimeout(ctx)
+	defer cancel()
+
+	// Execute listing
+	lister := list_terms.NewTermLister(repo)
+	response, err := lister.Execute(ctx, request)
+	if err != nil {
+		return nil, ListPostCategoriesOutput{}, tooltimeout.Err(ctx, "list_post_categories", fmt.Errorf("failed to list categories: %w", err))
+	}
+
+	// Convert response to JSON
+	jsonData, err := response.ToJSON()
+	if err != nil {
+		return nil, ListPostCategoriesOutput{}, fmt.Errorf("failed to serialize response: %w", err)
+	}
+
+	// Create human-readable message
+	var message string
+	if len(response.Terms) == 0 {
+		message = "No categories found"
+	} else {
+		message = fmt.Sprintf("Found %d category(ies) out of %d total (page %d of %d)",
+			len(response.Terms), response.TotalCount, response.CurrentPage, response.TotalPages)
+	}
+
+	return nil, ListPostCategoriesOutput{
+		Message: message,
+		Data:    jsonData,
+	}, nil
+}
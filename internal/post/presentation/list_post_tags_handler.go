@@ -0,0 +1,132 @@
+package presentation
+
+import (
+	"context"
+	"fmt"
+
+	"woocommerce-mcp/internal/post/application/list_terms"
+	"woocommerce-mcp/internal/post/domain"
+	"woocommerce-mcp/internal/post/infrastructure/wordpress"
+	kitDomain "woocommerce-mcp/kit/domain"
+	"woocommerce-mcp/kit/storeconfig"
+	"woocommerce-mcp/kit/toolargs"
+	"woocommerce-mcp/kit/tooltimeout"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ListPostTagsInput defines the input structure for the list_post_tags tool
+type ListPostTagsInput struct {
+	BaseURL   string `json:"base_url,omitempty" jsonschema:"WordPress site base URL (e.g., https://example.com); defaults to WC_BASE_URL"`
+	Search    string `json:"search,omitempty" jsonschema:"Search term to filter tags by name"`
+	HideEmpty string `json:"hide_empty,omitempty" jsonschema:"Hide tags without posts (true/false)"`
+	Page      string `json:"page,omitempty" jsonschema:"Page number for pagination (default: 1)"`
+	PerPage   string `json:"per_page,omitempty" jsonschema:"Number of tags per page (1-100, default: 10)"`
+	OrderBy   string `json:"orderby,omitempty" jsonschema:"Sort by field (id, include, name, slug, term_group, description, count)"`
+	Order     string `json:"order,omitempty" jsonschema:"Sort order (asc, desc)"`
+}
+
+// UnmarshalJSON decodes the arguments, also accepting numbers and booleans
+// for the string fields
+func (in *ListPostTagsInput) UnmarshalJSON(data []byte) error {
+	type plain ListPostTagsInput
+	return toolargs.Unmarshal(data, (*plain)(in))
+}
+
+// ListPostTagsOutput defines the output structure for the list_post_tags tool
+type ListPostTagsOutput struct {
+	Message string `json:"message" jsonschema:"Human-readable message about the listed tags"`
+	Data    string `json:"data" jsonschema:"JSON-formatted tag data"`
+}
+
+// ListPostTagsHandler handles list_post_tags tool calls
+type ListPostTagsHandler struct{}
+
+// NewListPostTagsHandler creates a new ListPostTagsHandler
+func NewListPostTagsHandler() *ListPostTagsHandler {
+	return &ListPostTagsHandler{}
+}
+
+// GetToolDefinition returns the MCP tool definition for list_post_tags
+func (h *ListPostTagsHandler) GetToolDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "list_post_tags",
+		Description: "List WordPress post tags. Tag IDs can be used with the tags filter of search_posts.",
+		InputSchema: toolargs.InputSchema[ListPostTagsInput](),
+	}
+}
+
+// GetInputSchema returns the input schema for the JSON-RPC tools/list endpoint
+func (h *ListPostTagsHandler) GetInputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"base_url":   map[string]string{"type": "string", "description": "WordPress site base URL"},
+			"search":     map[string]string{"type": "string", "description": "Search term to filter tags"},
+			"hide_empty": map[string]string{"type": "string", "description": "Hide tags without posts"},
+			"per_page":   map[string]string{"type": "string", "description": "Number of tags per page"},
+			"page":       map[string]string{"type": "string", "description": "Page number"},
+			"order":      map[string]string{"type": "string", "description": "Sort order"},
+			"orderby":    map[string]string{"type": "string", "description": "Sort field"},
+		},
+		"required": storeconfig.RequiredFields("base_url"),
+	}
+}
+
+// ExecuteMCPTool implements the MCP tool execution
+func (h *ListPostTagsHandler) ExecuteMCPTool(ctx context.Context, req *mcp.CallToolRequest, input ListPostTagsInput) (*mcp.CallToolResult, ListPostTagsOutput, error) {
+	// Fall back to the default store configured through the environment
+	storeconfig.ApplyDefaultBaseURL(&input.BaseURL)
+
+	// Validate required fields
+	if input.BaseURL == "" {
+		return nil, ListPostTagsOutput{}, kitDomain.NewValidationError("base_url is required")
+	}
+
+	// Create WordPress client
+	config := wordpress.NewConfig(input.BaseURL)
+	client := wordpress.NewClient(config)
+	repo := wordpress.NewRepository(client)
+
+	request := &list_terms.ListRequest{
+		BaseURL:   input.BaseURL,
+		Taxonomy:  domain.TaxonomyTag,
+		Search:    input.Search,
+		HideEmpty: input.HideEmpty,
+		Page:      input.Page,
+		PerPage:   input.PerPage,
+		OrderBy:   input.OrderBy,
+		Order:     input.Order,
+	}
+
+	// Bound the whole tool call, across all of its API requests
+	ctx, cancel := tooltimeout.WithTimeout(ctx)
+	defer cancel()
+
+	// Execute listing
+	lister := list_terms.NewTermLister(repo)
+	response, err := lister.Execute(ctx, request)
+	if err != nil {
+		return nil, ListPostTagsOutput{}, tooltimeout.Err(ctx, "list_post_tags", fmt.Errorf("failed to list tags: %w", err))
+	}
+
+	// Convert response to JSON
+	jsonData, err := response.ToJSON()
+	if err != nil {
+		return nil, ListPostTagsOutput{}, fmt.Errorf("failed to serialize response: %w", err)
+	}
+
+	// Create human-readable message
+	var message string
+	if len(response.Terms) == 0 {
+		message = "No tags found"
+	} else {
+		message = fmt.Sprintf("Found %d tag(s) out of %d total (page %d of %d)",
+			len(response.Terms), response.TotalCount, response.CurrentPage, response.TotalPages)
+	}
+
+	return nil, ListPostTagsOutput{
+		Message: message,
+		Data:    jsonData,
+	}, nil
+}
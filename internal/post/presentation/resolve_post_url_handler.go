@@ -0,0 +1,135 @@
+package presentation
+
+import (
+	"context"
+	"fmt"
+
+	"woocommerce-mcp/internal/post/application/resolve_post_url"
+	"woocommerce-mcp/internal/post/infrastructure/wordpress"
+	kitDomain "woocommerce-mcp/kit/domain"
+	"woocommerce-mcp/kit/jsonformat"
+	"woocommerce-mcp/kit/storeconfig"
+	"woocommerce-mcp/kit/toolargs"
+	"woocommerce-mcp/kit/tooltimeout"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ResolvePostURLDescription describes the resolve_post_url tool
+const ResolvePostURLDescription = "Find the WordPress post a pasted URL points to, e.g. https://example.com/2023/05/my-post/ or https://example.com/?p=123, and return it like search_posts does. Reports found=false when no post matches."
+
+// ResolvePostURLInput defines the input structure for the resolve_post_url tool
+type ResolvePostURLInput struct {
+	URL      string `json:"url" jsonschema:"Permalink of the post, e.g. https://example.com/2023/05/my-post/ or https://example.com/?p=123"`
+	BaseURL  string `json:"base_url,omitempty" jsonschema:"WordPress site base URL (e.g., https://example.com); defaults to WC_BASE_URL, then to the site of url"`
+	PostType string `json:"post_type,omitempty" jsonschema:"REST base of the post type the URL belongs to, e.g. pages or a custom type like portfolio (default: posts, or pages for ?page_id= URLs)"`
+	Pretty   string `json:"pretty,omitempty" jsonschema:"Indent the JSON data for human readers (true/false, default: false)"`
+}
+
+// UnmarshalJSON decodes the arguments, also accepting numbers and booleans
+// for the string fields
+func (in *ResolvePostURLInput) UnmarshalJSON(data []byte) error {
+	type plain ResolvePostURLInput
+	return toolargs.Unmarshal(data, (*plain)(in))
+}
+
+// ResolvePostURLOutput defines the output structure for the resolve_post_url tool
+type ResolvePostURLOutput struct {
+	Message string `json:"message" jsonschema:"Human-readable message about the resolved post"`
+	Data    string `json:"data" jsonschema:"JSON-formatted lookup result with the post"`
+}
+
+// ResolvePostURLHandler handles resolve_post_url tool calls
+type ResolvePostURLHandler struct{}
+
+// NewResolvePostURLHandler creates a new ResolvePostURLHandler
+func NewResolvePostURLHandler() *ResolvePostURLHandler {
+	return &ResolvePostURLHandler{}
+}
+
+// GetToolDefinition returns the MCP tool definition for resolve_post_url
+func (h *ResolvePostURLHandler) GetToolDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "resolve_post_url",
+		Description: ResolvePostURLDescription,
+		InputSchema: toolargs.InputSchema[ResolvePostURLInput](),
+	}
+}
+
+// GetInputSchema returns the input schema for the JSON-RPC tools/list endpoint
+func (h *ResolvePostURLHandler) GetInputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"url":       map[string]string{"type": "string", "description": "Permalink of the post (pretty or ?p=123)"},
+			"base_url":  map[string]string{"type": "string", "description": "WordPress site base URL (default: the site of url)"},
+			"post_type": map[string]string{"type": "string", "description": "REST base of the post type (default: posts)"},
+			"pretty":    map[string]string{"type": "string", "description": "Indent the JSON data (true/false)"},
+		},
+		"required": []string{"url"},
+	}
+}
+
+// ExecuteMCPTool implements the MCP tool execution
+func (h *ResolvePostURLHandler) ExecuteMCPTool(ctx context.Context, req *mcp.CallToolRequest, input ResolvePostURLInput) (*mcp.CallToolResult, ResolvePostURLOutput, error) {
+	// Fall back to the default store, then to the site the URL is on
+	storeconfig.ApplyDefaultBaseURL(&input.BaseURL)
+	if input.BaseURL == "" {
+		input.BaseURL = resolve_post_url.SiteURL(input.URL)
+	}
+
+	// Validate required fields
+	if input.URL == "" {
+		return nil, ResolvePostURLOutput{}, kitDomain.NewValidationError("url is required")
+	}
+	if input.BaseURL == "" {
+		return nil, ResolvePostURLOutput{}, kitDomain.NewValidationError("base_url is required")
+	}
+	pretty, err := jsonformat.ParsePretty(input.Pretty)
+	if err != nil {
+		return nil, ResolvePostURLOutput{}, kitDomain.NewValidationError("pretty must be true or false")
+	}
+
+	// Create WordPress client
+	config := wordpress.NewConfig(input.BaseURL)
+	client := wordpress.NewClient(config)
+	repo := wordpress.NewRepository(client)
+
+	request := &resolve_post_url.ResolveRequest{
+		URL:      input.URL,
+		PostType: input.PostType,
+	}
+
+	// Bound the whole tool call, across all of its API requests
+	ctx, cancel := tooltimeout.WithTimeout(ctx)
+	defer cancel()
+
+	// Execute lookup
+	resolver := resolve_post_url.NewPostResolver(repo)
+	response, err := resolver.Execute(ctx, request)
+	if err != nil {
+		return nil, ResolvePostURLOutput{}, tooltimeout.Err(ctx, "resolve_post_url", fmt.Errorf("failed to resolve post URL: %w", err))
+	}
+
+	// Convert response to JSON
+	jsonData, err := response.ToJSON(pretty)
+	if err != nil {
+		return nil, ResolvePostURLOutput{}, fmt.Errorf("failed to serialize response: %w", err)
+	}
+
+	// Create human-readable message
+	var message string
+	switch {
+	case response.Found:
+		message = fmt.Sprintf("Found post %d: %s", response.Post.ID, response.Post.Title)
+	case response.ID != 0:
+		message = fmt.Sprintf("No %s found with ID %d", response.PostType, response.ID)
+	default:
+		message = fmt.Sprintf("No %s found with slug '%s'", response.PostType, response.Slug)
+	}
+
+	return nil, ResolvePostURLOutput{
+		Message: message,
+		Data:    jsonData,
+	}, nil
+}
@@ -0,0 +1,85 @@
+package presentation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"woocommerce-mcp/internal/testutil/fakestore"
+	"woocommerce-mcp/kit/storeconfig"
+)
+
+// startSlugStore starts a fake store whose post lists honour the slug
+// filter, which the fake store itself ignores
+func startSlugStore(t *testing.T) *httptest.Server {
+	t.Helper()
+	posts := fakestore.DefaultPosts()
+	store := fakestore.New()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		slug := r.URL.Query().Get("slug")
+		if slug == "" {
+			store.Handler().ServeHTTP(w, r)
+			return
+		}
+		matching := []map[string]interface{}{}
+		for _, post := range posts {
+			if post["slug"] == slug {
+				matching = append(matching, post)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(matching)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestResolvePostURLFindsThePostOfTheSite(t *testing.T) {
+	t.Setenv(storeconfig.BaseURLEnv, "")
+	server := startSlugStore(t)
+	slug := fakestore.DefaultPosts()[2]["slug"].(string)
+
+	// Without a base_url the site of the pasted URL is asked
+	_, output, err := NewResolvePostURLHandler().ExecuteMCPTool(context.Background(), nil, ResolvePostURLInput{
+		URL: server.URL + "/2024/03/" + slug + "/?utm_source=newsletter",
+	})
+	if err != nil {
+		t.Fatalf("resolve_post_url: %v", err)
+	}
+	var data struct {
+		Found bool `json:"found"`
+		Post  struct {
+			ID int64 `json:"id"`
+		} `json:"post"`
+	}
+	if err := json.Unmarshal([]byte(output.Data), &data); err != nil {
+		t.Fatalf("data is not JSON: %v\n%s", err, output.Data)
+	}
+	if !data.Found || data.Post.ID != 103 || !strings.Contains(output.Message, "103") {
+		t.Errorf("output %+v, want post 103 found", output)
+	}
+}
+
+func TestResolvePostURLReportsUnknownPostsAsNotFound(t *testing.T) {
+	server := startSlugStore(t)
+
+	_, output, err := NewResolvePostURLHandler().ExecuteMCPTool(context.Background(), nil, ResolvePostURLInput{
+		URL:     server.URL + "/no-such-post/",
+		BaseURL: server.URL,
+	})
+	if err != nil {
+		t.Fatalf("resolve_post_url: %v", err)
+	}
+	if !strings.Contains(output.Data, `"found":false`) {
+		t.Errorf("data %s, want found=false", output.Data)
+	}
+}
+
+func TestResolvePostURLRequiresAURL(t *testing.T) {
+	if _, _, err := NewResolvePostURLHandler().ExecuteMCPTool(context.Background(), nil, ResolvePostURLInput{URL: "  "}); err == nil {
+		t.Error("an empty url was accepted")
+	}
+}
@@ -0,0 +1,88 @@
+package presentation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"woocommerce-mcp/internal/testutil/fakestore"
+	"woocommerce-mcp/kit/safemode"
+)
+
+func TestSafeModeSearchPostsIsAnonymousAndPublished(t *testing.T) {
+	t.Setenv(safemode.Env, "true")
+
+	store := fakestore.New()
+	posts := fakestore.DefaultPosts()
+	posts[1]["status"] = "draft"
+	store.SetPosts(posts)
+
+	var mu sync.Mutex
+	var authorized []string
+	handler := store.Handler()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			mu.Lock()
+			authorized = append(authorized, r.URL.RequestURI())
+			mu.Unlock()
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	_, output, err := NewSearchPostsHandler().ExecuteMCPTool(context.Background(), nil, SearchPostsInput{
+		BaseURL:             server.URL,
+		Username:            "editor",
+		ApplicationPassword: "abcd efgh",
+	})
+	if err != nil {
+		t.Fatalf("search_posts: %v", err)
+	}
+
+	var data struct {
+		Posts []struct {
+			ID     int64  `json:"id"`
+			Status string `json:"status"`
+		} `json:"posts"`
+	}
+	if err := json.Unmarshal([]byte(output.Data), &data); err != nil {
+		t.Fatalf("decode search data: %v", err)
+	}
+	if len(data.Posts) != 2 {
+		t.Errorf("got %d posts, want the 2 published ones", len(data.Posts))
+	}
+	for _, post := range data.Posts {
+		if post.Status != "publish" {
+			t.Errorf("safe mode returned post %d with status %q", post.ID, post.Status)
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(authorized) > 0 {
+		t.Errorf("safe mode sent credentials with %v", authorized)
+	}
+	for _, request := range store.Requests() {
+		if strings.Contains(request, "status=") && !strings.Contains(request, "status=publish") {
+			t.Errorf("request asked for other statuses: %s", request)
+		}
+	}
+}
+
+func TestSafeModeSearchPostsRejectsDraftStatus(t *testing.T) {
+	t.Setenv(safemode.Env, "true")
+
+	server := fakestore.New().Start()
+	defer server.Close()
+
+	_, _, err := NewSearchPostsHandler().ExecuteMCPTool(context.Background(), nil, SearchPostsInput{
+		BaseURL: server.URL,
+		Status:  "draft",
+	})
+	if err == nil || !strings.Contains(err.Error(), "safe mode") {
+		t.Fatalf("status=draft in safe mode: got error %v, want a safe mode rejection", err)
+	}
+}
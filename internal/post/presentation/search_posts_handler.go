@@ -2,30 +2,54 @@ package presentation
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
 
 	"woocommerce-mcp/internal/post/application/search_posts"
+	kitDomain "woocommerce-mcp/kit/domain"
+	"woocommerce-mcp/kit/jsonformat"
+	"woocommerce-mcp/kit/pagination"
+	"woocommerce-mcp/kit/retry"
+	"woocommerce-mcp/kit/storeconfig"
+	"woocommerce-mcp/kit/toolargs"
+	"woocommerce-mcp/kit/tooltimeout"
 
-	"github.com/gin-gonic/gin"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
 // SearchPostsInput defines the input structure for the search_posts tool
 type SearchPostsInput struct {
-	BaseURL    string `json:"base_url" jsonschema:"WordPress site base URL (e.g., https://example.com)"`
+	BaseURL    string `json:"base_url,omitempty" jsonschema:"WordPress site base URL (e.g., https://example.com); defaults to WC_BASE_URL"`
+	PostType   string `json:"post_type,omitempty" jsonschema:"REST base of the post type to search, e.g. pages or a custom type like portfolio (default: posts)"`
 	Search     string `json:"search,omitempty" jsonschema:"Search term to filter posts"`
-	Status     string `json:"status,omitempty" jsonschema:"Post status filter (publish, draft, private, pending, trash)"`
+	Status     string `json:"status,omitempty" jsonschema:"Post status filter (publish, future, draft, pending, private, trash); comma-separate to match several, e.g. publish,future. Statuses other than publish need an authenticated request"`
 	Author     string `json:"author,omitempty" jsonschema:"Author ID filter"`
 	Categories string `json:"categories,omitempty" jsonschema:"Comma-separated category IDs"`
 	Tags       string `json:"tags,omitempty" jsonschema:"Comma-separated tag IDs"`
+	Sticky     string `json:"sticky,omitempty" jsonschema:"true for only sticky (pinned) posts, false to exclude them; omit for both"`
 	Before     string `json:"before,omitempty" jsonschema:"Limit response to posts published before a given date (ISO 8601 format)"`
 	After      string `json:"after,omitempty" jsonschema:"Limit response to posts published after a given date (ISO 8601 format)"`
 	Page       string `json:"page,omitempty" jsonschema:"Page number for pagination (default: 1)"`
 	PerPage    string `json:"per_page,omitempty" jsonschema:"Number of posts per page (default: 10, max: 100)"`
-	OrderBy    string `json:"orderby,omitempty" jsonschema:"Sort by field (date, relevance, id, include, title, slug)"`
+	OrderBy    string `json:"orderby,omitempty" jsonschema:"Sort by field (date, relevance, id, include, title, slug). Defaults to relevance when search is set, otherwise date; relevance requires search"`
 	Order      string `json:"order,omitempty" jsonschema:"Sort order (asc, desc)"`
+
+	SearchColumns       string `json:"search_columns,omitempty" jsonschema:"Comma-separated columns the search term is matched in (post_title, post_content, post_excerpt); default: all"`
+	IncludeCommentCount string `json:"include_comment_count,omitempty" jsonschema:"Add the approved comment count of each post (true/false)"`
+	Embed               string `json:"embed,omitempty" jsonschema:"Add the author name, featured image URL and category and tag names of each post, fetched in the same request (true/false)"`
+	ContentFormat       string `json:"content_format,omitempty" jsonschema:"Return the content as rendered HTML (rendered, default) or as its stored source such as block markup (raw); raw needs username and application_password"`
+	Username            string `json:"username,omitempty" jsonschema:"WordPress username to authenticate as, together with application_password"`
+	ApplicationPassword string `json:"application_password,omitempty" jsonschema:"WordPress application password of the user (Users > Profile > Application Passwords)"`
+	Pretty              string `json:"pretty,omitempty" jsonschema:"Indent the JSON data for human readers (true/false, default: false)"`
+
+	MaxRetries     string `json:"max_retries,omitempty" jsonschema:"Retries of each store request that fails transiently, for this call only (0-5; default: API_MAX_RETRIES or 0)"`
+	RetryBackoffMS string `json:"retry_backoff_ms,omitempty" jsonschema:"Wait before the first retry in milliseconds, doubling with each further retry, for this call only (0-10000; default: API_RETRY_BACKOFF_MS or 200)"`
+}
+
+// UnmarshalJSON decodes the arguments, also accepting numbers and booleans
+// for the string fields
+func (in *SearchPostsInput) UnmarshalJSON(data []byte) error {
+	type plain SearchPostsInput
+	return toolargs.Unmarshal(data, (*plain)(in))
 }
 
 // SearchPostsOutput defines the output structure for the search_posts tool
@@ -46,7 +70,8 @@ func NewSearchPostsHandler() *SearchPostsHandler {
 func (h *SearchPostsHandler) GetToolDefinition() *mcp.Tool {
 	return &mcp.Tool{
 		Name:        "search_posts",
-		Description: "Search for blog posts in WordPress sites. Supports various filters like search terms, categories, tags, author, status, and more.",
+		Description: "Search for blog posts in WordPress sites. Supports various filters like search terms, categories, tags, author, status, and more. Set post_type to search pages or a custom post type exposed in the REST API.",
+		InputSchema: toolargs.InputSchema[SearchPostsInput](),
 	}
 }
 
@@ -55,55 +80,92 @@ func (h *SearchPostsHandler) GetInputSchema() map[string]interface{} {
 	return map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
-			"base_url":   map[string]string{"type": "string", "description": "WordPress site base URL"},
-			"search":     map[string]string{"type": "string", "description": "Search term to filter posts"},
-			"status":     map[string]string{"type": "string", "description": "Post status filter"},
-			"author":     map[string]string{"type": "string", "description": "Author ID filter"},
-			"categories": map[string]string{"type": "string", "description": "Comma-separated category IDs"},
-			"tags":       map[string]string{"type": "string", "description": "Comma-separated tag IDs"},
-			"before":     map[string]string{"type": "string", "description": "Posts published before date (ISO 8601)"},
-			"after":      map[string]string{"type": "string", "description": "Posts published after date (ISO 8601)"},
-			"per_page":   map[string]string{"type": "string", "description": "Number of posts per page"},
-			"page":       map[string]string{"type": "string", "description": "Page number"},
-			"order":      map[string]string{"type": "string", "description": "Sort order"},
-			"orderby":    map[string]string{"type": "string", "description": "Sort field"},
+			"base_url":              map[string]string{"type": "string", "description": "WordPress site base URL"},
+			"post_type":             map[string]string{"type": "string", "description": "REST base of the post type to search (default: posts)"},
+			"search":                map[string]string{"type": "string", "description": "Search term to filter posts"},
+			"status":                map[string]string{"type": "string", "description": "Post status filter (comma-separated statuses)"},
+			"author":                map[string]string{"type": "string", "description": "Author ID filter"},
+			"categories":            map[string]string{"type": "string", "description": "Comma-separated category IDs"},
+			"tags":                  map[string]string{"type": "string", "description": "Comma-separated tag IDs"},
+			"sticky":                map[string]string{"type": "string", "description": "Only sticky posts (true) or no sticky posts (false)"},
+			"before":                map[string]string{"type": "string", "description": "Posts published before date (ISO 8601)"},
+			"after":                 map[string]string{"type": "string", "description": "Posts published after date (ISO 8601)"},
+			"per_page":              map[string]string{"type": "string", "description": "Number of posts per page"},
+			"page":                  map[string]string{"type": "string", "description": "Page number"},
+			"order":                 map[string]string{"type": "string", "description": "Sort order"},
+			"orderby":               map[string]string{"type": "string", "description": "Sort field"},
+			"search_columns":        map[string]string{"type": "string", "description": "Columns the search term is matched in (post_title, post_content, post_excerpt)"},
+			"include_comment_count": map[string]string{"type": "string", "description": "Add the approved comment count of each post (true/false)"},
+			"embed":                 map[string]string{"type": "string", "description": "Add author name, featured image URL and term names of each post (true/false)"},
+			"content_format":        map[string]string{"type": "string", "description": "Content as rendered HTML (rendered) or stored source (raw, needs credentials)"},
+			"username":              map[string]string{"type": "string", "description": "WordPress username, together with application_password"},
+			"application_password":  map[string]string{"type": "string", "description": "WordPress application password of the user"},
+			"pretty":                map[string]string{"type": "string", "description": "Indent the JSON data (true/false)"},
+			"max_retries":           map[string]string{"type": "string", "description": "Retries of transiently failing store requests for this call (0-5)"},
+			"retry_backoff_ms":      map[string]string{"type": "string", "description": "Wait before the first retry in milliseconds, doubling per retry (0-10000)"},
 		},
-		"required": []string{"base_url"},
+		"required": storeconfig.RequiredFields("base_url"),
 	}
 }
 
 // ExecuteMCPTool implements the MCP tool execution
 func (h *SearchPostsHandler) ExecuteMCPTool(ctx context.Context, req *mcp.CallToolRequest, input SearchPostsInput) (*mcp.CallToolResult, SearchPostsOutput, error) {
+	// Fall back to the default store configured through the environment
+	storeconfig.ApplyDefaultBaseURL(&input.BaseURL)
+
 	// Validate required fields
 	if input.BaseURL == "" {
-		return nil, SearchPostsOutput{}, fmt.Errorf("base_url is required")
+		return nil, SearchPostsOutput{}, kitDomain.NewValidationError("base_url is required")
+	}
+	pretty, err := jsonformat.ParsePretty(input.Pretty)
+	if err != nil {
+		return nil, SearchPostsOutput{}, kitDomain.NewValidationError("pretty must be true or false")
+	}
+
+	// Let this call tune the retries of its store requests
+	ctx, err = retry.Override(ctx, input.MaxRetries, input.RetryBackoffMS)
+	if err != nil {
+		return nil, SearchPostsOutput{}, err
 	}
 
 	// Create search request
 	request := &search_posts.SearchRequest{
 		BaseURL:    input.BaseURL,
+		PostType:   input.PostType,
 		Search:     input.Search,
 		Status:     input.Status,
 		Author:     input.Author,
 		Categories: input.Categories,
 		Tags:       input.Tags,
+		Sticky:     input.Sticky,
 		Before:     input.Before,
 		After:      input.After,
 		Page:       input.Page,
 		PerPage:    input.PerPage,
 		OrderBy:    input.OrderBy,
 		Order:      input.Order,
+
+		SearchColumns:       input.SearchColumns,
+		IncludeCommentCount: input.IncludeCommentCount,
+		Embed:               input.Embed,
+		ContentFormat:       input.ContentFormat,
+		Username:            input.Username,
+		ApplicationPassword: input.ApplicationPassword,
 	}
 
+	// Bound the whole tool call, across all of its API requests
+	ctx, cancel := tooltimeout.WithTimeout(ctx)
+	defer cancel()
+
 	// Execute search
 	searcher := search_posts.NewPostSearcher(nil) // We pass nil since the searcher creates its own repository
 	response, err := searcher.Execute(ctx, request)
 	if err != nil {
-		return nil, SearchPostsOutput{}, fmt.Errorf("failed to search posts: %w", err)
+		return nil, SearchPostsOutput{}, tooltimeout.Err(ctx, "search_posts", fmt.Errorf("failed to search posts: %w", err))
 	}
 
 	// Convert response to JSON
-	jsonData, err := response.ToJSON()
+	jsonData, err := response.ToJSON(pretty)
 	if err != nil {
 		return nil, SearchPostsOutput{}, fmt.Errorf("failed to serialize response: %w", err)
 	}
@@ -112,118 +174,24 @@ func (h *SearchPostsHandler) ExecuteMCPTool(ctx context.Context, req *mcp.CallTo
 	var message string
 	if len(response.Posts) == 0 {
 		message = "No posts found matching the search criteria"
+		if filters := request.FilterSummary(); filters != "" {
+			message = fmt.Sprintf("No posts found for %s", filters)
+		}
+		if response.TotalCount > 0 {
+			message += fmt.Sprintf(" on page %d (%d total across %d page(s))",
+				response.CurrentPage, response.TotalCount, response.TotalPages)
+		}
+		message += "."
 	} else {
 		message = fmt.Sprintf("Found %d post(s) (page %d of %d)",
 			len(response.Posts), response.CurrentPage, response.TotalPages)
 	}
+	if response.PerPageCapped {
+		message += fmt.Sprintf(" (per_page capped at %d; use page for more)", pagination.MaxPerPage)
+	}
 
 	return nil, SearchPostsOutput{
 		Message: message,
 		Data:    jsonData,
 	}, nil
 }
-
-// HandleJSONRPC handles JSON-RPC tool calls
-func (h *SearchPostsHandler) HandleJSONRPC(c *gin.Context, requestID interface{}, arguments map[string]interface{}) {
-	// Convert arguments to SearchPostsInput
-	argsJSON, err := json.Marshal(arguments)
-	if err != nil {
-		h.sendJSONRPCError(c, requestID, -32602, "Invalid arguments", err.Error())
-		return
-	}
-
-	var input SearchPostsInput
-	if err := json.Unmarshal(argsJSON, &input); err != nil {
-		h.sendJSONRPCError(c, requestID, -32602, "Invalid input format", err.Error())
-		return
-	}
-
-	// Call the MCP tool directly
-	_, output, err := h.ExecuteMCPTool(c.Request.Context(), nil, input)
-	if err != nil {
-		h.sendJSONRPCError(c, requestID, -32603, "Tool execution failed", err.Error())
-		return
-	}
-
-	// Format response as expected by the message API
-	content := []map[string]interface{}{
-		{
-			"type": "text",
-			"text": output.Data,
-		},
-	}
-
-	response := map[string]interface{}{
-		"jsonrpc": "2.0",
-		"result":  map[string]interface{}{"content": content},
-		"id":      requestID,
-	}
-
-	h.sendSSEResponse(c, response)
-}
-
-// HandleLegacyHTTP handles legacy HTTP tool calls
-func (h *SearchPostsHandler) HandleLegacyHTTP(c *gin.Context, arguments map[string]interface{}) {
-	// Convert arguments to SearchPostsInput
-	argsJSON, err := json.Marshal(arguments)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"content": []map[string]interface{}{{"type": "text", "text": fmt.Sprintf("Invalid arguments: %v", err)}},
-			"isError": true,
-		})
-		return
-	}
-
-	var input SearchPostsInput
-	if err := json.Unmarshal(argsJSON, &input); err != nil {
-		c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"content": []map[string]interface{}{{"type": "text", "text": fmt.Sprintf("Invalid input format: %v", err)}},
-			"isError": true,
-		})
-		return
-	}
-
-	// Call the MCP tool directly
-	_, output, err := h.ExecuteMCPTool(c.Request.Context(), nil, input)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, map[string]interface{}{
-			"content": []map[string]interface{}{{"type": "text", "text": fmt.Sprintf("Tool execution failed: %v", err)}},
-			"isError": true,
-		})
-		return
-	}
-
-	// Return successful result
-	resultText := fmt.Sprintf("%s\n\n%s", output.Message, output.Data)
-	c.JSON(http.StatusOK, map[string]interface{}{
-		"content": []map[string]interface{}{{"type": "text", "text": resultText}},
-	})
-}
-
-// sendSSEResponse sends a JSON-RPC response as Server-Sent Event
-func (h *SearchPostsHandler) sendSSEResponse(c *gin.Context, response map[string]interface{}) {
-	responseData, err := json.Marshal(response)
-	if err != nil {
-		h.sendJSONRPCError(c, response["id"], -32603, "Internal error", err.Error())
-		return
-	}
-
-	// Send as SSE format
-	c.String(http.StatusOK, "data: %s\n\n", string(responseData))
-}
-
-// sendJSONRPCError sends a JSON-RPC error response as SSE
-func (h *SearchPostsHandler) sendJSONRPCError(c *gin.Context, id interface{}, code int, message, data string) {
-	errorResponse := map[string]interface{}{
-		"jsonrpc": "2.0",
-		"error": map[string]interface{}{
-			"code":    code,
-			"message": message,
-			"data":    data,
-		},
-		"id": id,
-	}
-
-	responseData, _ := json.Marshal(errorResponse)
-	c.String(http.StatusOK, "data: %s\n\n", string(responseData))
-}
@@ -0,0 +1,154 @@
+package presentation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"woocommerce-mcp/internal/testutil/fakestore"
+	"woocommerce-mcp/kit/retry"
+)
+
+// searchPostsOutput runs search_posts against a fake store holding the
+// default fixtures and returns the tool output
+func searchPostsOutput(t *testing.T, input SearchPostsInput) SearchPostsOutput {
+	t.Helper()
+
+	server := fakestore.New().Start()
+	t.Cleanup(server.Close)
+
+	input.BaseURL = server.URL
+	_, output, err := NewSearchPostsHandler().ExecuteMCPTool(context.Background(), nil, input)
+	if err != nil {
+		t.Fatalf("search_posts: %v", err)
+	}
+	return output
+}
+
+func TestEmptySearchPostsMessageEchoesTheFilters(t *testing.T) {
+	output := searchPostsOutput(t, SearchPostsInput{
+		Search:     "xyz",
+		Categories: "7",
+		Sticky:     "false",
+	})
+
+	for _, want := range []string{"No posts found for", "search='xyz'", "categories='7'", "excluding sticky"} {
+		if !strings.Contains(output.Message, want) {
+			t.Errorf("message %q does not contain %q", output.Message, want)
+		}
+	}
+}
+
+func TestEmptySearchPostsMessageOmitsCredentials(t *testing.T) {
+	output := searchPostsOutput(t, SearchPostsInput{
+		Search:              "xyz",
+		Username:            fakestore.Username,
+		ApplicationPassword: fakestore.ApplicationPassword,
+	})
+
+	if !strings.Contains(output.Message, "search='xyz'") {
+		t.Errorf("message %q does not echo the search", output.Message)
+	}
+	if strings.Contains(output.Message, fakestore.Username) || strings.Contains(output.Message, fakestore.ApplicationPassword) {
+		t.Errorf("message leaks the credentials: %q", output.Message)
+	}
+}
+
+func TestOversizedPerPageIsFlaggedAsCapped(t *testing.T) {
+	output := searchPostsOutput(t, SearchPostsInput{PerPage: "500"})
+
+	if !strings.Contains(output.Message, "per_page capped at 100; use page for more") {
+		t.Errorf("message %q does not note the cap", output.Message)
+	}
+	if !strings.Contains(output.Data, `"per_page_capped":true`) || !strings.Contains(output.Data, `"per_page":100`) {
+		t.Errorf("data does not flag the capped per_page:\n%s", output.Data)
+	}
+}
+
+func TestSearchPostsDataIsCompactUnlessPretty(t *testing.T) {
+	compact := searchPostsOutput(t, SearchPostsInput{})
+	if strings.Contains(compact.Data, "\n") || !json.Valid([]byte(compact.Data)) {
+		t.Errorf("default data is not compact JSON: %s", compact.Data)
+	}
+
+	pretty := searchPostsOutput(t, SearchPostsInput{Pretty: "true"})
+	if !strings.Contains(pretty.Data, "\n  \"") || !json.Valid([]byte(pretty.Data)) {
+		t.Errorf("pretty data is not indented JSON: %s", pretty.Data)
+	}
+}
+
+func TestSearchPostsRetriesWhenAsked(t *testing.T) {
+	t.Setenv(retry.MaxRetriesEnv, "0")
+
+	// the blog fails once before answering
+	blog := fakestore.New().Handler()
+	var failures int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&failures, 1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		blog.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	input := SearchPostsInput{BaseURL: server.URL}
+	if _, _, err := NewSearchPostsHandler().ExecuteMCPTool(context.Background(), nil, input); err == nil {
+		t.Fatal("without retries the failure succeeded")
+	}
+
+	atomic.StoreInt32(&failures, 0)
+	input.MaxRetries, input.RetryBackoffMS = "1", "1"
+	if _, _, err := NewSearchPostsHandler().ExecuteMCPTool(context.Background(), nil, input); err != nil {
+		t.Errorf("max_retries=1: %v", err)
+	}
+}
+
+func TestCustomPostTypeIsSearchedUnderItsRoute(t *testing.T) {
+	store := fakestore.New()
+	portfolio := fakestore.DefaultPosts()[:1]
+	portfolio[0]["type"] = "portfolio"
+	portfolio[0]["title"] = map[string]interface{}{"rendered": "Lookbook 2024"}
+	store.SetPostsOfType("portfolio", portfolio)
+	server := store.Start()
+	defer server.Close()
+
+	_, output, err := NewSearchPostsHandler().ExecuteMCPTool(context.Background(), nil, SearchPostsInput{BaseURL: server.URL, PostType: "portfolio"})
+	if err != nil {
+		t.Fatalf("search_posts: %v", err)
+	}
+	if !strings.Contains(output.Data, "Lookbook 2024") || strings.Contains(output.Data, "Running Tips") {
+		t.Errorf("data = %s, want only the portfolio post", output.Data)
+	}
+
+	requested := false
+	for _, request := range store.Requests() {
+		if strings.HasPrefix(request, "GET /wp-json/wp/v2/portfolio?") {
+			requested = true
+		}
+	}
+	if !requested {
+		t.Errorf("requests = %v, want the portfolio route", store.Requests())
+	}
+}
+
+func TestDefaultPostTypeSearchesPosts(t *testing.T) {
+	output := searchPostsOutput(t, SearchPostsInput{})
+	if !strings.Contains(output.Data, "Running Tips") {
+		t.Errorf("data = %s, want the regular posts", output.Data)
+	}
+}
+
+func TestUnregisteredPostTypeIsExplained(t *testing.T) {
+	server := fakestore.New().Start()
+	defer server.Close()
+
+	_, _, err := NewSearchPostsHandler().ExecuteMCPTool(context.Background(), nil, SearchPostsInput{BaseURL: server.URL, PostType: "recipes"})
+	if err == nil || !strings.Contains(err.Error(), `post type "recipes" is not available`) || !strings.Contains(err.Error(), "show_in_rest") {
+		t.Errorf("error = %v, want the unregistered post type explained", err)
+	}
+}
@@ -0,0 +1,106 @@
+package presentation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"woocommerce-mcp/internal/post/application/search_posts"
+	"woocommerce-mcp/internal/post/domain"
+	"woocommerce-mcp/internal/testutil/fakestore"
+)
+
+// searchPostsFakeStore runs search_posts against a fake store holding the
+// default fixtures and decodes the result
+func searchPostsFakeStore(t *testing.T, input SearchPostsInput) *search_posts.SearchResponse {
+	t.Helper()
+
+	server := fakestore.New().Start()
+	t.Cleanup(server.Close)
+
+	input.BaseURL = server.URL
+	_, output, err := NewSearchPostsHandler().ExecuteMCPTool(context.Background(), nil, input)
+	if err != nil {
+		t.Fatalf("search_posts: %v", err)
+	}
+
+	var response search_posts.SearchResponse
+	if err := json.Unmarshal([]byte(output.Data), &response); err != nil {
+		t.Fatalf("decode search data: %v", err)
+	}
+	return &response
+}
+
+func TestSearchPostsAgainstFakeStore(t *testing.T) {
+	response := searchPostsFakeStore(t, SearchPostsInput{Search: "Running"})
+
+	if response.TotalCount != 1 || len(response.Posts) != 1 {
+		t.Fatalf("got %d of %d posts, want 1 of 1", len(response.Posts), response.TotalCount)
+	}
+	if post := response.Posts[0]; post.ID != 103 || post.Title != "Running Tips" {
+		t.Errorf("got post %d %q, want 103 \"Running Tips\"", post.ID, post.Title)
+	}
+}
+
+func TestSearchPostsPaginatesFakeStore(t *testing.T) {
+	first := searchPostsFakeStore(t, SearchPostsInput{PerPage: "2", Page: "1", OrderBy: "id", Order: "asc"})
+	if first.TotalCount != 3 || first.TotalPages != 2 {
+		t.Fatalf("total_count=%d total_pages=%d, want 3 and 2", first.TotalCount, first.TotalPages)
+	}
+	if len(first.Posts) != 2 || !first.HasNext || first.HasPrev {
+		t.Errorf("page 1: %d posts, has_next=%v has_prev=%v", len(first.Posts), first.HasNext, first.HasPrev)
+	}
+
+	last := searchPostsFakeStore(t, SearchPostsInput{PerPage: "2", Page: "2", OrderBy: "id", Order: "asc"})
+	if len(last.Posts) != 1 || last.HasNext || !last.HasPrev {
+		t.Errorf("page 2: %d posts, has_next=%v has_prev=%v", len(last.Posts), last.HasNext, last.HasPrev)
+	}
+}
+
+func TestSearchPostsCapsPerPage(t *testing.T) {
+	store := fakestore.New()
+	server := store.Start()
+	defer server.Close()
+
+	// The fake store rejects per_page above 100, as WordPress does
+	_, output, err := NewSearchPostsHandler().ExecuteMCPTool(context.Background(), nil, SearchPostsInput{
+		BaseURL: server.URL,
+		PerPage: "500",
+	})
+	if err != nil {
+		t.Fatalf("search_posts: %v", err)
+	}
+
+	var response search_posts.SearchResponse
+	if err := json.Unmarshal([]byte(output.Data), &response); err != nil {
+		t.Fatalf("decode search data: %v", err)
+	}
+	if response.PerPage != 100 || !response.PerPageCapped {
+		t.Errorf("per_page=%d per_page_capped=%v, want 100 and true", response.PerPage, response.PerPageCapped)
+	}
+	if !strings.Contains(output.Message, "per_page capped at 100") {
+		t.Errorf("message %q does not report the cap", output.Message)
+	}
+	for _, request := range store.Requests() {
+		if strings.Contains(request, "per_page=500") {
+			t.Errorf("store was asked for %s", request)
+		}
+	}
+}
+
+func TestSearchPostsWithWrongApplicationPassword(t *testing.T) {
+	server := fakestore.New().Start()
+	defer server.Close()
+
+	_, _, err := NewSearchPostsHandler().ExecuteMCPTool(context.Background(), nil, SearchPostsInput{
+		BaseURL:             server.URL,
+		Username:            fakestore.Username,
+		ApplicationPassword: "wrong password",
+	})
+	var postErr *domain.PostError
+	if !errors.As(err, &postErr) || postErr.StatusCode != 401 {
+		t.Fatalf("got error %v, want a 401 WordPress API error", err)
+	}
+}
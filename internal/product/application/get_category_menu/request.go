@@ -0,0 +1,14 @@
+package get_category_menu
+
+// GetCategoryMenuRequest represents a request for the product category menu
+// of a store. The store and its credentials travel in the context the
+// repository is built from, not in the request.
+type GetCategoryMenuRequest struct {
+	// Depth is how many levels of categories are nested, 2 (top-level
+	// categories and their children) by default
+	Depth string `json:"depth,omitempty"`
+
+	// HideEmpty leaves out categories without products, unless one of their
+	// subcategories has some
+	HideEmpty string `json:"hide_empty,omitempty"`
+}
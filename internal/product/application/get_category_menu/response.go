@@ -0,0 +1,53 @@
+package get_category_menu
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// GetCategoryMenuResponse represents the product category menu of a store
+type GetCategoryMenuResponse struct {
+	// Categories holds the top-level categories, with their subcategories
+	// nested down to Depth levels
+	Categories []*MenuItem `json:"categories"`
+
+	// Depth is how many levels of categories are nested
+	Depth int `json:"depth"`
+
+	// TotalCategories counts the categories fetched from the store
+	TotalCategories int `json:"total_categories"`
+
+	// Truncated is set when MaxMenuCategories categories were fetched, so
+	// the store may have more that are not in the menu
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// MenuItem represents a category of the menu
+type MenuItem struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+
+	// Count is the number of products assigned to the category, as the
+	// store reports it
+	Count int `json:"count"`
+
+	// ChildCount is the number of subcategories, also when they are below
+	// the menu depth and not listed in Children
+	ChildCount int         `json:"child_count"`
+	Children   []*MenuItem `json:"children,omitempty"`
+}
+
+// ToJSON converts the response to JSON string. HTML escaping is off so
+// category names such as "Shoes & Boots" stay readable.
+func (r *GetCategoryMenuResponse) ToJSON() (string, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(r); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
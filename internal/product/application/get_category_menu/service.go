@@ -0,0 +1,156 @@
+package get_category_menu
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"woocommerce-mcp/internal/product/domain"
+	"woocommerce-mcp/kit/safemode"
+)
+
+// MaxMenuCategories bounds how many categories are fetched for a menu, so a
+// store with a huge taxonomy cannot keep the call paging
+const MaxMenuCategories = 1000
+
+// Menu depth bounds
+const (
+	DefaultMenuDepth = 2
+	MaxMenuDepth     = 10
+)
+
+// CategoryMenuBuilder builds the product category menu of a store
+type CategoryMenuBuilder struct {
+	categoryRepository domain.CategoryRepository
+}
+
+// NewCategoryMenuBuilder creates a new CategoryMenuBuilder
+func NewCategoryMenuBuilder(categoryRepository domain.CategoryRepository) *CategoryMenuBuilder {
+	return &CategoryMenuBuilder{
+		categoryRepository: categoryRepository,
+	}
+}
+
+// Execute fetches every category of the store, page by page and up to
+// MaxMenuCategories, and nests them under their parents
+func (b *CategoryMenuBuilder) Execute(ctx context.Context, request *GetCategoryMenuRequest) (*GetCategoryMenuResponse, error) {
+	depth := DefaultMenuDepth
+	if value := strings.TrimSpace(request.Depth); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > MaxMenuDepth {
+			return nil, domain.NewProductValidationError("depth", fmt.Sprintf("must be an integer from 1 to %d", MaxMenuDepth))
+		}
+		depth = parsed
+	}
+
+	hideEmpty := false
+	if value := strings.TrimSpace(request.HideEmpty); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, domain.NewProductValidationError("hide_empty", "must be true or false")
+		}
+		hideEmpty = parsed
+	}
+
+	// Category counts only include published products, so safe mode hides
+	// the categories that only hold unpublished ones
+	if safemode.Enabled() {
+		if value := strings.TrimSpace(request.HideEmpty); value != "" && !hideEmpty {
+			return nil, domain.NewProductValidationError("hide_empty", safemode.Rejection("hide_empty=false"))
+		}
+		hideEmpty = true
+	}
+
+	var categories []*domain.Category
+	pages := b.categoryRepository.CategoryPages()
+	for pages.Next(ctx) {
+		categories = append(categories, pages.Page()...)
+		if len(categories) >= MaxMenuCategories {
+			break
+		}
+	}
+	if err := pages.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+	// The bound is reached: the store may have more categories
+	truncated := len(categories) >= MaxMenuCategories
+	if truncated {
+		categories = categories[:MaxMenuCategories]
+	}
+
+	return &GetCategoryMenuResponse{
+		Categories:      BuildMenu(categories, depth, hideEmpty),
+		Depth:           depth,
+		TotalCategories: len(categories),
+		Truncated:       truncated,
+	}, nil
+}
+
+// BuildMenu nests a flat list of categories under their parents, down to
+// depth levels, keeping the order of the list among siblings. A category
+// whose parent is not in the list is shown at the top level, and so is a
+// category caught in a parent loop, so none is lost. With hideEmpty,
+// categories without products are left out unless a subcategory has some.
+func BuildMenu(categories []*domain.Category, depth int, hideEmpty bool) []*MenuItem {
+	known := make(map[int]bool, len(categories))
+	for _, category := range categories {
+		known[category.ID] = true
+	}
+
+	children := make(map[int][]*domain.Category)
+	var roots []*domain.Category
+	for _, category := range categories {
+		if category.Parent > 0 && known[category.Parent] && category.Parent != category.ID {
+			children[category.Parent] = append(children[category.Parent], category)
+		} else {
+			roots = append(roots, category)
+		}
+	}
+
+	visited := make(map[int]bool, len(categories))
+	var build func(category *domain.Category, level int) *MenuItem
+	build = func(category *domain.Category, level int) *MenuItem {
+		visited[category.ID] = true
+		item := &MenuItem{
+			ID:    category.ID,
+			Name:  category.Name,
+			Slug:  category.Slug,
+			Count: category.Count,
+		}
+		for _, child := range children[category.ID] {
+			if visited[child.ID] {
+				continue
+			}
+			childItem := build(child, level+1)
+			if childItem == nil {
+				continue
+			}
+			item.ChildCount++
+			if level < depth {
+				item.Children = append(item.Children, childItem)
+			}
+		}
+		if hideEmpty && item.Count == 0 && item.ChildCount == 0 {
+			return nil
+		}
+		return item
+	}
+
+	menu := make([]*MenuItem, 0, len(roots))
+	for _, root := range roots {
+		if item := build(root, 1); item != nil {
+			menu = append(menu, item)
+		}
+	}
+
+	// Categories only reachable through a parent loop
+	for _, category := range categories {
+		if !visited[category.ID] {
+			if item := build(category, 1); item != nil {
+				menu = append(menu, item)
+			}
+		}
+	}
+
+	return menu
+}
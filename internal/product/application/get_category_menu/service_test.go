@@ -0,0 +1,152 @@
+package get_category_menu
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"woocommerce-mcp/internal/product/domain"
+	"woocommerce-mcp/kit/pagination"
+)
+
+// pagedCategories serves categories in pages of a fixed size, recording how
+// many pages were fetched
+type pagedCategories struct {
+	domain.CategoryRepository
+	categories []*domain.Category
+	pageSize   int
+	fetched    int
+	err        error
+}
+
+func (r *pagedCategories) CategoryPages() *pagination.Iterator[[]*domain.Category] {
+	return pagination.NewIterator(1, func(ctx context.Context, page int) ([]*domain.Category, bool, error) {
+		r.fetched++
+		if r.err != nil {
+			return nil, false, r.err
+		}
+		start := (page - 1) * r.pageSize
+		end := min(start+r.pageSize, len(r.categories))
+		return r.categories[start:end], end < len(r.categories), nil
+	})
+}
+
+// category builds a category with a parent and a product count
+func category(id int, name string, parent, count int) *domain.Category {
+	c := domain.NewCategory(id, name, strings.ToLower(name))
+	c.Parent = parent
+	c.Count = count
+	return c
+}
+
+// flatCategories is a flat two-level list: Clothing (Shirts, Hats) and
+// Shoes (Running), listed children first
+func flatCategories() []*domain.Category {
+	return []*domain.Category{
+		category(3, "Shirts", 1, 4),
+		category(5, "Running", 2, 6),
+		category(1, "Clothing", 0, 0),
+		category(4, "Hats", 1, 0),
+		category(2, "Shoes", 0, 8),
+	}
+}
+
+// outline renders a menu as "Name(count)[children]" for comparison
+func outline(items []*MenuItem) string {
+	parts := make([]string, len(items))
+	for i, item := range items {
+		parts[i] = fmt.Sprintf("%s(%d)", item.Name, item.Count)
+		if item.ChildCount != len(item.Children) {
+			parts[i] += fmt.Sprintf("+%d", item.ChildCount)
+		}
+		if len(item.Children) > 0 {
+			parts[i] += "[" + outline(item.Children) + "]"
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestFlatCategoriesAreNestedUnderTheirParents(t *testing.T) {
+	repository := &pagedCategories{categories: flatCategories(), pageSize: 2}
+
+	response, err := NewCategoryMenuBuilder(repository).Execute(context.Background(), &GetCategoryMenuRequest{})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	if got, want := outline(response.Categories), "Clothing(0)[Shirts(4) Hats(0)] Shoes(8)[Running(6)]"; got != want {
+		t.Errorf("menu = %s, want %s", got, want)
+	}
+	if response.Depth != DefaultMenuDepth || response.TotalCategories != 5 || response.Truncated {
+		t.Errorf("response = depth %d, %d categories, truncated %v; want 2, 5, false", response.Depth, response.TotalCategories, response.Truncated)
+	}
+	if repository.fetched != 3 {
+		t.Errorf("fetched %d pages, want all 3", repository.fetched)
+	}
+}
+
+func TestMenuDepthAndEmptyCategories(t *testing.T) {
+	categories := append(flatCategories(), category(6, "Trail", 5, 2))
+
+	if got, want := outline(BuildMenu(categories, 1, false)), "Clothing(0)+2 Shoes(8)+1"; got != want {
+		t.Errorf("depth 1 menu = %s, want %s", got, want)
+	}
+	if got, want := outline(BuildMenu(categories, 3, false)), "Clothing(0)[Shirts(4) Hats(0)] Shoes(8)[Running(6)[Trail(2)]]"; got != want {
+		t.Errorf("depth 3 menu = %s, want %s", got, want)
+	}
+	// Clothing is kept for its non-empty Shirts
+	if got, want := outline(BuildMenu(categories, 2, true)), "Clothing(0)[Shirts(4)] Shoes(8)[Running(6)+1]"; got != want {
+		t.Errorf("menu without empty categories = %s, want %s", got, want)
+	}
+}
+
+func TestOrphansAndParentLoopsStayInTheMenu(t *testing.T) {
+	categories := []*domain.Category{
+		category(1, "Sale", 99, 3),
+		category(2, "A", 3, 1),
+		category(3, "B", 2, 1),
+	}
+
+	if got, want := outline(BuildMenu(categories, 2, false)), "Sale(3) A(1)[B(1)]"; got != want {
+		t.Errorf("menu = %s, want %s", got, want)
+	}
+}
+
+func TestMenuIsTruncatedAtTheBound(t *testing.T) {
+	categories := make([]*domain.Category, MaxMenuCategories+150)
+	for i := range categories {
+		categories[i] = category(i+1, fmt.Sprintf("Category %d", i+1), 0, 1)
+	}
+	repository := &pagedCategories{categories: categories, pageSize: pagination.MaxPerPage}
+
+	response, err := NewCategoryMenuBuilder(repository).Execute(context.Background(), &GetCategoryMenuRequest{})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if !response.Truncated || response.TotalCategories != MaxMenuCategories || len(response.Categories) != MaxMenuCategories {
+		t.Errorf("response = %d categories, truncated %v; want %d, true", response.TotalCategories, response.Truncated, MaxMenuCategories)
+	}
+	if want := MaxMenuCategories / pagination.MaxPerPage; repository.fetched != want {
+		t.Errorf("fetched %d pages, want %d", repository.fetched, want)
+	}
+}
+
+func TestMenuArgumentsAreValidated(t *testing.T) {
+	for _, request := range []*GetCategoryMenuRequest{{Depth: "0"}, {Depth: "11"}, {Depth: "two"}, {HideEmpty: "maybe"}} {
+		_, err := NewCategoryMenuBuilder(&pagedCategories{pageSize: 10}).Execute(context.Background(), request)
+		var validationErr *domain.ProductValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("request %+v: error = %v, want a validation error", request, err)
+		}
+	}
+}
+
+func TestCategoryPageErrorIsReported(t *testing.T) {
+	failure := errors.New("store unavailable")
+	_, err := NewCategoryMenuBuilder(&pagedCategories{err: failure}).Execute(context.Background(), &GetCategoryMenuRequest{})
+	if !errors.Is(err, failure) {
+		t.Errorf("error = %v, want %v", err, failure)
+	}
+}
@@ -0,0 +1,9 @@
+package get_product_breadcrumb
+
+// GetProductBreadcrumbRequest represents a request for the category
+// breadcrumbs of a product. The store and its credentials travel in the
+// context the repositories are built from, not in the request.
+type GetProductBreadcrumbRequest struct {
+	// ProductID is the ID of the product whose breadcrumbs are resolved
+	ProductID string `json:"product_id"`
+}
@@ -0,0 +1,75 @@
+package get_product_breadcrumb
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// BreadcrumbSeparator separates the category names of a breadcrumb path
+const BreadcrumbSeparator = " > "
+
+// GetProductBreadcrumbResponse represents the category breadcrumbs of a product
+type GetProductBreadcrumbResponse struct {
+	ProductID   int    `json:"product_id"`
+	ProductName string `json:"product_name"`
+
+	// Breadcrumbs holds one breadcrumb per category assigned to the product
+	Breadcrumbs []*Breadcrumb `json:"breadcrumbs"`
+}
+
+// Breadcrumb is the path from a top-level category down to a category
+// assigned to the product
+type Breadcrumb struct {
+	// Path joins the category names, e.g. "Apparel > Shoes > Running"
+	Path string `json:"path"`
+
+	// Categories lists the categories of the path, top-level one first
+	Categories []*CategoryDTO `json:"categories"`
+
+	// Incomplete is set when an ancestor could not be found, so the path
+	// starts below the top level
+	Incomplete bool `json:"incomplete,omitempty"`
+}
+
+// CategoryDTO represents a category of a breadcrumb
+type CategoryDTO struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// Paths returns the path of every breadcrumb
+func (r *GetProductBreadcrumbResponse) Paths() []string {
+	paths := make([]string, 0, len(r.Breadcrumbs))
+	for _, breadcrumb := range r.Breadcrumbs {
+		paths = append(paths, breadcrumb.Path)
+	}
+	return paths
+}
+
+// ToJSON converts the response to JSON string. HTML escaping is off so the
+// paths keep their " > " separators readable.
+func (r *GetProductBreadcrumbResponse) ToJSON() (string, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(r); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// newBreadcrumb builds a breadcrumb from its categories, top-level one first
+func newBreadcrumb(categories []*CategoryDTO, incomplete bool) *Breadcrumb {
+	names := make([]string, 0, len(categories))
+	for _, category := range categories {
+		names = append(names, category.Name)
+	}
+	return &Breadcrumb{
+		Path:       strings.Join(names, BreadcrumbSeparator),
+		Categories: categories,
+		Incomplete: incomplete,
+	}
+}
@@ -0,0 +1,141 @@
+package get_product_breadcrumb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"woocommerce-mcp/internal/product/domain"
+	"woocommerce-mcp/kit/safemode"
+)
+
+// maxCategoryDepth bounds how many levels of ancestors are looked up, so a
+// parent loop in the store's data cannot keep the resolver going
+const maxCategoryDepth = 20
+
+// BreadcrumbResolver resolves the category breadcrumbs of a product
+type BreadcrumbResolver struct {
+	productRepository  domain.ProductRepository
+	categoryRepository domain.CategoryRepository
+}
+
+// NewBreadcrumbResolver creates a new BreadcrumbResolver
+func NewBreadcrumbResolver(productRepository domain.ProductRepository, categoryRepository domain.CategoryRepository) *BreadcrumbResolver {
+	return &BreadcrumbResolver{
+		productRepository:  productRepository,
+		categoryRepository: categoryRepository,
+	}
+}
+
+// Execute loads the product, then walks the parent links of its categories
+// up to the top level. Each level of ancestors is fetched in one request and
+// every category is fetched at most once per call, however many breadcrumbs
+// share it.
+func (r *BreadcrumbResolver) Execute(ctx context.Context, request *GetProductBreadcrumbRequest) (*GetProductBreadcrumbResponse, error) {
+	productID, err := domain.NewProductIDFromString(strings.TrimSpace(request.ProductID))
+	if err != nil {
+		return nil, domain.NewProductValidationError("product_id", "product ID must be a positive integer")
+	}
+
+	// Safe mode does not reveal that unpublished products exist
+	findProduct := r.productRepository.FindByID
+	if safemode.Enabled() {
+		findProduct = func(ctx context.Context, id *domain.ProductID) (*domain.Product, error) {
+			return domain.FindPublishedByID(ctx, r.productRepository, id)
+		}
+	}
+	product, err := findProduct(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find product: %w", err)
+	}
+
+	assigned := make([]int, 0, len(product.Categories))
+	for _, category := range product.Categories {
+		assigned = append(assigned, category.ID)
+	}
+
+	categories, err := r.loadAncestry(ctx, assigned)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetProductBreadcrumbResponse{
+		ProductID:   productID.Value(),
+		ProductName: product.Name,
+		Breadcrumbs: make([]*Breadcrumb, 0, len(product.Categories)),
+	}
+	for _, category := range product.Categories {
+		response.Breadcrumbs = append(response.Breadcrumbs, breadcrumbOf(category, categories))
+	}
+
+	return response, nil
+}
+
+// loadAncestry fetches the given categories and all of their ancestors,
+// keyed by ID. The map is the per-call cache of category lookups.
+func (r *BreadcrumbResolver) loadAncestry(ctx context.Context, ids []int) (map[int]*domain.Category, error) {
+	categories := make(map[int]*domain.Category)
+	requested := make(map[int]bool)
+
+	pending := ids
+	for depth := 0; len(pending) > 0 && depth < maxCategoryDepth; depth++ {
+		var batch []int
+		for _, id := range pending {
+			if id > 0 && !requested[id] {
+				requested[id] = true
+				batch = append(batch, id)
+			}
+		}
+		if len(batch) == 0 {
+			break
+		}
+
+		found, err := r.categoryRepository.FindCategories(ctx, batch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get categories: %w", err)
+		}
+
+		pending = nil
+		for _, category := range found {
+			categories[category.ID] = category
+			if category.Parent > 0 {
+				pending = append(pending, category.Parent)
+			}
+		}
+	}
+
+	return categories, nil
+}
+
+// breadcrumbOf builds the breadcrumb of an assigned category by following
+// its parent links. The path is incomplete when an ancestor is missing or
+// the links loop.
+func breadcrumbOf(assigned *domain.Category, categories map[int]*domain.Category) *Breadcrumb {
+	var path []*CategoryDTO
+	visited := make(map[int]bool)
+	incomplete := false
+
+	id := assigned.ID
+	for id > 0 {
+		category, ok := categories[id]
+		if !ok || visited[id] {
+			incomplete = true
+			break
+		}
+		visited[id] = true
+		path = append(path, &CategoryDTO{ID: category.ID, Name: category.Name, Slug: category.Slug})
+		id = category.Parent
+	}
+
+	// Fall back to the category embedded in the product when the store did
+	// not return it
+	if len(path) == 0 {
+		path = append(path, &CategoryDTO{ID: assigned.ID, Name: assigned.Name, Slug: assigned.Slug})
+	}
+
+	// The walk went upwards; breadcrumbs start at the top level
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	return newBreadcrumb(path, incomplete)
+}
@@ -0,0 +1,159 @@
+package get_product_breadcrumb
+
+import (
+	"context"
+	"testing"
+
+	"woocommerce-mcp/internal/product/domain"
+)
+
+// productRepository serves one product
+type productRepository struct {
+	domain.ProductRepository
+	product *domain.Product
+}
+
+func (r *productRepository) FindByID(ctx context.Context, id *domain.ProductID) (*domain.Product, error) {
+	if id.Value() != r.product.ID.Value() {
+		return nil, domain.NewProductNotFoundError(id)
+	}
+	return r.product, nil
+}
+
+// categoryRepository serves a category tree, recording every lookup
+type categoryRepository struct {
+	domain.CategoryRepository
+	categories map[int]*domain.Category
+	lookups    [][]int
+}
+
+func (r *categoryRepository) FindCategories(ctx context.Context, ids []int) ([]*domain.Category, error) {
+	r.lookups = append(r.lookups, ids)
+	var found []*domain.Category
+	for _, id := range ids {
+		if category, ok := r.categories[id]; ok {
+			found = append(found, category)
+		}
+	}
+	return found, nil
+}
+
+// category builds a category of the tree
+func category(id int, name string, parent int) *domain.Category {
+	c := domain.NewCategory(id, name, "")
+	c.Parent = parent
+	return c
+}
+
+// threeLevels is the tree Apparel > Shoes > Running, with Apparel > Hats
+// beside it
+func threeLevels() *categoryRepository {
+	return &categoryRepository{categories: map[int]*domain.Category{
+		1: category(1, "Apparel", 0),
+		2: category(2, "Shoes", 1),
+		3: category(3, "Running", 2),
+		4: category(4, "Hats", 1),
+	}}
+}
+
+// productIn builds product 9 assigned to the given categories, which carry
+// no parent like the categories embedded in a product
+func productIn(categories ...*domain.Category) *domain.Product {
+	id, _ := domain.NewProductID(9)
+	product := domain.NewProduct(id, "Trail Runner")
+	product.Status = domain.ProductStatusPublish
+	for _, c := range categories {
+		product.Categories = append(product.Categories, domain.NewCategory(c.ID, c.Name, ""))
+	}
+	return product
+}
+
+func resolve(t *testing.T, product *domain.Product, categories *categoryRepository) *GetProductBreadcrumbResponse {
+	t.Helper()
+	response, err := NewBreadcrumbResolver(&productRepository{product: product}, categories).
+		Execute(context.Background(), &GetProductBreadcrumbRequest{ProductID: "9"})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	return response
+}
+
+func TestBreadcrumbWalksUpThreeLevels(t *testing.T) {
+	categories := threeLevels()
+	response := resolve(t, productIn(categories.categories[3]), categories)
+
+	paths := response.Paths()
+	if len(paths) != 1 || paths[0] != "Apparel > Shoes > Running" {
+		t.Fatalf("paths = %q, want Apparel > Shoes > Running", paths)
+	}
+	crumb := response.Breadcrumbs[0]
+	if crumb.Incomplete || len(crumb.Categories) != 3 || crumb.Categories[0].ID != 1 || crumb.Categories[2].ID != 3 {
+		t.Errorf("breadcrumb = %+v, want categories 1, 2 and 3", crumb)
+	}
+	if response.ProductID != 9 || response.ProductName != "Trail Runner" {
+		t.Errorf("product = %d %q, want 9 Trail Runner", response.ProductID, response.ProductName)
+	}
+}
+
+func TestSharedAncestorsAreLookedUpOnce(t *testing.T) {
+	categories := threeLevels()
+	response := resolve(t, productIn(categories.categories[3], categories.categories[4]), categories)
+
+	paths := response.Paths()
+	if len(paths) != 2 || paths[0] != "Apparel > Shoes > Running" || paths[1] != "Apparel > Hats" {
+		t.Errorf("paths = %q, want one per assigned category", paths)
+	}
+
+	seen := make(map[int]bool)
+	for _, lookup := range categories.lookups {
+		for _, id := range lookup {
+			if seen[id] {
+				t.Errorf("category %d looked up twice in %v", id, categories.lookups)
+			}
+			seen[id] = true
+		}
+	}
+	// Apparel is the parent of Hats, so it is fetched along with Shoes
+	if len(categories.lookups) != 2 {
+		t.Errorf("lookups = %v, want the assigned categories, then their parents", categories.lookups)
+	}
+}
+
+func TestMissingAncestorLeavesTheBreadcrumbIncomplete(t *testing.T) {
+	categories := threeLevels()
+	delete(categories.categories, 1)
+	response := resolve(t, productIn(categories.categories[3]), categories)
+
+	crumb := response.Breadcrumbs[0]
+	if crumb.Path != "Shoes > Running" || !crumb.Incomplete {
+		t.Errorf("breadcrumb = %q incomplete %v, want Shoes > Running marked incomplete", crumb.Path, crumb.Incomplete)
+	}
+}
+
+func TestParentLoopEndsTheWalk(t *testing.T) {
+	categories := threeLevels()
+	categories.categories[1].Parent = 3
+	response := resolve(t, productIn(categories.categories[3]), categories)
+
+	crumb := response.Breadcrumbs[0]
+	if !crumb.Incomplete || len(crumb.Categories) != 3 {
+		t.Errorf("breadcrumb = %+v, want the three categories marked incomplete", crumb)
+	}
+}
+
+func TestUnknownCategoryFallsBackToTheEmbeddedOne(t *testing.T) {
+	response := resolve(t, productIn(category(8, "Clearance", 0)), threeLevels())
+
+	crumb := response.Breadcrumbs[0]
+	if crumb.Path != "Clearance" || !crumb.Incomplete {
+		t.Errorf("breadcrumb = %q incomplete %v, want Clearance marked incomplete", crumb.Path, crumb.Incomplete)
+	}
+}
+
+func TestInvalidProductIDIsRejected(t *testing.T) {
+	_, err := NewBreadcrumbResolver(&productRepository{product: productIn()}, threeLevels()).
+		Execute(context.Background(), &GetProductBreadcrumbRequest{ProductID: "shoes"})
+	if err == nil {
+		t.Fatal("product_id shoes was accepted")
+	}
+}
@@ -0,0 +1,10 @@
+package get_products
+
+// GetProductsRequest represents a request for several products by ID. The
+// store and its credentials travel in the context the repository is built
+// from, not in the request.
+type GetProductsRequest struct {
+	// ProductIDs are the IDs of the products to fetch, in the order the
+	// products are returned
+	ProductIDs []int `json:"product_ids"`
+}
@@ -0,0 +1,24 @@
+package get_products
+
+import (
+	"encoding/json"
+	"woocommerce-mcp/internal/product/application/search_products"
+)
+
+// GetProductsResponse represents the products fetched by ID
+type GetProductsResponse struct {
+	// Products are in the order their IDs were requested
+	Products []*search_products.ProductDTO `json:"products"`
+
+	// MissingIDs are requested IDs that do not resolve to a product
+	MissingIDs []int `json:"missing_ids,omitempty"`
+}
+
+// ToJSON converts the response to JSON string
+func (r *GetProductsResponse) ToJSON() (string, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,117 @@
+package get_products
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"woocommerce-mcp/internal/product/application/search_products"
+	"woocommerce-mcp/internal/product/domain"
+	"woocommerce-mcp/kit/pagination"
+	"woocommerce-mcp/kit/safemode"
+)
+
+// MaxProductIDs caps how many products one call can fetch
+const MaxProductIDs = 500
+
+// ProductsGetter fetches products by ID in include-filtered batches
+type ProductsGetter struct {
+	productRepository domain.ProductRepository
+}
+
+// NewProductsGetter creates a new ProductsGetter
+func NewProductsGetter(productRepository domain.ProductRepository) *ProductsGetter {
+	return &ProductsGetter{
+		productRepository: productRepository,
+	}
+}
+
+// Execute fetches the requested products. The IDs are split into batches of
+// at most one API page, which are fetched concurrently, and the products are
+// returned in the requested order. IDs that no product has are reported as
+// missing, as are unpublished products in safe mode.
+func (g *ProductsGetter) Execute(ctx context.Context, request *GetProductsRequest) (*GetProductsResponse, error) {
+	ids, err := validateRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	batches := chunk(ids, pagination.MaxPerPage)
+	results := make([][]*domain.Product, len(batches))
+	errs := make([]error, len(batches))
+
+	var wg sync.WaitGroup
+	for i, batch := range batches {
+		wg.Add(1)
+		go func(i int, batch []int) {
+			defer wg.Done()
+			criteria := domain.NewSearchCriteria()
+			criteria.SetInclude(batch)
+			criteria.SetPagination(1, len(batch))
+			criteria.SetSorting("include", "asc")
+			if safemode.Enabled() {
+				criteria.SetStatus(domain.ProductStatusPublish)
+			}
+			results[i], errs[i] = g.productRepository.Search(ctx, criteria)
+		}(i, batch)
+	}
+	wg.Wait()
+
+	found := make(map[int]*domain.Product, len(ids))
+	for i, products := range results {
+		if errs[i] != nil {
+			return nil, fmt.Errorf("failed to fetch products %d-%d of %d: %w", i*pagination.MaxPerPage+1, i*pagination.MaxPerPage+len(batches[i]), len(ids), errs[i])
+		}
+		for _, product := range products {
+			found[product.ID.Value()] = product
+		}
+	}
+
+	response := &GetProductsResponse{
+		Products: make([]*search_products.ProductDTO, 0, len(found)),
+	}
+	for _, id := range ids {
+		product, ok := found[id]
+		if !ok {
+			response.MissingIDs = append(response.MissingIDs, id)
+			continue
+		}
+		response.Products = append(response.Products, search_products.ProductToDTO(product))
+	}
+
+	return response, nil
+}
+
+// validateRequest checks the requested IDs and returns them without
+// repeats, in their first requested order
+func validateRequest(request *GetProductsRequest) ([]int, error) {
+	if len(request.ProductIDs) == 0 {
+		return nil, domain.NewProductValidationError("product_ids", "at least one product ID is required")
+	}
+
+	seen := make(map[int]bool, len(request.ProductIDs))
+	ids := make([]int, 0, len(request.ProductIDs))
+	for _, id := range request.ProductIDs {
+		if id < 1 {
+			return nil, domain.NewProductValidationError("product_ids", fmt.Sprintf("product ID %d must be a positive integer", id))
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) > MaxProductIDs {
+		return nil, domain.NewProductValidationError("product_ids", fmt.Sprintf("at most %d product IDs can be fetched at once", MaxProductIDs))
+	}
+
+	return ids, nil
+}
+
+// chunk splits IDs into consecutive batches of at most size IDs
+func chunk(ids []int, size int) [][]int {
+	batches := make([][]int, 0, (len(ids)+size-1)/size)
+	for start := 0; start < len(ids); start += size {
+		end := min(start+size, len(ids))
+		batches = append(batches, ids[start:end])
+	}
+	return batches
+}
@@ -0,0 +1,148 @@
+package get_products
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+
+	"woocommerce-mcp/internal/product/domain"
+)
+
+// catalogRepository serves the products with IDs 1 to size and records the
+// include batches it was asked for
+type catalogRepository struct {
+	domain.ProductRepository
+	size int
+	err  error
+
+	mu      sync.Mutex
+	batches [][]int
+}
+
+func (r *catalogRepository) Search(ctx context.Context, criteria *domain.SearchCriteria) ([]*domain.Product, error) {
+	r.mu.Lock()
+	r.batches = append(r.batches, append([]int(nil), criteria.Include...))
+	r.mu.Unlock()
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	var products []*domain.Product
+	for _, id := range criteria.Include {
+		if id <= r.size {
+			productID, _ := domain.NewProductID(id)
+			products = append(products, domain.NewProduct(productID, "Product"))
+		}
+	}
+	return products, nil
+}
+
+// responseIDs returns the IDs of the products of a response, in order
+func responseIDs(response *GetProductsResponse) []int {
+	ids := make([]int, len(response.Products))
+	for i, product := range response.Products {
+		ids[i] = product.ID
+	}
+	return ids
+}
+
+// equalInts reports whether two int slices are equal
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSingleBatchKeepsTheRequestedOrder(t *testing.T) {
+	repository := &catalogRepository{size: 20}
+	response, err := NewProductsGetter(repository).Execute(context.Background(), &GetProductsRequest{ProductIDs: []int{7, 3, 7, 12}})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	if got := responseIDs(response); !equalInts(got, []int{7, 3, 12}) {
+		t.Errorf("products = %v, want [7 3 12] without the repeat", got)
+	}
+	if len(response.MissingIDs) != 0 {
+		t.Errorf("missing = %v, want none", response.MissingIDs)
+	}
+	if len(repository.batches) != 1 || !equalInts(repository.batches[0], []int{7, 3, 12}) {
+		t.Errorf("batches = %v, want one batch of the distinct IDs", repository.batches)
+	}
+}
+
+func TestManyIDsAreFetchedInBatchesOfOnePage(t *testing.T) {
+	ids := make([]int, 250)
+	for i := range ids {
+		ids[i] = 250 - i
+	}
+	repository := &catalogRepository{size: 250}
+	response, err := NewProductsGetter(repository).Execute(context.Background(), &GetProductsRequest{ProductIDs: ids})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	if got := responseIDs(response); !equalInts(got, ids) {
+		t.Errorf("got %d products, want all 250 in the requested order", len(got))
+	}
+	sizes := make([]int, len(repository.batches))
+	for i, batch := range repository.batches {
+		sizes[i] = len(batch)
+	}
+	sort.Ints(sizes)
+	if !equalInts(sizes, []int{50, 100, 100}) {
+		t.Errorf("batch sizes = %v, want 100, 100 and 50", sizes)
+	}
+}
+
+func TestMissingIDsAreReported(t *testing.T) {
+	repository := &catalogRepository{size: 5}
+	response, err := NewProductsGetter(repository).Execute(context.Background(), &GetProductsRequest{ProductIDs: []int{9, 2, 5, 6}})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if got := responseIDs(response); !equalInts(got, []int{2, 5}) {
+		t.Errorf("products = %v, want [2 5]", got)
+	}
+	if !equalInts(response.MissingIDs, []int{9, 6}) {
+		t.Errorf("missing = %v, want [9 6] in the requested order", response.MissingIDs)
+	}
+}
+
+func TestProductIDsAreValidated(t *testing.T) {
+	tooMany := make([]int, MaxProductIDs+1)
+	for i := range tooMany {
+		tooMany[i] = i + 1
+	}
+	for name, ids := range map[string][]int{
+		"none":     nil,
+		"zero":     {3, 0},
+		"negative": {-4},
+		"too many": tooMany,
+	} {
+		repository := &catalogRepository{size: 10}
+		_, err := NewProductsGetter(repository).Execute(context.Background(), &GetProductsRequest{ProductIDs: ids})
+		var validationErr *domain.ProductValidationError
+		if !errors.As(err, &validationErr) || validationErr.Field != "product_ids" {
+			t.Errorf("%s: got error %v, want a product_ids validation error", name, err)
+		}
+		if len(repository.batches) != 0 {
+			t.Errorf("%s: the store was searched", name)
+		}
+	}
+}
+
+func TestFailedBatchFailsTheCall(t *testing.T) {
+	repository := &catalogRepository{size: 10, err: errors.New("store unreachable")}
+	if _, err := NewProductsGetter(repository).Execute(context.Background(), &GetProductsRequest{ProductIDs: []int{1, 2}}); err == nil {
+		t.Error("succeeded, want the store error")
+	}
+}
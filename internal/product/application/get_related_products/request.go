@@ -0,0 +1,18 @@
+package get_related_products
+
+// RelatedProductsRequest represents a request for the products linked to a product
+type RelatedProductsRequest struct {
+	// Required authentication parameters
+	BaseURL        string `json:"base_url"`
+	ConsumerKey    string `json:"consumer_key"`
+	ConsumerSecret string `json:"consumer_secret"`
+
+	// ProductID is the ID of the product whose relations are resolved
+	ProductID string `json:"product_id"`
+
+	// Relation is one of related, upsell or cross_sell
+	Relation string `json:"relation"`
+
+	// Limit caps how many linked products are returned
+	Limit string `json:"limit,omitempty"`
+}
@@ -0,0 +1,28 @@
+package get_related_products
+
+import (
+	"encoding/json"
+	"woocommerce-mcp/internal/product/application/search_products"
+)
+
+// RelatedProductsResponse represents the products linked to a product
+type RelatedProductsResponse struct {
+	ProductID int                           `json:"product_id"`
+	Relation  string                        `json:"relation"`
+	Products  []*search_products.ProductDTO `json:"products"`
+
+	// TotalLinked is the number of IDs linked by the relation, before the limit
+	TotalLinked int `json:"total_linked"`
+
+	// MissingIDs are linked IDs that no longer resolve to a published product
+	MissingIDs []int `json:"missing_ids,omitempty"`
+}
+
+// ToJSON converts the response to JSON string
+func (r *RelatedProductsResponse) ToJSON() (string, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
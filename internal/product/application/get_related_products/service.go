@@ -0,0 +1,143 @@
+package get_related_products
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"woocommerce-mcp/internal/product/application/search_products"
+	"woocommerce-mcp/internal/product/domain"
+	"woocommerce-mcp/kit/safemode"
+)
+
+const (
+	// DefaultRelatedLimit is how many linked products are returned by default
+	DefaultRelatedLimit = 10
+	// MaxRelatedLimit caps how many linked products can be requested
+	MaxRelatedLimit = 50
+)
+
+// RelatedProductsFinder resolves a product's related, upsell or cross-sell IDs to products
+type RelatedProductsFinder struct {
+	productRepository domain.ProductRepository
+}
+
+// NewRelatedProductsFinder creates a new RelatedProductsFinder
+func NewRelatedProductsFinder(productRepository domain.ProductRepository) *RelatedProductsFinder {
+	return &RelatedProductsFinder{
+		productRepository: productRepository,
+	}
+}
+
+// Execute loads the product, then fetches the products linked by the
+// relation in a single include-filtered search
+func (f *RelatedProductsFinder) Execute(ctx context.Context, request *RelatedProductsRequest) (*RelatedProductsResponse, error) {
+	productID, relation, limit, err := validateRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	// Safe mode does not resolve the links of unpublished products
+	findProduct := f.productRepository.FindByID
+	if safemode.Enabled() {
+		findProduct = func(ctx context.Context, id *domain.ProductID) (*domain.Product, error) {
+			return domain.FindPublishedByID(ctx, f.productRepository, id)
+		}
+	}
+	product, err := findProduct(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find product: %w", err)
+	}
+
+	linkedIDs := uniqueIDs(product.RelatedProductIDs(relation))
+	response := &RelatedProductsResponse{
+		ProductID:   productID.Value(),
+		Relation:    relation.String(),
+		Products:    make([]*search_products.ProductDTO, 0),
+		TotalLinked: len(linkedIDs),
+	}
+	if len(linkedIDs) == 0 {
+		return response, nil
+	}
+	if len(linkedIDs) > limit {
+		linkedIDs = linkedIDs[:limit]
+	}
+
+	criteria := domain.NewSearchCriteria()
+	criteria.SetInclude(linkedIDs)
+	criteria.SetStatus(domain.ProductStatusPublish)
+	criteria.SetPagination(1, len(linkedIDs))
+	criteria.SetSorting("include", "asc")
+
+	products, err := f.productRepository.Search(ctx, criteria)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s products: %w", relation, err)
+	}
+
+	// Deleted, trashed or unpublished products are reported as missing
+	found := make(map[int]bool, len(products))
+	for _, linked := range products {
+		found[linked.ID.Value()] = true
+		response.Products = append(response.Products, search_products.ProductToDTO(linked))
+	}
+	for _, id := range linkedIDs {
+		if !found[id] {
+			response.MissingIDs = append(response.MissingIDs, id)
+		}
+	}
+
+	return response, nil
+}
+
+// validateRequest validates the request and returns its parsed values
+func validateRequest(request *RelatedProductsRequest) (*domain.ProductID, domain.ProductRelation, int, error) {
+	if request.BaseURL == "" {
+		return nil, "", 0, domain.NewProductValidationError("base_url", "base URL is required")
+	}
+	if request.ConsumerKey == "" {
+		return nil, "", 0, domain.NewProductValidationError("consumer_key", "consumer key is required")
+	}
+	if request.ConsumerSecret == "" {
+		return nil, "", 0, domain.NewProductValidationError("consumer_secret", "consumer secret is required")
+	}
+
+	productID, err := domain.NewProductIDFromString(strings.TrimSpace(request.ProductID))
+	if err != nil {
+		return nil, "", 0, domain.NewProductValidationError("product_id", "product ID must be a positive integer")
+	}
+
+	relation := domain.ProductRelation(strings.TrimSpace(request.Relation))
+	if relation == "" {
+		relation = domain.ProductRelationRelated
+	}
+	if !relation.IsValid() {
+		return nil, "", 0, domain.NewProductValidationError("relation", "must be related, upsell or cross_sell")
+	}
+
+	limit := DefaultRelatedLimit
+	if request.Limit != "" {
+		parsed, err := strconv.Atoi(strings.TrimSpace(request.Limit))
+		if err != nil || parsed < 1 {
+			return nil, "", 0, domain.NewProductValidationError("limit", "must be a positive integer")
+		}
+		limit = parsed
+	}
+	if limit > MaxRelatedLimit {
+		limit = MaxRelatedLimit
+	}
+
+	return productID, relation, limit, nil
+}
+
+// uniqueIDs drops duplicate and non-positive IDs while keeping their order
+func uniqueIDs(ids []int) []int {
+	seen := make(map[int]bool, len(ids))
+	unique := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if id > 0 && !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
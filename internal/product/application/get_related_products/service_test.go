@@ -0,0 +1,174 @@
+package get_related_products
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"woocommerce-mcp/internal/product/domain"
+)
+
+// catalogRepository serves products by ID and answers include-filtered
+// searches with the published products among them
+type catalogRepository struct {
+	domain.ProductRepository
+	products map[int]*domain.Product
+	searches []*domain.SearchCriteria
+}
+
+func (r *catalogRepository) FindByID(ctx context.Context, id *domain.ProductID) (*domain.Product, error) {
+	if product, ok := r.products[id.Value()]; ok {
+		return product, nil
+	}
+	return nil, domain.NewProductNotFoundError(id)
+}
+
+func (r *catalogRepository) Search(ctx context.Context, criteria *domain.SearchCriteria) ([]*domain.Product, error) {
+	r.searches = append(r.searches, criteria)
+	var found []*domain.Product
+	for _, id := range criteria.Include {
+		if product, ok := r.products[id]; ok && product.Status == domain.ProductStatusPublish {
+			found = append(found, product)
+		}
+	}
+	return found, nil
+}
+
+// newCatalog returns a catalog where product 1 links related, upsell and
+// cross-sell products; 8 is a draft and 9 was deleted
+func newCatalog() *catalogRepository {
+	catalog := &catalogRepository{products: make(map[int]*domain.Product)}
+	for id := 1; id <= 8; id++ {
+		productID, _ := domain.NewProductID(id)
+		product := domain.NewProduct(productID, "Product")
+		product.Status = domain.ProductStatusPublish
+		catalog.products[id] = product
+	}
+	catalog.products[8].Status = domain.ProductStatusDraft
+
+	source := catalog.products[1]
+	source.RelatedIDs = []int{2, 3, 2, 0}
+	source.UpsellIDs = []int{4, 9}
+	source.CrossSellIDs = []int{5, 6, 7, 8}
+	return catalog
+}
+
+// request builds a request for the products linked to product 1
+func request(relation, limit string) *RelatedProductsRequest {
+	return &RelatedProductsRequest{
+		BaseURL:        "https://store.example",
+		ConsumerKey:    "ck",
+		ConsumerSecret: "cs",
+		ProductID:      "1",
+		Relation:       relation,
+		Limit:          limit,
+	}
+}
+
+// linkedIDs returns the IDs of the products of a response, in order
+func linkedIDs(response *RelatedProductsResponse) []int {
+	ids := make([]int, len(response.Products))
+	for i, product := range response.Products {
+		ids[i] = product.ID
+	}
+	return ids
+}
+
+// equalIDs reports whether two ID lists are equal
+func equalIDs(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestRelationsResolveToProducts(t *testing.T) {
+	tests := []struct {
+		relation string
+		limit    string
+		ids      []int
+		missing  []int
+		total    int
+	}{
+		{relation: "", ids: []int{2, 3}, total: 2},
+		{relation: "related", ids: []int{2, 3}, total: 2},
+		{relation: "upsell", ids: []int{4}, missing: []int{9}, total: 2},
+		{relation: "cross_sell", ids: []int{5, 6, 7}, missing: []int{8}, total: 4},
+		{relation: "cross_sell", limit: "2", ids: []int{5, 6}, total: 4},
+	}
+	for _, tt := range tests {
+		catalog := newCatalog()
+		response, err := NewRelatedProductsFinder(catalog).Execute(context.Background(), request(tt.relation, tt.limit))
+		if err != nil {
+			t.Fatalf("relation %q: %v", tt.relation, err)
+		}
+		if got := linkedIDs(response); !equalIDs(got, tt.ids) {
+			t.Errorf("relation %q limit %q: products %v, want %v", tt.relation, tt.limit, got, tt.ids)
+		}
+		if !equalIDs(response.MissingIDs, tt.missing) {
+			t.Errorf("relation %q limit %q: missing %v, want %v", tt.relation, tt.limit, response.MissingIDs, tt.missing)
+		}
+		if response.TotalLinked != tt.total {
+			t.Errorf("relation %q: total_linked = %d, want %d", tt.relation, response.TotalLinked, tt.total)
+		}
+		if criteria := catalog.searches[0]; criteria.OrderBy != "include" || criteria.Status != domain.ProductStatusPublish {
+			t.Errorf("relation %q: searched %+v, want published products in include order", tt.relation, criteria)
+		}
+	}
+}
+
+func TestProductWithoutLinksSkipsTheSearch(t *testing.T) {
+	catalog := newCatalog()
+	catalog.products[1].UpsellIDs = nil
+
+	response, err := NewRelatedProductsFinder(catalog).Execute(context.Background(), request("upsell", ""))
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if len(response.Products) != 0 || response.TotalLinked != 0 || len(catalog.searches) != 0 {
+		t.Errorf("got %+v after %d searches, want an empty response without searching", response, len(catalog.searches))
+	}
+}
+
+func TestRelatedProductsRequestIsValidated(t *testing.T) {
+	tests := []struct {
+		request *RelatedProductsRequest
+		field   string
+	}{
+		{request("similar", ""), "relation"},
+		{request("related", "0"), "limit"},
+		{&RelatedProductsRequest{BaseURL: "https://store.example", ConsumerKey: "ck", ConsumerSecret: "cs", ProductID: "abc"}, "product_id"},
+	}
+	for _, tt := range tests {
+		_, err := NewRelatedProductsFinder(newCatalog()).Execute(context.Background(), tt.request)
+		var validationErr *domain.ProductValidationError
+		if !errors.As(err, &validationErr) || validationErr.Field != tt.field {
+			t.Errorf("%+v: got error %v, want a %s validation error", tt.request, err, tt.field)
+		}
+	}
+}
+
+func TestLimitIsCapped(t *testing.T) {
+	catalog := newCatalog()
+	ids := make([]int, 0, MaxRelatedLimit+10)
+	for id := 100; len(ids) < cap(ids); id++ {
+		ids = append(ids, id)
+	}
+	catalog.products[1].RelatedIDs = ids
+
+	response, err := NewRelatedProductsFinder(catalog).Execute(context.Background(), request("related", "500"))
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if got := len(catalog.searches[0].Include); got != MaxRelatedLimit {
+		t.Errorf("searched %d IDs, want the cap of %d", got, MaxRelatedLimit)
+	}
+	if response.TotalLinked != MaxRelatedLimit+10 || len(response.MissingIDs) != MaxRelatedLimit {
+		t.Errorf("total_linked = %d with %d missing, want %d linked and the capped IDs missing", response.TotalLinked, len(response.MissingIDs), MaxRelatedLimit+10)
+	}
+}
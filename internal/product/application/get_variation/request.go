@@ -0,0 +1,15 @@
+package get_variation
+
+// GetVariationRequest represents a request to find a variation of a variable product
+type GetVariationRequest struct {
+	// Required authentication parameters
+	BaseURL        string `json:"base_url"`
+	ConsumerKey    string `json:"consumer_key"`
+	ConsumerSecret string `json:"consumer_secret"`
+
+	// ProductID is the ID of the parent variable product
+	ProductID string `json:"product_id"`
+
+	// Attributes maps attribute names to the wanted values (e.g. color=red, size=L)
+	Attributes map[string]string `json:"attributes"`
+}
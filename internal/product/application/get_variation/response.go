@@ -0,0 +1,119 @@
+package get_variation
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"woocommerce-mcp/internal/product/domain"
+)
+
+// GetVariationResponse represents the result of a variation lookup
+type GetVariationResponse struct {
+	ProductID  int           `json:"product_id"`
+	Variation  *VariationDTO `json:"variation,omitempty"`
+	MatchCount int           `json:"match_count"`
+
+	// AvailableAttributes lists the attribute values offered by the product's
+	// variations; it is only set when no single variation matched
+	AvailableAttributes map[string][]string `json:"available_attributes,omitempty"`
+}
+
+// VariationDTO represents a product variation
+type VariationDTO struct {
+	ID            int                     `json:"id"`
+	ParentID      int                     `json:"parent_id"`
+	SKU           string                  `json:"sku,omitempty"`
+	Permalink     string                  `json:"permalink,omitempty"`
+	Price         string                  `json:"price"`
+	RegularPrice  string                  `json:"regular_price"`
+	SalePrice     string                  `json:"sale_price,omitempty"`
+	OnSale        bool                    `json:"on_sale"`
+	Purchasable   bool                    `json:"purchasable"`
+	ManageStock   bool                    `json:"manage_stock"`
+	StockQuantity *int                    `json:"stock_quantity"`
+	StockStatus   string                  `json:"stock_status"`
+	Attributes    []VariationAttributeDTO `json:"attributes"`
+}
+
+// VariationAttributeDTO represents an attribute value of a variation. An
+// empty option means the variation accepts any value.
+type VariationAttributeDTO struct {
+	Name   string `json:"name"`
+	Option string `json:"option"`
+}
+
+// ToJSON converts the response to JSON string
+func (r *GetVariationResponse) ToJSON() (string, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// HasMatch reports whether exactly one variation matched
+func (r *GetVariationResponse) HasMatch() bool {
+	return r.Variation != nil
+}
+
+// FromDomainVariation converts a domain variation to a DTO
+func FromDomainVariation(variation *domain.Variation) *VariationDTO {
+	dto := &VariationDTO{
+		ID:            variation.ID.Value(),
+		ParentID:      variation.ParentID,
+		SKU:           variation.SKU,
+		Permalink:     variation.Permalink,
+		OnSale:        variation.OnSale,
+		Purchasable:   variation.Purchasable,
+		ManageStock:   variation.ManageStock,
+		StockQuantity: variation.StockQuantity,
+		StockStatus:   string(variation.StockStatus),
+		Attributes:    make([]VariationAttributeDTO, len(variation.Attributes)),
+	}
+
+	if variation.Price != nil {
+		dto.Price = fmt.Sprintf("%.2f", variation.Price.Amount())
+	}
+	if variation.RegularPrice != nil {
+		dto.RegularPrice = fmt.Sprintf("%.2f", variation.RegularPrice.Amount())
+	}
+	if variation.SalePrice != nil {
+		dto.SalePrice = fmt.Sprintf("%.2f", variation.SalePrice.Amount())
+	}
+
+	for i, attribute := range variation.Attributes {
+		dto.Attributes[i] = VariationAttributeDTO{
+			Name:   attribute.Name,
+			Option: attribute.Option,
+		}
+	}
+
+	return dto
+}
+
+// availableAttributes collects the distinct values of each attribute across variations
+func availableAttributes(variations []*domain.Variation) map[string][]string {
+	seen := make(map[string]map[string]bool)
+	available := make(map[string][]string)
+	for _, variation := range variations {
+		for _, attribute := range variation.Attributes {
+			if seen[attribute.Name] == nil {
+				seen[attribute.Name] = make(map[string]bool)
+			}
+			option := attribute.Option
+			if option == "" {
+				option = "any"
+			}
+			if !seen[attribute.Name][option] {
+				seen[attribute.Name][option] = true
+				available[attribute.Name] = append(available[attribute.Name], option)
+			}
+		}
+	}
+
+	for name := range available {
+		sort.Strings(available[name])
+	}
+
+	return available
+}
@@ -0,0 +1,113 @@
+package get_variation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"woocommerce-mcp/internal/product/domain"
+	"woocommerce-mcp/kit/safemode"
+)
+
+// VariationFinder finds a single variation of a variable product by its attributes
+type VariationFinder struct {
+	repository        domain.VariationRepository
+	productRepository domain.ProductRepository
+}
+
+// NewVariationFinder creates a new VariationFinder. The product repository
+// checks in safe mode that the parent product is published.
+func NewVariationFinder(repository domain.VariationRepository, productRepository domain.ProductRepository) *VariationFinder {
+	return &VariationFinder{
+		repository:        repository,
+		productRepository: productRepository,
+	}
+}
+
+// Execute fetches the product's variations and returns the one matching all
+// requested attributes. When none or several match, the response has no
+// variation and lists the available attribute values instead.
+func (f *VariationFinder) Execute(ctx context.Context, request *GetVariationRequest) (*GetVariationResponse, error) {
+	productID, attributes, err := validateRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	// Safe mode does not reveal the variations of unpublished products
+	safeMode := safemode.Enabled()
+	if safeMode {
+		if _, err := domain.FindPublishedByID(ctx, f.productRepository, productID); err != nil {
+			return nil, fmt.Errorf("failed to find product: %w", err)
+		}
+	}
+
+	variations, err := f.repository.FindVariations(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get variations: %w", err)
+	}
+	if safeMode {
+		variations = publishedVariations(variations)
+	}
+
+	var matches []*domain.Variation
+	for _, variation := range variations {
+		if variation.MatchesAttributes(attributes) {
+			matches = append(matches, variation)
+		}
+	}
+
+	response := &GetVariationResponse{
+		ProductID:  productID.Value(),
+		MatchCount: len(matches),
+	}
+	if len(matches) == 1 {
+		response.Variation = FromDomainVariation(matches[0])
+	} else {
+		response.AvailableAttributes = availableAttributes(variations)
+	}
+
+	return response, nil
+}
+
+// publishedVariations keeps the variations with the publish status; private
+// variations are hidden from shoppers
+func publishedVariations(variations []*domain.Variation) []*domain.Variation {
+	published := make([]*domain.Variation, 0, len(variations))
+	for _, variation := range variations {
+		if variation.Status == domain.ProductStatusPublish {
+			published = append(published, variation)
+		}
+	}
+	return published
+}
+
+// validateRequest validates the request and returns the parsed product ID and attributes
+func validateRequest(request *GetVariationRequest) (*domain.ProductID, map[string]string, error) {
+	if request.BaseURL == "" {
+		return nil, nil, domain.NewProductValidationError("base_url", "base URL is required")
+	}
+	if request.ConsumerKey == "" {
+		return nil, nil, domain.NewProductValidationError("consumer_key", "consumer key is required")
+	}
+	if request.ConsumerSecret == "" {
+		return nil, nil, domain.NewProductValidationError("consumer_secret", "consumer secret is required")
+	}
+
+	productID, err := domain.NewProductIDFromString(strings.TrimSpace(request.ProductID))
+	if err != nil {
+		return nil, nil, domain.NewProductValidationError("product_id", "product ID must be a positive integer")
+	}
+
+	attributes := make(map[string]string, len(request.Attributes))
+	for name, value := range request.Attributes {
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if name == "" || value == "" {
+			return nil, nil, domain.NewProductValidationError("attributes", "attribute names and values must not be empty")
+		}
+		attributes[name] = value
+	}
+	if len(attributes) == 0 {
+		return nil, nil, domain.NewProductValidationError("attributes", "at least one attribute is required")
+	}
+
+	return productID, attributes, nil
+}
@@ -0,0 +1,62 @@
+package search_products
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"woocommerce-mcp/internal/product/domain"
+)
+
+// BroadQueryMaxPerPageEnv is the environment variable holding the largest
+// per_page a search without filters returns unless confirm_broad_query is set
+const BroadQueryMaxPerPageEnv = "BROAD_QUERY_MAX_PER_PAGE"
+
+// DefaultBroadQueryMaxPerPage is the per_page cap of searches without
+// filters when BROAD_QUERY_MAX_PER_PAGE is not set
+const DefaultBroadQueryMaxPerPage = 20
+
+// BroadQueryMaxPerPage returns the per_page cap of searches without filters
+// configured by the environment; 0 turns the cap off. Invalid values fall
+// back to the default.
+func BroadQueryMaxPerPage() int {
+	if value := strings.TrimSpace(os.Getenv(BroadQueryMaxPerPageEnv)); value != "" {
+		if limit, err := strconv.Atoi(value); err == nil && limit >= 0 {
+			return limit
+		}
+	}
+	return DefaultBroadQueryMaxPerPage
+}
+
+// confirmBroadQuery tells whether the request confirmed that a search
+// without filters may return a large page
+func confirmBroadQuery(request *SearchRequest) (bool, error) {
+	value := strings.TrimSpace(request.GetConfirmBroadQuery())
+	if value == "" {
+		return false, nil
+	}
+	confirmed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, domain.NewProductValidationError("confirm_broad_query", "must be true or false")
+	}
+	return confirmed, nil
+}
+
+// capBroadQuery lowers per_page to the broad query cap when the request has
+// no filters and did not confirm it, so a careless call cannot pull a large
+// slice of the catalog into a caller's context. It returns a note saying so,
+// or "" when the criteria are left as they are.
+func capBroadQuery(request *SearchRequest, criteria *domain.SearchCriteria) (string, error) {
+	confirmed, err := confirmBroadQuery(request)
+	if err != nil {
+		return "", err
+	}
+	limit := BroadQueryMaxPerPage()
+	if confirmed || limit == 0 || criteria.PerPage <= limit || request.FilterSummary() != "" {
+		return "", nil
+	}
+
+	requested := criteria.PerPage
+	criteria.PerPage = limit
+	return fmt.Sprintf("per_page lowered from %d to %d because the search has no filters; add a filter, or set confirm_broad_query=true to get the larger page", requested, limit), nil
+}
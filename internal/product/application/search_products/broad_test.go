@@ -0,0 +1,90 @@
+package search_products
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestBroadQueryMaxPerPageIsConfigured(t *testing.T) {
+	for value, want := range map[string]int{
+		"":    DefaultBroadQueryMaxPerPage,
+		"50":  50,
+		" 5 ": 5,
+		"0":   0,
+		"-1":  DefaultBroadQueryMaxPerPage,
+		"abc": DefaultBroadQueryMaxPerPage,
+	} {
+		t.Setenv(BroadQueryMaxPerPageEnv, value)
+		if got := BroadQueryMaxPerPage(); got != want {
+			t.Errorf("%s=%q: cap = %d, want %d", BroadQueryMaxPerPageEnv, value, got, want)
+		}
+	}
+}
+
+func TestSearchesWithoutFiltersAreCapped(t *testing.T) {
+	t.Setenv(BroadQueryMaxPerPageEnv, "")
+
+	repository := &stubRepository{}
+	response, err := NewProductSearcher(repository).Execute(context.Background(), NewSearchRequest().SetPagination("1", "100"))
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if got := repository.searches[0].PerPage; got != DefaultBroadQueryMaxPerPage {
+		t.Errorf("searched %d per page, want %d", got, DefaultBroadQueryMaxPerPage)
+	}
+	if !response.BroadQueryCapped {
+		t.Error("broad_query_capped not set")
+	}
+	if !strings.Contains(strings.Join(response.Notes, "\n"), "confirm_broad_query") {
+		t.Errorf("notes = %q, want one about confirm_broad_query", response.Notes)
+	}
+}
+
+func TestBroadQueryCapIsLifted(t *testing.T) {
+	for name, tt := range map[string]struct {
+		env     string
+		request *SearchRequest
+		perPage int
+	}{
+		"by a search":       {request: NewSearchRequest().SetSearch("shoe"), perPage: 100},
+		"by a category":     {request: NewSearchRequest().SetCategory("15"), perPage: 100},
+		"by a confirmation": {request: NewSearchRequest().SetConfirmBroadQuery("true"), perPage: 100},
+		"when turned off":   {env: "0", request: NewSearchRequest(), perPage: 100},
+		"within the cap":    {request: NewSearchRequest(), perPage: DefaultBroadQueryMaxPerPage},
+	} {
+		t.Setenv(BroadQueryMaxPerPageEnv, tt.env)
+
+		repository := &stubRepository{}
+		response, err := NewProductSearcher(repository).Execute(context.Background(), tt.request.SetPagination("1", strconv.Itoa(tt.perPage)))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if got := repository.searches[0].PerPage; got != tt.perPage || response.BroadQueryCapped {
+			t.Errorf("%s: searched %d per page (capped %v), want %d uncapped", name, got, response.BroadQueryCapped, tt.perPage)
+		}
+	}
+}
+
+func TestConfirmBroadQueryMustBeABoolean(t *testing.T) {
+	_, err := NewProductSearcher(&stubRepository{}).Execute(context.Background(), NewSearchRequest().SetConfirmBroadQuery("yes please"))
+	if validationField(err) != "confirm_broad_query" {
+		t.Errorf("error = %v, want a confirm_broad_query validation error", err)
+	}
+}
+
+func TestStreamsAreNotCapped(t *testing.T) {
+	t.Setenv(BroadQueryMaxPerPageEnv, "")
+
+	repository := &stubRepository{}
+	_, err := NewProductSearcher(repository).Stream(context.Background(), NewSearchRequest().SetPagination("1", "100"), func(page *SearchResponse) error {
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Stream: %v", err)
+	}
+	if got := repository.searches[0].PerPage; got != 100 {
+		t.Errorf("streamed %d per page, want 100", got)
+	}
+}
@@ -0,0 +1,125 @@
+package search_products
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"woocommerce-mcp/internal/product/domain"
+)
+
+// Facets that can be counted over the returned page
+const (
+	FacetCategory    = "category"
+	FacetTag         = "tag"
+	FacetStockStatus = "stock_status"
+	FacetType        = "type"
+	FacetStatus      = "status"
+	FacetOnSale      = "on_sale"
+	FacetFeatured    = "featured"
+)
+
+// facetValues maps each facet to the values a product counts towards; a
+// product may count towards several values of a facet, e.g. categories
+var facetValues = map[string]func(product *ProductDTO) []*FacetValue{
+	FacetCategory: func(product *ProductDTO) []*FacetValue {
+		values := make([]*FacetValue, 0, len(product.Categories))
+		for _, category := range product.Categories {
+			values = append(values, &FacetValue{Value: category.Slug, Name: category.Name})
+		}
+		return values
+	},
+	FacetTag: func(product *ProductDTO) []*FacetValue {
+		values := make([]*FacetValue, 0, len(product.Tags))
+		for _, tag := range product.Tags {
+			values = append(values, &FacetValue{Value: tag.Slug, Name: tag.Name})
+		}
+		return values
+	},
+	FacetStockStatus: func(product *ProductDTO) []*FacetValue {
+		return []*FacetValue{{Value: product.StockStatus}}
+	},
+	FacetType: func(product *ProductDTO) []*FacetValue {
+		return []*FacetValue{{Value: product.Type}}
+	},
+	FacetStatus: func(product *ProductDTO) []*FacetValue {
+		return []*FacetValue{{Value: product.Status}}
+	},
+	FacetOnSale: func(product *ProductDTO) []*FacetValue {
+		return []*FacetValue{{Value: strconv.FormatBool(product.OnSale)}}
+	},
+	FacetFeatured: func(product *ProductDTO) []*FacetValue {
+		return []*FacetValue{{Value: strconv.FormatBool(product.Featured)}}
+	},
+}
+
+// FacetValue is one value of a facet and the number of products having it
+type FacetValue struct {
+	// Value is the slug or raw value, e.g. "running-shoes" or "instock"
+	Value string `json:"value"`
+	// Name is the display name of a category or tag
+	Name  string `json:"name,omitempty"`
+	Count int    `json:"count"`
+}
+
+// FacetNames returns the supported facets, sorted
+func FacetNames() []string {
+	names := make([]string, 0, len(facetValues))
+	for name := range facetValues {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parseFacets parses the request's comma-separated facets, dropping repeats
+func parseFacets(request *SearchRequest) ([]string, error) {
+	var facets []string
+	seen := make(map[string]bool)
+	for _, facet := range strings.Split(request.GetFacets(), ",") {
+		facet = strings.ToLower(strings.TrimSpace(facet))
+		if facet == "" || seen[facet] {
+			continue
+		}
+		if _, ok := facetValues[facet]; !ok {
+			return nil, domain.NewProductValidationError("facets", fmt.Sprintf("unsupported facet %q; must be one of %s", facet, strings.Join(FacetNames(), ", ")))
+		}
+		seen[facet] = true
+		facets = append(facets, facet)
+	}
+	return facets, nil
+}
+
+// countFacets counts the values of each facet over a page of products.
+// Values are sorted by descending count, then by value. Products without a
+// value, e.g. without tags, are not counted for that facet.
+func countFacets(products []*ProductDTO, facets []string) map[string][]*FacetValue {
+	counted := make(map[string][]*FacetValue, len(facets))
+	for _, facet := range facets {
+		byValue := make(map[string]*FacetValue)
+		values := make([]*FacetValue, 0)
+		for _, product := range products {
+			for _, value := range facetValues[facet](product) {
+				if value.Value == "" {
+					continue
+				}
+				if existing, ok := byValue[value.Value]; ok {
+					existing.Count++
+					continue
+				}
+				value.Count = 1
+				byValue[value.Value] = value
+				values = append(values, value)
+			}
+		}
+
+		sort.SliceStable(values, func(i, j int) bool {
+			if values[i].Count != values[j].Count {
+				return values[i].Count > values[j].Count
+			}
+			return values[i].Value < values[j].Value
+		})
+		counted[facet] = values
+	}
+	return counted
+}
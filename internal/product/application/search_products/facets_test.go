@@ -0,0 +1,91 @@
+package search_products
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"woocommerce-mcp/internal/product/domain"
+)
+
+// facetCounts renders facet values as value=count pairs, in order
+func facetCounts(values []*FacetValue) []string {
+	counts := make([]string, len(values))
+	for i, value := range values {
+		counts[i] = value.Value + "=" + strconv.Itoa(value.Count)
+	}
+	return counts
+}
+
+func TestCategoryAndStockStatusFacetsAreCountedOverThePage(t *testing.T) {
+	shoes := domain.NewCategory(1, "Shoes", "shoes")
+	running := domain.NewCategory(2, "Running", "running")
+	socks := domain.NewCategory(3, "Socks", "socks")
+
+	var products []*domain.Product
+	for i, categories := range [][]*domain.Category{
+		{shoes, running},
+		{shoes},
+		{shoes, running},
+		{socks},
+		{},
+	} {
+		product := newProduct(i+1, "Product", 10)
+		for _, category := range categories {
+			product.AddCategory(category)
+		}
+		product.StockStatus = domain.StockStatusInStock
+		products = append(products, product)
+	}
+	products[2].StockStatus = domain.StockStatusOutOfStock
+	products[3].StockStatus = domain.StockStatusOnBackorder
+
+	request := NewSearchRequest().SetSearch("a").SetFacets(" stock_status , CATEGORY,category")
+	response, err := NewProductSearcher(&stubRepository{products: products}).Execute(context.Background(), request)
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	if len(response.Facets) != 2 {
+		t.Fatalf("facets = %v, want category and stock_status", response.Facets)
+	}
+	if got, want := facetCounts(response.Facets[FacetCategory]), []string{"shoes=3", "running=2", "socks=1"}; !equalStrings(got, want) {
+		t.Errorf("category facet = %v, want %v", got, want)
+	}
+	if name := response.Facets[FacetCategory][0].Name; name != "Shoes" {
+		t.Errorf("category facet name = %q, want Shoes", name)
+	}
+	if got, want := facetCounts(response.Facets[FacetStockStatus]), []string{"instock=3", "onbackorder=1", "outofstock=1"}; !equalStrings(got, want) {
+		t.Errorf("stock_status facet = %v, want %v", got, want)
+	}
+}
+
+func TestFacetsAreOmittedUnlessRequested(t *testing.T) {
+	repository := &stubRepository{products: []*domain.Product{newProduct(1, "Runner", 10)}}
+	response, err := NewProductSearcher(repository).Execute(context.Background(), NewSearchRequest())
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if response.Facets != nil {
+		t.Errorf("facets = %v without the facets argument, want none", response.Facets)
+	}
+}
+
+func TestUnsupportedFacetIsRejected(t *testing.T) {
+	_, err := NewProductSearcher(&stubRepository{}).Execute(context.Background(), NewSearchRequest().SetFacets("category,price"))
+	if validationField(err) != "facets" {
+		t.Errorf("error = %v, want a facets validation error", err)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
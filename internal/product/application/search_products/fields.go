@@ -0,0 +1,207 @@
+package search_products
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"woocommerce-mcp/internal/product/domain"
+)
+
+// FieldSelection lists the product fields kept in JSON output. A top-level
+// field maps to nil to be kept whole, or to the fields kept in each of its
+// objects, e.g. images.src maps images to {src}.
+type FieldSelection map[string]map[string]bool
+
+// ParseFields parses a comma-separated field list such as
+// "name,price,images.src,categories.name". Top-level names are the keys of a
+// product in JSON output; a dotted name selects a field of the objects of
+// dimensions, categories, tags, images, attributes, default_attributes or
+// meta_data. The product id is always kept. An empty list returns nil,
+// which keeps every field.
+func ParseFields(value string) (FieldSelection, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	productFields := jsonFieldTypes(reflect.TypeOf(ProductDTO{}))
+	selection := FieldSelection{"id": nil}
+	for _, part := range strings.Split(value, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" {
+			continue
+		}
+
+		name, nested, isNested := strings.Cut(part, ".")
+		fieldType, ok := productFields[name]
+		if !ok {
+			return nil, domain.NewProductValidationError("fields", fmt.Sprintf("unknown product field %q", name))
+		}
+		if !isNested {
+			selection[name] = nil
+			continue
+		}
+
+		objectType := objectElem(fieldType)
+		if objectType == nil {
+			return nil, domain.NewProductValidationError("fields", fmt.Sprintf("%q has no nested fields to select", name))
+		}
+		if _, ok := jsonFieldTypes(objectType)[nested]; !ok {
+			return nil, domain.NewProductValidationError("fields", fmt.Sprintf("unknown field %q of %s; must be one of %s", nested, name, strings.Join(jsonFieldNames(objectType), ", ")))
+		}
+
+		// A field already kept whole stays whole
+		if children, seen := selection[name]; seen && children == nil {
+			continue
+		}
+		if selection[name] == nil {
+			selection[name] = make(map[string]bool)
+		}
+		selection[name][nested] = true
+	}
+	return selection, nil
+}
+
+// Project serializes a product with only the selected fields, in the order
+// of the full product
+func (s FieldSelection) Project(product *ProductDTO) (json.RawMessage, error) {
+	data, err := json.Marshal(product)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	productType := reflect.TypeOf(ProductDTO{})
+	productFields := jsonFieldTypes(productType)
+	projected := make(map[string]json.RawMessage, len(s))
+	for name, children := range s {
+		raw, ok := fields[name]
+		if !ok {
+			continue
+		}
+		if children != nil {
+			if raw, err = projectNested(raw, objectElem(productFields[name]), children); err != nil {
+				return nil, err
+			}
+		}
+		projected[name] = raw
+	}
+	return orderedObject(projected, jsonFieldNames(productType)), nil
+}
+
+// projectNested keeps the child fields of an object, or of each object of
+// an array, leaving null as it is
+func projectNested(raw json.RawMessage, objectType reflect.Type, children map[string]bool) (json.RawMessage, error) {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 || trimmed[0] == 'n' {
+		return raw, nil
+	}
+	names := jsonFieldNames(objectType)
+	keep := func(object map[string]json.RawMessage) json.RawMessage {
+		for name := range object {
+			if !children[name] {
+				delete(object, name)
+			}
+		}
+		return orderedObject(object, names)
+	}
+
+	if trimmed[0] == '[' {
+		var objects []map[string]json.RawMessage
+		if err := json.Unmarshal(trimmed, &objects); err != nil {
+			return nil, err
+		}
+		projected := make([]json.RawMessage, len(objects))
+		for i, object := range objects {
+			projected[i] = keep(object)
+		}
+		return json.Marshal(projected)
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(trimmed, &object); err != nil {
+		return nil, err
+	}
+	return keep(object), nil
+}
+
+// orderedObject serializes the fields of a decoded object in the given
+// order, so a projected object lists its fields like the full one does
+func orderedObject(fields map[string]json.RawMessage, order []string) json.RawMessage {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	written := 0
+	for _, name := range order {
+		raw, ok := fields[name]
+		if !ok {
+			continue
+		}
+		if written > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(name)
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(raw)
+		written++
+	}
+	buf.WriteByte('}')
+	return buf.Bytes()
+}
+
+// jsonFieldTypes maps the JSON names of a struct's fields to their types
+func jsonFieldTypes(structType reflect.Type) map[string]reflect.Type {
+	types := make(map[string]reflect.Type)
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if name := jsonName(field); name != "" {
+			types[name] = field.Type
+		}
+	}
+	return types
+}
+
+// jsonFieldNames lists the JSON names of a struct's fields in declaration
+// order
+func jsonFieldNames(structType reflect.Type) []string {
+	names := make([]string, 0, structType.NumField())
+	for i := 0; i < structType.NumField(); i++ {
+		if name := jsonName(structType.Field(i)); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// jsonName returns the JSON name of a struct field, or "" when it is not
+// serialized
+func jsonName(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	default:
+		return name
+	}
+}
+
+// objectElem returns the struct type of a field holding an object or a
+// list of objects, or nil when the field holds neither
+func objectElem(fieldType reflect.Type) reflect.Type {
+	for fieldType.Kind() == reflect.Pointer || fieldType.Kind() == reflect.Slice {
+		fieldType = fieldType.Elem()
+	}
+	if fieldType.Kind() != reflect.Struct {
+		return nil
+	}
+	return fieldType
+}
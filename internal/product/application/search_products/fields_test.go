@@ -0,0 +1,89 @@
+package search_products
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFieldsAreParsed(t *testing.T) {
+	selection, err := ParseFields(" Name, images.src,images.alt ,categories.name,images , ")
+	if err != nil {
+		t.Fatalf("ParseFields: %v", err)
+	}
+	if _, ok := selection["id"]; !ok {
+		t.Error("id is not kept")
+	}
+	if children, ok := selection["name"]; !ok || children != nil {
+		t.Errorf("name = %v (kept %v), want it kept whole", children, ok)
+	}
+	if children := selection["categories"]; len(children) != 1 || !children["name"] {
+		t.Errorf("categories = %v, want only name", children)
+	}
+	// A field selected whole as well as by its children stays whole
+	if children, ok := selection["images"]; !ok || children != nil {
+		t.Errorf("images = %v (kept %v), want it kept whole", children, ok)
+	}
+
+	if selection, err := ParseFields("  "); err != nil || selection != nil {
+		t.Errorf("empty fields = %v, %v; want every field kept", selection, err)
+	}
+}
+
+func TestUnknownFieldsAreRejected(t *testing.T) {
+	for value, want := range map[string]string{
+		"nmae":         `unknown product field "nmae"`,
+		"images.url":   `unknown field "url" of images`,
+		"name.first":   `"name" has no nested fields`,
+		"price,sku.id": `"sku" has no nested fields`,
+	} {
+		_, err := ParseFields(value)
+		if validationField(err) != "fields" || !strings.Contains(err.Error(), want) {
+			t.Errorf("fields %q: error = %v, want a fields error containing %s", value, err, want)
+		}
+	}
+}
+
+func TestProjectKeepsTheSelectedNestedFields(t *testing.T) {
+	selection, err := ParseFields("images.src,name,categories.name,dimensions.width")
+	if err != nil {
+		t.Fatalf("ParseFields: %v", err)
+	}
+	product := &ProductDTO{
+		ID:         7,
+		Name:       "Runner",
+		SKU:        "RUN-7",
+		Dimensions: &DimensionsDTO{Length: "30", Width: "12", Height: "10"},
+		Categories: []*CategoryDTO{{ID: 15, Name: "Footwear", Slug: "footwear"}},
+		Images:     []*ImageDTO{{ID: 3, Src: "https://shop.example/runner.jpg", Alt: "Runner"}},
+	}
+
+	projected, err := selection.Project(product)
+	if err != nil {
+		t.Fatalf("Project: %v", err)
+	}
+	want := `{"id":7,"name":"Runner","dimensions":{"width":"12"},"categories":[{"name":"Footwear"}],"images":[{"src":"https://shop.example/runner.jpg"}]}`
+	if string(projected) != want {
+		t.Errorf("projected = %s\nwant        %s", projected, want)
+	}
+}
+
+func TestFieldsOnlyApplyToJSON(t *testing.T) {
+	if _, err := NewRenderer("csv", RenderOptions{Fields: "name"}); validationField(err) != "fields" {
+		t.Errorf("csv with fields: error = %v, want a fields validation error", err)
+	}
+	if _, err := NewRenderer("json", RenderOptions{Fields: "nmae"}); validationField(err) != "fields" {
+		t.Errorf("json with an unknown field: error = %v, want a fields validation error", err)
+	}
+
+	renderer, err := NewRenderer("json", RenderOptions{Fields: "name"})
+	if err != nil {
+		t.Fatalf("NewRenderer: %v", err)
+	}
+	output, err := renderer.Render(renderedResponse())
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if !strings.Contains(output, `{"id":1,"name":"Running Shoes"}`) || strings.Contains(output, "RUN-1") || !strings.Contains(output, `"total_count":12`) {
+		t.Errorf("output = %s, want products trimmed to id and name and the counts kept", output)
+	}
+}
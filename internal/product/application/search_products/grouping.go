@@ -0,0 +1,115 @@
+package search_products
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"woocommerce-mcp/internal/product/domain"
+)
+
+// Fields the rendered products can be grouped by
+const (
+	GroupByTaxClass    = "tax_class"
+	GroupByCategory    = "category"
+	GroupByStockStatus = "stock_status"
+)
+
+// groupKeys maps each group field to the keys of the groups a product
+// belongs to; a product in several categories belongs to each of them
+var groupKeys = map[string]func(product *ProductDTO) []string{
+	GroupByTaxClass: func(product *ProductDTO) []string {
+		// WooCommerce leaves the tax class of the standard rate empty
+		if product.TaxClass == "" {
+			return []string{"standard"}
+		}
+		return []string{product.TaxClass}
+	},
+	GroupByCategory: func(product *ProductDTO) []string {
+		if len(product.Categories) == 0 {
+			return []string{"uncategorized"}
+		}
+		keys := make([]string, 0, len(product.Categories))
+		for _, category := range product.Categories {
+			keys = append(keys, category.Slug)
+		}
+		return keys
+	},
+	GroupByStockStatus: func(product *ProductDTO) []string {
+		return []string{product.StockStatus}
+	},
+}
+
+// ProductGroup lists the products sharing the value of the group field
+type ProductGroup struct {
+	Count    int               `json:"count"`
+	Products []*GroupedProduct `json:"products"`
+}
+
+// GroupedProduct is the short form of a product listed in a group; the
+// full product is in the flat product list
+type GroupedProduct struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	SKU          string `json:"sku,omitempty"`
+	Price        string `json:"price"`
+	RegularPrice string `json:"regular_price"`
+	SalePrice    string `json:"sale_price,omitempty"`
+}
+
+// GroupByFields returns the supported group fields, sorted
+func GroupByFields() []string {
+	fields := make([]string, 0, len(groupKeys))
+	for field := range groupKeys {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// parseGroupBy parses a group_by argument; an empty value means no grouping
+func parseGroupBy(value string) (string, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return "", nil
+	}
+	if _, ok := groupKeys[value]; !ok {
+		return "", domain.NewProductValidationError("group_by", fmt.Sprintf("unsupported field %q; must be one of %s", value, strings.Join(GroupByFields(), ", ")))
+	}
+	return value, nil
+}
+
+// GroupProducts groups products by a supported field, keeping their order
+// within each group
+func GroupProducts(products []*ProductDTO, field string) map[string]*ProductGroup {
+	keysOf := groupKeys[field]
+	groups := make(map[string]*ProductGroup)
+	for _, product := range products {
+		for _, key := range keysOf(product) {
+			group, ok := groups[key]
+			if !ok {
+				group = &ProductGroup{Products: []*GroupedProduct{}}
+				groups[key] = group
+			}
+			group.Count++
+			group.Products = append(group.Products, &GroupedProduct{
+				ID:           product.ID,
+				Name:         product.Name,
+				SKU:          product.SKU,
+				Price:        product.Price,
+				RegularPrice: product.RegularPrice,
+				SalePrice:    product.SalePrice,
+			})
+		}
+	}
+	return groups
+}
+
+// sortedGroupKeys returns the keys of the groups, sorted
+func sortedGroupKeys(groups map[string]*ProductGroup) []string {
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,125 @@
+package search_products
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// groupedProducts returns a known result set: two standard-rate products,
+// one at reduced rate and one zero-rated, across three categories
+func groupedProducts() []*ProductDTO {
+	shoes := &CategoryDTO{ID: 1, Name: "Shoes", Slug: "shoes"}
+	kids := &CategoryDTO{ID: 2, Name: "Kids", Slug: "kids"}
+	books := &CategoryDTO{ID: 3, Name: "Books", Slug: "books"}
+	return []*ProductDTO{
+		{ID: 1, Name: "Runner", Price: "60.00", Categories: []*CategoryDTO{shoes}},
+		{ID: 2, Name: "Kids Runner", Price: "30.00", TaxClass: "reduced-rate", Categories: []*CategoryDTO{shoes, kids}},
+		{ID: 3, Name: "Picture Book", Price: "12.00", TaxClass: "zero-rate", Categories: []*CategoryDTO{books, kids}},
+		{ID: 4, Name: "Gift Card", Price: "25.00"},
+	}
+}
+
+// groupIDs renders groups as key=ids pairs for comparison
+func groupIDs(groups map[string]*ProductGroup) map[string]string {
+	rendered := make(map[string]string, len(groups))
+	for key, group := range groups {
+		ids := make([]string, len(group.Products))
+		for i, product := range group.Products {
+			ids[i] = strconv.Itoa(product.ID)
+		}
+		if group.Count != len(ids) {
+			rendered[key] = "count mismatch"
+			continue
+		}
+		rendered[key] = strings.Join(ids, ",")
+	}
+	return rendered
+}
+
+func TestProductsAreGroupedByTaxClass(t *testing.T) {
+	got := groupIDs(GroupProducts(groupedProducts(), GroupByTaxClass))
+	want := map[string]string{"standard": "1,4", "reduced-rate": "2", "zero-rate": "3"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("tax_class groups = %v, want %v", got, want)
+	}
+}
+
+func TestProductsAreGroupedByEveryCategory(t *testing.T) {
+	got := groupIDs(GroupProducts(groupedProducts(), GroupByCategory))
+	want := map[string]string{"shoes": "1,2", "kids": "2,3", "books": "3", "uncategorized": "4"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("category groups = %v, want %v", got, want)
+	}
+}
+
+func TestJSONRendererAddsTheGroups(t *testing.T) {
+	renderer, err := NewRenderer(FormatJSON, RenderOptions{GroupBy: " Tax_Class "})
+	if err != nil {
+		t.Fatalf("NewRenderer: %v", err)
+	}
+	output, err := renderer.Render(&SearchResponse{Products: groupedProducts(), TotalCount: 4})
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	var decoded struct {
+		Products []json.RawMessage       `json:"products"`
+		GroupBy  string                  `json:"group_by"`
+		Groups   map[string]ProductGroup `json:"groups"`
+	}
+	if err := json.Unmarshal([]byte(output), &decoded); err != nil {
+		t.Fatalf("decode: %v\n%s", err, output)
+	}
+	if decoded.GroupBy != GroupByTaxClass || len(decoded.Products) != 4 {
+		t.Errorf("group_by %q with %d products, want tax_class alongside the 4 products", decoded.GroupBy, len(decoded.Products))
+	}
+	if standard := decoded.Groups["standard"]; standard.Count != 2 || standard.Products[1].Name != "Gift Card" || standard.Products[1].Price != "25.00" {
+		t.Errorf("standard group = %+v, want Runner and Gift Card", standard)
+	}
+}
+
+func TestMarkdownAndCSVRenderersShowTheGroups(t *testing.T) {
+	response := &SearchResponse{Products: groupedProducts(), TotalCount: 4}
+
+	renderer, err := NewRenderer(FormatMarkdown, RenderOptions{GroupBy: GroupByCategory})
+	if err != nil {
+		t.Fatalf("NewRenderer(markdown): %v", err)
+	}
+	markdown, err := renderer.Render(response)
+	if err != nil {
+		t.Fatalf("render markdown: %v", err)
+	}
+	for _, want := range []string{"Grouped by category:", "| kids | 2 | 2, 3 |", "| uncategorized | 1 | 4 |"} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("markdown does not contain %q:\n%s", want, markdown)
+		}
+	}
+
+	renderer, err = NewRenderer(FormatCSV, RenderOptions{GroupBy: GroupByCategory})
+	if err != nil {
+		t.Fatalf("NewRenderer(csv): %v", err)
+	}
+	output, err := renderer.Render(response)
+	if err != nil {
+		t.Fatalf("render csv: %v", err)
+	}
+	records, err := csv.NewReader(strings.NewReader(output)).ReadAll()
+	if err != nil {
+		t.Fatalf("parse csv: %v", err)
+	}
+	last := len(records[0]) - 1
+	if records[0][last] != "category" || records[2][last] != "shoes|kids" {
+		t.Errorf("csv group column = %q / %q, want category / shoes|kids", records[0][last], records[2][last])
+	}
+}
+
+func TestUnsupportedGroupByIsAValidationError(t *testing.T) {
+	_, err := NewRenderer(FormatJSON, RenderOptions{GroupBy: "price"})
+	if validationField(err) != "group_by" {
+		t.Errorf("error = %v, want a group_by validation error", err)
+	}
+}
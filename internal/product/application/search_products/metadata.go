@@ -0,0 +1,65 @@
+package search_products
+
+import (
+	"strconv"
+	"strings"
+	"woocommerce-mcp/internal/product/domain"
+)
+
+// includeMeta tells whether the products of a response carry meta data.
+// Meta data is the bulkiest part of a product and most callers do not need
+// it, so it is left out unless include_meta is true or meta_keys names keys
+// to keep.
+func includeMeta(request *SearchRequest) (bool, error) {
+	value := ""
+	if request.IncludeMeta != nil {
+		value = strings.TrimSpace(*request.IncludeMeta)
+	}
+	if value == "" {
+		return request.MetaKeys != nil, nil
+	}
+
+	include, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, domain.NewProductValidationError("include_meta", "must be true or false")
+	}
+	if !include && request.MetaKeys != nil {
+		return false, domain.NewProductValidationError("include_meta", "cannot be false when meta_keys is set")
+	}
+	return include, nil
+}
+
+// parseMetaKeys parses the meta_keys allowlist of the request. It returns
+// nil when the request sets none, and an empty set when it sets an empty
+// list.
+func parseMetaKeys(request *SearchRequest) map[string]bool {
+	if request.MetaKeys == nil {
+		return nil
+	}
+
+	keys := make(map[string]bool)
+	for _, key := range strings.Split(*request.MetaKeys, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys[key] = true
+		}
+	}
+	return keys
+}
+
+// filterMetaData keeps the meta data whose key is allowed. Without an
+// allowlist every public key is kept; keys starting with an underscore are
+// private to WordPress and plugins, often serialized blobs, and are only
+// kept when the allowlist names them.
+func filterMetaData(metaData []*MetaDataDTO, allowed map[string]bool) []*MetaDataDTO {
+	var kept []*MetaDataDTO
+	for _, meta := range metaData {
+		if allowed != nil && !allowed[meta.Key] {
+			continue
+		}
+		if allowed == nil && strings.HasPrefix(meta.Key, "_") {
+			continue
+		}
+		kept = append(kept, meta)
+	}
+	return kept
+}
@@ -0,0 +1,62 @@
+package search_products
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"woocommerce-mcp/internal/product/domain"
+)
+
+// metaSearch searches a product carrying public and private meta data and
+// returns the meta data keys it was rendered with
+func metaSearch(t *testing.T, request *SearchRequest) []string {
+	t.Helper()
+	product := newProduct(1, "Runner", 10)
+	product.MetaData = []*domain.MetaData{
+		domain.NewMetaData(1, "warranty", "2 years"),
+		domain.NewMetaData(2, "brand", "Acme"),
+		domain.NewMetaData(3, "_edit_lock", "1700000000:1"),
+		domain.NewMetaData(4, "_wc_plugin_state", "a:1:{s:3:\"foo\";}"),
+	}
+
+	response, err := NewProductSearcher(&stubRepository{products: []*domain.Product{product}}).Execute(context.Background(), request)
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	keys := make([]string, 0)
+	for _, meta := range response.Products[0].MetaData {
+		keys = append(keys, meta.Key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestMetaDataIsFilteredByKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		request *SearchRequest
+		want    string
+	}{
+		{"private keys hidden by default", NewSearchRequest().SetIncludeMeta("true"), "brand,warranty"},
+		{"allowlist", NewSearchRequest().SetMetaKeys("warranty, missing"), "warranty"},
+		{"allowlisted private key", NewSearchRequest().SetMetaKeys("_edit_lock,brand"), "_edit_lock,brand"},
+		{"empty allowlist drops all", NewSearchRequest().SetMetaKeys(""), ""},
+		{"blank allowlist drops all", NewSearchRequest().SetMetaKeys(" , "), ""},
+		{"meta left out unless asked", NewSearchRequest(), ""},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(metaSearch(t, tt.request), ","); got != tt.want {
+			t.Errorf("%s: meta keys = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMetaKeysConflictWithExcludingMeta(t *testing.T) {
+	request := NewSearchRequest().SetMetaKeys("warranty").SetIncludeMeta("false")
+	_, err := NewProductSearcher(&stubRepository{}).Execute(context.Background(), request)
+	if validationField(err) != "include_meta" {
+		t.Errorf("error = %v, want an include_meta validation error", err)
+	}
+}
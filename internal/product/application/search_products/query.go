@@ -9,17 +9,14 @@ import (
 
 const SearchProductsQueryType query.Type = "search_products"
 
-// SearchProductsQuery represents a query to search for products
+// SearchProductsQuery represents a query to search for products in the
+// store the handler's searcher was built for
 type SearchProductsQuery struct {
-	// Required authentication parameters
-	BaseURL        string `json:"base_url"`
-	ConsumerKey    string `json:"consumer_key"`
-	ConsumerSecret string `json:"consumer_secret"`
-
 	// Optional search parameters
 	Search      string `json:"search,omitempty"`
 	Category    string `json:"category,omitempty"`
 	Tag         string `json:"tag,omitempty"`
+	Brand       string `json:"brand,omitempty"`
 	Status      string `json:"status,omitempty"`
 	ProductType string `json:"type,omitempty"`
 	Featured    string `json:"featured,omitempty"`
@@ -31,15 +28,18 @@ type SearchProductsQuery struct {
 	Page        string `json:"page,omitempty"`
 	Order       string `json:"order,omitempty"`
 	OrderBy     string `json:"orderby,omitempty"`
+
+	StrictPriceSort string `json:"strict_price_sort,omitempty"`
+
+	ModifiedAfter  string `json:"modified_after,omitempty"`
+	ModifiedBefore string `json:"modified_before,omitempty"`
+
+	CatalogVisibility string `json:"catalog_visibility,omitempty"`
 }
 
 // NewSearchProductsQuery creates a new SearchProductsQuery
-func NewSearchProductsQuery(baseURL, consumerKey, consumerSecret string) *SearchProductsQuery {
-	return &SearchProductsQuery{
-		BaseURL:        baseURL,
-		ConsumerKey:    consumerKey,
-		ConsumerSecret: consumerSecret,
-	}
+func NewSearchProductsQuery() *SearchProductsQuery {
+	return &SearchProductsQuery{}
 }
 
 // Type returns the query type
@@ -80,7 +80,7 @@ func (h *SearchProductsQueryHandler) Handle(ctx context.Context, query query.Que
 
 // queryToRequest converts SearchProductsQuery to SearchRequest
 func (h *SearchProductsQueryHandler) queryToRequest(q *SearchProductsQuery) *SearchRequest {
-	request := NewSearchRequest(q.BaseURL, q.ConsumerKey, q.ConsumerSecret)
+	request := NewSearchRequest()
 
 	if q.Search != "" {
 		request.SetSearch(q.Search)
@@ -91,6 +91,9 @@ func (h *SearchProductsQueryHandler) queryToRequest(q *SearchProductsQuery) *Sea
 	if q.Tag != "" {
 		request.SetTag(q.Tag)
 	}
+	if q.Brand != "" {
+		request.SetBrand(q.Brand)
+	}
 	if q.Status != "" {
 		request.SetStatus(q.Status)
 	}
@@ -109,12 +112,21 @@ func (h *SearchProductsQueryHandler) queryToRequest(q *SearchProductsQuery) *Sea
 	if q.StockStatus != "" {
 		request.SetStockStatus(q.StockStatus)
 	}
+	if q.ModifiedAfter != "" || q.ModifiedBefore != "" {
+		request.SetModifiedRange(q.ModifiedAfter, q.ModifiedBefore)
+	}
+	if q.CatalogVisibility != "" {
+		request.SetCatalogVisibility(q.CatalogVisibility)
+	}
 	if q.Page != "" || q.PerPage != "" {
 		request.SetPagination(q.Page, q.PerPage)
 	}
 	if q.OrderBy != "" || q.Order != "" {
 		request.SetSorting(q.OrderBy, q.Order)
 	}
+	if q.StrictPriceSort != "" {
+		request.SetStrictPriceSort(q.StrictPriceSort)
+	}
 
 	return request
 }
@@ -0,0 +1,270 @@
+package search_products
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"woocommerce-mcp/internal/product/domain"
+	"woocommerce-mcp/kit/jsonformat"
+)
+
+// Output formats of the search results
+const (
+	FormatJSON     = "json"
+	FormatMarkdown = "markdown"
+	FormatCSV      = "csv"
+)
+
+// ResultRenderer renders search results in one output format
+type ResultRenderer interface {
+	Render(response *SearchResponse) (string, error)
+}
+
+// RenderOptions tune the rendering of search results
+type RenderOptions struct {
+	// Pretty indents JSON output; other formats ignore it
+	Pretty bool
+	// GroupBy groups the products by one of the GroupByFields, alongside
+	// the flat product list; empty means no grouping
+	GroupBy string
+	// Fields lists the product fields kept in JSON output, as ParseFields
+	// takes them; empty keeps every field
+	Fields string
+}
+
+// renderers maps each output format to its renderer; adding a format only
+// takes a new ResultRenderer registered here
+var renderers = map[string]func(options RenderOptions) ResultRenderer{
+	FormatJSON: func(options RenderOptions) ResultRenderer {
+		// Fields were validated by NewRenderer
+		fields, _ := ParseFields(options.Fields)
+		return JSONRenderer{Pretty: options.Pretty, GroupBy: options.GroupBy, Fields: fields}
+	},
+	FormatMarkdown: func(options RenderOptions) ResultRenderer { return MarkdownRenderer{GroupBy: options.GroupBy} },
+	FormatCSV:      func(options RenderOptions) ResultRenderer { return CSVRenderer{GroupBy: options.GroupBy} },
+}
+
+// NewRenderer returns the renderer of a format; an empty format means JSON
+func NewRenderer(format string, options RenderOptions) (ResultRenderer, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		format = FormatJSON
+	}
+
+	newRenderer, ok := renderers[format]
+	if !ok {
+		return nil, domain.NewProductValidationError("format", fmt.Sprintf("unsupported format %q; must be one of %s", format, strings.Join(Formats(), ", ")))
+	}
+
+	groupBy, err := parseGroupBy(options.GroupBy)
+	if err != nil {
+		return nil, err
+	}
+	options.GroupBy = groupBy
+
+	if _, err := ParseFields(options.Fields); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(options.Fields) != "" && format != FormatJSON {
+		return nil, domain.NewProductValidationError("fields", fmt.Sprintf("only applies to the json format, not %s", format))
+	}
+	return newRenderer(options), nil
+}
+
+// Formats returns the supported output formats, sorted
+func Formats() []string {
+	formats := make([]string, 0, len(renderers))
+	for format := range renderers {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// JSONRenderer renders the full response as JSON
+type JSONRenderer struct {
+	Pretty  bool
+	GroupBy string
+	// Fields trims each product to the selected fields; nil keeps them all
+	Fields FieldSelection
+}
+
+// groupedResponse is a response with its products also grouped by a field
+type groupedResponse struct {
+	*SearchResponse
+	GroupBy string                   `json:"group_by"`
+	Groups  map[string]*ProductGroup `json:"groups"`
+}
+
+// projectedResponse is a response with its products trimmed to selected
+// fields; its Products shadow those of the embedded response
+type projectedResponse struct {
+	*SearchResponse
+	Products []json.RawMessage `json:"products"`
+}
+
+// projectedGroupedResponse is a projected response with its products also
+// grouped by a field
+type projectedGroupedResponse struct {
+	projectedResponse
+	GroupBy string                   `json:"group_by"`
+	Groups  map[string]*ProductGroup `json:"groups"`
+}
+
+// Render serializes the response, indented when Pretty is set. With GroupBy
+// set, a groups object maps each value of the field to its products. With
+// Fields set, each product only has the selected fields.
+func (r JSONRenderer) Render(response *SearchResponse) (string, error) {
+	if r.Fields != nil {
+		projected, err := r.project(response)
+		if err != nil {
+			return "", err
+		}
+		if r.GroupBy == "" {
+			return jsonformat.Marshal(projected, r.Pretty)
+		}
+		return jsonformat.Marshal(projectedGroupedResponse{
+			projectedResponse: projected,
+			GroupBy:           r.GroupBy,
+			Groups:            GroupProducts(response.Products, r.GroupBy),
+		}, r.Pretty)
+	}
+
+	if r.GroupBy == "" {
+		return jsonformat.Marshal(response, r.Pretty)
+	}
+	return jsonformat.Marshal(groupedResponse{
+		SearchResponse: response,
+		GroupBy:        r.GroupBy,
+		Groups:         GroupProducts(response.Products, r.GroupBy),
+	}, r.Pretty)
+}
+
+// project trims the products of a response to the selected fields
+func (r JSONRenderer) project(response *SearchResponse) (projectedResponse, error) {
+	products := make([]json.RawMessage, len(response.Products))
+	for i, product := range response.Products {
+		projected, err := r.Fields.Project(product)
+		if err != nil {
+			return projectedResponse{}, err
+		}
+		products[i] = projected
+	}
+	return projectedResponse{SearchResponse: response, Products: products}, nil
+}
+
+// MarkdownRenderer renders the products as a markdown table
+type MarkdownRenderer struct {
+	GroupBy string
+}
+
+// Render writes a pagination line followed by one table row per product.
+// With GroupBy set, a second table counts the products of each group.
+func (r MarkdownRenderer) Render(response *SearchResponse) (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Page %d of %d (%d product(s) in total)\n\n", response.CurrentPage, response.TotalPages, response.TotalCount)
+	if response.IsEmpty() {
+		b.WriteString("No products.\n")
+		return b.String(), nil
+	}
+
+	b.WriteString("| ID | Name | SKU | Price | Availability | Link |\n")
+	b.WriteString("|---|---|---|---|---|---|\n")
+	for _, product := range response.Products {
+		fmt.Fprintf(&b, "| %d | %s | %s | %s | %s | %s |\n",
+			product.ID,
+			markdownCell(product.Name),
+			markdownCell(product.SKU),
+			markdownCell(product.Price),
+			markdownCell(product.Availability),
+			markdownCell(product.Permalink),
+		)
+	}
+
+	if r.GroupBy != "" {
+		groups := GroupProducts(response.Products, r.GroupBy)
+		fmt.Fprintf(&b, "\nGrouped by %s:\n\n", r.GroupBy)
+		b.WriteString("| Group | Products | IDs |\n")
+		b.WriteString("|---|---|---|\n")
+		for _, key := range sortedGroupKeys(groups) {
+			group := groups[key]
+			ids := make([]string, len(group.Products))
+			for i, product := range group.Products {
+				ids[i] = strconv.Itoa(product.ID)
+			}
+			fmt.Fprintf(&b, "| %s | %d | %s |\n", markdownCell(key), group.Count, strings.Join(ids, ", "))
+		}
+	}
+	return b.String(), nil
+}
+
+// markdownCell escapes a value for a markdown table cell
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", "\\|")
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// csvHeader lists the columns of the CSV output
+var csvHeader = []string{
+	"id", "name", "sku", "type", "status", "price", "regular_price", "sale_price",
+	"stock_status", "stock_quantity", "availability", "in_stock", "available",
+	"permalink",
+}
+
+// CSVRenderer renders the products as CSV with a header row
+type CSVRenderer struct {
+	GroupBy string
+}
+
+// Render writes one CSV record per product. With GroupBy set, a last column
+// named after the field holds the groups of the product, separated by "|".
+func (r CSVRenderer) Render(response *SearchResponse) (string, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	header := csvHeader
+	if r.GroupBy != "" {
+		header = append(append([]string(nil), csvHeader...), r.GroupBy)
+	}
+	if err := writer.Write(header); err != nil {
+		return "", err
+	}
+
+	for _, product := range response.Products {
+		stockQuantity := ""
+		if product.StockQuantity != nil {
+			stockQuantity = strconv.Itoa(*product.StockQuantity)
+		}
+		record := []string{
+			strconv.Itoa(product.ID),
+			product.Name,
+			product.SKU,
+			product.Type,
+			product.Status,
+			product.Price,
+			product.RegularPrice,
+			product.SalePrice,
+			product.StockStatus,
+			stockQuantity,
+			product.Availability,
+			strconv.FormatBool(product.InStock),
+			strconv.FormatBool(product.Available),
+			product.Permalink,
+		}
+		if r.GroupBy != "" {
+			record = append(record, strings.Join(groupKeys[r.GroupBy](product), "|"))
+		}
+		if err := writer.Write(record); err != nil {
+			return "", err
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,102 @@
+package search_products
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// renderedResponse returns a page of two products, one with characters
+// that need escaping in markdown and CSV
+func renderedResponse() *SearchResponse {
+	quantity := 3
+	return &SearchResponse{
+		Products: []*ProductDTO{
+			{ID: 1, Name: "Running Shoes", SKU: "RUN-1", Price: "59.99", StockStatus: "instock", StockQuantity: &quantity, InStock: true, Available: true},
+			{ID: 2, Name: "Socks | \"Pack\", 3", SKU: "SOCK-3", Price: "9.99", StockStatus: "outofstock"},
+		},
+		TotalCount:  12,
+		CurrentPage: 1,
+		PerPage:     2,
+		TotalPages:  6,
+		HasNext:     true,
+	}
+}
+
+func render(t *testing.T, format string) string {
+	t.Helper()
+	renderer, err := NewRenderer(format, RenderOptions{})
+	if err != nil {
+		t.Fatalf("NewRenderer(%q): %v", format, err)
+	}
+	output, err := renderer.Render(renderedResponse())
+	if err != nil {
+		t.Fatalf("render %s: %v", format, err)
+	}
+	return output
+}
+
+func TestJSONRendererRoundTrips(t *testing.T) {
+	for _, format := range []string{"", " JSON "} {
+		var decoded SearchResponse
+		if err := json.Unmarshal([]byte(render(t, format)), &decoded); err != nil {
+			t.Fatalf("format %q: output is not valid JSON: %v", format, err)
+		}
+		if len(decoded.Products) != 2 || decoded.Products[1].Name != "Socks | \"Pack\", 3" || decoded.TotalCount != 12 {
+			t.Errorf("format %q: decoded %+v, want the rendered response", format, decoded)
+		}
+	}
+}
+
+func TestMarkdownRendererWritesOneRowPerProduct(t *testing.T) {
+	lines := strings.Split(strings.TrimSpace(render(t, FormatMarkdown)), "\n")
+	if lines[0] != "Page 1 of 6 (12 product(s) in total)" {
+		t.Errorf("first line = %q, want the pagination line", lines[0])
+	}
+
+	// pagination, blank line, header, separator and one row per product
+	if len(lines) != 6 {
+		t.Fatalf("got %d lines, want 6:\n%s", len(lines), strings.Join(lines, "\n"))
+	}
+	columns := strings.Count(lines[2], "|")
+	for _, row := range lines[3:] {
+		if got := strings.Count(row, "|") - strings.Count(row, "\\|"); got != columns {
+			t.Errorf("row %q has %d unescaped separators, want %d", row, got, columns)
+		}
+	}
+	if !strings.Contains(lines[5], "Socks \\| \"Pack\", 3") {
+		t.Errorf("row %q does not escape the pipe in the name", lines[5])
+	}
+}
+
+func TestCSVRendererWritesAHeaderAndOneRecordPerProduct(t *testing.T) {
+	records, err := csv.NewReader(strings.NewReader(render(t, FormatCSV))).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("got %d records, want a header and 2 products", len(records))
+	}
+	if strings.Join(records[0], ",") != strings.Join(csvHeader, ",") {
+		t.Errorf("header = %v, want %v", records[0], csvHeader)
+	}
+	if records[1][0] != "1" || records[1][9] != "3" || records[1][11] != "true" {
+		t.Errorf("first record = %v, want id 1 with 3 in stock", records[1])
+	}
+	if records[2][1] != "Socks | \"Pack\", 3" || records[2][9] != "" {
+		t.Errorf("second record = %v, want the quoted name and no quantity", records[2])
+	}
+}
+
+func TestUnknownFormatIsAValidationError(t *testing.T) {
+	_, err := NewRenderer("xml", RenderOptions{})
+	if field := validationField(err); field != "format" {
+		t.Fatalf("got error %v, want a format validation error", err)
+	}
+	for _, want := range []string{`"xml"`, "csv, json, markdown"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not contain %q", err, want)
+		}
+	}
+}
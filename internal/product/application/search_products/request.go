@@ -1,20 +1,21 @@
 package search_products
 
 import (
-	"woocommerce-mcp/kit/domain"
+	"fmt"
+	"strings"
+	"woocommerce-mcp/internal/product/domain"
+	"woocommerce-mcp/kit/searchterm"
 )
 
-// SearchRequest represents a request to search for products
+// SearchRequest represents a request to search for products. The store and
+// its credentials travel in the context the repository is built from, not
+// in the request.
 type SearchRequest struct {
-	// Required authentication parameters
-	BaseURL        string `json:"base_url" binding:"required"`
-	ConsumerKey    string `json:"consumer_key" binding:"required"`
-	ConsumerSecret string `json:"consumer_secret" binding:"required"`
-
 	// Optional search parameters
 	Search      *string `json:"search,omitempty"`
 	Category    *string `json:"category,omitempty"`
 	Tag         *string `json:"tag,omitempty"`
+	Brand       *string `json:"brand,omitempty"`
 	Status      *string `json:"status,omitempty"`
 	Type        *string `json:"type,omitempty"`
 	Featured    *string `json:"featured,omitempty"`
@@ -26,33 +27,78 @@ type SearchRequest struct {
 	Page        *string `json:"page,omitempty"`
 	Order       *string `json:"order,omitempty"`
 	OrderBy     *string `json:"orderby,omitempty"`
+
+	// StrictPriceSort re-sorts each page by numeric price when ordering by price
+	StrictPriceSort *string `json:"strict_price_sort,omitempty"`
+
+	// Modification window (ISO 8601, interpreted as GMT without an offset)
+	ModifiedAfter  *string `json:"modified_after,omitempty"`
+	ModifiedBefore *string `json:"modified_before,omitempty"`
+
+	// CatalogVisibility filters the returned page by catalog visibility
+	CatalogVisibility *string `json:"catalog_visibility,omitempty"`
+
+	// PurchasableOnly filters the returned page to purchasable products
+	PurchasableOnly *string `json:"purchasable_only,omitempty"`
+
+	// Parent limits the results to children of these comma-separated product IDs
+	Parent *string `json:"parent,omitempty"`
+
+	// SearchMode says how the search term is matched: text, sku or auto
+	SearchMode *string `json:"search_mode,omitempty"`
+
+	// SKUContains keeps the products whose SKU contains this text
+	SKUContains *string `json:"sku_contains,omitempty"`
+
+	// CategoryOperator and TagOperator say whether several categories or
+	// tags match any (or) or all (and) of them
+	CategoryOperator *string `json:"category_operator,omitempty"`
+	TagOperator      *string `json:"tag_operator,omitempty"`
+
+	// DateFormat is iso, a named format or a Go layout for the product dates
+	DateFormat *string `json:"date_format,omitempty"`
+
+	// Facets lists the comma-separated facets counted over the returned page
+	Facets *string `json:"facets,omitempty"`
+
+	// MetaKeys lists the comma-separated meta data keys to emit; nil emits
+	// every public key and an empty list emits none
+	MetaKeys *string `json:"meta_keys,omitempty"`
+
+	// IncludeMeta says whether products carry their meta data at all. Unset,
+	// they only do when MetaKeys is set.
+	IncludeMeta *string `json:"include_meta,omitempty"`
+
+	// ConfirmBroadQuery lets a search without filters return a page larger
+	// than BroadQueryMaxPerPage
+	ConfirmBroadQuery *string `json:"confirm_broad_query,omitempty"`
 }
 
 // NewSearchRequest creates a new SearchRequest
-func NewSearchRequest(baseURL, consumerKey, consumerSecret string) *SearchRequest {
-	return &SearchRequest{
-		BaseURL:        baseURL,
-		ConsumerKey:    consumerKey,
-		ConsumerSecret: consumerSecret,
-	}
+func NewSearchRequest() *SearchRequest {
+	return &SearchRequest{}
 }
 
-// Validate validates the search request
+// Validate checks every parameter of the request without searching, so a
+// malformed request can be rejected before any store request is made
 func (sr *SearchRequest) Validate() error {
-	// Validate required fields
-	if sr.BaseURL == "" {
-		return domain.NewValidationError("base_url is required")
+	if _, err := searchMode(sr); err != nil {
+		return err
 	}
-
-	if sr.ConsumerKey == "" {
-		return domain.NewValidationError("consumer_key is required")
+	if _, err := dateLayout(sr); err != nil {
+		return err
 	}
-
-	if sr.ConsumerSecret == "" {
-		return domain.NewValidationError("consumer_secret is required")
+	if _, err := parseFacets(sr); err != nil {
+		return err
 	}
-
-	return nil
+	if _, err := includeMeta(sr); err != nil {
+		return err
+	}
+	if _, err := confirmBroadQuery(sr); err != nil {
+		return err
+	}
+	_, err := requestToCriteria(sr)
+	return err
 }
 
 // SetSearch sets the search term
@@ -61,6 +107,18 @@ func (sr *SearchRequest) SetSearch(search string) *SearchRequest {
 	return sr
 }
 
+// SetSearchMode sets how the search term is matched
+func (sr *SearchRequest) SetSearchMode(searchMode string) *SearchRequest {
+	sr.SearchMode = &searchMode
+	return sr
+}
+
+// SetSKUContains sets the partial SKU filter
+func (sr *SearchRequest) SetSKUContains(skuContains string) *SearchRequest {
+	sr.SKUContains = &skuContains
+	return sr
+}
+
 // SetCategory sets the category filter
 func (sr *SearchRequest) SetCategory(category string) *SearchRequest {
 	sr.Category = &category
@@ -73,6 +131,30 @@ func (sr *SearchRequest) SetTag(tag string) *SearchRequest {
 	return sr
 }
 
+// SetCategoryOperator sets how several categories match
+func (sr *SearchRequest) SetCategoryOperator(operator string) *SearchRequest {
+	sr.CategoryOperator = &operator
+	return sr
+}
+
+// SetTagOperator sets how several tags match
+func (sr *SearchRequest) SetTagOperator(operator string) *SearchRequest {
+	sr.TagOperator = &operator
+	return sr
+}
+
+// SetBrand sets the brand filter
+func (sr *SearchRequest) SetBrand(brand string) *SearchRequest {
+	sr.Brand = &brand
+	return sr
+}
+
+// SetParent sets the parent product filter
+func (sr *SearchRequest) SetParent(parent string) *SearchRequest {
+	sr.Parent = &parent
+	return sr
+}
+
 // SetStatus sets the status filter
 func (sr *SearchRequest) SetStatus(status string) *SearchRequest {
 	sr.Status = &status
@@ -114,6 +196,17 @@ func (sr *SearchRequest) SetStockStatus(stockStatus string) *SearchRequest {
 	return sr
 }
 
+// SetModifiedRange sets the modification window filters
+func (sr *SearchRequest) SetModifiedRange(modifiedAfter, modifiedBefore string) *SearchRequest {
+	if modifiedAfter != "" {
+		sr.ModifiedAfter = &modifiedAfter
+	}
+	if modifiedBefore != "" {
+		sr.ModifiedBefore = &modifiedBefore
+	}
+	return sr
+}
+
 // SetPagination sets pagination parameters
 func (sr *SearchRequest) SetPagination(page, perPage string) *SearchRequest {
 	if page != "" {
@@ -136,19 +229,134 @@ func (sr *SearchRequest) SetSorting(orderBy, order string) *SearchRequest {
 	return sr
 }
 
-// GetBaseURL returns the base URL
-func (sr *SearchRequest) GetBaseURL() string {
-	return sr.BaseURL
+// SetCatalogVisibility sets the catalog visibility filter
+func (sr *SearchRequest) SetCatalogVisibility(catalogVisibility string) *SearchRequest {
+	sr.CatalogVisibility = &catalogVisibility
+	return sr
+}
+
+// SetPurchasableOnly sets whether the returned page keeps purchasable
+// products only
+func (sr *SearchRequest) SetPurchasableOnly(purchasableOnly string) *SearchRequest {
+	sr.PurchasableOnly = &purchasableOnly
+	return sr
 }
 
-// GetConsumerKey returns the consumer key
-func (sr *SearchRequest) GetConsumerKey() string {
-	return sr.ConsumerKey
+// SetDateFormat sets the format of the product dates
+func (sr *SearchRequest) SetDateFormat(dateFormat string) *SearchRequest {
+	sr.DateFormat = &dateFormat
+	return sr
 }
 
-// GetConsumerSecret returns the consumer secret
-func (sr *SearchRequest) GetConsumerSecret() string {
-	return sr.ConsumerSecret
+// SetFacets sets the facets counted over the returned page
+func (sr *SearchRequest) SetFacets(facets string) *SearchRequest {
+	sr.Facets = &facets
+	return sr
+}
+
+// SetMetaKeys sets the meta data keys to emit
+func (sr *SearchRequest) SetMetaKeys(metaKeys string) *SearchRequest {
+	sr.MetaKeys = &metaKeys
+	return sr
+}
+
+// SetIncludeMeta sets whether products carry their meta data
+func (sr *SearchRequest) SetIncludeMeta(includeMeta string) *SearchRequest {
+	sr.IncludeMeta = &includeMeta
+	return sr
+}
+
+// SetConfirmBroadQuery sets whether a search without filters may return a
+// large page
+func (sr *SearchRequest) SetConfirmBroadQuery(confirmBroadQuery string) *SearchRequest {
+	sr.ConfirmBroadQuery = &confirmBroadQuery
+	return sr
+}
+
+// SetStrictPriceSort sets the strict price sort flag
+func (sr *SearchRequest) SetStrictPriceSort(strictPriceSort string) *SearchRequest {
+	sr.StrictPriceSort = &strictPriceSort
+	return sr
+}
+
+// FilterSummary describes the effective filters in a short human-readable
+// form, e.g. "search='xyz', category='shoes', in stock only". Credentials
+// and pagination are never included.
+func (sr *SearchRequest) FilterSummary() string {
+	var parts []string
+	addValue := func(name, value string) {
+		if value = strings.TrimSpace(value); value != "" {
+			parts = append(parts, fmt.Sprintf("%s='%s'", name, value))
+		}
+	}
+
+	addValue("search", searchterm.Sanitize(sr.GetSearch()))
+	if skuContains := strings.TrimSpace(sr.GetSKUContains()); skuContains != "" {
+		parts = append(parts, fmt.Sprintf("SKU containing '%s'", skuContains))
+	}
+	addValue("category", sr.GetCategory())
+	if strings.EqualFold(strings.TrimSpace(sr.GetCategoryOperator()), "and") && strings.TrimSpace(sr.GetCategory()) != "" {
+		parts = append(parts, "in all categories")
+	}
+	addValue("tag", sr.GetTag())
+	if strings.EqualFold(strings.TrimSpace(sr.GetTagOperator()), "and") && strings.TrimSpace(sr.GetTag()) != "" {
+		parts = append(parts, "with all tags")
+	}
+	addValue("brand", sr.GetBrand())
+	addValue("parent", sr.GetParent())
+	addValue("status", sr.GetStatus())
+	addValue("type", sr.GetType())
+
+	switch strings.TrimSpace(sr.GetFeatured()) {
+	case "true":
+		parts = append(parts, "featured only")
+	case "false":
+		parts = append(parts, "not featured")
+	}
+	switch strings.TrimSpace(sr.GetOnSale()) {
+	case "true":
+		parts = append(parts, "on sale only")
+	case "false":
+		parts = append(parts, "not on sale")
+	}
+
+	minPrice, maxPrice := strings.TrimSpace(sr.GetMinPrice()), strings.TrimSpace(sr.GetMaxPrice())
+	switch {
+	case minPrice != "" && maxPrice != "":
+		parts = append(parts, fmt.Sprintf("price between %s and %s", minPrice, maxPrice))
+	case minPrice != "":
+		parts = append(parts, fmt.Sprintf("price from %s", minPrice))
+	case maxPrice != "":
+		parts = append(parts, fmt.Sprintf("price up to %s", maxPrice))
+	}
+
+	if modifiedAfter := strings.TrimSpace(sr.GetModifiedAfter()); modifiedAfter != "" {
+		parts = append(parts, fmt.Sprintf("modified after %s", modifiedAfter))
+	}
+	if modifiedBefore := strings.TrimSpace(sr.GetModifiedBefore()); modifiedBefore != "" {
+		parts = append(parts, fmt.Sprintf("modified before %s", modifiedBefore))
+	}
+
+	if catalogVisibility := strings.TrimSpace(sr.GetCatalogVisibility()); catalogVisibility != "" {
+		parts = append(parts, fmt.Sprintf("catalog visibility '%s'", catalogVisibility))
+	}
+	if strings.TrimSpace(sr.GetPurchasableOnly()) == "true" {
+		parts = append(parts, "purchasable only")
+	}
+
+	switch stockStatus := domain.ParseStockStatus(sr.GetStockStatus()); stockStatus {
+	case "":
+	case domain.StockStatusInStock:
+		parts = append(parts, "in stock only (backorderable products are 'onbackorder')")
+	case domain.StockStatusOutOfStock:
+		parts = append(parts, "out of stock only")
+	case domain.StockStatusOnBackorder:
+		parts = append(parts, "on backorder only (out of stock with backorders allowed)")
+	default:
+		addValue("stock_status", sr.GetStockStatus())
+	}
+
+	return strings.Join(parts, ", ")
 }
 
 // GetSearch returns the search term
@@ -175,6 +383,38 @@ func (sr *SearchRequest) GetTag() string {
 	return ""
 }
 
+// GetBrand returns the brand filter
+func (sr *SearchRequest) GetBrand() string {
+	if sr.Brand != nil {
+		return *sr.Brand
+	}
+	return ""
+}
+
+// GetSKUContains returns the partial SKU filter
+func (sr *SearchRequest) GetSKUContains() string {
+	if sr.SKUContains != nil {
+		return *sr.SKUContains
+	}
+	return ""
+}
+
+// GetSearchMode returns how the search term is matched
+func (sr *SearchRequest) GetSearchMode() string {
+	if sr.SearchMode != nil {
+		return *sr.SearchMode
+	}
+	return ""
+}
+
+// GetParent returns the parent product filter
+func (sr *SearchRequest) GetParent() string {
+	if sr.Parent != nil {
+		return *sr.Parent
+	}
+	return ""
+}
+
 // GetStatus returns the status filter
 func (sr *SearchRequest) GetStatus() string {
 	if sr.Status != nil {
@@ -231,6 +471,22 @@ func (sr *SearchRequest) GetStockStatus() string {
 	return ""
 }
 
+// GetModifiedAfter returns the lower bound of the modification window
+func (sr *SearchRequest) GetModifiedAfter() string {
+	if sr.ModifiedAfter != nil {
+		return *sr.ModifiedAfter
+	}
+	return ""
+}
+
+// GetModifiedBefore returns the upper bound of the modification window
+func (sr *SearchRequest) GetModifiedBefore() string {
+	if sr.ModifiedBefore != nil {
+		return *sr.ModifiedBefore
+	}
+	return ""
+}
+
 // GetPerPage returns the per page parameter
 func (sr *SearchRequest) GetPerPage() string {
 	if sr.PerPage != nil {
@@ -262,3 +518,77 @@ func (sr *SearchRequest) GetOrderBy() string {
 	}
 	return ""
 }
+
+// GetDateFormat returns the format of the product dates
+func (sr *SearchRequest) GetDateFormat() string {
+	if sr.DateFormat != nil {
+		return *sr.DateFormat
+	}
+	return ""
+}
+
+// GetFacets returns the facets counted over the returned page
+func (sr *SearchRequest) GetFacets() string {
+	if sr.Facets != nil {
+		return *sr.Facets
+	}
+	return ""
+}
+
+// GetMetaKeys returns the meta data keys to emit
+func (sr *SearchRequest) GetMetaKeys() string {
+	if sr.MetaKeys != nil {
+		return *sr.MetaKeys
+	}
+	return ""
+}
+
+// GetStrictPriceSort returns the strict price sort flag
+func (sr *SearchRequest) GetStrictPriceSort() string {
+	if sr.StrictPriceSort != nil {
+		return *sr.StrictPriceSort
+	}
+	return ""
+}
+
+// GetConfirmBroadQuery returns whether a search without filters may return
+// a large page
+func (sr *SearchRequest) GetConfirmBroadQuery() string {
+	if sr.ConfirmBroadQuery != nil {
+		return *sr.ConfirmBroadQuery
+	}
+	return ""
+}
+
+// GetCategoryOperator returns how several categories match
+func (sr *SearchRequest) GetCategoryOperator() string {
+	if sr.CategoryOperator != nil {
+		return *sr.CategoryOperator
+	}
+	return ""
+}
+
+// GetTagOperator returns how several tags match
+func (sr *SearchRequest) GetTagOperator() string {
+	if sr.TagOperator != nil {
+		return *sr.TagOperator
+	}
+	return ""
+}
+
+// GetCatalogVisibility returns the catalog visibility filter
+func (sr *SearchRequest) GetCatalogVisibility() string {
+	if sr.CatalogVisibility != nil {
+		return *sr.CatalogVisibility
+	}
+	return ""
+}
+
+// GetPurchasableOnly returns whether the returned page keeps purchasable
+// products only
+func (sr *SearchRequest) GetPurchasableOnly() string {
+	if sr.PurchasableOnly != nil {
+		return *sr.PurchasableOnly
+	}
+	return ""
+}
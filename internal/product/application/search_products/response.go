@@ -1,5 +1,11 @@
 package search_products
 
+import (
+	"time"
+	"woocommerce-mcp/internal/product/domain"
+	"woocommerce-mcp/kit/pagination"
+)
+
 // SearchResponse represents the response from a product search
 type SearchResponse struct {
 	Products    []*ProductDTO `json:"products"`
@@ -9,64 +15,158 @@ type SearchResponse struct {
 	TotalPages  int           `json:"total_pages"`
 	HasNext     bool          `json:"has_next"`
 	HasPrev     bool          `json:"has_prev"`
+
+	// Pagination repeats the flat fields above with the adjacent page numbers
+	Pagination *pagination.Pagination `json:"pagination"`
+
+	// PerPageCapped is set when the requested per_page exceeded the API cap
+	PerPageCapped bool `json:"per_page_capped"`
+
+	// BroadQueryCapped is set when per_page was lowered to
+	// BroadQueryMaxPerPage because the search has no filters
+	BroadQueryCapped bool `json:"broad_query_capped,omitempty"`
+
+	// PageOutOfRange is set when the requested page is past the last page;
+	// CurrentPage still echoes the requested page
+	PageOutOfRange bool `json:"page_out_of_range,omitempty"`
+
+	// SkippedCount is the number of products on the page that could not be
+	// read and were left out
+	SkippedCount int `json:"skipped_count,omitempty"`
+
+	// Notes explain how to read results that could be misleading
+	Notes []string `json:"notes,omitempty"`
+
+	// SearchMode is the match that produced the results (text or sku) when
+	// the request chose a search mode
+	SearchMode string `json:"search_mode,omitempty"`
+
+	// Facets counts the values of each requested facet over the returned
+	// page only, not the whole result set
+	Facets map[string][]*FacetValue `json:"facets,omitempty"`
+
+	// SaleSummary sums up the discounts of the returned page when the
+	// search is limited to products on sale
+	SaleSummary *SaleSummary `json:"sale_summary,omitempty"`
+
+	// Unfiltered counts the products matching without the price, stock
+	// status and on sale filters when the search found none with them
+	Unfiltered *UnfilteredCount `json:"unfiltered,omitempty"`
 }
 
-// ProductDTO represents a product data transfer object
+// UnfilteredCount counts the products an empty search would have found
+// without its most restrictive filters
+type UnfilteredCount struct {
+	// TotalCount is the number of products matching without the filters
+	TotalCount int `json:"total_count"`
+	// RemovedFilters names the filters left out, e.g. min_price or on_sale
+	RemovedFilters []string `json:"removed_filters"`
+}
+
+// SaleSummary sums up the discounts of a page of products on sale
+type SaleSummary struct {
+	// OnSaleCount is the number of products on the page that are on sale
+	OnSaleCount int `json:"on_sale_count"`
+	// DiscountedCount is the number of them with a known discount; variable
+	// products usually have no single regular price
+	DiscountedCount int `json:"discounted_count"`
+	// AverageDiscountPercent and MaxDiscountPercent cover the discounted
+	// products
+	AverageDiscountPercent float64 `json:"average_discount_percent"`
+	MaxDiscountPercent     float64 `json:"max_discount_percent"`
+}
+
+// ProductDTO represents a product data transfer object.
+// Optional fields are omitted when empty to keep the serialized product
+// compact; flags, prices, stock and counters are always emitted because
+// their false/zero values are meaningful.
 type ProductDTO struct {
 	ID                int                    `json:"id"`
 	Name              string                 `json:"name"`
 	Slug              string                 `json:"slug"`
 	Permalink         string                 `json:"permalink"`
 	DateCreated       string                 `json:"date_created"`
-	DateModified      string                 `json:"date_modified"`
+	DateCreatedGMT    string                 `json:"date_created_gmt,omitempty"`
+	DateModified      string                 `json:"date_modified,omitempty"`
+	DateModifiedGMT   string                 `json:"date_modified_gmt,omitempty"`
 	Type              string                 `json:"type"`
 	Status            string                 `json:"status"`
 	Featured          bool                   `json:"featured"`
-	CatalogVisibility string                 `json:"catalog_visibility"`
-	Description       string                 `json:"description"`
-	ShortDescription  string                 `json:"short_description"`
-	SKU               string                 `json:"sku"`
+	CatalogVisibility string                 `json:"catalog_visibility,omitempty"`
+	Description       string                 `json:"description,omitempty"`
+	ShortDescription  string                 `json:"short_description,omitempty"`
+	SKU               string                 `json:"sku,omitempty"`
 	Price             string                 `json:"price"`
 	RegularPrice      string                 `json:"regular_price"`
-	SalePrice         string                 `json:"sale_price"`
+	SalePrice         string                 `json:"sale_price,omitempty"`
 	OnSale            bool                   `json:"on_sale"`
+	DiscountPercent   float64                `json:"discount_percent,omitempty"`
+	SaleStartDate     string                 `json:"sale_start_date,omitempty"`
+	SaleEndDate       string                 `json:"sale_end_date,omitempty"`
+	SaleRemaining     string                 `json:"sale_remaining,omitempty"`
 	Purchasable       bool                   `json:"purchasable"`
 	TotalSales        int                    `json:"total_sales"`
 	Virtual           bool                   `json:"virtual"`
 	Downloadable      bool                   `json:"downloadable"`
-	ExternalURL       string                 `json:"external_url"`
-	ButtonText        string                 `json:"button_text"`
+	ExternalURL       string                 `json:"external_url,omitempty"`
+	ButtonText        string                 `json:"button_text,omitempty"`
 	TaxStatus         string                 `json:"tax_status"`
-	TaxClass          string                 `json:"tax_class"`
+	TaxClass          string                 `json:"tax_class,omitempty"`
 	ManageStock       bool                   `json:"manage_stock"`
-	StockQuantity     *int                   `json:"stock_quantity"`
+	StockManaged      bool                   `json:"stock_managed"`
+	StockQuantity     *int                   `json:"stock_quantity,omitempty"`
 	StockStatus       string                 `json:"stock_status"`
-	Backorders        string                 `json:"backorders"`
+	Availability      string                 `json:"availability,omitempty"`
+	InStock           bool                   `json:"in_stock"`
+	Available         bool                   `json:"available"`
+	Backorders        string                 `json:"backorders,omitempty"`
 	BackordersAllowed bool                   `json:"backorders_allowed"`
 	Backordered       bool                   `json:"backordered"`
-	Weight            string                 `json:"weight"`
-	Dimensions        *DimensionsDTO         `json:"dimensions"`
+	Weight            string                 `json:"weight,omitempty"`
+	WeightUnit        string                 `json:"weight_unit,omitempty"`
+	Dimensions        *DimensionsDTO         `json:"dimensions,omitempty"`
+	DimensionUnit     string                 `json:"dimension_unit,omitempty"`
 	ShippingRequired  bool                   `json:"shipping_required"`
 	ShippingTaxable   bool                   `json:"shipping_taxable"`
-	ShippingClass     string                 `json:"shipping_class"`
-	ShippingClassID   int                    `json:"shipping_class_id"`
+	ShippingClass     string                 `json:"shipping_class,omitempty"`
+	ShippingClassID   int                    `json:"shipping_class_id,omitempty"`
 	ReviewsAllowed    bool                   `json:"reviews_allowed"`
 	AverageRating     string                 `json:"average_rating"`
 	RatingCount       int                    `json:"rating_count"`
-	RelatedIDs        []int                  `json:"related_ids"`
-	UpsellIDs         []int                  `json:"upsell_ids"`
-	CrossSellIDs      []int                  `json:"cross_sell_ids"`
-	ParentID          int                    `json:"parent_id"`
-	PurchaseNote      string                 `json:"purchase_note"`
-	Categories        []*CategoryDTO         `json:"categories"`
-	Tags              []*TagDTO              `json:"tags"`
-	Images            []*ImageDTO            `json:"images"`
-	Attributes        []*AttributeDTO        `json:"attributes"`
-	DefaultAttributes []*DefaultAttributeDTO `json:"default_attributes"`
-	Variations        []int                  `json:"variations"`
-	GroupedProducts   []int                  `json:"grouped_products"`
-	MenuOrder         int                    `json:"menu_order"`
-	MetaData          []*MetaDataDTO         `json:"meta_data"`
+	RelatedIDs        []int                  `json:"related_ids,omitempty"`
+	UpsellIDs         []int                  `json:"upsell_ids,omitempty"`
+	CrossSellIDs      []int                  `json:"cross_sell_ids,omitempty"`
+	ParentID          int                    `json:"parent_id,omitempty"`
+	PurchaseNote      string                 `json:"purchase_note,omitempty"`
+	Categories        []*CategoryDTO         `json:"categories,omitempty"`
+	Tags              []*TagDTO              `json:"tags,omitempty"`
+	Images            []*ImageDTO            `json:"images,omitempty"`
+	Attributes        []*AttributeDTO        `json:"attributes,omitempty"`
+	DefaultAttributes []*DefaultAttributeDTO `json:"default_attributes,omitempty"`
+	Variations        []int                  `json:"variations,omitempty"`
+	GroupedProducts   []int                  `json:"grouped_products,omitempty"`
+	MenuOrder         int                    `json:"menu_order,omitempty"`
+	MetaData          []*MetaDataDTO         `json:"meta_data,omitempty"`
+
+	// saleStart and saleEnd are the instants of the sale schedule, kept for
+	// DescribeSale; zero when unscheduled
+	saleStart time.Time
+	saleEnd   time.Time
+}
+
+// DescribeSale sets the sale_remaining note of the product relative to
+// now. ProductToDTO leaves it out, so converted products do not depend on
+// the clock and serialize identically from call to call; handlers describe
+// the sale just before rendering.
+func (dto *ProductDTO) DescribeSale(now time.Time) {
+	dto.SaleRemaining = domain.DescribeSaleSchedule(dto.saleStart, dto.saleEnd, dto.OnSale, now)
+}
+
+// DescribeSales describes the sale of each product; see DescribeSale
+func DescribeSales(products []*ProductDTO, now time.Time) {
+	for _, product := range products {
+		product.DescribeSale(now)
+	}
 }
 
 // DimensionsDTO represents product dimensions
@@ -140,6 +240,7 @@ func NewSearchResponse(products []*ProductDTO, totalCount, currentPage, perPage
 		TotalPages:  totalPages,
 		HasNext:     currentPage < totalPages,
 		HasPrev:     currentPage > 1,
+		Pagination:  pagination.New(int64(totalCount), currentPage, perPage, totalPages),
 	}
 }
 
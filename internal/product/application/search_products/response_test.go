@@ -0,0 +1,124 @@
+package search_products
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"woocommerce-mcp/internal/product/domain"
+)
+
+func TestProductToDTOLeavesSaleRemainingToRenderTime(t *testing.T) {
+	id, _ := domain.NewProductID(1)
+	product := domain.NewProduct(id, "Sneakers")
+	product.OnSale = true
+	product.DateOnSaleToGMT = time.Date(2024, 6, 13, 12, 0, 0, 0, time.UTC)
+
+	first, _ := json.Marshal(ProductToDTO(product))
+	second, _ := json.Marshal(ProductToDTO(product))
+	if string(first) != string(second) {
+		t.Errorf("converting the same product twice differs:\n%s\n%s", first, second)
+	}
+	if strings.Contains(string(first), "sale_remaining") {
+		t.Errorf("ProductToDTO set sale_remaining: %s", first)
+	}
+	if !strings.Contains(string(first), `"sale_end_date":"2024-06-13T12:00:00Z"`) {
+		t.Errorf("sale_end_date missing: %s", first)
+	}
+
+	dto := ProductToDTO(product)
+	DescribeSales([]*ProductDTO{dto}, time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC))
+	if dto.SaleRemaining != "ends in 3 days" {
+		t.Errorf("SaleRemaining = %q, want \"ends in 3 days\"", dto.SaleRemaining)
+	}
+}
+
+func TestSparseProductSerializesCompactly(t *testing.T) {
+	id, _ := domain.NewProductID(7)
+	product := domain.NewProduct(id, "Plain Tee")
+	product.Status = domain.ProductStatusPublish
+	product.DateCreated = time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+	product.DateModified = product.DateCreated
+	product.Price, _ = domain.NewMoney(12.5, "USD")
+	product.RegularPrice, _ = domain.NewMoney(12.5, "USD")
+	product.StockStatus = domain.StockStatusInStock
+
+	// Empty optional fields are left out; flags, prices, stock and counters
+	// stay because their false/zero values are meaningful
+	const golden = `{"id":7,"name":"Plain Tee","slug":"","permalink":"","date_created":"2024-05-01T09:30:00","date_modified":"2024-05-01T09:30:00",` +
+		`"type":"simple","status":"publish","featured":false,"price":"12.50","regular_price":"12.50","on_sale":false,"purchasable":false,` +
+		`"total_sales":0,"virtual":false,"downloadable":false,"tax_status":"","manage_stock":false,"stock_managed":false,"stock_status":"instock",` +
+		`"availability":"In stock (quantity not tracked)","in_stock":true,"available":true,"backorders_allowed":false,"backordered":false,` +
+		`"shipping_required":false,"shipping_taxable":false,"reviews_allowed":false,"average_rating":"","rating_count":0}`
+
+	data, err := json.Marshal(ProductToDTO(product))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if string(data) != golden {
+		t.Errorf("sparse product serialized as\n%s\nwant\n%s", data, golden)
+	}
+
+	// Without omitempty every empty collection and string would be emitted
+	var all map[string]interface{}
+	json.Unmarshal(data, &all)
+	for _, field := range []string{"sku", "sale_price", "dimensions", "categories", "tags", "images", "attributes", "meta_data", "related_ids"} {
+		if _, ok := all[field]; ok {
+			t.Errorf("empty %s was serialized", field)
+		}
+	}
+}
+
+func TestPaginationIsNullAtTheBoundaries(t *testing.T) {
+	first, _ := json.Marshal(NewSearchResponse(nil, 25, 1, 10).Pagination)
+	if want := `{"current_page":1,"per_page":10,"total":25,"total_pages":3,"next_page":2,"prev_page":null}`; string(first) != want {
+		t.Errorf("first page pagination = %s, want %s", first, want)
+	}
+
+	last, _ := json.Marshal(NewSearchResponse(nil, 25, 3, 10).Pagination)
+	if want := `{"current_page":3,"per_page":10,"total":25,"total_pages":3,"next_page":null,"prev_page":2}`; string(last) != want {
+		t.Errorf("last page pagination = %s, want %s", last, want)
+	}
+}
+
+func TestNestedListsAreSortedCanonically(t *testing.T) {
+	id, _ := domain.NewProductID(7)
+	product := domain.NewProduct(id, "Runner")
+	product.Categories = []*domain.Category{{ID: 16, Name: "Sneakers"}, {ID: 15, Name: "Footwear"}}
+	product.Tags = []*domain.Tag{domain.NewTag(9, "sale", "sale"), domain.NewTag(3, "new", "new")}
+	product.Images = []*domain.Image{
+		{ID: 31, Src: "side.jpg", Position: 1},
+		{ID: 40, Src: "main.jpg", Position: 0},
+		{ID: 30, Src: "back.jpg", Position: 1},
+	}
+	product.MetaData = []*domain.MetaData{
+		domain.NewMetaData(5, "_color", "red"),
+		domain.NewMetaData(2, "_size", "42"),
+		domain.NewMetaData(1, "_color", "blue"),
+	}
+
+	dto := ProductToDTO(product)
+
+	var got []int
+	for _, category := range dto.Categories {
+		got = append(got, category.ID)
+	}
+	for _, tag := range dto.Tags {
+		got = append(got, tag.ID)
+	}
+	for _, image := range dto.Images {
+		got = append(got, image.ID)
+	}
+	for _, metaData := range dto.MetaData {
+		got = append(got, metaData.ID)
+	}
+	if want := []int{15, 16, 3, 9, 40, 30, 31, 1, 5, 2}; !equalIDs(got, want) {
+		t.Errorf("nested IDs = %v, want %v", got, want)
+	}
+
+	// The product itself is left in the order the API returned
+	if product.Categories[0].ID != 16 || product.Images[0].ID != 31 {
+		t.Error("ProductToDTO reordered the product's own lists")
+	}
+}
@@ -2,47 +2,226 @@ package search_products
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 	"woocommerce-mcp/internal/product/domain"
+	"woocommerce-mcp/kit/dateformat"
+	"woocommerce-mcp/kit/pagination"
+	"woocommerce-mcp/kit/safemode"
+	"woocommerce-mcp/kit/searchobserver"
+	"woocommerce-mcp/kit/searchterm"
 )
 
 // ProductSearcher handles product search operations
 type ProductSearcher struct {
 	productRepository domain.ProductRepository
+	observer          searchobserver.SearchObserver
 }
 
-// NewProductSearcher creates a new ProductSearcher
+// NewProductSearcher creates a new ProductSearcher notifying the default
+// search observer
 func NewProductSearcher(productRepository domain.ProductRepository) *ProductSearcher {
 	return &ProductSearcher{
 		productRepository: productRepository,
+		observer:          searchobserver.Default(),
 	}
 }
 
-// Execute performs the product search
+// SetObserver replaces the search observer; nil disables observation
+func (ps *ProductSearcher) SetObserver(observer searchobserver.SearchObserver) *ProductSearcher {
+	if observer == nil {
+		observer = searchobserver.Nop{}
+	}
+	ps.observer = observer
+	return ps
+}
+
+// Search modes, saying how the search term is matched
+const (
+	// SearchModeText matches the term against titles and content (default)
+	SearchModeText = "text"
+	// SearchModeSKU matches products whose SKU is exactly the term
+	SearchModeSKU = "sku"
+	// SearchModeAuto tries an exact SKU match first and falls back to a
+	// text search when no product has that SKU
+	SearchModeAuto = "auto"
+)
+
+// Execute performs the product search, notifying the search observer
 func (ps *ProductSearcher) Execute(ctx context.Context, request *SearchRequest) (*SearchResponse, error) {
-	// Validate the request
-	if err := request.Validate(); err != nil {
+	search := searchobserver.Search{Kind: searchobserver.KindProducts, Summary: request.FilterSummary()}
+	return searchobserver.Observe(ctx, ps.observer, search, func() (*SearchResponse, error) {
+		return ps.execute(ctx, request)
+	}, func(response *SearchResponse) (int, int) {
+		return len(response.Products), response.TotalCount
+	})
+}
+
+// execute performs the product search in the request's search mode
+func (ps *ProductSearcher) execute(ctx context.Context, request *SearchRequest) (*SearchResponse, error) {
+	mode, err := searchMode(request)
+	if err != nil {
+		return nil, err
+	}
+	if searchterm.Sanitize(request.GetSearch()) == "" {
+		// Without a term there is nothing to match
+		mode = ""
+	}
+	if mode == SearchModeAuto {
+		// Whether a store's text search covers SKUs depends on its
+		// configuration, so try the exact SKU first
+		response, err := ps.search(ctx, request, SearchModeSKU)
+		if err != nil || response.TotalCount > 0 {
+			return response, err
+		}
+		mode = SearchModeText
+	}
+
+	response, err := ps.search(ctx, request, mode)
+	if err != nil {
 		return nil, err
 	}
+	ps.countUnfiltered(ctx, request, mode, response)
+	return response, nil
+}
+
+// countUnfiltered tells an empty result caused by the price, stock status
+// and on sale filters from a search nothing matches at all, by counting the
+// products matching without them. It costs a single count request and only
+// runs when one of those filters was set; the count is a hint, so a failure
+// leaves the response as it is.
+func (ps *ProductSearcher) countUnfiltered(ctx context.Context, request *SearchRequest, mode string, response *SearchResponse) {
+	if response.TotalCount > 0 {
+		return
+	}
 
+	criteria, err := requestToCriteria(request)
+	if err != nil {
+		return
+	}
+	removed := criteria.RestrictiveFilters()
+	if len(removed) == 0 {
+		return
+	}
+	if mode == SearchModeSKU {
+		criteria.SetSKU(criteria.Search)
+		criteria.SetSearch("")
+	}
+
+	unfiltered := criteria.WithoutRestrictiveFilters()
+	if err := unfiltered.Validate(); err != nil {
+		return
+	}
+	count, err := ps.productRepository.Count(ctx, unfiltered)
+	if err != nil {
+		return
+	}
+
+	response.Unfiltered = &UnfilteredCount{
+		TotalCount:     int(count),
+		RemovedFilters: removed,
+	}
+}
+
+// dateLayout returns the layout of the request's date format, or "" for the
+// default output
+func dateLayout(request *SearchRequest) (string, error) {
+	layout, err := dateformat.ParseLayout(request.GetDateFormat())
+	if err != nil {
+		return "", domain.NewProductValidationError("date_format", err.Error())
+	}
+	return layout, nil
+}
+
+// searchMode returns the normalized search mode of a request
+func searchMode(request *SearchRequest) (string, error) {
+	mode := strings.ToLower(strings.TrimSpace(request.GetSearchMode()))
+	switch mode {
+	case "", SearchModeText, SearchModeSKU, SearchModeAuto:
+		return mode, nil
+	default:
+		return "", domain.NewProductValidationError("search_mode", fmt.Sprintf("unsupported search mode %q; must be auto, text or sku", mode))
+	}
+}
+
+// search runs the search with the term matched as the mode says; an empty
+// mode is a text search that is not reported in the response
+func (ps *ProductSearcher) search(ctx context.Context, request *SearchRequest, mode string) (*SearchResponse, error) {
 	// Convert request to domain search criteria
-	criteria, err := ps.requestToCriteria(request)
+	criteria, err := requestToCriteria(request)
 	if err != nil {
 		return nil, err
 	}
+	if mode == SearchModeSKU {
+		criteria.SetSKU(criteria.Search)
+		criteria.SetSearch("")
+	}
+
+	// Validation clamps per_page to the API cap; remember whether it did
+	perPageCapped := criteria.PerPage > pagination.MaxPerPage
 
 	// Validate criteria
 	if err := criteria.Validate(); err != nil {
 		return nil, err
 	}
 
-	// Search products
-	products, err := ps.productRepository.Search(ctx, criteria)
+	broadQueryNote, err := capBroadQuery(request, criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	layout, err := dateLayout(request)
+	if err != nil {
+		return nil, err
+	}
+
+	facets, err := parseFacets(request)
+	if err != nil {
+		return nil, err
+	}
+
+	withMeta, err := includeMeta(request)
+	if err != nil {
+		return nil, err
+	}
+
+	strictPriceSort := false
+	if request.StrictPriceSort != nil && *request.StrictPriceSort != "" {
+		strictPriceSort, err = strconv.ParseBool(*request.StrictPriceSort)
+		if err != nil {
+			return nil, domain.NewProductValidationError("strict_price_sort", "must be true or false")
+		}
+	}
+
+	// Search products, skipping unreadable ones when the repository supports it
+	var products []*domain.Product
+	skipped := 0
+	if partialSearcher, ok := ps.productRepository.(domain.PartialSearcher); ok {
+		products, skipped, err = partialSearcher.SearchPartial(ctx, criteria)
+	} else {
+		products, err = ps.productRepository.Search(ctx, criteria)
+	}
+	var apiErr *domain.WooCommerceAPIError
+	if errors.As(err, &apiErr) && apiErr.IsInvalidPageNumber() {
+		// Some stores reject a page past the last one instead of returning
+		// it empty; report it like an empty page
+		products, err = nil, nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to search products: %w", err)
 	}
 
+	// Variable products sort by their _price meta upstream, which can leave
+	// a page out of numeric order; re-sort within the page when requested
+	if strictPriceSort && criteria.OrderBy == "price" {
+		sortByPrice(products, criteria.Order == "desc")
+	}
+
 	// Get total count for pagination
 	totalCount, err := ps.productRepository.Count(ctx, criteria)
 	if err != nil {
@@ -52,12 +231,49 @@ func (ps *ProductSearcher) Execute(ctx context.Context, request *SearchRequest)
 	// Convert domain products to response DTOs
 	productDTOs := make([]*ProductDTO, len(products))
 	for i, product := range products {
-		productDTOs[i] = ps.productToDTO(product)
+		productDTOs[i] = ProductToDTO(product)
+		if layout != "" {
+			formatDates(productDTOs[i], product, layout)
+		}
+	}
+
+	// Units are a nicety; without them weights and dimensions stay unitless
+	if unitsProvider, ok := ps.productRepository.(domain.MeasurementUnitsProvider); ok && len(productDTOs) > 0 {
+		if units, err := unitsProvider.MeasurementUnits(ctx); err == nil {
+			SetMeasurementUnits(productDTOs, units)
+		}
 	}
 
 	// Calculate pagination info
 	totalPages := int((totalCount + int64(criteria.PerPage) - 1) / int64(criteria.PerPage))
 
+	// Meta data is left out unless asked for; plugins keep private state in
+	// it, which is noise to callers even then
+	metaKeys := parseMetaKeys(request)
+	for _, dto := range productDTOs {
+		if withMeta {
+			dto.MetaData = filterMetaData(dto.MetaData, metaKeys)
+		} else {
+			dto.MetaData = nil
+		}
+	}
+
+	var facetCounts map[string][]*FacetValue
+	if len(facets) > 0 {
+		facetCounts = countFacets(productDTOs, facets)
+	}
+
+	// Bargain searches get their discounts summed up
+	var saleSummary *SaleSummary
+	if criteria.OnSale != nil && *criteria.OnSale {
+		saleSummary = summarizeSale(products)
+	}
+
+	notes := ps.apiVersionNotes(ps.redirectNotes(searchNotes(criteria)))
+	if broadQueryNote != "" {
+		notes = append(notes, broadQueryNote)
+	}
+
 	return &SearchResponse{
 		Products:    productDTOs,
 		TotalCount:  int(totalCount),
@@ -66,16 +282,73 @@ func (ps *ProductSearcher) Execute(ctx context.Context, request *SearchRequest)
 		TotalPages:  totalPages,
 		HasNext:     criteria.Page < totalPages,
 		HasPrev:     criteria.Page > 1,
+		Pagination:  pagination.New(totalCount, criteria.Page, criteria.PerPage, totalPages),
+
+		PerPageCapped:    perPageCapped,
+		BroadQueryCapped: broadQueryNote != "",
+		PageOutOfRange:   criteria.Page > max(totalPages, 1),
+		SkippedCount:     skipped,
+		Notes:            notes,
+		SearchMode:       mode,
+		Facets:           facetCounts,
+		SaleSummary:      saleSummary,
 	}, nil
 }
 
+// searchNotes explains results that could be misread given the criteria
+func searchNotes(criteria *domain.SearchCriteria) []string {
+	var notes []string
+	if criteria.OrderBy == "menu_order" && !criteria.HasTaxonomyFilter() {
+		notes = append(notes, "orderby=menu_order without a category, tag or brand filter follows the store's global catalog order, not the order of any one category")
+	}
+	return notes
+}
+
+// redirectNotes adds a note when the store redirected the search to another
+// base URL, e.g. from http:// to https://. Credentials may not survive such
+// a redirect, so the caller is told once to use the final base URL.
+func (ps *ProductSearcher) redirectNotes(notes []string) []string {
+	reporter, ok := ps.productRepository.(domain.RedirectReporter)
+	if !ok {
+		return notes
+	}
+	if target, redirected := reporter.RedirectedBaseURL(); redirected {
+		notes = append(notes, fmt.Sprintf("store redirected to %s; consider using that base_url directly", target))
+	}
+	return notes
+}
+
+// apiVersionNotes adds a note when the store lacks the default REST API
+// version and the search fell back to an older one
+func (ps *ProductSearcher) apiVersionNotes(notes []string) []string {
+	reporter, ok := ps.productRepository.(domain.APIVersionReporter)
+	if !ok {
+		return notes
+	}
+	if version, negotiated := reporter.APIVersion(); negotiated {
+		notes = append(notes, fmt.Sprintf("store has no WooCommerce wc/v3 REST API; results come from wc/%s", version))
+	}
+	return notes
+}
+
 // requestToCriteria converts SearchRequest to domain SearchCriteria
-func (ps *ProductSearcher) requestToCriteria(request *SearchRequest) (*domain.SearchCriteria, error) {
+func requestToCriteria(request *SearchRequest) (*domain.SearchCriteria, error) {
 	criteria := domain.NewSearchCriteria()
 
 	// Set search term
-	if request.Search != nil && *request.Search != "" {
-		criteria.SetSearch(*request.Search)
+	if request.Search != nil {
+		criteria.SetSearch(searchterm.Sanitize(*request.Search))
+	}
+
+	// Set partial SKU, which is searched for in place of a search term
+	if skuContains := strings.TrimSpace(request.GetSKUContains()); skuContains != "" {
+		if criteria.Search != "" {
+			return nil, domain.NewProductValidationError("sku_contains", "cannot be combined with search")
+		}
+		if mode, err := searchMode(request); err == nil && mode != "" && mode != SearchModeText {
+			return nil, domain.NewProductValidationError("sku_contains", "cannot be combined with search_mode "+mode)
+		}
+		criteria.SetSKUContains(skuContains)
 	}
 
 	// Set category
@@ -88,6 +361,24 @@ func (ps *ProductSearcher) requestToCriteria(request *SearchRequest) (*domain.Se
 		criteria.SetTag(*request.Tag)
 	}
 
+	// Set brand
+	if request.Brand != nil && *request.Brand != "" {
+		criteria.SetBrand(*request.Brand)
+	}
+
+	// Set parent products
+	if request.Parent != nil && strings.TrimSpace(*request.Parent) != "" {
+		var parentIDs []int
+		for _, value := range strings.Split(*request.Parent, ",") {
+			id, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil || id < 1 {
+				return nil, domain.NewProductValidationError("parent", "must be a comma-separated list of positive product IDs")
+			}
+			parentIDs = append(parentIDs, id)
+		}
+		criteria.SetParent(parentIDs)
+	}
+
 	// Set status
 	if request.Status != nil && *request.Status != "" {
 		status := domain.ProductStatus(*request.Status)
@@ -97,31 +388,51 @@ func (ps *ProductSearcher) requestToCriteria(request *SearchRequest) (*domain.Se
 		criteria.SetStatus(status)
 	}
 
-	// Set type
+	// Safe mode only ever returns published products
+	if safemode.Enabled() {
+		if status := safemode.UnpublishedStatus(request.GetStatus()); status != "" {
+			return nil, domain.NewProductValidationError("status", safemode.Rejection(fmt.Sprintf("status '%s'", status)))
+		}
+		criteria.SetStatus(domain.ProductStatusPublish)
+	}
+
+	// Set type (comma-separated values match any of the given types)
 	if request.Type != nil && *request.Type != "" {
-		productType := domain.ProductType(*request.Type)
-		if !productType.IsValid() {
-			return nil, domain.NewInvalidProductTypeError(*request.Type)
+		var productTypes []domain.ProductType
+		seen := make(map[domain.ProductType]bool)
+		for _, value := range strings.Split(*request.Type, ",") {
+			productType := domain.ProductType(strings.TrimSpace(value))
+			if !productType.IsValid() {
+				return nil, domain.NewInvalidProductTypeError(strings.TrimSpace(value))
+			}
+			if !seen[productType] {
+				seen[productType] = true
+				productTypes = append(productTypes, productType)
+			}
 		}
-		criteria.SetType(productType)
+		criteria.SetTypes(productTypes)
 	}
 
 	// Set featured
 	if request.Featured != nil {
-		featured, err := strconv.ParseBool(*request.Featured)
+		featured, err := parseTriState(*request.Featured)
 		if err != nil {
-			return nil, domain.NewProductValidationError("featured", "must be true or false")
+			return nil, domain.NewProductValidationError("featured", "must be any, true or false")
+		}
+		if featured != nil {
+			criteria.SetFeatured(*featured)
 		}
-		criteria.SetFeatured(featured)
 	}
 
 	// Set on sale
 	if request.OnSale != nil {
-		onSale, err := strconv.ParseBool(*request.OnSale)
+		onSale, err := parseTriState(*request.OnSale)
 		if err != nil {
-			return nil, domain.NewProductValidationError("on_sale", "must be true or false")
+			return nil, domain.NewProductValidationError("on_sale", "must be any, true or false")
+		}
+		if onSale != nil {
+			criteria.SetOnSale(*onSale)
 		}
-		criteria.SetOnSale(onSale)
 	}
 
 	// Set price range
@@ -146,41 +457,104 @@ func (ps *ProductSearcher) requestToCriteria(request *SearchRequest) (*domain.Se
 
 	// Set stock status
 	if request.StockStatus != nil && *request.StockStatus != "" {
-		stockStatus := domain.StockStatus(*request.StockStatus)
+		stockStatus := domain.ParseStockStatus(*request.StockStatus)
 		if !stockStatus.IsValid() {
 			return nil, domain.NewInvalidStockStatusError(*request.StockStatus)
 		}
 		criteria.SetStockStatus(stockStatus)
 	}
 
+	// Set catalog visibility
+	if request.CatalogVisibility != nil && *request.CatalogVisibility != "" {
+		visibility := domain.CatalogVisibility(strings.ToLower(strings.TrimSpace(*request.CatalogVisibility)))
+		if !visibility.IsValid() {
+			return nil, domain.NewProductValidationError("catalog_visibility", "must be visible, catalog, search or hidden")
+		}
+		criteria.SetCatalogVisibility(visibility)
+	}
+
+	// Keep purchasable products only
+	if purchasableOnly := strings.TrimSpace(request.GetPurchasableOnly()); purchasableOnly != "" {
+		keep, err := strconv.ParseBool(purchasableOnly)
+		if err != nil {
+			return nil, domain.NewProductValidationError("purchasable_only", "must be true or false")
+		}
+		criteria.SetPurchasableOnly(keep)
+	}
+
+	// Set how several categories and tags match
+	categoryOperator, err := parseTermOperator("category_operator", request.GetCategoryOperator())
+	if err != nil {
+		return nil, err
+	}
+	tagOperator, err := parseTermOperator("tag_operator", request.GetTagOperator())
+	if err != nil {
+		return nil, err
+	}
+	criteria.SetTermOperators(categoryOperator, tagOperator)
+
+	// Set modification window
+	var modifiedAfter, modifiedBefore *time.Time
+	if request.ModifiedAfter != nil && *request.ModifiedAfter != "" {
+		after, err := parseGMTDateTime(*request.ModifiedAfter)
+		if err != nil {
+			return nil, domain.NewProductValidationError("modified_after", "must be an ISO 8601 date or date-time")
+		}
+		modifiedAfter = &after
+	}
+	if request.ModifiedBefore != nil && *request.ModifiedBefore != "" {
+		before, err := parseGMTDateTime(*request.ModifiedBefore)
+		if err != nil {
+			return nil, domain.NewProductValidationError("modified_before", "must be an ISO 8601 date or date-time")
+		}
+		modifiedBefore = &before
+	}
+	if modifiedAfter != nil && modifiedBefore != nil && !modifiedAfter.Before(*modifiedBefore) {
+		return nil, domain.NewProductValidationError("modified_after", "must be earlier than modified_before")
+	}
+	if modifiedAfter != nil || modifiedBefore != nil {
+		criteria.SetModifiedRange(modifiedAfter, modifiedBefore)
+	}
+
 	// Set pagination
 	page := 1
 	perPage := 10
 
 	if request.Page != nil && *request.Page != "" {
-		p, err := strconv.Atoi(*request.Page)
-		if err != nil || p < 1 {
-			return nil, domain.NewProductValidationError("page", "must be a positive integer")
+		p, err := pagination.ParsePage(*request.Page)
+		if err != nil {
+			return nil, domain.NewProductValidationError("page", err.Error())
 		}
 		page = p
 	}
 
 	if request.PerPage != nil && *request.PerPage != "" {
-		pp, err := strconv.Atoi(*request.PerPage)
-		if err != nil || pp < 1 {
-			return nil, domain.NewProductValidationError("per_page", "must be a positive integer")
+		pp, err := pagination.ParsePerPage(*request.PerPage)
+		if err != nil {
+			return nil, domain.NewProductValidationError("per_page", err.Error())
 		}
 		perPage = pp
 	}
 
 	criteria.SetPagination(page, perPage)
 
-	// Set sorting
+	// Set sorting. Incremental syncs default to the oldest changes first so
+	// the last product seen marks where the next sync starts.
 	orderBy := "date"
 	order := "desc"
+	if modifiedAfter != nil {
+		orderBy = "modified"
+		order = "asc"
+	}
 
 	if request.OrderBy != nil && *request.OrderBy != "" {
 		orderBy = *request.OrderBy
+
+		// The manual catalog order runs from the lowest menu_order up, so
+		// default to ascending rather than the API's descending
+		if orderBy == "menu_order" {
+			order = "asc"
+		}
 	}
 
 	if request.Order != nil && *request.Order != "" {
@@ -192,8 +566,157 @@ func (ps *ProductSearcher) requestToCriteria(request *SearchRequest) (*domain.Se
 	return criteria, nil
 }
 
-// productToDTO converts domain Product to ProductDTO
-func (ps *ProductSearcher) productToDTO(product *domain.Product) *ProductDTO {
+// parseTriState parses an any/true/false filter value; "any" and an empty
+// value mean no filter and yield nil
+func parseTriState(value string) (*bool, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" || value == "any" {
+		return nil, nil
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+// gmtDateTimeLayouts are the accepted formats of modification window bounds
+var gmtDateTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// parseTermOperator parses a category or tag operator; an empty value means
+// the default, or
+func parseTermOperator(field, value string) (domain.TermOperator, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return "", nil
+	}
+	operator := domain.TermOperator(value)
+	if !operator.IsValid() {
+		return "", domain.NewProductValidationError(field, "must be or or and")
+	}
+	return operator, nil
+}
+
+// parseGMTDateTime parses an ISO 8601 date or date-time. Values without an
+// offset are taken as GMT, matching WooCommerce's *_gmt fields.
+func parseGMTDateTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	var lastErr error
+	for _, layout := range gmtDateTimeLayouts {
+		parsed, err := time.Parse(layout, value)
+		if err == nil {
+			return parsed.UTC(), nil
+		}
+		lastErr = err
+	}
+	return time.Time{}, lastErr
+}
+
+// sortByPrice stably orders products by numeric price, keeping products
+// without a price at the end regardless of direction
+func sortByPrice(products []*domain.Product, descending bool) {
+	sort.SliceStable(products, func(i, j int) bool {
+		left, right := products[i].Price, products[j].Price
+		if left == nil || right == nil {
+			return left != nil && right == nil
+		}
+		if descending {
+			return left.Amount() > right.Amount()
+		}
+		return left.Amount() < right.Amount()
+	})
+}
+
+// SetMeasurementUnits annotates the weight and dimensions of product DTOs
+// with the store's units
+func SetMeasurementUnits(dtos []*ProductDTO, units *domain.MeasurementUnits) {
+	for _, dto := range dtos {
+		if dto.Weight != "" {
+			dto.WeightUnit = units.Weight
+		}
+		if dto.Dimensions != nil {
+			dto.DimensionUnit = units.Dimension
+		}
+	}
+}
+
+// summarizeSale sums up the discounts of a page of products
+func summarizeSale(products []*domain.Product) *SaleSummary {
+	summary := &SaleSummary{}
+	total := 0.0
+	for _, product := range products {
+		if !product.OnSale {
+			continue
+		}
+		summary.OnSaleCount++
+		discount, ok := product.DiscountPercent()
+		if !ok {
+			continue
+		}
+		summary.DiscountedCount++
+		total += discount
+		summary.MaxDiscountPercent = max(summary.MaxDiscountPercent, discount)
+	}
+
+	if summary.DiscountedCount > 0 {
+		summary.AverageDiscountPercent = roundPercent(total / float64(summary.DiscountedCount))
+		summary.MaxDiscountPercent = roundPercent(summary.MaxDiscountPercent)
+	}
+	return summary
+}
+
+// roundPercent rounds a percentage to one decimal
+func roundPercent(percent float64) float64 {
+	return math.Round(percent*10) / 10
+}
+
+// formatDates formats the dates of a product DTO with a Go layout instead of
+// the default output. GMT dates are formatted in UTC. Store-local dates
+// carry the store's offset, derived from their GMT counterpart, so layouts
+// with an offset stay correct; without a GMT date they are formatted as UTC.
+func formatDates(dto *ProductDTO, product *domain.Product, layout string) {
+	dto.DateCreated = storeTime(product.DateCreated, product.DateCreatedGMT).Format(layout)
+	dto.DateModified = storeTime(product.DateModified, product.DateModifiedGMT).Format(layout)
+	if !product.DateCreatedGMT.IsZero() {
+		dto.DateCreatedGMT = product.DateCreatedGMT.UTC().Format(layout)
+	}
+	if !product.DateModifiedGMT.IsZero() {
+		dto.DateModifiedGMT = product.DateModifiedGMT.UTC().Format(layout)
+	}
+	if start := product.SaleStart(); !start.IsZero() {
+		dto.SaleStartDate = saleTime(start, product.DateOnSaleFrom).Format(layout)
+	}
+	if end := product.SaleEnd(); !end.IsZero() {
+		dto.SaleEndDate = saleTime(end, product.DateOnSaleTo).Format(layout)
+	}
+}
+
+// saleTime places the instant of a sale date in the store's offset when the
+// store-local date is known too, and in UTC otherwise
+func saleTime(instant, local time.Time) time.Time {
+	if local.IsZero() || local.Equal(instant) {
+		return instant.UTC()
+	}
+	return storeTime(local, instant)
+}
+
+// storeTime places a store-local wall-clock time in the store's offset,
+// which is its difference from the same instant in GMT
+func storeTime(local, gmt time.Time) time.Time {
+	if local.IsZero() || gmt.IsZero() {
+		return local
+	}
+	offset := local.Sub(gmt).Round(time.Minute)
+	return gmt.In(time.FixedZone("", int(offset.Seconds())))
+}
+
+// ProductToDTO converts domain Product to ProductDTO
+func ProductToDTO(product *domain.Product) *ProductDTO {
 	dto := &ProductDTO{
 		ID:                product.ID.Value(),
 		Name:              product.Name,
@@ -218,8 +741,11 @@ func (ps *ProductSearcher) productToDTO(product *domain.Product) *ProductDTO {
 		TaxStatus:         product.TaxStatus,
 		TaxClass:          product.TaxClass,
 		ManageStock:       product.ManageStock,
-		StockQuantity:     product.StockQuantity,
+		StockManaged:      product.StockManaged(),
 		StockStatus:       string(product.StockStatus),
+		Availability:      product.Availability(),
+		InStock:           product.InStock(),
+		Available:         product.Available(),
 		Backorders:        product.Backorders,
 		BackordersAllowed: product.BackordersAllowed,
 		Backordered:       product.Backordered,
@@ -241,6 +767,20 @@ func (ps *ProductSearcher) productToDTO(product *domain.Product) *ProductDTO {
 		MenuOrder:         product.MenuOrder,
 	}
 
+	// GMT dates carry an explicit Z so they compare across store timezones;
+	// the local dates keep the store's wall-clock time
+	if !product.DateCreatedGMT.IsZero() {
+		dto.DateCreatedGMT = product.DateCreatedGMT.UTC().Format(time.RFC3339)
+	}
+	if !product.DateModifiedGMT.IsZero() {
+		dto.DateModifiedGMT = product.DateModifiedGMT.UTC().Format(time.RFC3339)
+	}
+
+	// Only report a quantity the store counts
+	if product.StockManaged() {
+		dto.StockQuantity = product.StockQuantity
+	}
+
 	// Convert price
 	if product.Price != nil {
 		priceStr := fmt.Sprintf("%.2f", product.Price.Amount())
@@ -258,9 +798,22 @@ func (ps *ProductSearcher) productToDTO(product *domain.Product) *ProductDTO {
 		salePriceStr := fmt.Sprintf("%.2f", product.SalePrice.Amount())
 		dto.SalePrice = salePriceStr
 	}
+	if discount, ok := product.DiscountPercent(); ok {
+		dto.DiscountPercent = roundPercent(discount)
+	}
+
+	// Sale schedule, so callers can tell how long a deal lasts. The time
+	// remaining depends on the clock and is only set by DescribeSale.
+	dto.saleStart, dto.saleEnd = product.SaleStart(), product.SaleEnd()
+	if !dto.saleStart.IsZero() {
+		dto.SaleStartDate = saleTime(dto.saleStart, product.DateOnSaleFrom).Format(time.RFC3339)
+	}
+	if !dto.saleEnd.IsZero() {
+		dto.SaleEndDate = saleTime(dto.saleEnd, product.DateOnSaleTo).Format(time.RFC3339)
+	}
 
 	// Convert dimensions
-	if product.Dimensions != nil {
+	if d := product.Dimensions; d != nil && (d.Length != "" || d.Width != "" || d.Height != "") {
 		dto.Dimensions = &DimensionsDTO{
 			Length: product.Dimensions.Length,
 			Width:  product.Dimensions.Width,
@@ -335,5 +888,34 @@ func (ps *ProductSearcher) productToDTO(product *domain.Product) *ProductDTO {
 		}
 	}
 
+	sortNested(dto)
 	return dto
 }
+
+// sortNested puts the nested lists of a product in a canonical order, since
+// the API does not keep their order stable: categories and tags by ID,
+// images by position then ID and meta data by key then ID. Identical
+// products then serialize identically, so outputs can be compared, cached
+// and hashed.
+func sortNested(dto *ProductDTO) {
+	sort.SliceStable(dto.Categories, func(i, j int) bool {
+		return dto.Categories[i].ID < dto.Categories[j].ID
+	})
+	sort.SliceStable(dto.Tags, func(i, j int) bool {
+		return dto.Tags[i].ID < dto.Tags[j].ID
+	})
+	sort.SliceStable(dto.Images, func(i, j int) bool {
+		left, right := dto.Images[i], dto.Images[j]
+		if left.Position != right.Position {
+			return left.Position < right.Position
+		}
+		return left.ID < right.ID
+	})
+	sort.SliceStable(dto.MetaData, func(i, j int) bool {
+		left, right := dto.MetaData[i], dto.MetaData[j]
+		if left.Key != right.Key {
+			return left.Key < right.Key
+		}
+		return left.ID < right.ID
+	})
+}
@@ -0,0 +1,800 @@
+package search_products
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"woocommerce-mcp/internal/product/domain"
+	"woocommerce-mcp/kit/searchobserver"
+)
+
+// stubRepository answers every search with its products and records the
+// criteria it was asked for
+type stubRepository struct {
+	domain.ProductRepository
+	products []*domain.Product
+	searches []*domain.SearchCriteria
+	counts   []*domain.SearchCriteria
+}
+
+func (r *stubRepository) Search(ctx context.Context, criteria *domain.SearchCriteria) ([]*domain.Product, error) {
+	r.searches = append(r.searches, criteria)
+	return append([]*domain.Product(nil), r.products...), nil
+}
+
+func (r *stubRepository) Count(ctx context.Context, criteria *domain.SearchCriteria) (int64, error) {
+	r.counts = append(r.counts, criteria)
+	return int64(len(r.products)), nil
+}
+
+// newProduct creates a published simple product with an ID, name and price
+func newProduct(id int, name string, price float64) *domain.Product {
+	productID, _ := domain.NewProductID(id)
+	product := domain.NewProduct(productID, name)
+	product.Status = domain.ProductStatusPublish
+	product.Price, _ = domain.NewMoney(price, "USD")
+	return product
+}
+
+// productIDs returns the IDs of the products of a response, in order
+func productIDs(response *SearchResponse) []int {
+	ids := make([]int, len(response.Products))
+	for i, product := range response.Products {
+		ids[i] = product.ID
+	}
+	return ids
+}
+
+// equalIDs reports whether two ID lists are equal
+func equalIDs(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestStrictPriceSortOrdersThePageNumerically(t *testing.T) {
+	// The store sorted the variable products by their _price meta
+	variable := func(id int, price float64) *domain.Product {
+		product := newProduct(id, "Variable", price)
+		product.Type = domain.ProductTypeVariable
+		return product
+	}
+	unpriced := newProduct(5, "Unpriced", 0)
+	unpriced.Price = nil
+	repository := &stubRepository{products: []*domain.Product{
+		variable(1, 25), newProduct(2, "Simple", 10), unpriced, variable(3, 5), newProduct(4, "Simple", 20),
+	}}
+
+	tests := []struct {
+		order, strict string
+		want          []int
+	}{
+		{"asc", "true", []int{3, 2, 4, 1, 5}},
+		{"desc", "true", []int{1, 4, 2, 3, 5}},
+		{"asc", "", []int{1, 2, 5, 3, 4}},
+	}
+	for _, tt := range tests {
+		request := NewSearchRequest().SetSorting("price", tt.order)
+		if tt.strict != "" {
+			request.SetStrictPriceSort(tt.strict)
+		}
+		response, err := NewProductSearcher(repository).Execute(context.Background(), request)
+		if err != nil {
+			t.Fatalf("order=%s strict=%q: %v", tt.order, tt.strict, err)
+		}
+		if got := productIDs(response); !equalIDs(got, tt.want) {
+			t.Errorf("order=%s strict=%q: got %v, want %v", tt.order, tt.strict, got, tt.want)
+		}
+	}
+}
+
+func TestStrictPriceSortMustBeABoolean(t *testing.T) {
+	request := NewSearchRequest().SetSorting("price", "asc").SetStrictPriceSort("yes please")
+	_, err := NewProductSearcher(&stubRepository{}).Execute(context.Background(), request)
+	if field := validationField(err); field != "strict_price_sort" {
+		t.Errorf("got error %v, want a strict_price_sort validation error", err)
+	}
+}
+
+func TestMenuOrderDefaultsToAscending(t *testing.T) {
+	for order, want := range map[string]string{"": "asc", "desc": "desc"} {
+		repository := &stubRepository{}
+		request := NewSearchRequest().SetSorting("menu_order", order)
+		if _, err := NewProductSearcher(repository).Execute(context.Background(), request); err != nil {
+			t.Fatalf("order=%q: %v", order, err)
+		}
+		if criteria := repository.searches[0]; criteria.OrderBy != "menu_order" || criteria.Order != want {
+			t.Errorf("order=%q: sorting = %s %s, want menu_order %s", order, criteria.OrderBy, criteria.Order, want)
+		}
+	}
+}
+
+func TestMenuOrderWithoutATaxonomyIsNoted(t *testing.T) {
+	tests := []struct {
+		name    string
+		request *SearchRequest
+		noted   bool
+	}{
+		{"no filter", NewSearchRequest().SetSorting("menu_order", ""), true},
+		{"search only", NewSearchRequest().SetSearch("shoes").SetSorting("menu_order", ""), true},
+		{"category", NewSearchRequest().SetCategory("15").SetSorting("menu_order", ""), false},
+		{"tag", NewSearchRequest().SetTag("3").SetSorting("menu_order", ""), false},
+		{"brand", NewSearchRequest().SetBrand("7").SetSorting("menu_order", ""), false},
+		{"other order", NewSearchRequest().SetSorting("title", ""), false},
+	}
+	for _, tt := range tests {
+		response, err := NewProductSearcher(&stubRepository{}).Execute(context.Background(), tt.request)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		noted := len(response.Notes) == 1 && strings.Contains(response.Notes[0], "global catalog order")
+		if noted != tt.noted || (!tt.noted && len(response.Notes) != 0) {
+			t.Errorf("%s: notes = %v, want a global catalog order note: %v", tt.name, response.Notes, tt.noted)
+		}
+	}
+}
+
+func TestParentIDsAreValidated(t *testing.T) {
+	repository := &stubRepository{}
+	request := NewSearchRequest().SetParent(" 12, 7 ")
+	if _, err := NewProductSearcher(repository).Execute(context.Background(), request); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if got := repository.searches[0].Parent; !equalIDs(got, []int{12, 7}) {
+		t.Errorf("parent = %v, want [12 7]", got)
+	}
+
+	for _, parent := range []string{"abc", "12,", "0", "-3", "12;7"} {
+		_, err := NewProductSearcher(&stubRepository{}).Execute(context.Background(), NewSearchRequest().SetParent(parent))
+		if field := validationField(err); field != "parent" {
+			t.Errorf("parent=%q: got error %v, want a parent validation error", parent, err)
+		}
+	}
+}
+
+func TestSearchModeIsValidated(t *testing.T) {
+	request := NewSearchRequest().SetSearch("shoes").SetSearchMode("fuzzy")
+	_, err := NewProductSearcher(&stubRepository{}).Execute(context.Background(), request)
+	if field := validationField(err); field != "search_mode" {
+		t.Errorf("got error %v, want a search_mode validation error", err)
+	}
+}
+
+// invalidPageRepository rejects every search like stores that refuse a
+// page past the last one
+type invalidPageRepository struct {
+	stubRepository
+}
+
+func (r *invalidPageRepository) Search(ctx context.Context, criteria *domain.SearchCriteria) ([]*domain.Product, error) {
+	return nil, domain.NewWooCommerceAPIError(400, "Invalid page number.", domain.InvalidPageNumberCode)
+}
+
+func TestPagesPastTheLastAreFlagged(t *testing.T) {
+	var products []*domain.Product
+	for id := 1; id <= 5; id++ {
+		products = append(products, newProduct(id, "Sneakers", 10))
+	}
+
+	tests := []struct {
+		name       string
+		repository domain.ProductRepository
+		page       string
+		outOfRange bool
+	}{
+		{"first page", &stubRepository{products: products}, "1", false},
+		{"last page", &stubRepository{products: products}, "3", false},
+		{"past the last page", &stubRepository{products: products}, "50", true},
+		{"rejected page", &invalidPageRepository{stubRepository{products: products}}, "50", true},
+		{"empty catalog", &stubRepository{}, "1", false},
+	}
+	for _, tt := range tests {
+		response, err := NewProductSearcher(tt.repository).Execute(context.Background(), NewSearchRequest().SetPagination(tt.page, "2"))
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if response.PageOutOfRange != tt.outOfRange {
+			t.Errorf("%s: page_out_of_range = %v, want %v", tt.name, response.PageOutOfRange, tt.outOfRange)
+		}
+		if response.CurrentPage != mustAtoi(t, tt.page) {
+			t.Errorf("%s: current_page = %d, want the requested %s", tt.name, response.CurrentPage, tt.page)
+		}
+	}
+}
+
+// mustAtoi parses a number of a test table
+func mustAtoi(t *testing.T, value string) int {
+	t.Helper()
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestTermOperatorsAreValidated(t *testing.T) {
+	repository := &stubRepository{}
+	request := NewSearchRequest().SetTag("10,20").SetTagOperator(" AND ")
+	if _, err := NewProductSearcher(repository).Execute(context.Background(), request); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if criteria := repository.searches[0]; criteria.TagOperator != domain.TermOperatorAnd || criteria.CategoryOperator != "" {
+		t.Errorf("operators = %q/%q, want tag and only", criteria.CategoryOperator, criteria.TagOperator)
+	}
+
+	for _, request := range []*SearchRequest{
+		NewSearchRequest().SetCategory("15,16").SetCategoryOperator("xor"),
+		NewSearchRequest().SetTag("10,20").SetTagOperator("all"),
+	} {
+		if _, err := NewProductSearcher(&stubRepository{}).Execute(context.Background(), request); err == nil || !strings.Contains(err.Error(), "operator") {
+			t.Errorf("got error %v, want an operator error", err)
+		}
+	}
+}
+
+// redirectedRepository reports a store redirect once, like the WooCommerce
+// repository
+type redirectedRepository struct {
+	stubRepository
+	target string
+}
+
+func (r *redirectedRepository) RedirectedBaseURL() (string, bool) {
+	target := r.target
+	r.target = ""
+	return target, target != ""
+}
+
+func TestStoreRedirectIsNotedOnce(t *testing.T) {
+	repository := &redirectedRepository{
+		stubRepository: stubRepository{products: []*domain.Product{newProduct(1, "Sneakers", 10)}},
+		target:         "https://shop.example",
+	}
+
+	response, err := NewProductSearcher(repository).Execute(context.Background(), NewSearchRequest())
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if len(response.Notes) != 1 || !strings.Contains(response.Notes[0], "store redirected to https://shop.example") {
+		t.Errorf("notes = %v, want the redirect noted", response.Notes)
+	}
+	if len(response.Products) != 1 {
+		t.Errorf("got %d products, want the search completed", len(response.Products))
+	}
+
+	response, err = NewProductSearcher(repository).Execute(context.Background(), NewSearchRequest())
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if len(response.Notes) != 0 {
+		t.Errorf("notes = %v on the second search, want none", response.Notes)
+	}
+}
+
+// validationField returns the field of a product validation error, or ""
+func validationField(err error) string {
+	var validationErr *domain.ProductValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr.Field
+	}
+	return ""
+}
+
+func TestModifiedAfterDefaultsToOldestChangeFirst(t *testing.T) {
+	repository := &stubRepository{}
+	request := NewSearchRequest().SetModifiedRange("2024-05-01T10:30:00+02:00", "2024-05-02")
+	if _, err := NewProductSearcher(repository).Execute(context.Background(), request); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	criteria := repository.searches[0]
+	if want := time.Date(2024, 5, 1, 8, 30, 0, 0, time.UTC); criteria.ModifiedAfter == nil || !criteria.ModifiedAfter.Equal(want) {
+		t.Errorf("modified_after = %v, want %v", criteria.ModifiedAfter, want)
+	}
+	if want := time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC); criteria.ModifiedBefore == nil || !criteria.ModifiedBefore.Equal(want) {
+		t.Errorf("modified_before = %v, want %v", criteria.ModifiedBefore, want)
+	}
+	if criteria.OrderBy != "modified" || criteria.Order != "asc" {
+		t.Errorf("sorting = %s %s, want modified asc", criteria.OrderBy, criteria.Order)
+	}
+}
+
+func TestModifiedRangeIsValidated(t *testing.T) {
+	tests := []struct {
+		after, before string
+		field         string
+	}{
+		{"yesterday", "", "modified_after"},
+		{"", "05/02/2024", "modified_before"},
+		{"2024-05-02", "2024-05-01", "modified_after"},
+	}
+	for _, tt := range tests {
+		request := NewSearchRequest().SetModifiedRange(tt.after, tt.before)
+		_, err := NewProductSearcher(&stubRepository{}).Execute(context.Background(), request)
+		if field := validationField(err); field != tt.field {
+			t.Errorf("after=%q before=%q: got error %v, want a %s validation error", tt.after, tt.before, err, tt.field)
+		}
+	}
+}
+
+func TestDateModifiedGMTIsExposed(t *testing.T) {
+	product := newProduct(1, "Sneakers", 10)
+	product.DateModifiedGMT = time.Date(2024, 5, 1, 8, 30, 0, 0, time.UTC)
+
+	if got := ProductToDTO(product).DateModifiedGMT; got != "2024-05-01T08:30:00Z" {
+		t.Errorf("date_modified_gmt = %q, want 2024-05-01T08:30:00Z", got)
+	}
+}
+
+func TestLocalAndGMTDatesAreExposed(t *testing.T) {
+	product := newProduct(1, "Sneakers", 10)
+	product.DateCreated = time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	product.DateCreatedGMT = time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	product.DateModified = time.Date(2024, 2, 1, 9, 30, 0, 0, time.UTC)
+	product.DateModifiedGMT = time.Date(2024, 2, 1, 8, 30, 0, 0, time.UTC)
+
+	dto := ProductToDTO(product)
+	for name, got := range map[string]struct{ value, want string }{
+		"date_created":      {dto.DateCreated, "2024-01-15T10:00:00"},
+		"date_created_gmt":  {dto.DateCreatedGMT, "2024-01-15T09:00:00Z"},
+		"date_modified":     {dto.DateModified, "2024-02-01T09:30:00"},
+		"date_modified_gmt": {dto.DateModifiedGMT, "2024-02-01T08:30:00Z"},
+	} {
+		if got.value != got.want {
+			t.Errorf("%s = %q, want %q", name, got.value, got.want)
+		}
+	}
+
+	product.DateCreatedGMT = time.Time{}
+	if got := ProductToDTO(product).DateCreatedGMT; got != "" {
+		t.Errorf("date_created_gmt = %q without a GMT date, want it omitted", got)
+	}
+}
+
+func TestStockFlagsAreExposed(t *testing.T) {
+	product := newProduct(1, "Sneakers", 10)
+	product.StockStatus = domain.StockStatusOnBackorder
+	product.BackordersAllowed = true
+
+	data, err := json.Marshal(ProductToDTO(product))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	for _, want := range []string{`"in_stock":false`, `"available":true`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("product %s does not contain %s", data, want)
+		}
+	}
+}
+
+func TestStockQuantityIsOnlyReportedWhenManaged(t *testing.T) {
+	zero := 0
+	managed := newProduct(1, "Sneakers", 10)
+	managed.ManageStock = true
+	managed.StockQuantity = &zero
+	unmanaged := newProduct(2, "Boots", 10)
+	unmanaged.StockQuantity = &zero
+
+	for product, want := range map[*domain.Product][]string{
+		managed:   {`"stock_managed":true`, `"stock_quantity":0`},
+		unmanaged: {`"stock_managed":false`},
+	} {
+		data, err := json.Marshal(ProductToDTO(product))
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		for _, field := range want {
+			if !strings.Contains(string(data), field) {
+				t.Errorf("product %s does not contain %s", data, field)
+			}
+		}
+		if !product.ManageStock && strings.Contains(string(data), "stock_quantity") {
+			t.Errorf("unmanaged product %s reports a stock_quantity", data)
+		}
+	}
+}
+
+func TestDatesAreRenderedInTheDateFormat(t *testing.T) {
+	// The store runs an hour ahead of GMT
+	product := newProduct(1, "Sneakers", 10)
+	product.DateCreated = time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	product.DateCreatedGMT = time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	repository := &stubRepository{products: []*domain.Product{product}}
+
+	tests := []struct {
+		format            string
+		created, createdZ string
+	}{
+		{"", "2024-01-15T10:00:00", "2024-01-15T09:00:00Z"},
+		{"iso", "2024-01-15T10:00:00", "2024-01-15T09:00:00Z"},
+		{"rfc3339", "2024-01-15T10:00:00+01:00", "2024-01-15T09:00:00Z"},
+		{"date_only", "2024-01-15", "2024-01-15"},
+		{"Jan 2, 2006 15:04", "Jan 15, 2024 10:00", "Jan 15, 2024 09:00"},
+	}
+	for _, tt := range tests {
+		response, err := NewProductSearcher(repository).Execute(context.Background(), NewSearchRequest().SetDateFormat(tt.format))
+		if err != nil {
+			t.Fatalf("date_format=%q: %v", tt.format, err)
+		}
+		dto := response.Products[0]
+		if dto.DateCreated != tt.created || dto.DateCreatedGMT != tt.createdZ {
+			t.Errorf("date_format=%q: dates %q/%q, want %q/%q", tt.format, dto.DateCreated, dto.DateCreatedGMT, tt.created, tt.createdZ)
+		}
+	}
+
+	_, err := NewProductSearcher(repository).Execute(context.Background(), NewSearchRequest().SetDateFormat("relative"))
+	if field := validationField(err); field != "date_format" {
+		t.Errorf("date_format=relative: got error %v, want a date_format validation error", err)
+	}
+}
+
+func TestFeaturedAndOnSaleAreTriState(t *testing.T) {
+	tests := []struct {
+		value string
+		want  interface{}
+	}{
+		{"any", nil},
+		{" ANY ", nil},
+		{"true", true},
+		{"false", false},
+	}
+	for _, tt := range tests {
+		repository := &stubRepository{}
+		request := NewSearchRequest().SetFeatured(tt.value).SetOnSale(tt.value)
+		if _, err := NewProductSearcher(repository).Execute(context.Background(), request); err != nil {
+			t.Fatalf("%q: %v", tt.value, err)
+		}
+
+		criteria := repository.searches[0]
+		for field, got := range map[string]*bool{"featured": criteria.Featured, "on_sale": criteria.OnSale} {
+			var value interface{}
+			if got != nil {
+				value = *got
+			}
+			if value != tt.want {
+				t.Errorf("%s=%q: filter = %v, want %v", field, tt.value, value, tt.want)
+			}
+		}
+	}
+
+	for _, field := range []string{"featured", "on_sale"} {
+		request := NewSearchRequest()
+		if field == "featured" {
+			request.SetFeatured("sometimes")
+		} else {
+			request.SetOnSale("sometimes")
+		}
+		_, err := NewProductSearcher(&stubRepository{}).Execute(context.Background(), request)
+		if got := validationField(err); got != field {
+			t.Errorf("%s=sometimes: got error %v, want a %s validation error", field, err, field)
+		}
+	}
+}
+
+func TestCatalogVisibilityIsValidated(t *testing.T) {
+	repository := &stubRepository{}
+	request := NewSearchRequest().SetCatalogVisibility(" Hidden ")
+	if _, err := NewProductSearcher(repository).Execute(context.Background(), request); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if got := repository.searches[0].CatalogVisibility; got != domain.CatalogVisibilityHidden {
+		t.Errorf("catalog visibility = %q, want hidden", got)
+	}
+
+	request = NewSearchRequest().SetCatalogVisibility("private")
+	_, err := NewProductSearcher(&stubRepository{}).Execute(context.Background(), request)
+	if field := validationField(err); field != "catalog_visibility" {
+		t.Errorf("got error %v, want a catalog_visibility validation error", err)
+	}
+}
+
+// unitsRepository is a stub repository that also reports measurement units
+type unitsRepository struct {
+	stubRepository
+	units *domain.MeasurementUnits
+	err   error
+}
+
+func (r *unitsRepository) MeasurementUnits(ctx context.Context) (*domain.MeasurementUnits, error) {
+	return r.units, r.err
+}
+
+func TestMeasurementUnitsAnnotateWeightAndDimensions(t *testing.T) {
+	measured := newProduct(1, "Boots", 80)
+	measured.Weight = "1.2"
+	measured.Dimensions = domain.NewDimensions("30", "12", "10")
+	unmeasured := newProduct(2, "Gift card", 25)
+
+	tests := []struct {
+		name              string
+		units             *domain.MeasurementUnits
+		err               error
+		weight, dimension string
+	}{
+		{name: "units available", units: &domain.MeasurementUnits{Weight: "kg", Dimension: "cm"}, weight: "kg", dimension: "cm"},
+		{name: "settings unavailable", err: errors.New("forbidden")},
+	}
+	for _, tt := range tests {
+		repository := &unitsRepository{stubRepository: stubRepository{products: []*domain.Product{measured, unmeasured}}, units: tt.units, err: tt.err}
+		response, err := NewProductSearcher(repository).Execute(context.Background(), NewSearchRequest())
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+
+		got := response.Products[0]
+		if got.WeightUnit != tt.weight || got.DimensionUnit != tt.dimension {
+			t.Errorf("%s: units = %q/%q, want %q/%q", tt.name, got.WeightUnit, got.DimensionUnit, tt.weight, tt.dimension)
+		}
+		if other := response.Products[1]; other.WeightUnit != "" || other.DimensionUnit != "" {
+			t.Errorf("%s: product without weight or dimensions got units %q/%q", tt.name, other.WeightUnit, other.DimensionUnit)
+		}
+	}
+}
+
+func TestBackorderStockStatusRoundTrips(t *testing.T) {
+	for _, value := range []string{"onbackorder", "on_backorder", "OnBackorder"} {
+		repository := &stubRepository{}
+		request := NewSearchRequest().SetStockStatus(value)
+		if _, err := NewProductSearcher(repository).Execute(context.Background(), request); err != nil {
+			t.Fatalf("stock_status=%q: %v", value, err)
+		}
+		if got := repository.searches[0].StockStatus; got != domain.StockStatusOnBackorder {
+			t.Errorf("stock_status=%q searched %q, want onbackorder", value, got)
+		}
+	}
+}
+
+func TestInvalidPerPageIsReportedWithTheValue(t *testing.T) {
+	for value, want := range map[string]string{
+		"0":   "got '0'",
+		"-1":  "got negative '-1'",
+		"abc": "got non-numeric 'abc'",
+	} {
+		request := NewSearchRequest().SetPagination("1", value)
+		_, err := NewProductSearcher(&stubRepository{}).Execute(context.Background(), request)
+		if validationField(err) != "per_page" || !strings.Contains(err.Error(), want) {
+			t.Errorf("per_page %q: error = %v, want a per_page error ending %q", value, err, want)
+		}
+	}
+
+	// A search term keeps the broad query cap out of the way
+	for value, want := range map[string]int{"10": 10, "101": 100} {
+		repository := &stubRepository{}
+		request := NewSearchRequest().SetSearch("shoe").SetPagination("1", value)
+		if _, err := NewProductSearcher(repository).Execute(context.Background(), request); err != nil {
+			t.Fatalf("per_page %q: %v", value, err)
+		}
+		if len(repository.searches) != 1 || repository.searches[0].PerPage != want {
+			t.Errorf("per_page %q: searched %d per page, want %d", value, repository.searches[0].PerPage, want)
+		}
+	}
+}
+
+// recordingObserver records the search observer callbacks it receives
+type recordingObserver struct {
+	searches []searchobserver.Search
+	results  []searchobserver.Result
+}
+
+func (o *recordingObserver) OnSearch(ctx context.Context, search searchobserver.Search) {
+	o.searches = append(o.searches, search)
+}
+
+func (o *recordingObserver) OnResult(ctx context.Context, search searchobserver.Search, result searchobserver.Result) {
+	o.results = append(o.results, result)
+}
+
+func TestObserverReceivesTheSearchAndItsResult(t *testing.T) {
+	repository := &stubRepository{products: []*domain.Product{newProduct(1, "Runner", 10), newProduct(2, "Trail", 20)}}
+	observer := &recordingObserver{}
+	searcher := NewProductSearcher(repository).SetObserver(observer)
+
+	if _, err := searcher.Execute(context.Background(), NewSearchRequest().SetSearch("shoes").SetStockStatus("instock")); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	if len(observer.searches) != 1 {
+		t.Fatalf("searches = %+v, want one", observer.searches)
+	}
+	search := observer.searches[0]
+	if search.Kind != searchobserver.KindProducts || !strings.Contains(search.Summary, "search='shoes'") {
+		t.Errorf("search = %+v, want the products search for shoes", search)
+	}
+	if len(observer.results) != 1 || observer.results[0].Count != 2 || observer.results[0].TotalCount != 2 || observer.results[0].Err != nil {
+		t.Errorf("results = %+v, want 2 of 2 products", observer.results)
+	}
+}
+
+func TestObserverReceivesAFailedSearch(t *testing.T) {
+	observer := &recordingObserver{}
+	searcher := NewProductSearcher(&stubRepository{}).SetObserver(observer)
+
+	if _, err := searcher.Execute(context.Background(), NewSearchRequest().SetPagination("0", "")); err == nil {
+		t.Fatal("page 0 was accepted")
+	}
+	if len(observer.searches) != 1 || len(observer.results) != 1 || observer.results[0].Err == nil {
+		t.Errorf("searches %+v, results %+v; want the failed search reported", observer.searches, observer.results)
+	}
+}
+
+func TestOnSaleSearchesSumUpDiscounts(t *testing.T) {
+	onSale := func(id int, regular, sale float64) *domain.Product {
+		product := newProduct(id, "Deal", sale)
+		product.OnSale = true
+		if regular > 0 {
+			product.RegularPrice, _ = domain.NewMoney(regular, "USD")
+			product.SalePrice, _ = domain.NewMoney(sale, "USD")
+		}
+		return product
+	}
+	// 20% and 30% off, plus a variable product without a regular price
+	repository := &stubRepository{products: []*domain.Product{
+		onSale(1, 100, 80),
+		onSale(2, 50, 35),
+		onSale(3, 0, 40),
+	}}
+
+	response, err := NewProductSearcher(repository).Execute(context.Background(), NewSearchRequest().SetOnSale("true"))
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	summary := response.SaleSummary
+	if summary == nil {
+		t.Fatal("no sale summary for an on_sale search")
+	}
+	if summary.OnSaleCount != 3 || summary.DiscountedCount != 2 || summary.AverageDiscountPercent != 25 || summary.MaxDiscountPercent != 30 {
+		t.Errorf("sale summary = %+v, want 3 on sale, 2 discounted, avg 25%%, max 30%%", summary)
+	}
+	for i, want := range []float64{20, 30, 0} {
+		if got := response.Products[i].DiscountPercent; got != want {
+			t.Errorf("product %d discount_percent = %v, want %v", response.Products[i].ID, got, want)
+		}
+	}
+	if response.Products[0].SalePrice == "" {
+		t.Error("sale_price missing from a product on sale")
+	}
+}
+
+func TestSaleSummaryIsOmittedOutsideOnSaleSearches(t *testing.T) {
+	repository := &stubRepository{products: []*domain.Product{newProduct(1, "Runner", 10)}}
+	response, err := NewProductSearcher(repository).Execute(context.Background(), NewSearchRequest().SetSearch("runner"))
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if response.SaleSummary != nil {
+		t.Errorf("sale summary = %+v without on_sale, want none", response.SaleSummary)
+	}
+}
+
+func TestSearchTermIsSanitized(t *testing.T) {
+	repository := &stubRepository{}
+	request := NewSearchRequest().SetSearch(" \trunning\x00   shoes & socks\n")
+	if _, err := NewProductSearcher(repository).Execute(context.Background(), request); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if len(repository.searches) != 1 || repository.searches[0].Search != "running shoes & socks" {
+		t.Errorf("searched %q, want the sanitized term", repository.searches[0].Search)
+	}
+}
+
+// restrictedRepository finds nothing when a price, stock status or on sale
+// filter is set, and its products otherwise
+type restrictedRepository struct {
+	stubRepository
+}
+
+func (r *restrictedRepository) Search(ctx context.Context, criteria *domain.SearchCriteria) ([]*domain.Product, error) {
+	if len(criteria.RestrictiveFilters()) > 0 {
+		r.searches = append(r.searches, criteria)
+		return nil, nil
+	}
+	return r.stubRepository.Search(ctx, criteria)
+}
+
+func (r *restrictedRepository) Count(ctx context.Context, criteria *domain.SearchCriteria) (int64, error) {
+	if len(criteria.RestrictiveFilters()) > 0 {
+		r.counts = append(r.counts, criteria)
+		return 0, nil
+	}
+	return r.stubRepository.Count(ctx, criteria)
+}
+
+func TestEmptyResultCountsWithoutRestrictiveFilters(t *testing.T) {
+	repository := &restrictedRepository{stubRepository{products: []*domain.Product{newProduct(1, "Runner", 10), newProduct(2, "Trail", 20)}}}
+	request := NewSearchRequest().SetSearch("runner").SetPriceRange("500", "").SetStockStatus("instock")
+
+	response, err := NewProductSearcher(repository).Execute(context.Background(), request)
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if response.Unfiltered == nil {
+		t.Fatal("no unfiltered count for an empty result emptied by filters")
+	}
+	if response.Unfiltered.TotalCount != 2 || strings.Join(response.Unfiltered.RemovedFilters, ",") != "min_price,stock_status" {
+		t.Errorf("unfiltered = %+v, want 2 without min_price and stock_status", response.Unfiltered)
+	}
+	last := repository.counts[len(repository.counts)-1]
+	if last.MinPrice != nil || last.StockStatus != "" || last.Search != "runner" {
+		t.Errorf("unfiltered count criteria = %+v, want the search kept and the filters dropped", last)
+	}
+}
+
+func TestUnfilteredCountIsSkippedWithoutRestrictiveFilters(t *testing.T) {
+	// An empty search without restrictive filters
+	repository := &stubRepository{}
+	response, err := NewProductSearcher(repository).Execute(context.Background(), NewSearchRequest().SetSearch("runner").SetSearchMode(SearchModeText))
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if response.Unfiltered != nil || len(repository.counts) != 1 {
+		t.Errorf("unfiltered = %+v after %d counts, want no extra count", response.Unfiltered, len(repository.counts))
+	}
+
+	// A restrictive filter that still found products
+	repository = &stubRepository{products: []*domain.Product{newProduct(1, "Runner", 10)}}
+	response, err = NewProductSearcher(repository).Execute(context.Background(), NewSearchRequest().SetSearch("runner").SetOnSale("true"))
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if response.Unfiltered != nil {
+		t.Errorf("unfiltered = %+v for a search with results, want none", response.Unfiltered)
+	}
+}
+
+func TestSKUContainsIsSearchedForAlone(t *testing.T) {
+	repository := &stubRepository{}
+	if _, err := NewProductSearcher(repository).Execute(context.Background(), NewSearchRequest().SetSKUContains(" ABC- ")); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if criteria := repository.searches[0]; criteria.SKUContains != "ABC-" || criteria.Search != "ABC-" {
+		t.Errorf("criteria sku_contains %q, search %q; want both ABC-", criteria.SKUContains, criteria.Search)
+	}
+
+	for _, request := range []*SearchRequest{
+		NewSearchRequest().SetSKUContains("ABC-").SetSearch("boots"),
+		NewSearchRequest().SetSKUContains("ABC-").SetSearchMode(SearchModeSKU),
+	} {
+		_, err := NewProductSearcher(&stubRepository{}).Execute(context.Background(), request)
+		if validationField(err) != "sku_contains" {
+			t.Errorf("error = %v, want a sku_contains validation error", err)
+		}
+	}
+}
+
+func TestPurchasableOnlyIsParsed(t *testing.T) {
+	for value, want := range map[string]bool{"": false, "false": false, " true ": true} {
+		repository := &stubRepository{}
+		if _, err := NewProductSearcher(repository).Execute(context.Background(), NewSearchRequest().SetPurchasableOnly(value)); err != nil {
+			t.Fatalf("purchasable_only %q: %v", value, err)
+		}
+		if got := repository.searches[0].PurchasableOnly; got != want {
+			t.Errorf("purchasable_only %q = %v, want %v", value, got, want)
+		}
+	}
+
+	_, err := NewProductSearcher(&stubRepository{}).Execute(context.Background(), NewSearchRequest().SetPurchasableOnly("maybe"))
+	if validationField(err) != "purchasable_only" {
+		t.Errorf("error = %v, want a purchasable_only validation error", err)
+	}
+}
+
+func TestPurchasableOnlyIsAFilter(t *testing.T) {
+	if got := NewSearchRequest().SetPurchasableOnly("true").FilterSummary(); !strings.Contains(got, "purchasable only") {
+		t.Errorf("filter summary = %q, want it to mention purchasable only", got)
+	}
+	if got := NewSearchRequest().SetPurchasableOnly("false").FilterSummary(); got != "" {
+		t.Errorf("filter summary = %q for purchasable_only=false, want none", got)
+	}
+}
@@ -0,0 +1,63 @@
+package search_products
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"woocommerce-mcp/kit/pagination"
+)
+
+// Stream runs the search page after page, from the requested page to the
+// last one, handing each page to emit as soon as it arrives. Only one page
+// is held at a time, so exports of large catalogs stay within bounded
+// memory. Streaming stops at the first error from the search or from emit.
+//
+// A catalog that changes mid-export can shift a product across a page
+// boundary, so it is listed twice. Products already emitted on an earlier
+// page are dropped, keeping the first occurrence, and the number dropped is
+// returned.
+func (ps *ProductSearcher) Stream(ctx context.Context, request *SearchRequest, emit func(*SearchResponse) error) (int, error) {
+	seen := make(map[int]bool)
+	duplicatesSkipped := 0
+
+	// An export is meant to cover the whole result set, so pages are not
+	// capped like a search without filters
+	request.SetConfirmBroadQuery("true")
+
+	// An invalid page is reported by the first search
+	first, err := strconv.Atoi(strings.TrimSpace(request.GetPage()))
+	if err != nil {
+		first = 1
+	}
+
+	pages := pagination.NewIterator(first, func(ctx context.Context, page int) (*SearchResponse, bool, error) {
+		if page != first {
+			request.SetPagination(strconv.Itoa(page), request.GetPerPage())
+		}
+		response, err := ps.Execute(ctx, request)
+		if err != nil {
+			return nil, false, err
+		}
+		return response, response.HasNext, nil
+	})
+	for pages.Next(ctx) {
+		response := pages.Page()
+
+		products := response.Products[:0]
+		for _, product := range response.Products {
+			if seen[product.ID] {
+				duplicatesSkipped++
+				continue
+			}
+			seen[product.ID] = true
+			products = append(products, product)
+		}
+		response.Products = products
+
+		if err := emit(response); err != nil {
+			return duplicatesSkipped, err
+		}
+	}
+
+	return duplicatesSkipped, pages.Err()
+}
@@ -0,0 +1,71 @@
+package search_products
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"woocommerce-mcp/internal/product/domain"
+)
+
+// shiftingRepository serves fixed pages of a catalog that changed between
+// them, so products reappear across page boundaries
+type shiftingRepository struct {
+	domain.ProductRepository
+	pages map[int][]int
+	total int64
+}
+
+func (r *shiftingRepository) Search(ctx context.Context, criteria *domain.SearchCriteria) ([]*domain.Product, error) {
+	var products []*domain.Product
+	for _, id := range r.pages[criteria.Page] {
+		products = append(products, newProduct(id, "Product", 10))
+	}
+	return products, nil
+}
+
+func (r *shiftingRepository) Count(ctx context.Context, criteria *domain.SearchCriteria) (int64, error) {
+	return r.total, nil
+}
+
+func TestStreamSkipsProductsRepeatedAcrossPages(t *testing.T) {
+	repository := &shiftingRepository{
+		pages: map[int][]int{
+			1: {1, 2, 3},
+			2: {3, 4, 5},
+			3: {5, 6, 7},
+		},
+		total: 9,
+	}
+
+	var emitted []int
+	pages := 0
+	skipped, err := NewProductSearcher(repository).Stream(context.Background(), NewSearchRequest().SetPagination("1", "3"), func(page *SearchResponse) error {
+		pages++
+		emitted = append(emitted, productIDs(page)...)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Stream: %v", err)
+	}
+	if want := []int{1, 2, 3, 4, 5, 6, 7}; !equalIDs(emitted, want) {
+		t.Errorf("emitted %v, want %v with the first occurrence kept", emitted, want)
+	}
+	if skipped != 2 || pages != 3 {
+		t.Errorf("skipped %d duplicate(s) over %d page(s), want 2 over 3", skipped, pages)
+	}
+}
+
+func TestStreamStopsAtTheFirstEmitError(t *testing.T) {
+	repository := &shiftingRepository{pages: map[int][]int{1: {1, 2}, 2: {2, 3}}, total: 4}
+	stop := errors.New("client went away")
+
+	pages := 0
+	skipped, err := NewProductSearcher(repository).Stream(context.Background(), NewSearchRequest().SetPagination("1", "2"), func(page *SearchResponse) error {
+		pages++
+		return stop
+	})
+	if !errors.Is(err, stop) || pages != 1 || skipped != 0 {
+		t.Errorf("got %v after %d page(s) and %d skipped, want the emit error after the first page", err, pages, skipped)
+	}
+}
@@ -0,0 +1,15 @@
+package trending_products
+
+// TrendingProductsRequest represents a request for the best-selling products of a period
+type TrendingProductsRequest struct {
+	// Required authentication parameters
+	BaseURL        string `json:"base_url"`
+	ConsumerKey    string `json:"consumer_key"`
+	ConsumerSecret string `json:"consumer_secret"`
+
+	// Period is one of week, month, last_month or year
+	Period string `json:"period,omitempty"`
+
+	// Limit caps how many products are returned
+	Limit string `json:"limit,omitempty"`
+}
@@ -0,0 +1,42 @@
+package trending_products
+
+import (
+	"encoding/json"
+	"woocommerce-mcp/internal/product/application/search_products"
+)
+
+// Ranking sources
+const (
+	// SourceTopSellers ranks products by their sales within the period
+	SourceTopSellers = "top_sellers"
+	// SourcePopularity ranks products by lifetime sales
+	SourcePopularity = "popularity"
+)
+
+// TrendingProductsResponse represents the best-selling products of a period
+type TrendingProductsResponse struct {
+	Period   string                `json:"period"`
+	Source   string                `json:"source"`
+	Products []*TrendingProductDTO `json:"products"`
+
+	// Degraded is set when the sales report was unavailable and products
+	// are ranked by lifetime sales instead
+	Degraded bool `json:"degraded"`
+}
+
+// TrendingProductDTO is a product with its rank and the units sold in the period
+type TrendingProductDTO struct {
+	Rank int `json:"rank"`
+	// QuantitySold is only known when ranked from the sales report
+	QuantitySold *int `json:"quantity_sold,omitempty"`
+	*search_products.ProductDTO
+}
+
+// ToJSON converts the response to JSON string
+func (r *TrendingProductsResponse) ToJSON() (string, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,202 @@
+package trending_products
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"woocommerce-mcp/internal/product/application/search_products"
+	"woocommerce-mcp/internal/product/domain"
+	"woocommerce-mcp/kit/safemode"
+)
+
+const (
+	// DefaultTrendingLimit is how many products are returned by default
+	DefaultTrendingLimit = 10
+	// MaxTrendingLimit caps how many products can be requested
+	MaxTrendingLimit = 50
+)
+
+// TrendingProductsFinder ranks products by their recent sales
+type TrendingProductsFinder struct {
+	productRepository   domain.ProductRepository
+	topSellerRepository domain.TopSellerRepository
+}
+
+// NewTrendingProductsFinder creates a new TrendingProductsFinder
+func NewTrendingProductsFinder(productRepository domain.ProductRepository, topSellerRepository domain.TopSellerRepository) *TrendingProductsFinder {
+	return &TrendingProductsFinder{
+		productRepository:   productRepository,
+		topSellerRepository: topSellerRepository,
+	}
+}
+
+// Execute ranks products by the top sellers report of the period. When the
+// API key may not read reports, it falls back to lifetime popularity.
+func (f *TrendingProductsFinder) Execute(ctx context.Context, request *TrendingProductsRequest) (*TrendingProductsResponse, error) {
+	period, limit, err := validateRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	topSellers, err := f.topSellerRepository.FindTopSellers(ctx, period)
+	if err != nil {
+		if !isReportUnavailable(err) {
+			return nil, err
+		}
+		return f.byPopularity(ctx, period, limit)
+	}
+
+	return f.byTopSellers(ctx, period, limit, topSellers)
+}
+
+// byTopSellers resolves the top seller IDs to products in a single
+// include-filtered search, keeping the report's ranking
+func (f *TrendingProductsFinder) byTopSellers(ctx context.Context, period domain.SalesPeriod, limit int, topSellers []*domain.TopSeller) (*TrendingProductsResponse, error) {
+	response := &TrendingProductsResponse{
+		Period:   period.String(),
+		Source:   SourceTopSellers,
+		Products: make([]*TrendingProductDTO, 0),
+	}
+
+	// The report may list a product more than once (e.g. per variation)
+	quantities := make(map[int]int)
+	var ids []int
+	for _, topSeller := range topSellers {
+		if topSeller.ProductID <= 0 {
+			continue
+		}
+		if _, seen := quantities[topSeller.ProductID]; !seen {
+			ids = append(ids, topSeller.ProductID)
+		}
+		quantities[topSeller.ProductID] += topSeller.Quantity
+	}
+	if len(ids) == 0 {
+		return response, nil
+	}
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+
+	criteria := domain.NewSearchCriteria()
+	criteria.SetInclude(ids)
+	criteria.SetStatus(domain.ProductStatusPublish)
+	criteria.SetPagination(1, len(ids))
+	criteria.SetSorting("include", "asc")
+
+	products, err := f.productRepository.Search(ctx, criteria)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch top-selling products: %w", err)
+	}
+
+	// Deleted or unpublished top sellers are skipped
+	byID := make(map[int]*domain.Product, len(products))
+	for _, product := range publishedOnly(products) {
+		byID[product.ID.Value()] = product
+	}
+	for _, id := range ids {
+		product, ok := byID[id]
+		if !ok {
+			continue
+		}
+		quantity := quantities[id]
+		response.Products = append(response.Products, &TrendingProductDTO{
+			Rank:         len(response.Products) + 1,
+			QuantitySold: &quantity,
+			ProductDTO:   search_products.ProductToDTO(product),
+		})
+	}
+
+	return response, nil
+}
+
+// byPopularity ranks published products by lifetime sales
+func (f *TrendingProductsFinder) byPopularity(ctx context.Context, period domain.SalesPeriod, limit int) (*TrendingProductsResponse, error) {
+	criteria := domain.NewSearchCriteria()
+	criteria.SetStatus(domain.ProductStatusPublish)
+	criteria.SetPagination(1, limit)
+	criteria.SetSorting("popularity", "desc")
+
+	products, err := f.productRepository.Search(ctx, criteria)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch popular products: %w", err)
+	}
+	products = publishedOnly(products)
+
+	response := &TrendingProductsResponse{
+		Period:   period.String(),
+		Source:   SourcePopularity,
+		Products: make([]*TrendingProductDTO, 0, len(products)),
+		Degraded: true,
+	}
+	for i, product := range products {
+		response.Products = append(response.Products, &TrendingProductDTO{
+			Rank:       i + 1,
+			ProductDTO: search_products.ProductToDTO(product),
+		})
+	}
+
+	return response, nil
+}
+
+// publishedOnly drops unpublished products in safe mode. Both rankings ask
+// the store for published products only; this keeps safe mode from relying
+// on the store honoring the filter.
+func publishedOnly(products []*domain.Product) []*domain.Product {
+	if !safemode.Enabled() {
+		return products
+	}
+	published := make([]*domain.Product, 0, len(products))
+	for _, product := range products {
+		if product.Status == domain.ProductStatusPublish {
+			published = append(published, product)
+		}
+	}
+	return published
+}
+
+// isReportUnavailable reports whether the sales report cannot be read with
+// the given credentials or does not exist on the store
+func isReportUnavailable(err error) bool {
+	var apiErr *domain.WooCommerceAPIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.IsPermissionDenied() || (apiErr.IsNotFound() && !apiErr.IsRESTAPINotFound())
+}
+
+// validateRequest validates the request and returns its parsed values
+func validateRequest(request *TrendingProductsRequest) (domain.SalesPeriod, int, error) {
+	if request.BaseURL == "" {
+		return "", 0, domain.NewProductValidationError("base_url", "base URL is required")
+	}
+	if request.ConsumerKey == "" {
+		return "", 0, domain.NewProductValidationError("consumer_key", "consumer key is required")
+	}
+	if request.ConsumerSecret == "" {
+		return "", 0, domain.NewProductValidationError("consumer_secret", "consumer secret is required")
+	}
+
+	period := domain.SalesPeriod(strings.ToLower(strings.TrimSpace(request.Period)))
+	if period == "" {
+		period = domain.SalesPeriodWeek
+	}
+	if !period.IsValid() {
+		return "", 0, domain.NewProductValidationError("period", "must be week, month, last_month or year")
+	}
+
+	limit := DefaultTrendingLimit
+	if request.Limit != "" {
+		parsed, err := strconv.Atoi(strings.TrimSpace(request.Limit))
+		if err != nil || parsed < 1 {
+			return "", 0, domain.NewProductValidationError("limit", "must be a positive integer")
+		}
+		limit = parsed
+	}
+	if limit > MaxTrendingLimit {
+		limit = MaxTrendingLimit
+	}
+
+	return period, limit, nil
+}
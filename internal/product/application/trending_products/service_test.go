@@ -0,0 +1,151 @@
+package trending_products
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"woocommerce-mcp/internal/product/domain"
+	"woocommerce-mcp/kit/safemode"
+)
+
+// permissiveRepository answers every search with its products, whatever
+// status the criteria ask for, like a store ignoring the status filter
+type permissiveRepository struct {
+	domain.ProductRepository
+	products []*domain.Product
+	searches []*domain.SearchCriteria
+}
+
+func (r *permissiveRepository) Search(ctx context.Context, criteria *domain.SearchCriteria) ([]*domain.Product, error) {
+	r.searches = append(r.searches, criteria)
+	return r.products, nil
+}
+
+// reportRepository answers the top sellers report with its top sellers, or
+// fails with its error
+type reportRepository struct {
+	topSellers []*domain.TopSeller
+	err        error
+	periods    []domain.SalesPeriod
+}
+
+func (r *reportRepository) FindTopSellers(ctx context.Context, period domain.SalesPeriod) ([]*domain.TopSeller, error) {
+	r.periods = append(r.periods, period)
+	return r.topSellers, r.err
+}
+
+// product creates a product with an ID and status
+func product(id int, status domain.ProductStatus) *domain.Product {
+	productID, _ := domain.NewProductID(id)
+	p := domain.NewProduct(productID, "Product")
+	p.Status = status
+	return p
+}
+
+func TestSafeModeSkipsUnpublishedTopSellers(t *testing.T) {
+	request := &TrendingProductsRequest{BaseURL: "https://shop.example", ConsumerKey: "ck", ConsumerSecret: "cs"}
+	repository := &permissiveRepository{products: []*domain.Product{
+		product(1, domain.ProductStatusDraft),
+		product(2, domain.ProductStatusPublish),
+	}}
+	report := &reportRepository{topSellers: []*domain.TopSeller{{ProductID: 1, Quantity: 9}, {ProductID: 2, Quantity: 4}}}
+
+	response, err := NewTrendingProductsFinder(repository, report).Execute(context.Background(), request)
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if len(response.Products) != 2 {
+		t.Fatalf("got %d products without safe mode, want both", len(response.Products))
+	}
+
+	t.Setenv(safemode.Env, "true")
+	response, err = NewTrendingProductsFinder(repository, report).Execute(context.Background(), request)
+	if err != nil {
+		t.Fatalf("Execute in safe mode: %v", err)
+	}
+	if len(response.Products) != 1 || response.Products[0].ID != 2 || response.Products[0].Rank != 1 {
+		t.Errorf("got products %+v in safe mode, want only product 2 ranked first", response.Products)
+	}
+}
+
+func TestTopSellersAreRankedByTheReport(t *testing.T) {
+	request := &TrendingProductsRequest{BaseURL: "https://shop.example", ConsumerKey: "ck", ConsumerSecret: "cs", Period: "Month", Limit: "2"}
+	repository := &permissiveRepository{products: []*domain.Product{
+		product(3, domain.ProductStatusPublish),
+		product(7, domain.ProductStatusPublish),
+	}}
+	// Product 7 is listed once per variation
+	report := &reportRepository{topSellers: []*domain.TopSeller{
+		{ProductID: 7, Quantity: 5}, {ProductID: 3, Quantity: 4}, {ProductID: 7, Quantity: 2}, {ProductID: 9, Quantity: 1},
+	}}
+
+	response, err := NewTrendingProductsFinder(repository, report).Execute(context.Background(), request)
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	if len(report.periods) != 1 || report.periods[0] != domain.SalesPeriodMonth {
+		t.Errorf("report periods = %v, want month", report.periods)
+	}
+	if response.Source != SourceTopSellers || response.Degraded || response.Period != "month" {
+		t.Errorf("got source %s (degraded %v) for %s, want the month's top sellers", response.Source, response.Degraded, response.Period)
+	}
+	if criteria := repository.searches[0]; len(criteria.Include) != 2 || criteria.Include[0] != 7 || criteria.Include[1] != 3 {
+		t.Errorf("resolved IDs %v, want the limited ranking [7 3]", criteria.Include)
+	}
+
+	want := []struct{ id, quantity int }{{7, 7}, {3, 4}}
+	if len(response.Products) != len(want) {
+		t.Fatalf("got %d products, want %d", len(response.Products), len(want))
+	}
+	for i, w := range want {
+		got := response.Products[i]
+		if got.ID != w.id || got.Rank != i+1 || got.QuantitySold == nil || *got.QuantitySold != w.quantity {
+			t.Errorf("rank %d = product %d with %v sold, want product %d with %d", i+1, got.ID, got.QuantitySold, w.id, w.quantity)
+		}
+	}
+}
+
+func TestForbiddenReportFallsBackToPopularity(t *testing.T) {
+	request := &TrendingProductsRequest{BaseURL: "https://shop.example", ConsumerKey: "ck", ConsumerSecret: "cs"}
+	for _, status := range []int{403, 404} {
+		repository := &permissiveRepository{products: []*domain.Product{
+			product(4, domain.ProductStatusPublish),
+			product(2, domain.ProductStatusPublish),
+		}}
+		report := &reportRepository{err: domain.NewWooCommerceAPIError(status, "Sorry, you cannot list resources.", "woocommerce_rest_cannot_view")}
+
+		response, err := NewTrendingProductsFinder(repository, report).Execute(context.Background(), request)
+		if err != nil {
+			t.Fatalf("status %d: %v", status, err)
+		}
+		if response.Source != SourcePopularity || !response.Degraded {
+			t.Errorf("status %d: got source %s (degraded %v), want the degraded popularity ranking", status, response.Source, response.Degraded)
+		}
+		if criteria := repository.searches[0]; criteria.OrderBy != "popularity" || criteria.Order != "desc" || criteria.PerPage != DefaultTrendingLimit {
+			t.Errorf("status %d: searched %s %s with %d per page, want popularity desc with the default limit", status, criteria.OrderBy, criteria.Order, criteria.PerPage)
+		}
+		if len(response.Products) != 2 || response.Products[0].ID != 4 || response.Products[0].QuantitySold != nil {
+			t.Errorf("status %d: got %+v, want the store's order without quantities", status, response.Products)
+		}
+	}
+}
+
+func TestOtherReportErrorsAreReturned(t *testing.T) {
+	request := &TrendingProductsRequest{BaseURL: "https://shop.example", ConsumerKey: "ck", ConsumerSecret: "cs"}
+	for name, reportErr := range map[string]error{
+		"unauthorized": domain.NewWooCommerceAPIError(401, "Invalid signature", "woocommerce_rest_authentication_error"),
+		"server error": domain.NewWooCommerceAPIError(500, "Database down", "internal_server_error"),
+		"no REST API":  domain.NewRESTAPINotFoundError(404),
+	} {
+		repository := &permissiveRepository{}
+		_, err := NewTrendingProductsFinder(repository, &reportRepository{err: reportErr}).Execute(context.Background(), request)
+		if !errors.Is(err, reportErr) {
+			t.Errorf("%s: got error %v, want the report error", name, err)
+		}
+		if len(repository.searches) != 0 {
+			t.Errorf("%s: fell back to searching products", name)
+		}
+	}
+}
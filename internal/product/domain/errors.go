@@ -2,6 +2,8 @@ package domain
 
 import (
 	"fmt"
+	"net/http"
+	"time"
 	"woocommerce-mcp/kit/domain"
 )
 
@@ -64,6 +66,9 @@ type WooCommerceAPIError struct {
 	StatusCode int
 	Message    string
 	Code       string
+	// RetryAfter is how long the store asked callers to wait before trying
+	// again (Retry-After header), or 0 when it did not say
+	RetryAfter time.Duration
 }
 
 // NewWooCommerceAPIError creates a new WooCommerceAPIError
@@ -89,14 +94,54 @@ func (e *WooCommerceAPIError) Is(target error) bool {
 	return ok
 }
 
+// RESTAPINotFoundCode is the error code used when the WooCommerce REST API is unavailable
+const RESTAPINotFoundCode = "woocommerce_rest_api_not_found"
+
+// NewRESTAPINotFoundError creates an error for stores where the WooCommerce REST API is unavailable
+func NewRESTAPINotFoundError(statusCode int) *WooCommerceAPIError {
+	return NewWooCommerceAPIError(
+		statusCode,
+		"WooCommerce REST API not found at this URL — verify WooCommerce is active and pretty permalinks are enabled",
+		RESTAPINotFoundCode,
+	)
+}
+
+// IsRESTAPINotFound checks if the error represents a missing WooCommerce REST API
+func (e *WooCommerceAPIError) IsRESTAPINotFound() bool {
+	return e.Code == RESTAPINotFoundCode
+}
+
+// InvalidPageNumberCode is the error code WordPress uses for a page past the
+// last page of results
+const InvalidPageNumberCode = "rest_post_invalid_page_number"
+
+// IsInvalidPageNumber checks if the error rejects a page past the last page
+func (e *WooCommerceAPIError) IsInvalidPageNumber() bool {
+	return e.StatusCode == http.StatusBadRequest && e.Code == InvalidPageNumberCode
+}
+
 // IsNotFound checks if the error represents a not found error
 func (e *WooCommerceAPIError) IsNotFound() bool {
 	return e.StatusCode == 404
 }
 
-// IsUnauthorized checks if the error represents an unauthorized error
+// IsUnauthorized checks if the error represents an unauthorized error,
+// either an authentication failure or a permission denial
 func (e *WooCommerceAPIError) IsUnauthorized() bool {
-	return e.StatusCode == 401 || e.StatusCode == 403
+	return e.IsAuthenticationFailure() || e.IsPermissionDenied()
+}
+
+// IsAuthenticationFailure checks if the store rejected the credentials
+// (401), e.g. a mistyped or revoked API key
+func (e *WooCommerceAPIError) IsAuthenticationFailure() bool {
+	return e.StatusCode == http.StatusUnauthorized
+}
+
+// IsPermissionDenied checks if the credentials are valid but lack
+// permission for the resource (403), e.g. an API key without access to the
+// endpoint
+func (e *WooCommerceAPIError) IsPermissionDenied() bool {
+	return e.StatusCode == http.StatusForbidden
 }
 
 // IsBadRequest checks if the error represents a bad request error
@@ -185,6 +230,86 @@ func (e *AuthenticationError) Is(target error) bool {
 	return ok
 }
 
+// Machine-readable codes and types of the product errors
+
+// ErrorCode returns the machine-readable error code
+func (e *ProductNotFoundError) ErrorCode() string {
+	return "PRODUCT_NOT_FOUND"
+}
+
+// ErrorType returns the error type
+func (e *ProductNotFoundError) ErrorType() string {
+	return "NotFoundError"
+}
+
+// ErrorCode returns the machine-readable error code
+func (e *ProductValidationError) ErrorCode() string {
+	return "VALIDATION_ERROR"
+}
+
+// ErrorType returns the error type
+func (e *ProductValidationError) ErrorType() string {
+	return "ValidationError"
+}
+
+// ErrorCode returns the machine-readable error code. The WooCommerce error
+// code is used when the store returned one.
+func (e *WooCommerceAPIError) ErrorCode() string {
+	if e.Code != "" {
+		return e.Code
+	}
+	return fmt.Sprintf("WOOCOMMERCE_API_ERROR_%d", e.StatusCode)
+}
+
+// ErrorType returns the error type
+func (e *WooCommerceAPIError) ErrorType() string {
+	return "WooCommerceAPIError"
+}
+
+// HTTPStatus returns the HTTP status returned by the store
+func (e *WooCommerceAPIError) HTTPStatus() int {
+	return e.StatusCode
+}
+
+// RetryAfterDelay returns how long a rate-limited caller should wait; it is
+// only set for 429 responses
+func (e *WooCommerceAPIError) RetryAfterDelay() time.Duration {
+	if e.StatusCode != http.StatusTooManyRequests {
+		return 0
+	}
+	return e.RetryAfter
+}
+
+// ErrorCode returns the machine-readable error code
+func (e *SearchCriteriaError) ErrorCode() string {
+	return "VALIDATION_ERROR"
+}
+
+// ErrorType returns the error type
+func (e *SearchCriteriaError) ErrorType() string {
+	return "ValidationError"
+}
+
+// ErrorCode returns the machine-readable error code
+func (e *ConnectionError) ErrorCode() string {
+	return "CONNECTION_ERROR"
+}
+
+// ErrorType returns the error type
+func (e *ConnectionError) ErrorType() string {
+	return "ConnectionError"
+}
+
+// ErrorCode returns the machine-readable error code
+func (e *AuthenticationError) ErrorCode() string {
+	return "AUTHENTICATION_ERROR"
+}
+
+// ErrorType returns the error type
+func (e *AuthenticationError) ErrorType() string {
+	return "AuthenticationError"
+}
+
 // Helper functions to create common domain errors
 
 // NewInvalidProductIDError creates a validation error for invalid product ID
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestAuthenticationFailureAndPermissionDenialAreTold(t *testing.T) {
+	for _, tc := range []struct {
+		status                  int
+		authFailure, permDenied bool
+	}{
+		{http.StatusUnauthorized, true, false},
+		{http.StatusForbidden, false, true},
+		{http.StatusNotFound, false, false},
+		{http.StatusInternalServerError, false, false},
+	} {
+		err := NewWooCommerceAPIError(tc.status, "rejected", "woocommerce_rest_cannot_view")
+		if got := err.IsAuthenticationFailure(); got != tc.authFailure {
+			t.Errorf("%d: IsAuthenticationFailure() = %v, want %v", tc.status, got, tc.authFailure)
+		}
+		if got := err.IsPermissionDenied(); got != tc.permDenied {
+			t.Errorf("%d: IsPermissionDenied() = %v, want %v", tc.status, got, tc.permDenied)
+		}
+		if got := err.IsUnauthorized(); got != (tc.authFailure || tc.permDenied) {
+			t.Errorf("%d: IsUnauthorized() = %v, want %v", tc.status, got, tc.authFailure || tc.permDenied)
+		}
+	}
+}
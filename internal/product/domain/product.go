@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"fmt"
 	"time"
 	"woocommerce-mcp/kit/domain"
 )
@@ -12,7 +13,9 @@ type Product struct {
 	Slug              string              `json:"slug"`
 	Permalink         string              `json:"permalink"`
 	DateCreated       time.Time           `json:"date_created"`
+	DateCreatedGMT    time.Time           `json:"date_created_gmt"`
 	DateModified      time.Time           `json:"date_modified"`
+	DateModifiedGMT   time.Time           `json:"date_modified_gmt"`
 	Type              ProductType         `json:"type"`
 	Status            ProductStatus       `json:"status"`
 	Featured          bool                `json:"featured"`
@@ -24,6 +27,10 @@ type Product struct {
 	RegularPrice      *Money              `json:"regular_price"`
 	SalePrice         *Money              `json:"sale_price"`
 	OnSale            bool                `json:"on_sale"`
+	DateOnSaleFrom    time.Time           `json:"date_on_sale_from"`
+	DateOnSaleFromGMT time.Time           `json:"date_on_sale_from_gmt"`
+	DateOnSaleTo      time.Time           `json:"date_on_sale_to"`
+	DateOnSaleToGMT   time.Time           `json:"date_on_sale_to_gmt"`
 	Purchasable       bool                `json:"purchasable"`
 	TotalSales        int                 `json:"total_sales"`
 	Virtual           bool                `json:"virtual"`
@@ -123,6 +130,154 @@ func (p *Product) SetStatus(status ProductStatus) {
 	p.DateModified = time.Now()
 }
 
+// RelatedProductIDs returns the IDs of the products linked by the given relation
+func (p *Product) RelatedProductIDs(relation ProductRelation) []int {
+	switch relation {
+	case ProductRelationRelated:
+		return p.RelatedIDs
+	case ProductRelationUpsell:
+		return p.UpsellIDs
+	case ProductRelationCrossSell:
+		return p.CrossSellIDs
+	default:
+		return nil
+	}
+}
+
+// StockManaged reports whether the store counts the product's units, so
+// StockQuantity holds a meaningful quantity. Products without managed stock
+// are only described by their stock status.
+func (p *Product) StockManaged() bool {
+	return p.ManageStock && p.StockQuantity != nil
+}
+
+// Availability summarizes the stock state for people, e.g. "In stock (5)",
+// "In stock (quantity not tracked)", "Out of stock" or "Available on
+// backorder". It is empty when the stock status is unknown.
+func (p *Product) Availability() string {
+	switch p.StockStatus {
+	case StockStatusInStock:
+		availability := "In stock (quantity not tracked)"
+		if p.StockManaged() {
+			availability = fmt.Sprintf("In stock (%d)", *p.StockQuantity)
+		}
+		if p.BackordersAllowed {
+			availability += ", backorders allowed"
+		}
+		return availability
+	case StockStatusOutOfStock:
+		return "Out of stock"
+	case StockStatusOnBackorder:
+		return "Available on backorder"
+	default:
+		return ""
+	}
+}
+
+// InStock reports whether the product can be bought from stock: its stock
+// status is instock and, when stock is managed, units are left
+func (p *Product) InStock() bool {
+	if p.StockStatus != StockStatusInStock {
+		return false
+	}
+	if p.StockManaged() {
+		return *p.StockQuantity > 0
+	}
+	return true
+}
+
+// Available reports whether the product can be ordered at all, from stock
+// or on backorder
+func (p *Product) Available() bool {
+	return p.InStock() || p.StockStatus == StockStatusOnBackorder || p.BackordersAllowed
+}
+
+// SaleStart returns the instant the scheduled sale of the product starts, or
+// the zero time when the schedule has none. The GMT date is preferred; a
+// store-local date alone is taken as UTC.
+func (p *Product) SaleStart() time.Time {
+	return saleInstant(p.DateOnSaleFrom, p.DateOnSaleFromGMT)
+}
+
+// SaleEnd returns the instant the scheduled sale ends; see SaleStart
+func (p *Product) SaleEnd() time.Time {
+	return saleInstant(p.DateOnSaleTo, p.DateOnSaleToGMT)
+}
+
+// saleInstant returns the GMT date of a sale bound, else its local date
+func saleInstant(local, gmt time.Time) time.Time {
+	if !gmt.IsZero() {
+		return gmt
+	}
+	return local
+}
+
+// SaleRemaining describes the sale schedule relative to now for people,
+// e.g. "ends in 3 days", "starts in 5 hours", "ended 2 days ago", or "no end
+// date" for a running sale without an end. It is empty when the product is
+// not on sale and has no schedule.
+func (p *Product) SaleRemaining(now time.Time) string {
+	return DescribeSaleSchedule(p.SaleStart(), p.SaleEnd(), p.OnSale, now)
+}
+
+// DescribeSaleSchedule describes a sale running from start to end relative
+// to now, like Product.SaleRemaining. Zero times stand for a missing bound.
+func DescribeSaleSchedule(start, end time.Time, onSale bool, now time.Time) string {
+	switch {
+	case !end.IsZero() && !now.Before(end):
+		return "ended " + describeDuration(now.Sub(end)) + " ago"
+	case !start.IsZero() && now.Before(start):
+		return "starts in " + describeDuration(start.Sub(now))
+	case !end.IsZero():
+		return "ends in " + describeDuration(end.Sub(now))
+	case onSale || !start.IsZero():
+		return "no end date"
+	default:
+		return ""
+	}
+}
+
+// describeDuration writes a duration in its largest whole unit, e.g.
+// "3 days", "1 hour" or "less than a minute"
+func describeDuration(d time.Duration) string {
+	plural := func(n int, unit string) string {
+		if n == 1 {
+			return "1 " + unit
+		}
+		return fmt.Sprintf("%d %ss", n, unit)
+	}
+	switch {
+	case d >= 24*time.Hour:
+		return plural(int(d/(24*time.Hour)), "day")
+	case d >= time.Hour:
+		return plural(int(d/time.Hour), "hour")
+	case d >= time.Minute:
+		return plural(int(d/time.Minute), "minute")
+	default:
+		return "less than a minute"
+	}
+}
+
+// DiscountPercent returns how much cheaper the product is than its regular
+// price, in percent. The sale price is used when set, else the current
+// price. It reports false when the product is not on sale or either price
+// is missing, as for most variable products.
+func (p *Product) DiscountPercent() (float64, bool) {
+	if !p.OnSale || p.RegularPrice == nil || p.RegularPrice.Amount() <= 0 {
+		return 0, false
+	}
+
+	price := p.SalePrice
+	if price == nil || price.Amount() <= 0 {
+		price = p.Price
+	}
+	if price == nil || price.Amount() <= 0 || price.Amount() >= p.RegularPrice.Amount() {
+		return 0, false
+	}
+
+	return (p.RegularPrice.Amount() - price.Amount()) / p.RegularPrice.Amount() * 100, true
+}
+
 // AddCategory adds a category to the product
 func (p *Product) AddCategory(category *Category) {
 	if category == nil {
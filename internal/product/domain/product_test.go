@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSaleRemaining(t *testing.T) {
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	for _, tc := range []struct {
+		name    string
+		product Product
+		want    string
+	}{
+		{
+			name:    "scheduled",
+			product: Product{OnSale: true, DateOnSaleFromGMT: now.Add(-day), DateOnSaleToGMT: now.Add(3*day + time.Hour)},
+			want:    "ends in 3 days",
+		},
+		{
+			name:    "upcoming",
+			product: Product{DateOnSaleFromGMT: now.Add(5 * time.Hour), DateOnSaleToGMT: now.Add(2 * day)},
+			want:    "starts in 5 hours",
+		},
+		{
+			name:    "open-ended",
+			product: Product{OnSale: true, DateOnSaleFromGMT: now.Add(-day)},
+			want:    "no end date",
+		},
+		{
+			name:    "open-ended without schedule",
+			product: Product{OnSale: true},
+			want:    "no end date",
+		},
+		{
+			name:    "expired",
+			product: Product{DateOnSaleFromGMT: now.Add(-9 * day), DateOnSaleToGMT: now.Add(-2 * day)},
+			want:    "ended 2 days ago",
+		},
+		{
+			name:    "local date only",
+			product: Product{OnSale: true, DateOnSaleTo: now.Add(90 * time.Second)},
+			want:    "ends in 1 minute",
+		},
+		{
+			name:    "not on sale",
+			product: Product{},
+			want:    "",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.product.SaleRemaining(now); got != tc.want {
+				t.Errorf("SaleRemaining() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAvailability(t *testing.T) {
+	five := 5
+	for _, tc := range []struct {
+		name    string
+		product Product
+		want    string
+	}{
+		{
+			name:    "in stock, untracked",
+			product: Product{StockStatus: StockStatusInStock},
+			want:    "In stock (quantity not tracked)",
+		},
+		{
+			name:    "in stock, tracked",
+			product: Product{StockStatus: StockStatusInStock, ManageStock: true, StockQuantity: &five},
+			want:    "In stock (5)",
+		},
+		{
+			name:    "in stock, quantity not tracked",
+			product: Product{StockStatus: StockStatusInStock, StockQuantity: &five},
+			want:    "In stock (quantity not tracked)",
+		},
+		{
+			name:    "in stock, tracked and backorderable",
+			product: Product{StockStatus: StockStatusInStock, ManageStock: true, StockQuantity: &five, Backorders: "notify", BackordersAllowed: true},
+			want:    "In stock (5), backorders allowed",
+		},
+		{
+			name:    "out of stock",
+			product: Product{StockStatus: StockStatusOutOfStock, ManageStock: true, Backorders: "no"},
+			want:    "Out of stock",
+		},
+		{
+			name:    "on backorder",
+			product: Product{StockStatus: StockStatusOnBackorder, ManageStock: true, Backorders: "yes", BackordersAllowed: true},
+			want:    "Available on backorder",
+		},
+		{
+			name:    "unknown",
+			product: Product{},
+			want:    "",
+		},
+	} {
+		if got := tc.product.Availability(); got != tc.want {
+			t.Errorf("%s: Availability() = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestInStockAndAvailable(t *testing.T) {
+	zero, five := 0, 5
+	for _, tc := range []struct {
+		name      string
+		product   Product
+		inStock   bool
+		available bool
+	}{
+		{"in stock, unmanaged", Product{StockStatus: StockStatusInStock}, true, true},
+		{"in stock, managed with quantity", Product{StockStatus: StockStatusInStock, ManageStock: true, StockQuantity: &five}, true, true},
+		{"in stock, managed at zero", Product{StockStatus: StockStatusInStock, ManageStock: true, StockQuantity: &zero}, false, false},
+		{"in stock, managed at zero with backorders", Product{StockStatus: StockStatusInStock, ManageStock: true, StockQuantity: &zero, BackordersAllowed: true}, false, true},
+		{"in stock, unmanaged at zero", Product{StockStatus: StockStatusInStock, StockQuantity: &zero}, true, true},
+		{"out of stock, unmanaged", Product{StockStatus: StockStatusOutOfStock}, false, false},
+		{"out of stock, managed", Product{StockStatus: StockStatusOutOfStock, ManageStock: true, StockQuantity: &zero}, false, false},
+		{"on backorder, unmanaged", Product{StockStatus: StockStatusOnBackorder}, false, true},
+		{"on backorder, managed", Product{StockStatus: StockStatusOnBackorder, ManageStock: true, StockQuantity: &zero, BackordersAllowed: true}, false, true},
+		{"unknown", Product{}, false, false},
+	} {
+		if got := tc.product.InStock(); got != tc.inStock {
+			t.Errorf("%s: InStock() = %v, want %v", tc.name, got, tc.inStock)
+		}
+		if got := tc.product.Available(); got != tc.available {
+			t.Errorf("%s: Available() = %v, want %v", tc.name, got, tc.available)
+		}
+	}
+}
+
+func TestDiscountPercent(t *testing.T) {
+	money := func(amount float64) *Money {
+		m, _ := NewMoney(amount, "USD")
+		return m
+	}
+	for _, tc := range []struct {
+		name    string
+		product Product
+		want    float64
+		ok      bool
+	}{
+		{"sale price", Product{OnSale: true, RegularPrice: money(100), SalePrice: money(80), Price: money(80)}, 20, true},
+		{"price without sale price", Product{OnSale: true, RegularPrice: money(50), Price: money(35)}, 30, true},
+		{"not on sale", Product{RegularPrice: money(100), SalePrice: money(80)}, 0, false},
+		{"no regular price", Product{OnSale: true, Price: money(80)}, 0, false},
+		{"sale price not lower", Product{OnSale: true, RegularPrice: money(80), SalePrice: money(80)}, 0, false},
+	} {
+		got, ok := tc.product.DiscountPercent()
+		if ok != tc.ok || (ok && math.Abs(got-tc.want) > 1e-9) {
+			t.Errorf("%s: DiscountPercent() = %v, %v; want %v, %v", tc.name, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestStockManaged(t *testing.T) {
+	zero := 0
+	for _, tc := range []struct {
+		name    string
+		product Product
+		want    bool
+	}{
+		{"managed with units", Product{ManageStock: true, StockQuantity: &zero}, true},
+		{"managed without a quantity", Product{ManageStock: true}, false},
+		{"unmanaged with a quantity", Product{StockQuantity: &zero}, false},
+		{"unmanaged", Product{}, false},
+	} {
+		if got := tc.product.StockManaged(); got != tc.want {
+			t.Errorf("%s: StockManaged() = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
@@ -2,7 +2,9 @@ package domain
 
 import (
 	"context"
+	"time"
 	"woocommerce-mcp/kit/domain"
+	"woocommerce-mcp/kit/pagination"
 )
 
 // ProductRepository defines the interface for product data access
@@ -26,23 +28,137 @@ type ProductRepository interface {
 	Count(ctx context.Context, criteria *SearchCriteria) (int64, error)
 }
 
+// FindPublishedByID finds a product by its ID among published products only,
+// so a draft, pending or private product is reported as not found
+func FindPublishedByID(ctx context.Context, repository ProductRepository, id *ProductID) (*Product, error) {
+	criteria := NewSearchCriteria()
+	criteria.SetInclude([]int{id.Value()})
+	criteria.SetStatus(ProductStatusPublish)
+	criteria.SetPagination(1, 1)
+
+	products, err := repository.Search(ctx, criteria)
+	if err != nil {
+		return nil, err
+	}
+	for _, product := range products {
+		if product.ID.Equals(id) && product.Status == ProductStatusPublish {
+			return product, nil
+		}
+	}
+	return nil, NewProductNotFoundError(id)
+}
+
+// PartialSearcher is implemented by product repositories that skip products
+// they cannot read instead of failing the whole search
+type PartialSearcher interface {
+	// SearchPartial searches like Search and also returns how many products
+	// were skipped
+	SearchPartial(ctx context.Context, criteria *SearchCriteria) ([]*Product, int, error)
+}
+
+// ProductPage is one page of a product search walked by a PageSearcher
+type ProductPage struct {
+	Products []*Product
+	// Skipped counts the products of the page that could not be read
+	Skipped int
+	// TotalCount and TotalPages describe the whole result set
+	TotalCount int64
+	TotalPages int
+}
+
+// PageSearcher is implemented by product repositories that can walk every
+// page of a search, so features aggregating over a catalog share one paging
+// loop
+type PageSearcher interface {
+	// SearchPages returns an iterator over the pages of the search, from
+	// criteria.Page to the last one
+	SearchPages(criteria *SearchCriteria) *pagination.Iterator[*ProductPage]
+}
+
+// MeasurementUnitsProvider is implemented by product repositories that can
+// tell the units of product weights and dimensions
+type MeasurementUnitsProvider interface {
+	// MeasurementUnits returns the store's weight and dimension units
+	MeasurementUnits(ctx context.Context) (*MeasurementUnits, error)
+}
+
+// RedirectReporter is implemented by product repositories that notice when
+// the store redirects their requests to another base URL
+type RedirectReporter interface {
+	// RedirectedBaseURL returns the base URL requests were redirected to.
+	// Each redirect is only reported once.
+	RedirectedBaseURL() (string, bool)
+}
+
+// APIVersionReporter is implemented by product repositories that fall back
+// to an older WooCommerce REST API version on stores lacking the default one
+type APIVersionReporter interface {
+	// APIVersion returns the API version requests are sent to, and whether
+	// it was negotiated instead of the default one
+	APIVersion() (string, bool)
+}
+
+// CategoryRepository defines the interface for product category data access
+type CategoryRepository interface {
+	// FindCategories returns the categories with the given IDs, including
+	// their parent IDs. IDs that match no category are left out.
+	FindCategories(ctx context.Context, ids []int) ([]*Category, error)
+
+	// CategoryPages returns an iterator over all categories of the store,
+	// one page of up to pagination.MaxPerPage categories at a time
+	CategoryPages() *pagination.Iterator[[]*Category]
+}
+
+// VariationRepository defines the interface for product variation data access
+type VariationRepository interface {
+	// FindVariations returns all variations of a variable product
+	FindVariations(ctx context.Context, parentID *ProductID) ([]*Variation, error)
+}
+
+// TopSellerRepository defines the interface for sales report data access
+type TopSellerRepository interface {
+	// FindTopSellers returns the best-selling products of a period, best first
+	FindTopSellers(ctx context.Context, period SalesPeriod) ([]*TopSeller, error)
+}
+
 // SearchCriteria represents search criteria for products
 type SearchCriteria struct {
 	// Search term for name, description, or SKU
 	Search string
 
+	// SKU matches products with exactly this SKU
+	SKU string
+
+	// SKUContains keeps products whose SKU contains this text. The API has
+	// no such filter, so repositories search for the text and apply it to
+	// the fetched page only.
+	SKUContains string
+
 	// Category filter
 	Category string
 
 	// Tag filter
 	Tag string
 
+	// CategoryOperator and TagOperator say how several comma-separated
+	// categories or tags match. The API only matches any of them, so
+	// repositories apply TermOperatorAnd to the fetched page only.
+	CategoryOperator TermOperator
+	TagOperator      TermOperator
+
+	// Brand filter (product_brand term IDs, comma-separated)
+	Brand string
+
 	// Status filter
 	Status ProductStatus
 
 	// Type filter
 	Type ProductType
 
+	// Types matches any of several product types. WooCommerce accepts a
+	// single type per request, so repositories query each type separately.
+	Types []ProductType
+
 	// Featured filter
 	Featured *bool
 
@@ -56,6 +172,24 @@ type SearchCriteria struct {
 	// Stock status filter
 	StockStatus StockStatus
 
+	// Include limits the result set to specific product IDs
+	Include []int
+
+	// Parent limits the result set to children of the given product IDs
+	Parent []int
+
+	// CatalogVisibility filter. The API cannot filter on it, so repositories
+	// apply it to the fetched page only.
+	CatalogVisibility CatalogVisibility
+
+	// PurchasableOnly keeps purchasable products only. The API cannot filter
+	// on it, so repositories apply it to the fetched page only.
+	PurchasableOnly bool
+
+	// Modification window, used for incremental syncs
+	ModifiedAfter  *time.Time
+	ModifiedBefore *time.Time
+
 	// Pagination
 	Page    int
 	PerPage int
@@ -85,8 +219,8 @@ func (sc *SearchCriteria) Validate() error {
 		sc.PerPage = 10
 	}
 
-	if sc.PerPage > 100 {
-		sc.PerPage = 100
+	if sc.PerPage > pagination.MaxPerPage {
+		sc.PerPage = pagination.MaxPerPage
 	}
 
 	// Validate status if provided
@@ -98,19 +232,42 @@ func (sc *SearchCriteria) Validate() error {
 	if sc.Type != "" && !sc.Type.IsValid() {
 		return domain.NewValidationError("invalid product type")
 	}
+	for _, productType := range sc.Types {
+		if !productType.IsValid() {
+			return domain.NewValidationError("invalid product type")
+		}
+	}
 
 	// Validate stock status if provided
 	if sc.StockStatus != "" && !sc.StockStatus.IsValid() {
 		return domain.NewValidationError("invalid stock status")
 	}
 
+	// Validate catalog visibility if provided
+	if sc.CatalogVisibility != "" && !sc.CatalogVisibility.IsValid() {
+		return domain.NewValidationError("invalid catalog visibility")
+	}
+
+	// Validate term operators if provided
+	if sc.CategoryOperator != "" && !sc.CategoryOperator.IsValid() {
+		return domain.NewValidationError("category_operator must be 'or' or 'and'")
+	}
+	if sc.TagOperator != "" && !sc.TagOperator.IsValid() {
+		return domain.NewValidationError("tag_operator must be 'or' or 'and'")
+	}
+
+	// Validate modification window
+	if sc.ModifiedAfter != nil && sc.ModifiedBefore != nil && !sc.ModifiedAfter.Before(*sc.ModifiedBefore) {
+		return domain.NewValidationError("modified_after must be earlier than modified_before")
+	}
+
 	// Validate order direction
 	if sc.Order != "" && sc.Order != "asc" && sc.Order != "desc" {
 		return domain.NewValidationError("order must be 'asc' or 'desc'")
 	}
 
 	// Validate order by field
-	validOrderByFields := []string{"date", "id", "title", "slug", "price", "popularity", "rating", "menu_order"}
+	validOrderByFields := []string{"date", "id", "title", "slug", "price", "popularity", "rating", "menu_order", "modified", "include"}
 	if sc.OrderBy != "" {
 		valid := false
 		for _, field := range validOrderByFields {
@@ -127,12 +284,61 @@ func (sc *SearchCriteria) Validate() error {
 	return nil
 }
 
+// HasTaxonomyFilter reports whether the search is limited to a category,
+// tag or brand
+func (sc *SearchCriteria) HasTaxonomyFilter() bool {
+	return sc.Category != "" || sc.Tag != "" || sc.Brand != ""
+}
+
+// RestrictiveFilters names the set price, stock status and on sale filters,
+// the ones most likely to filter out every product, by their argument names
+func (sc *SearchCriteria) RestrictiveFilters() []string {
+	var filters []string
+	if sc.MinPrice != nil {
+		filters = append(filters, "min_price")
+	}
+	if sc.MaxPrice != nil {
+		filters = append(filters, "max_price")
+	}
+	if sc.StockStatus != "" {
+		filters = append(filters, "stock_status")
+	}
+	if sc.OnSale != nil {
+		filters = append(filters, "on_sale")
+	}
+	return filters
+}
+
+// WithoutRestrictiveFilters returns a copy of the criteria without the
+// price, stock status and on sale filters
+func (sc *SearchCriteria) WithoutRestrictiveFilters() *SearchCriteria {
+	criteria := *sc
+	criteria.MinPrice = nil
+	criteria.MaxPrice = nil
+	criteria.StockStatus = ""
+	criteria.OnSale = nil
+	return &criteria
+}
+
 // SetSearch sets the search term
 func (sc *SearchCriteria) SetSearch(search string) *SearchCriteria {
 	sc.Search = search
 	return sc
 }
 
+// SetSKU sets the exact SKU filter
+func (sc *SearchCriteria) SetSKU(sku string) *SearchCriteria {
+	sc.SKU = sku
+	return sc
+}
+
+// SetSKUContains sets the partial SKU filter, searching for its text
+func (sc *SearchCriteria) SetSKUContains(text string) *SearchCriteria {
+	sc.SKUContains = text
+	sc.Search = text
+	return sc
+}
+
 // SetCategory sets the category filter
 func (sc *SearchCriteria) SetCategory(category string) *SearchCriteria {
 	sc.Category = category
@@ -145,6 +351,19 @@ func (sc *SearchCriteria) SetTag(tag string) *SearchCriteria {
 	return sc
 }
 
+// SetTermOperators sets how several categories and tags match
+func (sc *SearchCriteria) SetTermOperators(categoryOperator, tagOperator TermOperator) *SearchCriteria {
+	sc.CategoryOperator = categoryOperator
+	sc.TagOperator = tagOperator
+	return sc
+}
+
+// SetBrand sets the brand filter
+func (sc *SearchCriteria) SetBrand(brand string) *SearchCriteria {
+	sc.Brand = brand
+	return sc
+}
+
 // SetStatus sets the status filter
 func (sc *SearchCriteria) SetStatus(status ProductStatus) *SearchCriteria {
 	sc.Status = status
@@ -157,6 +376,17 @@ func (sc *SearchCriteria) SetType(productType ProductType) *SearchCriteria {
 	return sc
 }
 
+// SetTypes sets a filter matching any of the given types
+func (sc *SearchCriteria) SetTypes(types []ProductType) *SearchCriteria {
+	if len(types) == 1 {
+		sc.Type = types[0]
+		sc.Types = nil
+		return sc
+	}
+	sc.Types = types
+	return sc
+}
+
 // SetFeatured sets the featured filter
 func (sc *SearchCriteria) SetFeatured(featured bool) *SearchCriteria {
 	sc.Featured = &featured
@@ -182,6 +412,37 @@ func (sc *SearchCriteria) SetStockStatus(stockStatus StockStatus) *SearchCriteri
 	return sc
 }
 
+// SetCatalogVisibility sets the catalog visibility filter
+func (sc *SearchCriteria) SetCatalogVisibility(visibility CatalogVisibility) *SearchCriteria {
+	sc.CatalogVisibility = visibility
+	return sc
+}
+
+// SetPurchasableOnly sets whether only purchasable products are kept
+func (sc *SearchCriteria) SetPurchasableOnly(purchasableOnly bool) *SearchCriteria {
+	sc.PurchasableOnly = purchasableOnly
+	return sc
+}
+
+// SetInclude limits the result set to the given product IDs
+func (sc *SearchCriteria) SetInclude(ids []int) *SearchCriteria {
+	sc.Include = ids
+	return sc
+}
+
+// SetParent limits the result set to children of the given product IDs
+func (sc *SearchCriteria) SetParent(ids []int) *SearchCriteria {
+	sc.Parent = ids
+	return sc
+}
+
+// SetModifiedRange sets the modification window; either bound may be nil
+func (sc *SearchCriteria) SetModifiedRange(after, before *time.Time) *SearchCriteria {
+	sc.ModifiedAfter = after
+	sc.ModifiedBefore = before
+	return sc
+}
+
 // SetPagination sets pagination parameters
 func (sc *SearchCriteria) SetPagination(page, perPage int) *SearchCriteria {
 	sc.Page = page
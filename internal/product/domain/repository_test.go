@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSearchCriteriaCarriesNoCredentials(t *testing.T) {
+	criteriaType := reflect.TypeOf(SearchCriteria{})
+	for i := 0; i < criteriaType.NumField(); i++ {
+		name := strings.ToLower(criteriaType.Field(i).Name)
+		for _, auth := range []string{"consumer", "secret", "password", "credential", "baseurl"} {
+			if strings.Contains(name, auth) {
+				t.Errorf("SearchCriteria has the auth field %s", criteriaType.Field(i).Name)
+			}
+		}
+	}
+
+	data, err := json.Marshal(NewSearchCriteria().SetSearch("shoes"))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	for _, auth := range []string{"consumer_key", "consumer_secret", "base_url"} {
+		if strings.Contains(strings.ToLower(string(data)), auth) {
+			t.Errorf("criteria %s contain %s", data, auth)
+		}
+	}
+}
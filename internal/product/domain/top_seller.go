@@ -0,0 +1,33 @@
+package domain
+
+// SalesPeriod is the reporting window of a top sellers report
+type SalesPeriod string
+
+const (
+	SalesPeriodWeek      SalesPeriod = "week"
+	SalesPeriodMonth     SalesPeriod = "month"
+	SalesPeriodLastMonth SalesPeriod = "last_month"
+	SalesPeriodYear      SalesPeriod = "year"
+)
+
+// IsValid checks if the sales period is valid
+func (p SalesPeriod) IsValid() bool {
+	switch p {
+	case SalesPeriodWeek, SalesPeriodMonth, SalesPeriodLastMonth, SalesPeriodYear:
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns string representation
+func (p SalesPeriod) String() string {
+	return string(p)
+}
+
+// TopSeller is a product's sales within a reporting period
+type TopSeller struct {
+	ProductID int
+	Name      string
+	Quantity  int
+}
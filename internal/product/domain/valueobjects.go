@@ -97,6 +97,30 @@ func (ps ProductStatus) String() string {
 	return string(ps)
 }
 
+// ProductRelation represents a kind of product relationship
+type ProductRelation string
+
+const (
+	ProductRelationRelated   ProductRelation = "related"
+	ProductRelationUpsell    ProductRelation = "upsell"
+	ProductRelationCrossSell ProductRelation = "cross_sell"
+)
+
+// IsValid checks if the product relation is valid
+func (pr ProductRelation) IsValid() bool {
+	switch pr {
+	case ProductRelationRelated, ProductRelationUpsell, ProductRelationCrossSell:
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns string representation
+func (pr ProductRelation) String() string {
+	return string(pr)
+}
+
 // StockStatus represents the stock status of a product
 type StockStatus string
 
@@ -121,6 +145,58 @@ func (ss StockStatus) String() string {
 	return string(ss)
 }
 
+// ParseStockStatus normalizes a stock status filter value, accepting case and
+// underscore variants such as "on_backorder" or "In_Stock"
+func ParseStockStatus(value string) StockStatus {
+	return StockStatus(strings.ReplaceAll(strings.ToLower(strings.TrimSpace(value)), "_", ""))
+}
+
+// CatalogVisibility represents where a product is shown in the storefront
+type CatalogVisibility string
+
+const (
+	CatalogVisibilityVisible CatalogVisibility = "visible"
+	CatalogVisibilityCatalog CatalogVisibility = "catalog"
+	CatalogVisibilitySearch  CatalogVisibility = "search"
+	CatalogVisibilityHidden  CatalogVisibility = "hidden"
+)
+
+// IsValid checks if the catalog visibility is valid
+func (cv CatalogVisibility) IsValid() bool {
+	switch cv {
+	case CatalogVisibilityVisible, CatalogVisibilityCatalog, CatalogVisibilitySearch, CatalogVisibilityHidden:
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns string representation
+func (cv CatalogVisibility) String() string {
+	return string(cv)
+}
+
+// TermOperator says how a filter listing several categories or tags matches
+type TermOperator string
+
+const (
+	// TermOperatorOr matches products in any of the listed terms, as
+	// WooCommerce does
+	TermOperatorOr TermOperator = "or"
+	// TermOperatorAnd matches products in all of the listed terms
+	TermOperatorAnd TermOperator = "and"
+)
+
+// IsValid checks if the term operator is valid
+func (to TermOperator) IsValid() bool {
+	switch to {
+	case TermOperatorOr, TermOperatorAnd:
+		return true
+	default:
+		return false
+	}
+}
+
 // Money represents a monetary value
 type Money struct {
 	amount   float64
@@ -141,20 +217,115 @@ func NewMoney(amount float64, currency string) (*Money, error) {
 	}, nil
 }
 
-// NewMoneyFromString creates Money from string representation
+// NewMoneyFromString creates Money from string representation. Common
+// thousands grouping is accepted, e.g. 1,299.00 and 1.299,00 both read as
+// 1299; see parseAmount.
 func NewMoneyFromString(value, currency string) (*Money, error) {
-	if value == "" {
+	if strings.TrimSpace(value) == "" {
 		return NewMoney(0, currency)
 	}
 
-	amount, err := strconv.ParseFloat(value, 64)
+	amount, err := parseAmount(value)
 	if err != nil {
-		return nil, domain.NewValidationError("invalid money format")
+		return nil, err
 	}
 
 	return NewMoney(amount, currency)
 }
 
+// parseAmount parses a price written with "." or "," as separators. The
+// WooCommerce API always writes prices as plain decimals; grouped values
+// come from callers, so the separators are guessed rather than taken from
+// the store's display format. Thousands separators must group digits by
+// three, and nothing but digits and the separators may appear, so
+// malformed values such as "abc" or "1,2,3" are rejected.
+//
+// The last of "." and "," in the value is the decimal separator when both
+// appear. A lone separator is a thousands separator when it appears more
+// than once, or, for ",", when exactly three digits follow it, so 1,299
+// reads as 1299 while 1.299 and 1,5 read as decimals.
+func parseAmount(value string) (float64, error) {
+	trimmed := strings.TrimSpace(value)
+	decimalSeparator, thousandSeparator := guessSeparators(trimmed)
+
+	digits := strings.TrimPrefix(trimmed, "-")
+	integer, fraction, hasFraction := strings.Cut(digits, decimalSeparator)
+	if hasFraction && !isDigits(fraction) {
+		return 0, invalidMoneyError(value)
+	}
+	if strings.Contains(integer, thousandSeparator) {
+		groups := strings.Split(integer, thousandSeparator)
+		if len(groups[0]) == 0 || len(groups[0]) > 3 {
+			return 0, invalidMoneyError(value)
+		}
+		for _, group := range groups[1:] {
+			if len(group) != 3 {
+				return 0, invalidMoneyError(value)
+			}
+		}
+		integer = strings.Join(groups, "")
+	}
+	if !isDigits(integer) && !(integer == "" && hasFraction && fraction != "") {
+		return 0, invalidMoneyError(value)
+	}
+
+	normalized := integer
+	if hasFraction {
+		normalized += "." + fraction
+	}
+	if strings.HasPrefix(trimmed, "-") {
+		normalized = "-" + normalized
+	}
+
+	amount, err := strconv.ParseFloat(normalized, 64)
+	if err != nil {
+		return 0, invalidMoneyError(value)
+	}
+	return amount, nil
+}
+
+// guessSeparators picks the decimal and thousands separators of a price
+// written with "." or ","
+func guessSeparators(value string) (decimalSeparator, thousandSeparator string) {
+	lastDot := strings.LastIndex(value, ".")
+	lastComma := strings.LastIndex(value, ",")
+
+	switch {
+	case lastDot >= 0 && lastComma >= 0:
+		if lastDot > lastComma {
+			return ".", ","
+		}
+		return ",", "."
+	case lastComma >= 0:
+		if strings.Count(value, ",") > 1 || len(value)-lastComma-1 == 3 {
+			return ".", ","
+		}
+		return ",", "."
+	case strings.Count(value, ".") > 1:
+		return ",", "."
+	default:
+		return ".", ","
+	}
+}
+
+// isDigits reports whether a non-empty string holds only ASCII digits
+func isDigits(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// invalidMoneyError reports a price that cannot be parsed
+func invalidMoneyError(value string) error {
+	return domain.NewValidationError(fmt.Sprintf("invalid money format %q: use digits with an optional decimal part, e.g. 1299.00 or 1,299.00", value))
+}
+
 // Amount returns the monetary amount
 func (m *Money) Amount() float64 {
 	return m.amount
@@ -190,6 +361,13 @@ type Dimensions struct {
 	Height string `json:"height"`
 }
 
+// MeasurementUnits represents the store-wide units of product weights and
+// dimensions (e.g. "kg" and "cm")
+type MeasurementUnits struct {
+	Weight    string
+	Dimension string
+}
+
 // NewDimensions creates new dimensions
 func NewDimensions(length, width, height string) *Dimensions {
 	return &Dimensions{
@@ -209,6 +387,12 @@ type Category struct {
 	ID   int    `json:"id"`
 	Name string `json:"name"`
 	Slug string `json:"slug"`
+	// Parent is the ID of the parent category, 0 for a top-level one. The
+	// categories embedded in a product do not carry it.
+	Parent int `json:"parent,omitempty"`
+	// Count is the number of products assigned to the category. The
+	// categories embedded in a product do not carry it either.
+	Count int `json:"count,omitempty"`
 }
 
 // NewCategory creates a new category
@@ -0,0 +1,58 @@
+package domain
+
+import "testing"
+
+func TestNewMoneyFromString(t *testing.T) {
+	for _, tc := range []struct {
+		value   string
+		want    float64
+		wantErr bool
+	}{
+		{value: "1,299.00", want: 1299},
+		{value: "1.299,00", want: 1299},
+		{value: "1299", want: 1299},
+		{value: "1,299", want: 1299},
+		{value: "1.234.567", want: 1234567},
+		{value: "1,5", want: 1.5},
+		{value: "1.299", want: 1.299},
+		{value: "12.50", want: 12.5},
+		{value: "", want: 0},
+		{value: "abc", wantErr: true},
+		{value: "1,2,3", wantErr: true},
+		{value: "12,34.5", wantErr: true},
+		{value: "-5", wantErr: true},
+	} {
+		money, err := NewMoneyFromString(tc.value, "EUR")
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("NewMoneyFromString(%q) = %v, want an error", tc.value, money.Amount())
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("NewMoneyFromString(%q): %v", tc.value, err)
+			continue
+		}
+		if money.Amount() != tc.want || money.Currency() != "EUR" {
+			t.Errorf("NewMoneyFromString(%q) = %v, want %v EUR", tc.value, money, tc.want)
+		}
+	}
+}
+
+func TestParseStockStatus(t *testing.T) {
+	for value, want := range map[string]StockStatus{
+		"onbackorder":    StockStatusOnBackorder,
+		"on_backorder":   StockStatusOnBackorder,
+		" On_Backorder ": StockStatusOnBackorder,
+		"In_Stock":       StockStatusInStock,
+		"outofstock":     StockStatusOutOfStock,
+		"":               "",
+	} {
+		if got := ParseStockStatus(value); got != want {
+			t.Errorf("ParseStockStatus(%q) = %q, want %q", value, got, want)
+		}
+	}
+	if status := ParseStockStatus("sold out"); status.IsValid() {
+		t.Errorf("ParseStockStatus(%q) = %q is valid, want an invalid status", "sold out", status)
+	}
+}
@@ -0,0 +1,65 @@
+package domain
+
+import "strings"
+
+// Variation represents a single variation of a variable product
+type Variation struct {
+	ID            *ProductID          `json:"id"`
+	ParentID      int                 `json:"parent_id"`
+	Status        ProductStatus       `json:"status"`
+	SKU           string              `json:"sku"`
+	Permalink     string              `json:"permalink"`
+	Price         *Money              `json:"price"`
+	RegularPrice  *Money              `json:"regular_price"`
+	SalePrice     *Money              `json:"sale_price"`
+	OnSale        bool                `json:"on_sale"`
+	Purchasable   bool                `json:"purchasable"`
+	ManageStock   bool                `json:"manage_stock"`
+	StockQuantity *int                `json:"stock_quantity"`
+	StockStatus   StockStatus         `json:"stock_status"`
+	Attributes    []*DefaultAttribute `json:"attributes"`
+}
+
+// NewVariation creates a new variation of the given parent product
+func NewVariation(id *ProductID, parentID int) *Variation {
+	return &Variation{
+		ID:         id,
+		ParentID:   parentID,
+		Attributes: make([]*DefaultAttribute, 0),
+	}
+}
+
+// MatchesAttributes reports whether the variation matches every requested
+// attribute name/value pair. Names and values are compared case-insensitively,
+// a "pa_" taxonomy prefix on names is ignored, and a variation attribute with
+// an empty option ("Any ...") matches every value.
+func (v *Variation) MatchesAttributes(wanted map[string]string) bool {
+	for name, value := range wanted {
+		attribute := v.attribute(name)
+		if attribute == nil {
+			return false
+		}
+		if attribute.Option != "" && !strings.EqualFold(strings.TrimSpace(attribute.Option), strings.TrimSpace(value)) {
+			return false
+		}
+	}
+	return true
+}
+
+// attribute finds a variation attribute by name
+func (v *Variation) attribute(name string) *DefaultAttribute {
+	name = normalizeAttributeName(name)
+	for _, attribute := range v.Attributes {
+		if normalizeAttributeName(attribute.Name) == name {
+			return attribute
+		}
+	}
+	return nil
+}
+
+// normalizeAttributeName lowercases an attribute name and strips the
+// "pa_" prefix of global attribute taxonomies
+func normalizeAttributeName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return strings.TrimPrefix(name, "pa_")
+}
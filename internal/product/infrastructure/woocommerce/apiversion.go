@@ -0,0 +1,157 @@
+package woocommerce
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"woocommerce-mcp/internal/product/domain"
+	"woocommerce-mcp/kit/requestid"
+)
+
+// APIVersionEnv is the environment variable pinning the WooCommerce REST API
+// version (v1, v2 or v3). When it is empty, v3 is used and v2 is tried once
+// on stores that have no v3 products route.
+const APIVersionEnv = "WC_API_VERSION"
+
+// WooCommerce REST API versions
+const (
+	// DefaultAPIVersion is the version used unless another one is pinned
+	DefaultAPIVersion = "v3"
+	// fallbackAPIVersion is probed when the default version is missing
+	fallbackAPIVersion = "v2"
+)
+
+// apiVersions lists the API versions that can be pinned
+var apiVersions = []string{"v1", "v2", "v3"}
+
+// normalizeAPIVersion accepts "v3", "V3" or "3"; other values are returned
+// unchanged so buildURL can reject them
+func normalizeAPIVersion(version string) string {
+	version = strings.ToLower(strings.TrimSpace(version))
+	if version != "" && !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	for _, valid := range apiVersions {
+		if version == valid {
+			return version
+		}
+	}
+	return strings.TrimSpace(version)
+}
+
+// validateAPIVersion checks a pinned API version; an empty one negotiates
+func validateAPIVersion(version string) error {
+	if version == "" {
+		return nil
+	}
+	for _, valid := range apiVersions {
+		if version == valid {
+			return nil
+		}
+	}
+	return domain.NewProductValidationError("api_version", fmt.Sprintf("invalid %s %q; must be one of %s", APIVersionEnv, version, strings.Join(apiVersions, ", ")))
+}
+
+// APIVersion returns the REST API version requests are sent to, and whether
+// it was negotiated because the store lacks the default version
+func (c *Client) APIVersion() (string, bool) {
+	if c.config.APIVersion != "" {
+		return c.config.APIVersion, false
+	}
+
+	c.apiVersion.Lock()
+	defer c.apiVersion.Unlock()
+	if c.apiVersion.negotiated != "" {
+		return c.apiVersion.negotiated, true
+	}
+	return DefaultAPIVersion, false
+}
+
+// route returns the REST route of a path under the API version in use, e.g.
+// "wc/v3/products" for "products"
+func (c *Client) route(path string) string {
+	version, _ := c.APIVersion()
+	return "wc/" + version + "/" + path
+}
+
+// fallBackAPIVersion decides whether a request that failed with err under the
+// given version should be retried. Only a missing REST API under the
+// default version of an unpinned client qualifies. The fallback version is
+// probed with a one-product request, one probe at a time and without
+// holding the lock other requests need to route; if it answers, later
+// requests use it. Once a probe got a definite answer it is not repeated,
+// while a probe that failed for a transient reason is tried again by the
+// next request.
+func (c *Client) fallBackAPIVersion(ctx context.Context, version string, err error) bool {
+	var apiErr *domain.WooCommerceAPIError
+	if c.config.APIVersion != "" || version != DefaultAPIVersion || !errors.As(err, &apiErr) || !apiErr.IsRESTAPINotFound() {
+		return false
+	}
+
+	for {
+		c.apiVersion.Lock()
+		if c.apiVersion.probed {
+			// Another request may have negotiated the fallback in the meantime
+			negotiated := c.apiVersion.negotiated != ""
+			c.apiVersion.Unlock()
+			return negotiated
+		}
+		if probing := c.apiVersion.probing; probing != nil {
+			// Wait for the probe in flight rather than sending another one
+			c.apiVersion.Unlock()
+			select {
+			case <-probing:
+				continue
+			case <-ctx.Done():
+				return false
+			}
+		}
+		probing := make(chan struct{})
+		c.apiVersion.probing = probing
+		c.apiVersion.Unlock()
+
+		available, definite := c.probeAPIVersion(ctx, fallbackAPIVersion)
+
+		c.apiVersion.Lock()
+		c.apiVersion.probing = nil
+		if definite {
+			c.apiVersion.probed = true
+			if available {
+				c.apiVersion.negotiated = fallbackAPIVersion
+			}
+		}
+		c.apiVersion.Unlock()
+		close(probing)
+
+		if available {
+			requestid.Logf(ctx, "Store %s has no wc/%s REST API; using wc/%s", c.config.BaseURL, DefaultAPIVersion, fallbackAPIVersion)
+		}
+		return available
+	}
+}
+
+// probeAPIVersion reports whether the store serves the products route of an
+// API version, and whether that answer is definite: the route answered or
+// the store said it has no such route. Connection errors, server errors and
+// a cancelled context leave the answer open.
+func (c *Client) probeAPIVersion(ctx context.Context, version string) (available, definite bool) {
+	u, err := c.buildURL("wc/" + version + "/products")
+	if err != nil {
+		return false, false
+	}
+	query := u.Query()
+	c.addAuthParams(query)
+	query.Set("per_page", "1")
+	u.RawQuery = query.Encode()
+
+	var body json.RawMessage
+	_, err = c.api().DoJSON(ctx, "GET", u.String(), &body)
+	err = checkRESTAPIAvailable(err, body)
+	if err == nil {
+		return true, true
+	}
+	var apiErr *domain.WooCommerceAPIError
+	return false, errors.As(err, &apiErr) && apiErr.IsRESTAPINotFound()
+}
@@ -0,0 +1,185 @@
+package woocommerce
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"woocommerce-mcp/internal/product/domain"
+	"woocommerce-mcp/kit/retry"
+)
+
+// legacyStore is a store serving only the wc/v2 REST API. v2 answers the
+// products route with the handler given; v2Probes counts its one-product
+// probes.
+type legacyStore struct {
+	mu       sync.Mutex
+	v2Probes int
+	v2       func(w http.ResponseWriter, r *http.Request)
+}
+
+func (s *legacyStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/wp-json/wc/v2/products":
+		if r.URL.Query().Get("per_page") == "1" {
+			s.mu.Lock()
+			s.v2Probes++
+			s.mu.Unlock()
+		}
+		s.v2(w, r)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"code":"rest_no_route","message":"No route was found matching the URL and request method."}`))
+	}
+}
+
+func (s *legacyStore) probes() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.v2Probes
+}
+
+// writeProducts answers with an empty product list
+func writeProducts(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(`[]`))
+}
+
+func TestAPIVersionFallsBackToV2(t *testing.T) {
+	t.Setenv(retry.MaxRetriesEnv, "0")
+	store := &legacyStore{v2: func(w http.ResponseWriter, r *http.Request) { writeProducts(w) }}
+	server := httptest.NewServer(store)
+	defer server.Close()
+
+	client := NewClient(NewConfig(server.URL, "ck", "cs"))
+	for i := 0; i < 2; i++ {
+		if _, err := client.SearchProducts(context.Background(), domain.NewSearchCriteria()); err != nil {
+			t.Fatalf("search %d: %v", i+1, err)
+		}
+	}
+	if version, negotiated := client.APIVersion(); version != "v2" || !negotiated {
+		t.Errorf("APIVersion() = %s, %v; want v2 negotiated", version, negotiated)
+	}
+	if got := store.probes(); got != 1 {
+		t.Errorf("v2 was probed %d times, want once", got)
+	}
+}
+
+func TestAPIVersionProbeIsRetriedAfterTransientFailure(t *testing.T) {
+	t.Setenv(retry.MaxRetriesEnv, "0")
+	var mu sync.Mutex
+	unavailable := true
+	store := &legacyStore{v2: func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if unavailable {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		writeProducts(w)
+	}}
+	server := httptest.NewServer(store)
+	defer server.Close()
+
+	client := NewClient(NewConfig(server.URL, "ck", "cs"))
+	if _, err := client.SearchProducts(context.Background(), domain.NewSearchCriteria()); err == nil {
+		t.Fatal("search succeeded while v2 was unavailable")
+	}
+
+	mu.Lock()
+	unavailable = false
+	mu.Unlock()
+	if _, err := client.SearchProducts(context.Background(), domain.NewSearchCriteria()); err != nil {
+		t.Fatalf("search after v2 recovered: %v", err)
+	}
+	if version, _ := client.APIVersion(); version != "v2" {
+		t.Errorf("APIVersion() = %s after v2 recovered, want v2", version)
+	}
+	if got := store.probes(); got != 2 {
+		t.Errorf("v2 was probed %d times, want 2", got)
+	}
+}
+
+func TestAPIVersionProbeIsNotRepeatedForMissingRoute(t *testing.T) {
+	t.Setenv(retry.MaxRetriesEnv, "0")
+	store := &legacyStore{v2: func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"code":"rest_no_route","message":"No route"}`))
+	}}
+	server := httptest.NewServer(store)
+	defer server.Close()
+
+	client := NewClient(NewConfig(server.URL, "ck", "cs"))
+	for i := 0; i < 2; i++ {
+		if _, err := client.SearchProducts(context.Background(), domain.NewSearchCriteria()); err == nil {
+			t.Fatalf("search %d succeeded on a store without products route", i+1)
+		}
+	}
+	if got := store.probes(); got != 1 {
+		t.Errorf("v2 was probed %d times, want once", got)
+	}
+	if version, negotiated := client.APIVersion(); version != "v3" || negotiated {
+		t.Errorf("APIVersion() = %s, %v; want v3 not negotiated", version, negotiated)
+	}
+}
+
+func TestAPIVersionProbeDoesNotBlockOtherRequests(t *testing.T) {
+	t.Setenv(retry.MaxRetriesEnv, "0")
+	probing := make(chan struct{})
+	release := make(chan struct{})
+	var once sync.Once
+	store := &legacyStore{v2: func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("per_page") == "1" {
+			once.Do(func() { close(probing) })
+			<-release
+		}
+		writeProducts(w)
+	}}
+	server := httptest.NewServer(store)
+	defer server.Close()
+
+	client := NewClient(NewConfig(server.URL, "ck", "cs"))
+	results := make(chan error, 2)
+	go func() {
+		_, err := client.SearchProducts(context.Background(), domain.NewSearchCriteria())
+		results <- err
+	}()
+	<-probing
+
+	// Routing does not wait for the probe in flight
+	routed := make(chan string, 1)
+	go func() {
+		version, _ := client.APIVersion()
+		routed <- version
+	}()
+	select {
+	case version := <-routed:
+		if version != "v3" {
+			t.Errorf("APIVersion() = %s during the probe, want v3", version)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("APIVersion() blocked behind the probe")
+	}
+
+	// A concurrent search waits for the probe instead of sending another
+	go func() {
+		_, err := client.SearchProducts(context.Background(), domain.NewSearchCriteria())
+		results <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	for i := 0; i < 2; i++ {
+		if err := <-results; err != nil {
+			t.Errorf("search: %v", err)
+		}
+	}
+	if got := store.probes(); got != 1 {
+		t.Errorf("v2 was probed %d times, want once", got)
+	}
+}
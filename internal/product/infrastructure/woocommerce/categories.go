@@ -0,0 +1,67 @@
+package woocommerce
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+	"woocommerce-mcp/internal/product/domain"
+	"woocommerce-mcp/kit/pagination"
+)
+
+// GetCategories retrieves product categories by ID, one include-filtered
+// request per page of IDs
+func (c *Client) GetCategories(ctx context.Context, ids []int) ([]*domain.Category, error) {
+	var categories []*domain.Category
+	for start := 0; start < len(ids); start += pagination.MaxPerPage {
+		end := start + pagination.MaxPerPage
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		include := make([]string, 0, end-start)
+		for _, id := range ids[start:end] {
+			include = append(include, strconv.Itoa(id))
+		}
+
+		var apiCategories []APICategory
+		params := url.Values{}
+		params.Set("include", strings.Join(include, ","))
+		params.Set("per_page", strconv.Itoa(pagination.MaxPerPage))
+		if err := c.getJSON(ctx, c.route("products/categories"), params, &apiCategories); err != nil {
+			return nil, err
+		}
+
+		for _, apiCategory := range apiCategories {
+			categories = append(categories, apiCategoryToDomain(apiCategory))
+		}
+	}
+
+	return categories, nil
+}
+
+// ListCategories retrieves one page of all product categories, with
+// pagination.MaxPerPage categories per page
+func (c *Client) ListCategories(ctx context.Context, page int) ([]*domain.Category, error) {
+	var apiCategories []APICategory
+	params := url.Values{}
+	params.Set("page", strconv.Itoa(page))
+	params.Set("per_page", strconv.Itoa(pagination.MaxPerPage))
+	if err := c.getJSON(ctx, c.route("products/categories"), params, &apiCategories); err != nil {
+		return nil, err
+	}
+
+	categories := make([]*domain.Category, 0, len(apiCategories))
+	for _, apiCategory := range apiCategories {
+		categories = append(categories, apiCategoryToDomain(apiCategory))
+	}
+	return categories, nil
+}
+
+// apiCategoryToDomain converts a category of the categories endpoint
+func apiCategoryToDomain(apiCategory APICategory) *domain.Category {
+	category := domain.NewCategory(apiCategory.ID, apiCategory.Name, apiCategory.Slug)
+	category.Parent = apiCategory.Parent
+	category.Count = apiCategory.Count
+	return category
+}
@@ -0,0 +1,207 @@
+package woocommerce
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+	"woocommerce-mcp/internal/product/domain"
+)
+
+// Environment variables configuring the per-host circuit breaker
+const (
+	CircuitBreakerThresholdEnv = "CIRCUIT_BREAKER_FAILURE_THRESHOLD"
+	CircuitBreakerWindowEnv    = "CIRCUIT_BREAKER_WINDOW"
+	CircuitBreakerCooldownEnv  = "CIRCUIT_BREAKER_COOLDOWN"
+)
+
+// Circuit breaker defaults
+const (
+	DefaultCircuitBreakerThreshold = 5
+	DefaultCircuitBreakerWindow    = 30 * time.Second
+	DefaultCircuitBreakerCooldown  = 30 * time.Second
+)
+
+// CircuitBreakerConfig configures the circuit breaker. A threshold of zero
+// disables it.
+type CircuitBreakerConfig struct {
+	// Threshold is the number of consecutive failures that opens the circuit
+	Threshold int
+	// Window is the span within which the failures must occur
+	Window time.Duration
+	// Cooldown is how long the circuit stays open before a probe is allowed
+	Cooldown time.Duration
+}
+
+// circuitBreakerConfigFromEnv reads the circuit breaker configuration,
+// falling back to the defaults for unset or invalid values
+func circuitBreakerConfigFromEnv() CircuitBreakerConfig {
+	config := CircuitBreakerConfig{
+		Threshold: DefaultCircuitBreakerThreshold,
+		Window:    DefaultCircuitBreakerWindow,
+		Cooldown:  DefaultCircuitBreakerCooldown,
+	}
+
+	if value := os.Getenv(CircuitBreakerThresholdEnv); value != "" {
+		if threshold, err := strconv.Atoi(value); err == nil && threshold >= 0 {
+			config.Threshold = threshold
+		}
+	}
+	if value := os.Getenv(CircuitBreakerWindowEnv); value != "" {
+		if window, err := time.ParseDuration(value); err == nil && window > 0 {
+			config.Window = window
+		}
+	}
+	if value := os.Getenv(CircuitBreakerCooldownEnv); value != "" {
+		if cooldown, err := time.ParseDuration(value); err == nil && cooldown > 0 {
+			config.Cooldown = cooldown
+		}
+	}
+
+	return config
+}
+
+// circuitState is the state of a circuit breaker
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreaker stops sending requests to a store host after repeated
+// failures. Once the cooldown has passed, a single probe request is let
+// through: success closes the circuit, failure opens it again.
+type circuitBreaker struct {
+	mu           sync.Mutex
+	config       CircuitBreakerConfig
+	state        circuitState
+	failures     int
+	firstFailure time.Time
+	openedAt     time.Time
+	probing      bool
+}
+
+// allow reports whether a request may be sent, returning how long until
+// the next probe when it may not
+func (cb *circuitBreaker) allow(now time.Time) (bool, time.Duration) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case circuitOpen:
+		if wait := cb.openedAt.Add(cb.config.Cooldown).Sub(now); wait > 0 {
+			return false, wait
+		}
+		cb.state = circuitHalfOpen
+		cb.probing = true
+		return true, 0
+	case circuitHalfOpen:
+		if cb.probing {
+			return false, cb.config.Cooldown
+		}
+		cb.probing = true
+		return true, 0
+	default:
+		return true, 0
+	}
+}
+
+// record updates the breaker with the outcome of a request
+func (cb *circuitBreaker) record(success bool, now time.Time) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if success {
+		cb.state = circuitClosed
+		cb.failures = 0
+		cb.probing = false
+		return
+	}
+
+	if cb.state == circuitHalfOpen {
+		cb.trip(now)
+		return
+	}
+
+	// Only consecutive failures within the window count
+	if cb.failures == 0 || now.Sub(cb.firstFailure) > cb.config.Window {
+		cb.failures = 0
+		cb.firstFailure = now
+	}
+	cb.failures++
+	if cb.failures >= cb.config.Threshold {
+		cb.trip(now)
+	}
+}
+
+// release frees the half-open probe slot without recording an outcome
+func (cb *circuitBreaker) release() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.probing = false
+}
+
+// trip opens the circuit. The caller must hold the lock.
+func (cb *circuitBreaker) trip(now time.Time) {
+	cb.state = circuitOpen
+	cb.openedAt = now
+	cb.failures = 0
+	cb.probing = false
+}
+
+// circuitBreakers holds one breaker per store host. Clients are created per
+// request, so the registry is shared at package level.
+var circuitBreakers = struct {
+	sync.Mutex
+	byHost map[string]*circuitBreaker
+}{byHost: make(map[string]*circuitBreaker)}
+
+// circuitBreakerFor returns the breaker of a host, creating it on first use
+func circuitBreakerFor(host string, config CircuitBreakerConfig) *circuitBreaker {
+	circuitBreakers.Lock()
+	defer circuitBreakers.Unlock()
+
+	breaker, ok := circuitBreakers.byHost[host]
+	if !ok {
+		breaker = &circuitBreaker{config: config}
+		circuitBreakers.byHost[host] = breaker
+	}
+	return breaker
+}
+
+// do sends a request through the store host's circuit breaker. Transport
+// errors and 5xx responses count as failures; other responses show the
+// store is up and count as successes.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	if c.config.CircuitBreaker.Threshold <= 0 {
+		resp, err := c.httpClient.Do(req)
+		if err == nil {
+			c.noteRedirect(req, resp)
+		}
+		return resp, err
+	}
+
+	breaker := circuitBreakerFor(req.URL.Host, c.config.CircuitBreaker)
+	if ok, wait := breaker.allow(time.Now()); !ok {
+		return nil, domain.NewConnectionError(req.URL.Host, fmt.Sprintf(
+			"store temporarily unavailable after repeated failures; retry in %s", wait.Round(time.Second)))
+	}
+
+	resp, err := c.httpClient.Do(req)
+	switch {
+	case err != nil && req.Context().Err() != nil:
+		// A cancelled call says nothing about the store; release the probe slot
+		breaker.release()
+	default:
+		breaker.record(err == nil && resp.StatusCode < http.StatusInternalServerError, time.Now())
+	}
+	if err == nil {
+		c.noteRedirect(req, resp)
+	}
+
+	return resp, err
+}
@@ -0,0 +1,151 @@
+package woocommerce
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"woocommerce-mcp/internal/product/domain"
+	"woocommerce-mcp/kit/retry"
+)
+
+func TestCircuitBreakerTransitions(t *testing.T) {
+	breaker := &circuitBreaker{config: CircuitBreakerConfig{Threshold: 2, Window: time.Minute, Cooldown: 10 * time.Second}}
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	// Closed: failures below the threshold let requests through
+	breaker.record(false, start)
+	if ok, _ := breaker.allow(start); !ok || breaker.state != circuitClosed {
+		t.Fatalf("after one failure: allowed %v in state %d, want closed", ok, breaker.state)
+	}
+
+	// Open: the threshold is reached and requests are refused until the cooldown
+	breaker.record(false, start.Add(time.Second))
+	if ok, wait := breaker.allow(start.Add(5 * time.Second)); ok || wait != 6*time.Second || breaker.state != circuitOpen {
+		t.Fatalf("after two failures: allowed %v, wait %s in state %d, want open for 6s", ok, wait, breaker.state)
+	}
+
+	// Half-open: a single probe is let through once the cooldown has passed
+	probeTime := start.Add(11 * time.Second)
+	if ok, _ := breaker.allow(probeTime); !ok || breaker.state != circuitHalfOpen {
+		t.Fatalf("after the cooldown: allowed %v in state %d, want a half-open probe", ok, breaker.state)
+	}
+	if ok, _ := breaker.allow(probeTime); ok {
+		t.Fatal("a second request was allowed while the probe is in flight")
+	}
+
+	// Closed again: the probe succeeded
+	breaker.record(true, probeTime)
+	if ok, _ := breaker.allow(probeTime); !ok || breaker.state != circuitClosed {
+		t.Fatalf("after a successful probe: allowed %v in state %d, want closed", ok, breaker.state)
+	}
+}
+
+func TestCircuitBreakerReopensWhenTheProbeFails(t *testing.T) {
+	breaker := &circuitBreaker{config: CircuitBreakerConfig{Threshold: 1, Window: time.Minute, Cooldown: 10 * time.Second}}
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	breaker.record(false, start)
+	probeTime := start.Add(10 * time.Second)
+	if ok, _ := breaker.allow(probeTime); !ok {
+		t.Fatal("the probe was not allowed after the cooldown")
+	}
+	breaker.record(false, probeTime)
+
+	if ok, wait := breaker.allow(probeTime.Add(time.Second)); ok || wait != 9*time.Second || breaker.state != circuitOpen {
+		t.Errorf("after a failed probe: allowed %v, wait %s in state %d, want open for a new cooldown", ok, wait, breaker.state)
+	}
+}
+
+func TestCircuitBreakerReleasesACancelledProbe(t *testing.T) {
+	breaker := &circuitBreaker{config: CircuitBreakerConfig{Threshold: 1, Window: time.Minute, Cooldown: time.Second}}
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	breaker.record(false, start)
+	probeTime := start.Add(time.Second)
+	breaker.allow(probeTime)
+	breaker.release()
+
+	if ok, _ := breaker.allow(probeTime); !ok {
+		t.Error("a new probe was refused after the cancelled one was released")
+	}
+}
+
+func TestCircuitBreakerOnlyCountsFailuresWithinTheWindow(t *testing.T) {
+	breaker := &circuitBreaker{config: CircuitBreakerConfig{Threshold: 2, Window: time.Minute, Cooldown: time.Minute}}
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	breaker.record(false, start)
+	breaker.record(false, start.Add(2*time.Minute))
+	if breaker.state != circuitClosed {
+		t.Errorf("failures two minutes apart opened the circuit")
+	}
+
+	breaker.record(true, start.Add(2*time.Minute))
+	breaker.record(false, start.Add(3*time.Minute))
+	if breaker.state != circuitClosed {
+		t.Errorf("a success did not reset the consecutive failures")
+	}
+}
+
+func TestCircuitBreakerConfigFromEnvironment(t *testing.T) {
+	t.Setenv(CircuitBreakerThresholdEnv, "3")
+	t.Setenv(CircuitBreakerWindowEnv, "10s")
+	t.Setenv(CircuitBreakerCooldownEnv, "not a duration")
+
+	config := circuitBreakerConfigFromEnv()
+	if config.Threshold != 3 || config.Window != 10*time.Second || config.Cooldown != DefaultCircuitBreakerCooldown {
+		t.Errorf("config = %+v, want threshold 3, window 10s and the default cooldown", config)
+	}
+}
+
+func TestCircuitBreakerShortCircuitsAFailingStore(t *testing.T) {
+	t.Setenv(retry.MaxRetriesEnv, "0")
+	t.Setenv(CircuitBreakerThresholdEnv, "2")
+	t.Setenv(CircuitBreakerCooldownEnv, "100ms")
+
+	var failing atomic.Bool
+	failing.Store(true)
+	store, baseURL := startStub(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if failing.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"code":"internal_server_error","message":"Database down"}`))
+			return
+		}
+		w.Write([]byte(`[]`))
+	})
+	search := func() error {
+		client := NewClient(NewConfig(baseURL, "ck", "cs"))
+		_, err := client.SearchProducts(context.Background(), domain.NewSearchCriteria())
+		return err
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := search(); err == nil {
+			t.Fatalf("request %d to the failing store succeeded", i+1)
+		}
+	}
+	err := search()
+	if err == nil || !strings.Contains(err.Error(), "store temporarily unavailable") {
+		t.Fatalf("got error %v, want the circuit to be open", err)
+	}
+	if got := len(store.paths()); got != 2 {
+		t.Errorf("the store received %d requests, want the open circuit to stop the third", got)
+	}
+
+	// Once the cooldown has passed, the probe finds the store back up
+	failing.Store(false)
+	time.Sleep(150 * time.Millisecond)
+	for i := 0; i < 2; i++ {
+		if err := search(); err != nil {
+			t.Fatalf("request %d after recovery: %v", i+1, err)
+		}
+	}
+	if got := len(store.paths()); got != 4 {
+		t.Errorf("the store received %d requests, want the closed circuit to pass both", got)
+	}
+}
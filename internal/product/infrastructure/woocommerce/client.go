@@ -1,16 +1,25 @@
 package woocommerce
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
+	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 	"woocommerce-mcp/internal/product/domain"
+	"woocommerce-mcp/kit/contentencoding"
+	"woocommerce-mcp/kit/httpjson"
+	"woocommerce-mcp/kit/requestid"
+	"woocommerce-mcp/kit/retry"
+	"woocommerce-mcp/kit/storeconfig"
+	"woocommerce-mcp/kit/storehttp"
 )
 
 // Config represents WooCommerce API configuration
@@ -19,22 +28,61 @@ type Config struct {
 	ConsumerKey    string
 	ConsumerSecret string
 	Timeout        time.Duration
+	SettingsTTL    time.Duration
+	// ProxyURL routes outbound requests through an HTTP proxy. When empty,
+	// the standard HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment is used.
+	ProxyURL string
+	// CircuitBreaker stops calling a store host after repeated failures
+	CircuitBreaker CircuitBreakerConfig
+	// APIVersion pins the REST API version, e.g. "v2". When empty, v3 is
+	// used and v2 is tried once if the store has no v3 products route.
+	APIVersion string
 }
 
 // NewConfig creates a new WooCommerce configuration
 func NewConfig(baseURL, consumerKey, consumerSecret string) *Config {
 	return &Config{
-		BaseURL:        strings.TrimSuffix(baseURL, "/"),
+		BaseURL:        storehttp.NormalizeBaseURL(baseURL),
 		ConsumerKey:    consumerKey,
 		ConsumerSecret: consumerSecret,
 		Timeout:        30 * time.Second,
+		ProxyURL:       storehttp.ProxyURL(),
+		SettingsTTL:    DefaultSettingsTTL,
+		CircuitBreaker: circuitBreakerConfigFromEnv(),
+		APIVersion:     normalizeAPIVersion(os.Getenv(APIVersionEnv)),
 	}
 }
 
+// NewConfigFromContext creates the configuration of the store carried by a
+// context (see storeconfig.WithStore)
+func NewConfigFromContext(ctx context.Context) (*Config, error) {
+	store, ok := storeconfig.FromContext(ctx)
+	if !ok || !store.HasCredentials() {
+		return nil, domain.NewProductValidationError("store", "base_url, consumer_key and consumer_secret are required")
+	}
+	return NewConfig(store.BaseURL, store.ConsumerKey, store.ConsumerSecret), nil
+}
+
 // Client represents a WooCommerce API client
 type Client struct {
 	config     *Config
 	httpClient *http.Client
+
+	// redirect holds the base URL the store last redirected a request to
+	redirect struct {
+		sync.Mutex
+		target string
+	}
+
+	// apiVersion holds the API version negotiated with a store lacking the
+	// default one
+	apiVersion struct {
+		sync.Mutex
+		negotiated string
+		probed     bool
+		// probing is closed when the probe in flight finishes
+		probing chan struct{}
+	}
 }
 
 // NewClient creates a new WooCommerce client
@@ -42,20 +90,44 @@ func NewClient(config *Config) *Client {
 	return &Client{
 		config: config,
 		httpClient: &http.Client{
-			Timeout: config.Timeout,
+			Timeout:   config.Timeout,
+			Transport: requestid.NewTransport(retry.NewTransport(contentencoding.NewTransport(storehttp.NewTransport(config.ProxyURL)))),
 		},
 	}
 }
 
-// SearchProducts searches for products using the WooCommerce API
+// CloseIdleConnections closes the keep-alive connections the client is not
+// using. Requests in flight are unaffected, and the client stays usable.
+func (c *Client) CloseIdleConnections() {
+	c.httpClient.CloseIdleConnections()
+}
+
+// SearchProducts searches for products using the WooCommerce API. Products
+// that cannot be read are skipped; see SearchProductsPartial.
 func (c *Client) SearchProducts(ctx context.Context, criteria *domain.SearchCriteria) ([]*domain.Product, error) {
-	// Build the API endpoint URL
-	endpoint := fmt.Sprintf("%s/wp-json/wc/v3/products", c.config.BaseURL)
+	products, _, err := c.SearchProductsPartial(ctx, criteria)
+	return products, err
+}
+
+// SearchProductsPartial searches for products using the WooCommerce API and
+// also returns the number of products skipped because they could not be read
+// (e.g. a malformed ID), so one bad product does not fail the whole page.
+// Skipped product IDs are logged.
+func (c *Client) SearchProductsPartial(ctx context.Context, criteria *domain.SearchCriteria) ([]*domain.Product, int, error) {
+	version, _ := c.APIVersion()
+	products, skipped, err := c.searchProductsPartial(ctx, criteria)
+	if err != nil && c.fallBackAPIVersion(ctx, version, err) {
+		return c.searchProductsPartial(ctx, criteria)
+	}
+	return products, skipped, err
+}
 
-	// Parse base URL
-	u, err := url.Parse(endpoint)
+// searchProductsPartial runs one product search under the API version in use
+func (c *Client) searchProductsPartial(ctx context.Context, criteria *domain.SearchCriteria) ([]*domain.Product, int, error) {
+	// Build the API endpoint URL
+	u, err := c.buildURL(c.route("products"))
 	if err != nil {
-		return nil, domain.NewConnectionError(endpoint, fmt.Sprintf("invalid base URL: %v", err))
+		return nil, 0, err
 	}
 
 	// Build query parameters
@@ -65,58 +137,74 @@ func (c *Client) SearchProducts(ctx context.Context, criteria *domain.SearchCrit
 
 	u.RawQuery = query.Encode()
 
-	// Create HTTP request
-	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Make HTTP request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, domain.NewConnectionError(u.String(), fmt.Sprintf("HTTP request failed: %v", err))
-	}
-	defer resp.Body.Close()
-
-	// Read response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
+	// Keep the body raw until it is known to be a product list
+	var body json.RawMessage
+	_, err = c.api().DoJSON(ctx, "GET", u.String(), &body)
 
-	// Check for HTTP errors
-	if resp.StatusCode != http.StatusOK {
-		return nil, c.handleAPIError(resp.StatusCode, body)
+	// Detect stores where the route is missing or WordPress serves something else
+	if err := checkRESTAPIAvailable(err, body); err != nil {
+		return nil, 0, err
 	}
 
-	// Parse JSON response
-	var apiProducts []APIProduct
-	if err := json.Unmarshal(body, &apiProducts); err != nil {
-		return nil, fmt.Errorf("failed to parse JSON response: %w", err)
+	// Parse JSON response one product at a time, so a product with
+	// unexpected field types does not fail the whole page
+	var rawProducts []json.RawMessage
+	if err := json.Unmarshal(body, &rawProducts); err != nil {
+		return nil, 0, fmt.Errorf("failed to parse JSON response: %w", err)
 	}
 
 	// Convert API products to domain products
-	products := make([]*domain.Product, len(apiProducts))
-	for i, apiProduct := range apiProducts {
+	products := make([]*domain.Product, 0, len(rawProducts))
+	var skipped []string
+	for _, rawProduct := range rawProducts {
+		var apiProduct APIProduct
+		if err := json.Unmarshal(rawProduct, &apiProduct); err != nil {
+			skipped = append(skipped, fmt.Sprintf("%s (%v)", rawProductID(rawProduct), err))
+			continue
+		}
 		domainProduct, err := c.apiProductToDomain(&apiProduct)
 		if err != nil {
-			return nil, fmt.Errorf("failed to convert product %d: %w", apiProduct.ID, err)
+			skipped = append(skipped, fmt.Sprintf("%d (%v)", apiProduct.ID, err))
+			continue
 		}
-		products[i] = domainProduct
+		products = append(products, domainProduct)
 	}
 
-	return products, nil
+	if len(skipped) > 0 {
+		requestid.Logf(ctx, "Skipped %d unreadable product(s) from %s: %s", len(skipped), c.config.BaseURL, strings.Join(skipped, ", "))
+	}
+
+	return products, len(skipped), nil
+}
+
+// rawProductID extracts the ID of a product that failed to parse, for logging
+func rawProductID(rawProduct json.RawMessage) string {
+	var product struct {
+		ID json.RawMessage `json:"id"`
+	}
+	if err := json.Unmarshal(rawProduct, &product); err != nil || len(product.ID) == 0 {
+		return "unknown"
+	}
+	return string(product.ID)
 }
 
 // CountProducts counts products matching the criteria
 func (c *Client) CountProducts(ctx context.Context, criteria *domain.SearchCriteria) (int64, error) {
+	version, _ := c.APIVersion()
+	total, err := c.countProducts(ctx, criteria)
+	if err != nil && c.fallBackAPIVersion(ctx, version, err) {
+		return c.countProducts(ctx, criteria)
+	}
+	return total, err
+}
+
+// countProducts runs one product count under the API version in use
+func (c *Client) countProducts(ctx context.Context, criteria *domain.SearchCriteria) (int64, error) {
 	// For WooCommerce API, we need to make a HEAD request or parse headers
 	// Since WooCommerce doesn't provide a direct count endpoint, we'll use the X-WP-Total header
-	endpoint := fmt.Sprintf("%s/wp-json/wc/v3/products", c.config.BaseURL)
-
-	u, err := url.Parse(endpoint)
+	u, err := c.buildURL(c.route("products"))
 	if err != nil {
-		return 0, domain.NewConnectionError(endpoint, fmt.Sprintf("invalid base URL: %v", err))
+		return 0, err
 	}
 
 	// Build query parameters (same as search but we only need the count)
@@ -129,26 +217,13 @@ func (c *Client) CountProducts(ctx context.Context, criteria *domain.SearchCrite
 
 	u.RawQuery = query.Encode()
 
-	// Create HTTP request
-	req, err := http.NewRequestWithContext(ctx, "HEAD", u.String(), nil)
-	if err != nil {
-		return 0, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Make HTTP request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return 0, domain.NewConnectionError(u.String(), fmt.Sprintf("HTTP request failed: %v", err))
-	}
-	defer resp.Body.Close()
-
-	// Check for HTTP errors
-	if resp.StatusCode != http.StatusOK {
-		return 0, c.handleAPIError(resp.StatusCode, nil)
+	header, err := c.api().DoJSON(ctx, "HEAD", u.String(), nil)
+	if err := checkRESTAPIAvailable(err, nil); err != nil {
+		return 0, err
 	}
 
 	// Get total count from header
-	totalHeader := resp.Header.Get("X-WP-Total")
+	totalHeader := header.Get("X-WP-Total")
 	if totalHeader == "" {
 		// Fallback: make a GET request and count manually
 		return c.countProductsFallback(ctx, criteria)
@@ -180,6 +255,21 @@ func (c *Client) countProductsFallback(ctx context.Context, criteria *domain.Sea
 	return int64(len(products)), nil
 }
 
+// buildURL resolves a REST route (e.g. "wc/v3/products") against the base URL,
+// appending it under wp-json relative to any path prefix of the store
+func (c *Client) buildURL(route string) (*url.URL, error) {
+	// Fail requests loudly rather than silently using another API version
+	if err := validateAPIVersion(c.config.APIVersion); err != nil {
+		return nil, err
+	}
+
+	u, err := storehttp.RouteURL(c.config.BaseURL, route)
+	if err != nil {
+		return nil, domain.NewConnectionError(c.config.BaseURL, err.Error())
+	}
+	return u, nil
+}
+
 // addAuthParams adds authentication parameters to the query
 func (c *Client) addAuthParams(query url.Values) {
 	query.Set("consumer_key", c.config.ConsumerKey)
@@ -191,20 +281,27 @@ func (c *Client) addSearchParams(query url.Values, criteria *domain.SearchCriter
 	if criteria.Search != "" {
 		query.Set("search", criteria.Search)
 	}
+	if criteria.SKU != "" {
+		query.Set("sku", criteria.SKU)
+	}
 	if criteria.Category != "" {
 		query.Set("category", criteria.Category)
 	}
 	if criteria.Tag != "" {
 		query.Set("tag", criteria.Tag)
 	}
+	if criteria.Brand != "" {
+		query.Set("brand", criteria.Brand)
+	}
 	if criteria.Status != "" {
 		query.Set("status", string(criteria.Status))
 	}
 	if criteria.Type != "" {
 		query.Set("type", string(criteria.Type))
 	}
-	if criteria.Featured != nil {
-		query.Set("featured", strconv.FormatBool(*criteria.Featured))
+	// WooCommerce ignores featured=false; non-featured filtering happens in the repository
+	if criteria.Featured != nil && *criteria.Featured {
+		query.Set("featured", "true")
 	}
 	if criteria.OnSale != nil {
 		query.Set("on_sale", strconv.FormatBool(*criteria.OnSale))
@@ -219,6 +316,32 @@ func (c *Client) addSearchParams(query url.Values, criteria *domain.SearchCriter
 		query.Set("stock_status", string(criteria.StockStatus))
 	}
 
+	if len(criteria.Include) > 0 {
+		ids := make([]string, len(criteria.Include))
+		for i, id := range criteria.Include {
+			ids[i] = strconv.Itoa(id)
+		}
+		query.Set("include", strings.Join(ids, ","))
+	}
+	if len(criteria.Parent) > 0 {
+		ids := make([]string, len(criteria.Parent))
+		for i, id := range criteria.Parent {
+			ids[i] = strconv.Itoa(id)
+		}
+		query.Set("parent", strings.Join(ids, ","))
+	}
+
+	// Modification window; the bounds are always sent in GMT
+	if criteria.ModifiedAfter != nil {
+		query.Set("modified_after", criteria.ModifiedAfter.UTC().Format("2006-01-02T15:04:05"))
+	}
+	if criteria.ModifiedBefore != nil {
+		query.Set("modified_before", criteria.ModifiedBefore.UTC().Format("2006-01-02T15:04:05"))
+	}
+	if criteria.ModifiedAfter != nil || criteria.ModifiedBefore != nil {
+		query.Set("dates_are_gmt", "true")
+	}
+
 	// Pagination
 	query.Set("per_page", strconv.Itoa(criteria.PerPage))
 	query.Set("page", strconv.Itoa(criteria.Page))
@@ -232,27 +355,57 @@ func (c *Client) addSearchParams(query url.Values, criteria *domain.SearchCriter
 	}
 }
 
-// handleAPIError handles API errors and converts them to domain errors
-func (c *Client) handleAPIError(statusCode int, body []byte) error {
-	message := string(body)
-	if len(body) == 0 {
-		message = http.StatusText(statusCode)
+// checkRESTAPIAvailable detects a disabled or unreachable WooCommerce REST API
+// from the outcome of a products request: a 404, an HTML page served in
+// place of the JSON product list or the WordPress REST index (an object
+// listing namespaces). Other errors are returned as they are.
+func checkRESTAPIAvailable(err error, body []byte) error {
+	var apiErr *domain.WooCommerceAPIError
+	if errors.As(err, &apiErr) && apiErr.IsNotFound() {
+		return domain.NewRESTAPINotFoundError(http.StatusNotFound)
 	}
-
-	// Try to parse error response for more details
-	var apiError struct {
-		Code    string `json:"code"`
-		Message string `json:"message"`
+	var notJSONErr *httpjson.NotJSONError
+	if errors.As(err, &notJSONErr) {
+		return domain.NewRESTAPINotFoundError(http.StatusOK)
 	}
-	if len(body) > 0 {
-		if err := json.Unmarshal(body, &apiError); err == nil {
-			if apiError.Message != "" {
-				message = apiError.Message
-			}
+	if err != nil {
+		return err
+	}
+
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		var index struct {
+			Namespaces []string `json:"namespaces"`
+		}
+		if err := json.Unmarshal(trimmed, &index); err == nil && index.Namespaces != nil {
+			return domain.NewRESTAPINotFoundError(http.StatusOK)
 		}
 	}
 
-	return domain.NewWooCommerceAPIError(statusCode, message, apiError.Code)
+	return nil
+}
+
+// api returns the JSON client for store requests, which go through the
+// circuit breaker and fail with domain errors
+func (c *Client) api() httpjson.Client {
+	return httpjson.Client{
+		Send: c.do,
+		ConnectionError: func(url string, err error) error {
+			return domain.NewConnectionError(url, fmt.Sprintf("HTTP request failed: %v", err))
+		},
+		StatusError: c.handleAPIError,
+	}
+}
+
+// handleAPIError handles API errors and converts them to domain errors
+func (c *Client) handleAPIError(statusCode int, header http.Header, body []byte) error {
+	message, code := httpjson.ErrorMessage(statusCode, body)
+
+	apiErr := domain.NewWooCommerceAPIError(statusCode, message, code)
+	if retryAfter, ok := retry.ParseRetryAfter(header.Get("Retry-After"), time.Now()); ok {
+		apiErr.RetryAfter = retryAfter
+	}
+	return apiErr
 }
 
 // apiProductToDomain converts an API product to a domain product
@@ -316,6 +469,23 @@ func (c *Client) apiProductToDomain(apiProduct *APIProduct) (*domain.Product, er
 			product.DateModified = dateModified
 		}
 	}
+	if apiProduct.DateCreatedGMT != "" {
+		if dateCreatedGMT, err := time.Parse("2006-01-02T15:04:05", apiProduct.DateCreatedGMT); err == nil {
+			product.DateCreatedGMT = dateCreatedGMT
+		}
+	}
+	if apiProduct.DateModifiedGMT != "" {
+		if dateModifiedGMT, err := time.Parse("2006-01-02T15:04:05", apiProduct.DateModifiedGMT); err == nil {
+			product.DateModifiedGMT = dateModifiedGMT
+		}
+	}
+
+	// Sale schedule; stores that keep it only in the legacy meta are read
+	// from there once the metadata is converted
+	product.DateOnSaleFrom = parseAPIDate(apiProduct.DateOnSaleFrom)
+	product.DateOnSaleFromGMT = parseAPIDate(apiProduct.DateOnSaleFromGMT)
+	product.DateOnSaleTo = parseAPIDate(apiProduct.DateOnSaleTo)
+	product.DateOnSaleToGMT = parseAPIDate(apiProduct.DateOnSaleToGMT)
 
 	// Set product type
 	if apiProduct.Type != "" {
@@ -407,6 +577,51 @@ func (c *Client) apiProductToDomain(apiProduct *APIProduct) (*domain.Product, er
 		metaData := domain.NewMetaData(apiMetaData.ID, apiMetaData.Key, apiMetaData.Value)
 		product.MetaData = append(product.MetaData, metaData)
 	}
+	if product.DateOnSaleFromGMT.IsZero() && product.DateOnSaleFrom.IsZero() {
+		product.DateOnSaleFromGMT = metaTimestamp(product.MetaData, "_sale_price_dates_from")
+	}
+	if product.DateOnSaleToGMT.IsZero() && product.DateOnSaleTo.IsZero() {
+		product.DateOnSaleToGMT = metaTimestamp(product.MetaData, "_sale_price_dates_to")
+	}
 
 	return product, nil
 }
+
+// parseAPIDate parses a date of the REST API, which has no offset; null,
+// empty and malformed dates return the zero time
+func parseAPIDate(value string) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	date, err := time.Parse("2006-01-02T15:04:05", value)
+	if err != nil {
+		return time.Time{}
+	}
+	return date
+}
+
+// metaTimestamp reads a Unix timestamp stored in product meta, such as the
+// _sale_price_dates_from meta of the sale schedule, or returns the zero time
+func metaTimestamp(metaData []*domain.MetaData, key string) time.Time {
+	for _, meta := range metaData {
+		if meta.Key != key {
+			continue
+		}
+		var seconds int64
+		switch value := meta.Value.(type) {
+		case string:
+			parsed, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+			if err != nil {
+				return time.Time{}
+			}
+			seconds = parsed
+		case float64:
+			seconds = int64(value)
+		}
+		if seconds <= 0 {
+			return time.Time{}
+		}
+		return time.Unix(seconds, 0).UTC()
+	}
+	return time.Time{}
+}
@@ -0,0 +1,114 @@
+package woocommerce
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// DefaultClientTTL is how long an idle cached client is kept before being recreated
+const DefaultClientTTL = 5 * time.Minute
+
+// clientCacheEntry holds a cached client and its expiry
+type clientCacheEntry struct {
+	client    *Client
+	expiresAt time.Time
+}
+
+// clientCache reuses configured clients across tool calls made with the same
+// store credentials. Entries are keyed by a salted hash so that credentials
+// are never stored as map keys in plaintext.
+type clientCache struct {
+	mu      sync.Mutex
+	salt    []byte
+	ttl     time.Duration
+	entries map[string]*clientCacheEntry
+}
+
+var storeClientCache = newClientCache(DefaultClientTTL)
+
+// newClientCache creates a client cache with a random per-process salt
+func newClientCache(ttl time.Duration) *clientCache {
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		panic("failed to generate client cache salt: " + err.Error())
+	}
+
+	return &clientCache{
+		salt:    salt,
+		ttl:     ttl,
+		entries: make(map[string]*clientCacheEntry),
+	}
+}
+
+// key derives the cache key for a configuration. The secret is part of the
+// key so a wrong secret never reuses a client created with the right one.
+func (cc *clientCache) key(config *Config) string {
+	mac := hmac.New(sha256.New, cc.salt)
+	for _, part := range []string{config.BaseURL, config.ConsumerKey, config.ConsumerSecret, config.ProxyURL, config.APIVersion} {
+		mac.Write([]byte(part))
+		mac.Write([]byte{0})
+	}
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// get returns the cached client for a configuration, creating it when
+// missing or expired. Each hit extends the entry's lifetime.
+func (cc *clientCache) get(config *Config, now time.Time) *Client {
+	key := cc.key(config)
+
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	cc.evictExpired(now)
+
+	entry, ok := cc.entries[key]
+	if !ok {
+		entry = &clientCacheEntry{client: NewClient(config)}
+		cc.entries[key] = entry
+	}
+	entry.expiresAt = now.Add(cc.ttl)
+
+	return entry.client
+}
+
+// evictExpired drops expired entries and closes their idle connections, so
+// a store that is no longer called does not keep sockets open. The caller
+// must hold the lock.
+func (cc *clientCache) evictExpired(now time.Time) {
+	for key, entry := range cc.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(cc.entries, key)
+			entry.client.CloseIdleConnections()
+		}
+	}
+}
+
+// close drops every entry and closes its idle connections. The cache stays
+// usable, and closing it again is a no-op.
+func (cc *clientCache) close() {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	for key, entry := range cc.entries {
+		delete(cc.entries, key)
+		entry.client.CloseIdleConnections()
+	}
+}
+
+// NewCachedClient returns a client for the configuration, reusing the one
+// created by a recent call with the same store and credentials so that its
+// connection pool is shared across tool calls
+func NewCachedClient(config *Config) *Client {
+	return storeClientCache.get(config, time.Now())
+}
+
+// CloseCachedClients drops the cached clients and closes their idle
+// connections. It is meant for shutdown, but safe to call at any time: a
+// request in flight completes, and the next call creates a new client.
+func CloseCachedClients() {
+	storeClientCache.close()
+}
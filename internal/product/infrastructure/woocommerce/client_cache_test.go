@@ -0,0 +1,147 @@
+package woocommerce
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClientCacheReusesClientsForTheSameCredentials(t *testing.T) {
+	cache := newClientCache(time.Minute)
+	now := time.Now()
+
+	first := cache.get(NewConfig("https://shop.example/", "ck_1", "cs_1"), now)
+	second := cache.get(NewConfig("https://shop.example", "ck_1", "cs_1"), now.Add(time.Second))
+	if first != second {
+		t.Error("identical credentials got different clients")
+	}
+
+	for name, config := range map[string]*Config{
+		"other store":  NewConfig("https://other.example", "ck_1", "cs_1"),
+		"other key":    NewConfig("https://shop.example", "ck_2", "cs_1"),
+		"other secret": NewConfig("https://shop.example", "ck_1", "cs_wrong"),
+	} {
+		if cache.get(config, now) == first {
+			t.Errorf("%s reused the client of other credentials", name)
+		}
+	}
+}
+
+func TestClientCacheExpiresIdleClients(t *testing.T) {
+	cache := newClientCache(time.Minute)
+	config := NewConfig("https://shop.example", "ck_1", "cs_1")
+	now := time.Now()
+
+	first := cache.get(config, now)
+	// Each use extends the lifetime
+	if cache.get(config, now.Add(50*time.Second)) != first {
+		t.Fatal("client expired before its TTL")
+	}
+	if cache.get(config, now.Add(100*time.Second)) != first {
+		t.Fatal("a use did not extend the client's lifetime")
+	}
+	if cache.get(config, now.Add(200*time.Second)) == first {
+		t.Error("idle client was reused after its TTL")
+	}
+}
+
+func TestClientCacheKeysHideTheCredentials(t *testing.T) {
+	cache := newClientCache(time.Minute)
+	cache.get(NewConfig("https://shop.example", "ck_visible", "cs_secret"), time.Now())
+
+	for key := range cache.entries {
+		if strings.Contains(key, "cs_secret") || strings.Contains(key, "ck_visible") || strings.Contains(key, "shop.example") {
+			t.Errorf("cache key %q holds plaintext credentials", key)
+		}
+	}
+
+	// Another process salts its keys differently
+	other := newClientCache(time.Minute)
+	config := NewConfig("https://shop.example", "ck_visible", "cs_secret")
+	if cache.key(config) == other.key(config) {
+		t.Error("two caches derived the same key")
+	}
+}
+
+// startCountingServer starts a server counting the connections it saw closed
+func startCountingServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var closed atomic.Int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("[]"))
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateClosed {
+			closed.Add(1)
+		}
+	}
+	server.Start()
+	t.Cleanup(server.Close)
+	return server, &closed
+}
+
+// leaveIdleConnection makes a request with the client, leaving its
+// keep-alive connection idle
+func leaveIdleConnection(t *testing.T, client *Client, url string) {
+	t.Helper()
+	resp, err := client.httpClient.Get(url)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+}
+
+// waitClosed waits for the server to see a connection closed
+func waitClosed(t *testing.T, closed *atomic.Int32, why string) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for closed.Load() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("idle connection still open after %s", why)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestClientCacheCloseReleasesIdleConnections(t *testing.T) {
+	server, closed := startCountingServer(t)
+	cache := newClientCache(time.Minute)
+	config := NewConfig(server.URL, "ck_1", "cs_1")
+	now := time.Now()
+
+	first := cache.get(config, now)
+	leaveIdleConnection(t, first, server.URL)
+	if closed.Load() != 0 {
+		t.Fatal("connection closed before the cache was")
+	}
+
+	cache.close()
+	waitClosed(t, closed, "close")
+	if len(cache.entries) != 0 {
+		t.Errorf("%d clients cached after close, want none", len(cache.entries))
+	}
+
+	// Closing again is a no-op, and the cache stays usable
+	cache.close()
+	if cache.get(config, now) == first {
+		t.Error("a closed client was handed out again")
+	}
+}
+
+func TestClientCacheEvictionReleasesIdleConnections(t *testing.T) {
+	server, closed := startCountingServer(t)
+	cache := newClientCache(time.Minute)
+	now := time.Now()
+
+	leaveIdleConnection(t, cache.get(NewConfig(server.URL, "ck_1", "cs_1"), now), server.URL)
+
+	// Another store's call evicts the expired client
+	cache.get(NewConfig("https://other.example", "ck_2", "cs_2"), now.Add(2*time.Minute))
+	waitClosed(t, closed, "eviction")
+}
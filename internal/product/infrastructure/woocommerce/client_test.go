@@ -0,0 +1,368 @@
+package woocommerce
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"woocommerce-mcp/internal/product/domain"
+	"woocommerce-mcp/kit/retry"
+	"woocommerce-mcp/kit/storeconfig"
+	"woocommerce-mcp/kit/storehttp"
+	"woocommerce-mcp/kit/tlsconfig"
+)
+
+func TestConnectionErrorsHideTheCredentials(t *testing.T) {
+	// A closed server refuses the connection
+	server := httptest.NewServer(nil)
+	server.Close()
+
+	client := NewClient(NewConfig(server.URL, "ck_visible", "cs_secret"))
+	_, err := client.SearchProducts(context.Background(), domain.NewSearchCriteria())
+	if err == nil {
+		t.Fatal("searching a closed server succeeded")
+	}
+	if strings.Contains(err.Error(), "cs_secret") || strings.Contains(err.Error(), "consumer_key") {
+		t.Errorf("connection error leaks the credentials: %v", err)
+	}
+}
+
+// stubStore answers every request with its handler and records the requests
+type stubStore struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	handler  http.HandlerFunc
+}
+
+// startStub starts a stub store; a nil handler answers with an empty list
+func startStub(t *testing.T, handler http.HandlerFunc) (*stubStore, string) {
+	t.Helper()
+	if handler == nil {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`[]`))
+		}
+	}
+	store := &stubStore{handler: handler}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		store.mu.Lock()
+		store.requests = append(store.requests, r)
+		store.mu.Unlock()
+		store.handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return store, server.URL
+}
+
+// paths returns the paths requested so far
+func (s *stubStore) paths() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	paths := make([]string, len(s.requests))
+	for i, r := range s.requests {
+		paths[i] = r.URL.Path
+	}
+	return paths
+}
+
+func TestRoutesKeepTheBaseURLPrefix(t *testing.T) {
+	for suffix, want := range map[string]string{
+		"":               "/wp-json/wc/v3/products",
+		"/":              "/wp-json/wc/v3/products",
+		"/shop":          "/shop/wp-json/wc/v3/products",
+		"/shop/":         "/shop/wp-json/wc/v3/products",
+		"/shop/wp-json/": "/shop/wp-json/wc/v3/products",
+	} {
+		store, baseURL := startStub(t, nil)
+		client := NewClient(NewConfig(baseURL+suffix, "ck", "cs"))
+		if _, err := client.SearchProducts(context.Background(), domain.NewSearchCriteria()); err != nil {
+			t.Fatalf("base URL %q: %v", suffix, err)
+		}
+		if paths := store.paths(); len(paths) != 1 || paths[0] != want {
+			t.Errorf("base URL %q requested %v, want %s", suffix, paths, want)
+		}
+	}
+}
+
+func TestMissingRESTAPIIsReported(t *testing.T) {
+	t.Setenv(retry.MaxRetriesEnv, "0")
+	responses := map[string]struct {
+		status      int
+		contentType string
+		body        string
+	}{
+		"disabled API":     {http.StatusNotFound, "application/json", `{"code":"rest_no_route","message":"No route was found matching the URL and request method."}`},
+		"WordPress index":  {http.StatusOK, "application/json", `{"name":"Shop","namespaces":["oembed/1.0","wp/v2"],"routes":{}}`},
+		"plain permalinks": {http.StatusOK, "text/html; charset=UTF-8", "<!DOCTYPE html><html><body>Shop home</body></html>"},
+	}
+	for name, response := range responses {
+		_, baseURL := startStub(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", response.contentType)
+			w.WriteHeader(response.status)
+			w.Write([]byte(response.body))
+		})
+
+		_, err := NewClient(NewConfig(baseURL, "ck", "cs")).SearchProducts(context.Background(), domain.NewSearchCriteria())
+		var apiErr *domain.WooCommerceAPIError
+		if !errors.As(err, &apiErr) || !apiErr.IsRESTAPINotFound() {
+			t.Errorf("%s: got error %v, want the REST API not found error", name, err)
+			continue
+		}
+		if !strings.Contains(err.Error(), "verify WooCommerce is active and pretty permalinks are enabled") {
+			t.Errorf("%s: error %q gives no advice", name, err)
+		}
+	}
+}
+
+func TestProxyURLFromEnvironmentIsUsed(t *testing.T) {
+	t.Setenv(retry.MaxRetriesEnv, "0")
+	proxy, proxyURL := startStub(t, nil)
+	t.Setenv(storehttp.ProxyURLEnv, proxyURL)
+
+	config := NewConfig("http://shop.example", "ck", "cs")
+	if config.ProxyURL != proxyURL {
+		t.Fatalf("Config.ProxyURL = %q, want %q", config.ProxyURL, proxyURL)
+	}
+	if _, err := NewClient(config).SearchProducts(context.Background(), domain.NewSearchCriteria()); err != nil {
+		t.Fatalf("search through the proxy: %v", err)
+	}
+
+	proxy.mu.Lock()
+	defer proxy.mu.Unlock()
+	if len(proxy.requests) != 1 || proxy.requests[0].URL.Host != "shop.example" {
+		t.Errorf("proxy received %d request(s), want the products request to shop.example", len(proxy.requests))
+	}
+}
+
+func TestModifiedRangeIsSentInGMT(t *testing.T) {
+	store, baseURL := startStub(t, nil)
+	client := NewClient(NewConfig(baseURL, "ck", "cs"))
+
+	after := time.Date(2024, 5, 1, 10, 30, 0, 0, time.FixedZone("CEST", 2*60*60))
+	before := time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)
+	criteria := domain.NewSearchCriteria().SetModifiedRange(&after, &before)
+	if _, err := client.SearchProducts(context.Background(), criteria); err != nil {
+		t.Fatalf("SearchProducts: %v", err)
+	}
+
+	store.mu.Lock()
+	query := store.requests[0].URL.Query()
+	store.mu.Unlock()
+	for param, want := range map[string]string{
+		"modified_after":  "2024-05-01T08:30:00",
+		"modified_before": "2024-05-02T00:00:00",
+		"dates_are_gmt":   "true",
+	} {
+		if got := query.Get(param); got != want {
+			t.Errorf("%s = %q, want %q", param, got, want)
+		}
+	}
+}
+
+func TestDatesAreGMTOnlyWithAModifiedRange(t *testing.T) {
+	store, baseURL := startStub(t, nil)
+	client := NewClient(NewConfig(baseURL, "ck", "cs"))
+	if _, err := client.SearchProducts(context.Background(), domain.NewSearchCriteria()); err != nil {
+		t.Fatalf("SearchProducts: %v", err)
+	}
+
+	store.mu.Lock()
+	query := store.requests[0].URL.Query()
+	store.mu.Unlock()
+	for _, param := range []string{"modified_after", "modified_before", "dates_are_gmt"} {
+		if query.Has(param) {
+			t.Errorf("%s sent without a modification window: %s", param, query.Encode())
+		}
+	}
+}
+
+func TestTopSellersReportIsReadForThePeriod(t *testing.T) {
+	store, baseURL := startStub(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"name":"Sneakers","product_id":7,"quantity":12},{"name":"Socks","product_id":3,"quantity":4}]`))
+	})
+	client := NewClient(NewConfig(baseURL, "ck", "cs"))
+
+	topSellers, err := client.GetTopSellers(context.Background(), domain.SalesPeriodMonth)
+	if err != nil {
+		t.Fatalf("GetTopSellers: %v", err)
+	}
+	if len(topSellers) != 2 || topSellers[0].ProductID != 7 || topSellers[0].Quantity != 12 {
+		t.Errorf("top sellers = %+v, want product 7 first with 12 sold", topSellers)
+	}
+
+	store.mu.Lock()
+	request := store.requests[0]
+	store.mu.Unlock()
+	if request.URL.Path != "/wp-json/wc/v3/reports/top_sellers" || request.URL.Query().Get("period") != "month" {
+		t.Errorf("requested %s, want the month's top sellers report", request.URL)
+	}
+}
+
+func TestUnreadableProductsAreSkipped(t *testing.T) {
+	_, baseURL := startStub(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[
+			{"id":1,"name":"Sneakers","price":"49.99","status":"publish"},
+			{"id":"two","name":"Malformed ID","status":"publish"},
+			{"id":0,"name":"Zero ID","status":"publish"},
+			{"id":4,"name":"Socks","price":"5.00","status":"publish"}
+		]`))
+	})
+	client := NewClient(NewConfig(baseURL, "ck", "cs"))
+
+	products, skipped, err := client.SearchProductsPartial(context.Background(), domain.NewSearchCriteria())
+	if err != nil {
+		t.Fatalf("SearchProductsPartial: %v", err)
+	}
+	if skipped != 2 {
+		t.Errorf("skipped = %d, want the malformed and the zero ID", skipped)
+	}
+	if len(products) != 2 || products[0].ID.Value() != 1 || products[1].ID.Value() != 4 {
+		t.Errorf("got %d products, want the two readable ones in order", len(products))
+	}
+
+	if products, err := client.SearchProducts(context.Background(), domain.NewSearchCriteria()); err != nil || len(products) != 2 {
+		t.Errorf("SearchProducts = %d products, %v, want the readable ones", len(products), err)
+	}
+}
+
+func TestMenuOrderIsForwarded(t *testing.T) {
+	store, baseURL := startStub(t, nil)
+	client := NewClient(NewConfig(baseURL, "ck", "cs"))
+
+	criteria := domain.NewSearchCriteria().SetSorting("menu_order", "asc")
+	criteria.Category = "15"
+	if _, err := client.SearchProducts(context.Background(), criteria); err != nil {
+		t.Fatalf("SearchProducts: %v", err)
+	}
+
+	store.mu.Lock()
+	query := store.requests[0].URL.Query()
+	store.mu.Unlock()
+	for param, want := range map[string]string{"orderby": "menu_order", "order": "asc", "category": "15"} {
+		if got := query.Get(param); got != want {
+			t.Errorf("%s = %q, want %q", param, got, want)
+		}
+	}
+}
+
+func TestGzipEncodedProductsAreParsed(t *testing.T) {
+	_, baseURL := startStub(t, func(w http.ResponseWriter, r *http.Request) {
+		var buf bytes.Buffer
+		writer := gzip.NewWriter(&buf)
+		writer.Write([]byte(`[{"id":1,"name":"Running Shoes","type":"simple","status":"publish","price":"59.99"}]`))
+		writer.Close()
+
+		// compressed whether or not the client asked for it
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(buf.Bytes())
+	})
+	client := NewClient(NewConfig(baseURL, "ck", "cs"))
+
+	products, err := client.SearchProducts(context.Background(), domain.NewSearchCriteria())
+	if err != nil {
+		t.Fatalf("SearchProducts: %v", err)
+	}
+	if len(products) != 1 || products[0].Name != "Running Shoes" {
+		t.Errorf("got %v, want the decoded product", products)
+	}
+}
+
+func TestSelfSignedStoreIsReachedWithTheInsecureFlag(t *testing.T) {
+	t.Setenv(retry.MaxRetriesEnv, "0")
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	t.Setenv(tlsconfig.InsecureSkipVerifyEnv, "")
+	if _, err := NewClient(NewConfig(server.URL, "ck", "cs")).SearchProducts(context.Background(), domain.NewSearchCriteria()); err == nil {
+		t.Error("a self-signed certificate was accepted without the flag")
+	}
+
+	t.Setenv(tlsconfig.InsecureSkipVerifyEnv, "true")
+	if _, err := NewClient(NewConfig(server.URL, "ck", "cs")).SearchProducts(context.Background(), domain.NewSearchCriteria()); err != nil {
+		t.Errorf("with %s: %v", tlsconfig.InsecureSkipVerifyEnv, err)
+	}
+}
+
+func TestParentIsForwarded(t *testing.T) {
+	for _, tt := range []struct {
+		parent []int
+		want   string
+	}{
+		{[]int{12, 7}, "12,7"},
+		{nil, ""},
+	} {
+		store, baseURL := startStub(t, nil)
+		criteria := domain.NewSearchCriteria()
+		criteria.SetParent(tt.parent)
+		if _, err := NewClient(NewConfig(baseURL, "ck", "cs")).SearchProducts(context.Background(), criteria); err != nil {
+			t.Fatalf("SearchProducts: %v", err)
+		}
+
+		store.mu.Lock()
+		query := store.requests[0].URL.Query()
+		store.mu.Unlock()
+		if got := query.Get("parent"); got != tt.want || query.Has("parent") != (tt.want != "") {
+			t.Errorf("parent %v: sent %q, want %q", tt.parent, got, tt.want)
+		}
+	}
+}
+
+func TestConfigIsBuiltFromTheContextStore(t *testing.T) {
+	store := storeconfig.Store{BaseURL: "https://shop.example", ConsumerKey: "ck_1", ConsumerSecret: "cs_1"}
+	config, err := NewConfigFromContext(storeconfig.WithStore(context.Background(), store))
+	if err != nil {
+		t.Fatalf("NewConfigFromContext: %v", err)
+	}
+	if config.BaseURL != store.BaseURL || config.ConsumerKey != store.ConsumerKey || config.ConsumerSecret != store.ConsumerSecret {
+		t.Errorf("config = %+v, want the credentials of %+v", config, store)
+	}
+
+	for name, ctx := range map[string]context.Context{
+		"no store":          context.Background(),
+		"store without key": storeconfig.WithStore(context.Background(), storeconfig.Store{BaseURL: "https://shop.example", ConsumerSecret: "cs_1"}),
+	} {
+		var validationErr *domain.ProductValidationError
+		if _, err := NewConfigFromContext(ctx); !errors.As(err, &validationErr) || validationErr.Field != "store" {
+			t.Errorf("%s: got error %v, want a store validation error", name, err)
+		}
+	}
+}
+
+func TestRetryAfterOfARateLimitIsCaptured(t *testing.T) {
+	t.Setenv(retry.MaxRetriesEnv, "0")
+	for _, tt := range []struct {
+		status int
+		want   time.Duration
+	}{
+		{http.StatusTooManyRequests, 7 * time.Second},
+		{http.StatusServiceUnavailable, 0},
+	} {
+		_, baseURL := startStub(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(tt.status)
+			w.Write([]byte(`{"code":"rate_limited","message":"Slow down"}`))
+		})
+
+		_, err := NewClient(NewConfig(baseURL, "ck", "cs")).SearchProducts(context.Background(), domain.NewSearchCriteria())
+		var apiErr *domain.WooCommerceAPIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("status %d: got error %v, want a WooCommerceAPIError", tt.status, err)
+		}
+		if apiErr.RetryAfter != 7*time.Second || apiErr.RetryAfterDelay() != tt.want {
+			t.Errorf("status %d: retry after %s, delay %s; want 7s captured and a %s delay", tt.status, apiErr.RetryAfter, apiErr.RetryAfterDelay(), tt.want)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package woocommerce
+
+import (
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// reportedRedirects holds the store redirects already reported, keyed by the
+// configured base URL and the redirect target, so each is reported once per
+// process
+var reportedRedirects sync.Map
+
+// noteRedirect records the base URL a store redirected a request to, e.g.
+// from http:// to https://, when the scheme or host of the final request
+// differs from the one that was sent
+func (c *Client) noteRedirect(req *http.Request, resp *http.Response) {
+	if resp.Request == nil || resp.Request.URL == nil {
+		return
+	}
+	final := resp.Request.URL
+	if final.Scheme == req.URL.Scheme && final.Host == req.URL.Host {
+		return
+	}
+
+	base, err := url.Parse(c.config.BaseURL)
+	if err != nil {
+		return
+	}
+	base.Scheme = final.Scheme
+	base.Host = final.Host
+
+	c.redirect.Lock()
+	c.redirect.target = base.String()
+	c.redirect.Unlock()
+}
+
+// RedirectedBaseURL returns the base URL the store redirected requests to,
+// the first time it is asked after a redirect was seen; later calls report
+// nothing for the same redirect
+func (c *Client) RedirectedBaseURL() (string, bool) {
+	c.redirect.Lock()
+	target := c.redirect.target
+	c.redirect.Unlock()
+	if target == "" {
+		return "", false
+	}
+
+	if _, reported := reportedRedirects.LoadOrStore(c.config.BaseURL+" "+target, true); reported {
+		return "", false
+	}
+	return target, true
+}
@@ -0,0 +1,29 @@
+package woocommerce
+
+import (
+	"context"
+	"net/url"
+	"woocommerce-mcp/internal/product/domain"
+)
+
+// GetTopSellers retrieves the top sellers report for a period
+func (c *Client) GetTopSellers(ctx context.Context, period domain.SalesPeriod) ([]*domain.TopSeller, error) {
+	params := url.Values{}
+	params.Set("period", period.String())
+
+	var apiTopSellers []APITopSeller
+	if err := c.getJSON(ctx, c.route("reports/top_sellers"), params, &apiTopSellers); err != nil {
+		return nil, err
+	}
+
+	topSellers := make([]*domain.TopSeller, 0, len(apiTopSellers))
+	for _, apiTopSeller := range apiTopSellers {
+		topSellers = append(topSellers, &domain.TopSeller{
+			ProductID: apiTopSeller.ProductID,
+			Name:      apiTopSeller.Name,
+			Quantity:  apiTopSeller.Quantity,
+		})
+	}
+
+	return topSellers, nil
+}
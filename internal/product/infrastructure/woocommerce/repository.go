@@ -1,10 +1,18 @@
 package woocommerce
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 	"woocommerce-mcp/internal/product/domain"
 	kitDomain "woocommerce-mcp/kit/domain"
+	"woocommerce-mcp/kit/pagination"
 )
 
 // Repository implements the ProductRepository interface using WooCommerce API
@@ -21,16 +29,390 @@ func NewRepository(client *Client) *Repository {
 
 // Search searches for products based on criteria
 func (r *Repository) Search(ctx context.Context, criteria *domain.SearchCriteria) ([]*domain.Product, error) {
+	products, _, err := r.SearchPartial(ctx, criteria)
+	return products, err
+}
+
+// SearchPartial searches for products based on criteria, skipping products
+// that cannot be read, and returns how many were skipped
+func (r *Repository) SearchPartial(ctx context.Context, criteria *domain.SearchCriteria) ([]*domain.Product, int, error) {
 	if criteria == nil {
-		return nil, kitDomain.NewValidationError("search criteria cannot be nil")
+		return nil, 0, kitDomain.NewValidationError("search criteria cannot be nil")
 	}
 
-	products, err := r.client.SearchProducts(ctx, criteria)
+	if len(criteria.Types) > 1 {
+		return r.searchMultipleTypes(ctx, criteria)
+	}
+
+	products, skipped, err := r.client.SearchProductsPartial(ctx, criteria)
 	if err != nil {
-		return nil, fmt.Errorf("failed to search products: %w", err)
+		return nil, 0, fmt.Errorf("failed to search products: %w", err)
 	}
 
-	return products, nil
+	return filterPage(criteria, products), skipped, nil
+}
+
+// filterPage applies the filters the API cannot apply to a fetched page
+func filterPage(criteria *domain.SearchCriteria, products []*domain.Product) []*domain.Product {
+	products = excludeFeatured(criteria, products)
+	products = filterCatalogVisibility(criteria, products)
+	products = filterPurchasable(criteria, products)
+	products = filterSKUContains(criteria, products)
+	return filterAllTerms(criteria, products)
+}
+
+// SearchPages returns an iterator over the pages of a search, from
+// criteria.Page to the last one. The matching products are counted with the
+// first page; a page is only fetched when Next is called.
+func (r *Repository) SearchPages(criteria *domain.SearchCriteria) *pagination.Iterator[*domain.ProductPage] {
+	totalCount, totalPages := int64(-1), 0
+	return pagination.NewIterator(criteria.Page, func(ctx context.Context, page int) (*domain.ProductPage, bool, error) {
+		pageCriteria := *criteria
+		pageCriteria.Page = page
+		if err := pageCriteria.Validate(); err != nil {
+			return nil, false, err
+		}
+
+		if totalCount < 0 {
+			count, err := r.Count(ctx, &pageCriteria)
+			if err != nil {
+				return nil, false, err
+			}
+			totalCount = count
+			totalPages = int((count + int64(pageCriteria.PerPage) - 1) / int64(pageCriteria.PerPage))
+		}
+
+		products, skipped, err := r.SearchPartial(ctx, &pageCriteria)
+		if err != nil {
+			return nil, false, err
+		}
+		return &domain.ProductPage{
+			Products:   products,
+			Skipped:    skipped,
+			TotalCount: totalCount,
+			TotalPages: totalPages,
+		}, page < totalPages, nil
+	})
+}
+
+// RedirectedBaseURL returns the base URL the store redirected requests to,
+// once per redirect
+func (r *Repository) RedirectedBaseURL() (string, bool) {
+	return r.client.RedirectedBaseURL()
+}
+
+// excludeFeatured drops featured products when non-featured products were
+// requested. The API cannot filter them out, so this only applies within the
+// fetched page and a page may hold fewer than PerPage products.
+func excludeFeatured(criteria *domain.SearchCriteria, products []*domain.Product) []*domain.Product {
+	if criteria.Featured == nil || *criteria.Featured {
+		return products
+	}
+
+	filtered := make([]*domain.Product, 0, len(products))
+	for _, product := range products {
+		if !product.Featured {
+			filtered = append(filtered, product)
+		}
+	}
+	return filtered
+}
+
+// filterCatalogVisibility keeps only products with the requested catalog
+// visibility. Like excludeFeatured it only applies within the fetched page.
+func filterCatalogVisibility(criteria *domain.SearchCriteria, products []*domain.Product) []*domain.Product {
+	if criteria.CatalogVisibility == "" {
+		return products
+	}
+
+	filtered := make([]*domain.Product, 0, len(products))
+	for _, product := range products {
+		if domain.CatalogVisibility(product.CatalogVisibility) == criteria.CatalogVisibility {
+			filtered = append(filtered, product)
+		}
+	}
+	return filtered
+}
+
+// filterPurchasable drops products that cannot be bought, such as external
+// products or products without a price, when only purchasable products were
+// requested. Like excludeFeatured it only applies within the fetched page.
+func filterPurchasable(criteria *domain.SearchCriteria, products []*domain.Product) []*domain.Product {
+	if !criteria.PurchasableOnly {
+		return products
+	}
+
+	filtered := make([]*domain.Product, 0, len(products))
+	for _, product := range products {
+		if product.Purchasable {
+			filtered = append(filtered, product)
+		}
+	}
+	return filtered
+}
+
+// filterSKUContains keeps only products whose SKU contains the requested
+// text, ignoring case. The search for the text also matches names and
+// descriptions, and it only matches SKUs on some stores; like
+// excludeFeatured this only applies within the fetched page.
+func filterSKUContains(criteria *domain.SearchCriteria, products []*domain.Product) []*domain.Product {
+	if criteria.SKUContains == "" {
+		return products
+	}
+
+	text := strings.ToLower(criteria.SKUContains)
+	filtered := make([]*domain.Product, 0, len(products))
+	for _, product := range products {
+		if strings.Contains(strings.ToLower(product.SKU), text) {
+			filtered = append(filtered, product)
+		}
+	}
+	return filtered
+}
+
+// filterAllTerms keeps only products in every listed category or tag when
+// the and operator is requested; the API matches any of them. Like
+// excludeFeatured it only applies within the fetched page.
+func filterAllTerms(criteria *domain.SearchCriteria, products []*domain.Product) []*domain.Product {
+	var categories, tags []string
+	if criteria.CategoryOperator == domain.TermOperatorAnd {
+		categories = splitTerms(criteria.Category)
+	}
+	if criteria.TagOperator == domain.TermOperatorAnd {
+		tags = splitTerms(criteria.Tag)
+	}
+	if len(categories) < 2 && len(tags) < 2 {
+		return products
+	}
+
+	filtered := make([]*domain.Product, 0, len(products))
+	for _, product := range products {
+		productCategories := make([]termRef, len(product.Categories))
+		for i, category := range product.Categories {
+			productCategories[i] = termRef{id: category.ID, slug: category.Slug}
+		}
+		productTags := make([]termRef, len(product.Tags))
+		for i, tag := range product.Tags {
+			productTags[i] = termRef{id: tag.ID, slug: tag.Slug}
+		}
+		if hasAllTerms(productCategories, categories) && hasAllTerms(productTags, tags) {
+			filtered = append(filtered, product)
+		}
+	}
+	return filtered
+}
+
+// termRef is a category or tag of a product
+type termRef struct {
+	id   int
+	slug string
+}
+
+// hasAllTerms reports whether every wanted term, given by ID or slug, is
+// among the terms of a product
+func hasAllTerms(terms []termRef, wanted []string) bool {
+	for _, want := range wanted {
+		found := false
+		for _, term := range terms {
+			if strconv.Itoa(term.id) == want || strings.EqualFold(term.slug, want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// splitTerms splits a comma-separated list of term IDs or slugs
+func splitTerms(value string) []string {
+	var terms []string
+	for _, term := range strings.Split(value, ",") {
+		if term = strings.TrimSpace(term); term != "" {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// MaxMultiTypeDepth is how far into the merged results a search for several
+// product types may page: page times per_page may not exceed it. Every type
+// is fetched from its first product, so deeper pages are rejected instead of
+// costing ever more store requests.
+const MaxMultiTypeDepth = 1000
+
+// searchMultipleTypes queries each product type in parallel and merges the
+// results into one page. A page of the merged order can draw on any type, so
+// every type is fetched from its first product up to the end of the
+// requested page; the products are then merged in the requested order,
+// dropping duplicate IDs, and the requested page is cut from them. Deep
+// pages therefore cost more requests than shallow ones, and pages past
+// MaxMultiTypeDepth are rejected. Count sums the per-type totals, so
+// pagination follows the merged result set.
+func (r *Repository) searchMultipleTypes(ctx context.Context, criteria *domain.SearchCriteria) ([]*domain.Product, int, error) {
+	end := criteria.Page * criteria.PerPage
+	if end > MaxMultiTypeDepth {
+		return nil, 0, domain.NewProductValidationError("page", fmt.Sprintf(
+			"page %d with per_page %d goes past the first %d products, the deepest a search for several types can page; narrow the search or search one type at a time",
+			criteria.Page, criteria.PerPage, MaxMultiTypeDepth))
+	}
+	results := make([][]*domain.Product, len(criteria.Types))
+	skippedCounts := make([]int, len(criteria.Types))
+	errs := make([]error, len(criteria.Types))
+
+	var wg sync.WaitGroup
+	for i, productType := range criteria.Types {
+		wg.Add(1)
+		go func(i int, typeCriteria domain.SearchCriteria) {
+			defer wg.Done()
+			results[i], skippedCounts[i], errs[i] = r.searchLeading(ctx, typeCriteria, end)
+		}(i, singleTypeCriteria(criteria, productType))
+	}
+	wg.Wait()
+
+	var products []*domain.Product
+	skipped := 0
+	seen := make(map[int]bool)
+	for i, typeProducts := range results {
+		if errs[i] != nil {
+			return nil, 0, fmt.Errorf("failed to search products of type %s: %w", criteria.Types[i], errs[i])
+		}
+		skipped += skippedCounts[i]
+		for _, product := range typeProducts {
+			if seen[product.ID.Value()] {
+				continue
+			}
+			seen[product.ID.Value()] = true
+			products = append(products, product)
+		}
+	}
+	sortProducts(products, criteria)
+
+	start := (criteria.Page - 1) * criteria.PerPage
+	if start >= len(products) {
+		return []*domain.Product{}, skipped, nil
+	}
+	products = products[start:min(end, len(products))]
+
+	return filterPage(criteria, products), skipped, nil
+}
+
+// searchLeading returns the first limit products matching the criteria,
+// fetching as many pages as that takes
+func (r *Repository) searchLeading(ctx context.Context, criteria domain.SearchCriteria, limit int) ([]*domain.Product, int, error) {
+	var products []*domain.Product
+	skipped := 0
+	criteria.PerPage = min(limit, pagination.MaxPerPage)
+	for criteria.Page = 1; len(products) < limit; criteria.Page++ {
+		page, pageSkipped, err := r.client.SearchProductsPartial(ctx, &criteria)
+		if err != nil {
+			return nil, 0, err
+		}
+		products = append(products, page...)
+		skipped += pageSkipped
+
+		// A short page is the last one
+		if len(page)+pageSkipped < criteria.PerPage {
+			break
+		}
+	}
+
+	if len(products) > limit {
+		products = products[:limit]
+	}
+	return products, skipped, nil
+}
+
+// sortProducts puts products fetched by separate requests in the order the
+// criteria ask the API for, ties broken by ID in the same direction. The
+// API's relevance order, used when orderby is not set for a search, cannot
+// be reproduced, so each request's products then stay in the order they
+// were fetched, one request after another.
+func sortProducts(products []*domain.Product, criteria *domain.SearchCriteria) {
+	if criteria.OrderBy == "" {
+		return
+	}
+
+	position := make(map[int]int, len(criteria.Include))
+	for i, id := range criteria.Include {
+		position[id] = i
+	}
+
+	descending := criteria.Order != "asc"
+	sort.SliceStable(products, func(i, j int) bool {
+		a, b := products[i], products[j]
+		order := compareProducts(a, b, criteria.OrderBy, position)
+		if order == 0 {
+			order = cmp.Compare(a.ID.Value(), b.ID.Value())
+		}
+		if descending {
+			return order > 0
+		}
+		return order < 0
+	})
+}
+
+// compareProducts compares two products by an orderby field of the API,
+// returning a negative number when a sorts first in ascending order
+func compareProducts(a, b *domain.Product, orderBy string, includePosition map[int]int) int {
+	switch orderBy {
+	case "date":
+		return productInstant(a.DateCreated, a.DateCreatedGMT).Compare(productInstant(b.DateCreated, b.DateCreatedGMT))
+	case "modified":
+		return productInstant(a.DateModified, a.DateModifiedGMT).Compare(productInstant(b.DateModified, b.DateModifiedGMT))
+	case "title":
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	case "slug":
+		return strings.Compare(a.Slug, b.Slug)
+	case "price":
+		return cmp.Compare(priceAmount(a.Price), priceAmount(b.Price))
+	case "popularity":
+		return cmp.Compare(a.TotalSales, b.TotalSales)
+	case "rating":
+		return cmp.Compare(parseRating(a.AverageRating), parseRating(b.AverageRating))
+	case "menu_order":
+		return cmp.Compare(a.MenuOrder, b.MenuOrder)
+	case "include":
+		return cmp.Compare(includePosition[a.ID.Value()], includePosition[b.ID.Value()])
+	default:
+		return 0
+	}
+}
+
+// productInstant returns the GMT time of a product date when known, else
+// its store-local time
+func productInstant(local, gmt time.Time) time.Time {
+	if !gmt.IsZero() {
+		return gmt
+	}
+	return local
+}
+
+// priceAmount returns the amount of a price, 0 when it is missing
+func priceAmount(price *domain.Money) float64 {
+	if price == nil {
+		return 0
+	}
+	return price.Amount()
+}
+
+// parseRating parses an average rating, 0 when it is missing
+func parseRating(rating string) float64 {
+	value, err := strconv.ParseFloat(rating, 64)
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
+// singleTypeCriteria returns a copy of the criteria narrowed to one product type
+func singleTypeCriteria(criteria *domain.SearchCriteria, productType domain.ProductType) domain.SearchCriteria {
+	typeCriteria := *criteria
+	typeCriteria.Type = productType
+	typeCriteria.Types = nil
+	return typeCriteria
 }
 
 // FindByID finds a product by its ID
@@ -39,13 +421,11 @@ func (r *Repository) FindByID(ctx context.Context, id *domain.ProductID) (*domai
 		return nil, kitDomain.NewValidationError("product ID cannot be nil")
 	}
 
-	// Create search criteria to find by ID
+	// Search with the include parameter, which returns the product whatever its position in the catalog
 	criteria := domain.NewSearchCriteria()
+	criteria.SetInclude([]int{id.Value()})
 	criteria.SetPagination(1, 1)
 
-	// WooCommerce API doesn't have a direct "find by ID" endpoint in the search,
-	// so we need to use the specific product endpoint or search with include parameter
-	// For now, we'll search and filter by ID
 	products, err := r.client.SearchProducts(ctx, criteria)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find product by ID: %w", err)
@@ -87,6 +467,75 @@ func (r *Repository) FindBySKU(ctx context.Context, sku string) (*domain.Product
 	return nil, kitDomain.NewNotFoundError("product", sku)
 }
 
+// MeasurementUnits returns the store's weight and dimension units from the
+// cached store settings
+func (r *Repository) MeasurementUnits(ctx context.Context) (*domain.MeasurementUnits, error) {
+	settings, err := r.client.StoreSettings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get store settings: %w", err)
+	}
+
+	return &domain.MeasurementUnits{
+		Weight:    settings.WeightUnit,
+		Dimension: settings.DimensionUnit,
+	}, nil
+}
+
+// FindVariations returns all variations of a variable product
+func (r *Repository) FindVariations(ctx context.Context, parentID *domain.ProductID) ([]*domain.Variation, error) {
+	if parentID == nil {
+		return nil, kitDomain.NewValidationError("parent product ID cannot be nil")
+	}
+
+	variations, err := r.client.GetVariations(ctx, parentID.Value())
+	if err != nil {
+		var apiErr *domain.WooCommerceAPIError
+		if errors.As(err, &apiErr) && apiErr.IsNotFound() && !apiErr.IsRESTAPINotFound() {
+			return nil, domain.NewProductNotFoundError(parentID)
+		}
+		return nil, fmt.Errorf("failed to get product variations: %w", err)
+	}
+
+	return variations, nil
+}
+
+// APIVersion returns the REST API version the client sends requests to
+func (r *Repository) APIVersion() (string, bool) {
+	return r.client.APIVersion()
+}
+
+// FindCategories returns the categories with the given IDs
+func (r *Repository) FindCategories(ctx context.Context, ids []int) ([]*domain.Category, error) {
+	categories, err := r.client.GetCategories(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product categories: %w", err)
+	}
+
+	return categories, nil
+}
+
+// CategoryPages returns an iterator over all categories of the store. A
+// page holding fewer than pagination.MaxPerPage categories is the last one.
+func (r *Repository) CategoryPages() *pagination.Iterator[[]*domain.Category] {
+	return pagination.NewIterator(1, func(ctx context.Context, page int) ([]*domain.Category, bool, error) {
+		categories, err := r.client.ListCategories(ctx, page)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to list product categories: %w", err)
+		}
+		return categories, len(categories) == pagination.MaxPerPage, nil
+	})
+}
+
+// FindTopSellers returns the best-selling products of a period
+func (r *Repository) FindTopSellers(ctx context.Context, period domain.SalesPeriod) ([]*domain.TopSeller, error) {
+	topSellers, err := r.client.GetTopSellers(ctx, period)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get top sellers: %w", err)
+	}
+
+	return topSellers, nil
+}
+
 // Save saves a product (not implemented for read-only MCP)
 func (r *Repository) Save(ctx context.Context, product *domain.Product) error {
 	return kitDomain.NewDomainError("NOT_IMPLEMENTED", "save operation is not supported in read-only mode")
@@ -103,7 +552,21 @@ func (r *Repository) Count(ctx context.Context, criteria *domain.SearchCriteria)
 		return 0, kitDomain.NewValidationError("search criteria cannot be nil")
 	}
 
-	count, err := r.client.CountProducts(ctx, criteria)
+	// Product types are mutually exclusive, so per-type totals add up exactly
+	if len(criteria.Types) > 1 {
+		var total int64
+		for _, productType := range criteria.Types {
+			typeCriteria := singleTypeCriteria(criteria, productType)
+			count, err := r.countProducts(ctx, &typeCriteria)
+			if err != nil {
+				return 0, fmt.Errorf("failed to count products of type %s: %w", productType, err)
+			}
+			total += count
+		}
+		return total, nil
+	}
+
+	count, err := r.countProducts(ctx, criteria)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count products: %w", err)
 	}
@@ -111,6 +574,30 @@ func (r *Repository) Count(ctx context.Context, criteria *domain.SearchCriteria)
 	return count, nil
 }
 
+// countProducts counts products of a single request. Non-featured products
+// are counted as all products minus the featured ones.
+func (r *Repository) countProducts(ctx context.Context, criteria *domain.SearchCriteria) (int64, error) {
+	count, err := r.client.CountProducts(ctx, criteria)
+	if err != nil {
+		return 0, err
+	}
+
+	if criteria.Featured != nil && !*criteria.Featured {
+		featuredCriteria := *criteria
+		featuredCriteria.SetFeatured(true)
+		featuredCount, err := r.client.CountProducts(ctx, &featuredCriteria)
+		if err != nil {
+			return 0, err
+		}
+		count -= featuredCount
+		if count < 0 {
+			count = 0
+		}
+	}
+
+	return count, nil
+}
+
 // NewRepositoryFromConfig creates a new repository from configuration
 func NewRepositoryFromConfig(baseURL, consumerKey, consumerSecret string) *Repository {
 	config := NewConfig(baseURL, consumerKey, consumerSecret)
@@ -0,0 +1,468 @@
+package woocommerce
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"woocommerce-mcp/internal/product/domain"
+	"woocommerce-mcp/internal/testutil/fakestore"
+	"woocommerce-mcp/kit/retry"
+)
+
+func TestSearchMultipleTypesMergesPages(t *testing.T) {
+	store := fakestore.New()
+	products := fakestore.DefaultProducts()
+	for i, product := range products {
+		if i%2 == 1 {
+			product["type"] = "variable"
+		}
+	}
+	store.SetProducts(products)
+	server := store.Start()
+	defer server.Close()
+	repository := NewRepository(NewClient(NewConfig(server.URL, fakestore.ConsumerKey, fakestore.ConsumerSecret)))
+
+	search := func(page int) []int {
+		t.Helper()
+		criteria := domain.NewSearchCriteria()
+		criteria.Types = []domain.ProductType{domain.ProductTypeSimple, domain.ProductTypeVariable}
+		criteria.SetPagination(page, 5)
+		criteria.SetSorting("id", "asc")
+		found, err := repository.Search(context.Background(), criteria)
+		if err != nil {
+			t.Fatalf("search page %d: %v", page, err)
+		}
+		ids := make([]int, len(found))
+		for i, product := range found {
+			ids[i] = product.ID.Value()
+		}
+		return ids
+	}
+
+	for page, want := range map[int][]int{
+		1: {1, 2, 3, 4, 5},
+		2: {6, 7, 8, 9, 10},
+		3: {11, 12},
+		4: {},
+	} {
+		got := search(page)
+		if len(got) != len(want) {
+			t.Errorf("page %d = %v, want %v", page, got, want)
+			continue
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("page %d = %v, want %v", page, got, want)
+				break
+			}
+		}
+	}
+
+	criteria := domain.NewSearchCriteria()
+	criteria.Types = []domain.ProductType{domain.ProductTypeSimple, domain.ProductTypeVariable}
+	count, err := repository.Count(context.Background(), criteria)
+	if err != nil || count != 12 {
+		t.Errorf("count = %d, %v; want the summed total 12", count, err)
+	}
+}
+
+func TestSearchMultipleTypesRejectsDeepPages(t *testing.T) {
+	store := fakestore.New()
+	server := store.Start()
+	defer server.Close()
+	repository := NewRepository(NewClient(NewConfig(server.URL, fakestore.ConsumerKey, fakestore.ConsumerSecret)))
+
+	criteria := domain.NewSearchCriteria()
+	criteria.Types = []domain.ProductType{domain.ProductTypeSimple, domain.ProductTypeVariable}
+	criteria.SetPagination(MaxMultiTypeDepth/10+1, 10)
+	_, err := repository.Search(context.Background(), criteria)
+
+	var validationErr *domain.ProductValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "page" {
+		t.Fatalf("got error %v, want a page validation error", err)
+	}
+	if requests := store.Requests(); len(requests) != 0 {
+		t.Errorf("a rejected page reached the store: %v", requests)
+	}
+
+	// The deepest allowed page is still served
+	criteria.SetPagination(MaxMultiTypeDepth/10, 10)
+	if _, err := repository.Search(context.Background(), criteria); err != nil {
+		t.Errorf("page at the depth limit: %v", err)
+	}
+}
+
+func TestSortProductsByPriceDescending(t *testing.T) {
+	product := func(id int, price float64) *domain.Product {
+		productID, _ := domain.NewProductID(id)
+		p := domain.NewProduct(productID, "")
+		p.Price, _ = domain.NewMoney(price, "USD")
+		return p
+	}
+	products := []*domain.Product{product(1, 5), product(2, 20), product(3, 5), product(4, 10)}
+
+	criteria := domain.NewSearchCriteria()
+	criteria.SetSorting("price", "desc")
+	sortProducts(products, criteria)
+
+	want := []int{2, 4, 3, 1}
+	for i, id := range want {
+		if products[i].ID.Value() != id {
+			t.Fatalf("sorted products[%d] = %d, want order %v", i, products[i].ID.Value(), want)
+		}
+	}
+}
+
+func TestNonFeaturedProductsAreFilteredWithinThePage(t *testing.T) {
+	store := fakestore.New()
+	products := fakestore.DefaultProducts()
+	for _, product := range products {
+		product["featured"] = idOf(product)%3 == 0
+	}
+	store.SetProducts(products)
+	server := store.Start()
+	defer server.Close()
+	repository := NewRepository(NewClient(NewConfig(server.URL, fakestore.ConsumerKey, fakestore.ConsumerSecret)))
+
+	tests := []struct {
+		name     string
+		featured *bool
+		ids      []int
+		count    int64
+	}{
+		{name: "any", featured: nil, ids: []int{1, 2, 3, 4, 5, 6}, count: 12},
+		{name: "featured", featured: boolPtr(true), ids: []int{3, 6, 9, 12}, count: 4},
+		{name: "not featured", featured: boolPtr(false), ids: []int{1, 2, 4, 5}, count: 8},
+	}
+	for _, tt := range tests {
+		criteria := domain.NewSearchCriteria()
+		if tt.featured != nil {
+			criteria.SetFeatured(*tt.featured)
+		}
+		criteria.SetPagination(1, 6)
+		criteria.SetSorting("id", "asc")
+
+		found, err := repository.Search(context.Background(), criteria)
+		if err != nil {
+			t.Fatalf("%s: search: %v", tt.name, err)
+		}
+		ids := make([]int, len(found))
+		for i, product := range found {
+			ids[i] = product.ID.Value()
+		}
+		if !equalInts(ids, tt.ids) {
+			t.Errorf("%s: found %v, want %v", tt.name, ids, tt.ids)
+		}
+
+		count, err := repository.Count(context.Background(), criteria)
+		if err != nil {
+			t.Fatalf("%s: count: %v", tt.name, err)
+		}
+		if count != tt.count {
+			t.Errorf("%s: count = %d, want %d", tt.name, count, tt.count)
+		}
+	}
+}
+
+// idOf returns the ID of a product fixture
+func idOf(product map[string]interface{}) int {
+	id, _ := product["id"].(int)
+	return id
+}
+
+// boolPtr returns a pointer to a bool
+func boolPtr(value bool) *bool {
+	return &value
+}
+
+// equalInts reports whether two int slices are equal
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestCatalogVisibilityIsFilteredWithinThePage(t *testing.T) {
+	visibilities := []string{"visible", "catalog", "search", "hidden"}
+	store := fakestore.New()
+	products := fakestore.DefaultProducts()
+	for i, product := range products {
+		product["catalog_visibility"] = visibilities[i%len(visibilities)]
+	}
+	store.SetProducts(products)
+	server := store.Start()
+	defer server.Close()
+	repository := NewRepository(NewClient(NewConfig(server.URL, fakestore.ConsumerKey, fakestore.ConsumerSecret)))
+
+	tests := map[domain.CatalogVisibility][]int{
+		"":                              {1, 2, 3, 4, 5, 6, 7, 8},
+		domain.CatalogVisibilityVisible: {1, 5},
+		domain.CatalogVisibilityCatalog: {2, 6},
+		domain.CatalogVisibilitySearch:  {3, 7},
+		domain.CatalogVisibilityHidden:  {4, 8},
+	}
+	for visibility, want := range tests {
+		criteria := domain.NewSearchCriteria()
+		criteria.SetCatalogVisibility(visibility)
+		criteria.SetPagination(1, 8)
+		criteria.SetSorting("id", "asc")
+
+		found, err := repository.Search(context.Background(), criteria)
+		if err != nil {
+			t.Fatalf("visibility %q: %v", visibility, err)
+		}
+		ids := make([]int, len(found))
+		for i, product := range found {
+			ids[i] = product.ID.Value()
+		}
+		if !equalInts(ids, want) {
+			t.Errorf("visibility %q: found %v, want %v", visibility, ids, want)
+		}
+	}
+}
+
+func TestPurchasableOnlyIsFilteredWithinThePage(t *testing.T) {
+	store := fakestore.New()
+	products := fakestore.DefaultProducts()
+	for _, id := range []int{2, 5} {
+		products[id-1]["purchasable"] = false
+	}
+	store.SetProducts(products)
+	server := store.Start()
+	defer server.Close()
+	repository := NewRepository(NewClient(NewConfig(server.URL, fakestore.ConsumerKey, fakestore.ConsumerSecret)))
+
+	for purchasableOnly, want := range map[bool][]int{
+		false: {1, 2, 3, 4, 5, 6},
+		true:  {1, 3, 4, 6},
+	} {
+		criteria := domain.NewSearchCriteria()
+		criteria.SetPurchasableOnly(purchasableOnly)
+		criteria.SetPagination(1, 6)
+		criteria.SetSorting("id", "asc")
+
+		found, err := repository.Search(context.Background(), criteria)
+		if err != nil {
+			t.Fatalf("purchasable only %v: %v", purchasableOnly, err)
+		}
+		ids := make([]int, len(found))
+		for i, product := range found {
+			ids[i] = product.ID.Value()
+		}
+		if !equalInts(ids, want) {
+			t.Errorf("purchasable only %v: found %v, want %v", purchasableOnly, ids, want)
+		}
+	}
+}
+
+func TestGMTDatesAreReadApartFromLocalDates(t *testing.T) {
+	server := fakestore.New().Start()
+	defer server.Close()
+	repository := NewRepository(NewClient(NewConfig(server.URL, fakestore.ConsumerKey, fakestore.ConsumerSecret)))
+
+	productID, _ := domain.NewProductID(1)
+	product, err := repository.FindByID(context.Background(), productID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	for name, got := range map[string]struct{ value, want time.Time }{
+		"date_created":      {product.DateCreated, time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)},
+		"date_created_gmt":  {product.DateCreatedGMT, time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)},
+		"date_modified":     {product.DateModified, time.Date(2024, 2, 1, 9, 30, 0, 0, time.UTC)},
+		"date_modified_gmt": {product.DateModifiedGMT, time.Date(2024, 2, 1, 8, 30, 0, 0, time.UTC)},
+	} {
+		if !got.value.Equal(got.want) {
+			t.Errorf("%s = %v, want %v", name, got.value, got.want)
+		}
+	}
+}
+
+func TestAndOperatorKeepsProductsWithEveryTermWithinThePage(t *testing.T) {
+	store := fakestore.New()
+	products := fakestore.DefaultProducts()
+	for _, product := range products {
+		// products 1-4 are tagged summer, 3-6 sale; 3 and 4 have both
+		var tags []map[string]interface{}
+		if id := idOf(product); id <= 4 {
+			tags = append(tags, map[string]interface{}{"id": 10, "name": "Summer", "slug": "summer"})
+		}
+		if id := idOf(product); id >= 3 && id <= 6 {
+			tags = append(tags, map[string]interface{}{"id": 20, "name": "Sale", "slug": "sale"})
+		}
+		product["tags"] = tags
+	}
+	store.SetProducts(products)
+	server := store.Start()
+	defer server.Close()
+	repository := NewRepository(NewClient(NewConfig(server.URL, fakestore.ConsumerKey, fakestore.ConsumerSecret)))
+
+	all := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+	tests := []struct {
+		name             string
+		category, tag    string
+		categoryOperator domain.TermOperator
+		tagOperator      domain.TermOperator
+		ids              []int
+	}{
+		// the store itself matches any term; the fake store returns everything
+		{name: "tags or", tag: "10,20", tagOperator: domain.TermOperatorOr, ids: all},
+		{name: "tags and by ID", tag: "10,20", tagOperator: domain.TermOperatorAnd, ids: []int{3, 4}},
+		{name: "tags and by slug", tag: "summer, SALE", tagOperator: domain.TermOperatorAnd, ids: []int{3, 4}},
+		{name: "single tag and", tag: "10", tagOperator: domain.TermOperatorAnd, ids: all},
+		{name: "categories and", category: "15,sneakers", categoryOperator: domain.TermOperatorAnd, ids: []int{1, 2, 5}},
+		{name: "categories and tags and", category: "15,16", tag: "sale,summer", categoryOperator: domain.TermOperatorAnd, tagOperator: domain.TermOperatorAnd, ids: []int{}},
+		{name: "categories or", category: "15,16", categoryOperator: domain.TermOperatorOr, ids: all},
+	}
+	for _, tt := range tests {
+		criteria := domain.NewSearchCriteria()
+		criteria.Category, criteria.Tag = tt.category, tt.tag
+		criteria.SetTermOperators(tt.categoryOperator, tt.tagOperator)
+		criteria.SetPagination(1, 12)
+		criteria.SetSorting("id", "asc")
+
+		found, err := repository.Search(context.Background(), criteria)
+		if err != nil {
+			t.Fatalf("%s: search: %v", tt.name, err)
+		}
+		ids := make([]int, len(found))
+		for i, product := range found {
+			ids[i] = product.ID.Value()
+		}
+		if !equalInts(ids, tt.ids) {
+			t.Errorf("%s: found %v, want %v", tt.name, ids, tt.ids)
+		}
+	}
+}
+
+func TestStoreRedirectIsReportedOnce(t *testing.T) {
+	target := fakestore.New().Start()
+	defer target.Close()
+	redirecting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL+r.URL.RequestURI(), http.StatusMovedPermanently)
+	}))
+	defer redirecting.Close()
+	repository := NewRepository(NewClient(NewConfig(redirecting.URL, fakestore.ConsumerKey, fakestore.ConsumerSecret)))
+
+	if _, err := repository.Search(context.Background(), domain.NewSearchCriteria()); err != nil {
+		t.Fatalf("redirected search: %v", err)
+	}
+	if got, ok := repository.RedirectedBaseURL(); !ok || got != target.URL {
+		t.Errorf("redirected base URL = %q, %v; want %s", got, ok, target.URL)
+	}
+
+	if _, err := repository.Search(context.Background(), domain.NewSearchCriteria()); err != nil {
+		t.Fatalf("second search: %v", err)
+	}
+	if got, ok := repository.RedirectedBaseURL(); ok {
+		t.Errorf("redirect to %s reported twice", got)
+	}
+}
+
+func TestUnredirectedStoreReportsNothing(t *testing.T) {
+	server := fakestore.New().Start()
+	defer server.Close()
+	repository := NewRepository(NewClient(NewConfig(server.URL, fakestore.ConsumerKey, fakestore.ConsumerSecret)))
+
+	if _, err := repository.Search(context.Background(), domain.NewSearchCriteria()); err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if got, ok := repository.RedirectedBaseURL(); ok {
+		t.Errorf("redirect to %s reported without a redirect", got)
+	}
+}
+
+func TestSearchPagesCrawlsEveryPage(t *testing.T) {
+	server := fakestore.New().Start()
+	defer server.Close()
+	repository := NewRepository(NewClient(NewConfig(server.URL, fakestore.ConsumerKey, fakestore.ConsumerSecret)))
+
+	criteria := domain.NewSearchCriteria()
+	criteria.SetPagination(1, 5)
+	criteria.SetSorting("id", "asc")
+	pages := repository.SearchPages(criteria)
+
+	var ids []int
+	for pages.Next(context.Background()) {
+		page := pages.Page()
+		if page.TotalCount != 12 || page.TotalPages != 3 {
+			t.Errorf("page %d totals = %d/%d, want 12/3", pages.PageNumber(), page.TotalCount, page.TotalPages)
+		}
+		for _, product := range page.Products {
+			ids = append(ids, product.ID.Value())
+		}
+	}
+	if err := pages.Err(); err != nil {
+		t.Fatalf("crawl: %v", err)
+	}
+	if want := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}; !equalInts(ids, want) {
+		t.Errorf("crawled %v, want %v", ids, want)
+	}
+}
+
+func TestSearchPagesStopsAtAFailingPage(t *testing.T) {
+	store := fakestore.New()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			http.Error(w, `{"code":"internal_error"}`, http.StatusInternalServerError)
+			return
+		}
+		store.Handler().ServeHTTP(w, r)
+	}))
+	defer server.Close()
+	t.Setenv(retry.MaxRetriesEnv, "0")
+	repository := NewRepository(NewClient(NewConfig(server.URL, fakestore.ConsumerKey, fakestore.ConsumerSecret)))
+
+	criteria := domain.NewSearchCriteria()
+	criteria.SetPagination(1, 5)
+	pages := repository.SearchPages(criteria)
+
+	crawled := 0
+	for pages.Next(context.Background()) {
+		crawled++
+	}
+	if crawled != 1 {
+		t.Errorf("crawled %d pages, want only the one before the failure", crawled)
+	}
+	if pages.Err() == nil {
+		t.Error("a failing page ended the crawl without an error")
+	}
+}
+
+func TestSKUContainsKeepsMatchingSKUsWithinThePage(t *testing.T) {
+	store := fakestore.New()
+	products := fakestore.DefaultProducts()
+	products[0]["sku"] = "ABC-100"
+	products[1]["sku"] = "abc-200"
+	products[2]["sku"] = "XYZ-300"
+	// Found by the search for its name, but its SKU does not match
+	products[2]["name"] = "Laces for ABC-100"
+	store.SetProducts(products)
+	server := store.Start()
+	defer server.Close()
+	repository := NewRepository(NewClient(NewConfig(server.URL, fakestore.ConsumerKey, fakestore.ConsumerSecret)))
+
+	criteria := domain.NewSearchCriteria()
+	criteria.SetSKUContains("ABC-")
+	criteria.SetSorting("id", "asc")
+	found, err := repository.Search(context.Background(), criteria)
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	ids := make([]int, len(found))
+	for i, product := range found {
+		ids[i] = product.ID.Value()
+	}
+	if want := []int{1, 2}; !equalInts(ids, want) {
+		t.Errorf("found %v, want %v", ids, want)
+	}
+}
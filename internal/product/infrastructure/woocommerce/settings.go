@@ -0,0 +1,201 @@
+package woocommerce
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+	"woocommerce-mcp/internal/product/domain"
+	"woocommerce-mcp/kit/requestid"
+)
+
+// DefaultSettingsTTL is how long store settings are cached before being refetched
+const DefaultSettingsTTL = 10 * time.Minute
+
+// defaultCurrency stands in for the store currency when it cannot be read,
+// like the default of domain.NewMoney
+const defaultCurrency = "USD"
+
+// StoreSettings represents the store-wide currency, price formatting and
+// measurement unit settings
+type StoreSettings struct {
+	Currency          string `json:"currency"`
+	CurrencySymbol    string `json:"currency_symbol"`
+	DecimalSeparator  string `json:"decimal_separator"`
+	ThousandSeparator string `json:"thousand_separator"`
+	Decimals          int    `json:"decimals"`
+
+	// Units are empty when the product settings could not be read
+	WeightUnit    string `json:"weight_unit,omitempty"`
+	DimensionUnit string `json:"dimension_unit,omitempty"`
+
+	// Defaulted is set when the API key may not read the general settings
+	// and the default price format stands in for them
+	Defaulted bool `json:"defaulted,omitempty"`
+}
+
+// settingsCacheEntry holds cached settings for a single store
+type settingsCacheEntry struct {
+	settings  *StoreSettings
+	expiresAt time.Time
+}
+
+// settingsCache caches store settings per store and credentials. It is
+// shared at package level, so settings are also reused by clients created
+// outside the client cache and outlive an evicted client of their store.
+type settingsCache struct {
+	mu      sync.Mutex
+	entries map[string]*settingsCacheEntry
+}
+
+var storeSettingsCache = &settingsCache{
+	entries: make(map[string]*settingsCacheEntry),
+}
+
+// get returns the cached settings for a store if they have not expired
+func (sc *settingsCache) get(key string, now time.Time) (*StoreSettings, bool) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	entry, ok := sc.entries[key]
+	if !ok || !now.Before(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.settings, true
+}
+
+// set stores the settings for a store for the given TTL. Expired entries
+// are evicted first, so stores and credentials no longer used do not pile up.
+func (sc *settingsCache) set(key string, settings *StoreSettings, now time.Time, ttl time.Duration) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	sc.evictExpired(now)
+	sc.entries[key] = &settingsCacheEntry{
+		settings:  settings,
+		expiresAt: now.Add(ttl),
+	}
+}
+
+// evictExpired drops expired entries. The caller must hold the lock.
+func (sc *settingsCache) evictExpired(now time.Time) {
+	for key, entry := range sc.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(sc.entries, key)
+		}
+	}
+}
+
+// StoreSettings returns the store currency, price formatting and unit settings,
+// fetching them lazily and caching them for Config.SettingsTTL. Entries are
+// keyed like cached clients, by a salted hash of the store and credentials:
+// what a key may read differs, so the defaults a read-only key gets must not
+// be served to a key allowed to read the settings.
+func (c *Client) StoreSettings(ctx context.Context) (*StoreSettings, error) {
+	key := storeClientCache.key(c.config)
+	if settings, ok := storeSettingsCache.get(key, time.Now()); ok {
+		return settings, nil
+	}
+
+	settings, err := c.fetchStoreSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := c.config.SettingsTTL
+	if ttl <= 0 {
+		ttl = DefaultSettingsTTL
+	}
+	storeSettingsCache.set(key, settings, time.Now(), ttl)
+
+	return settings, nil
+}
+
+// fetchStoreSettings retrieves the general settings, current currency and
+// product unit settings from the API. The settings routes need a key with
+// read_write access, so a read-only key gets the default currency and price
+// format instead of an error; they are cached like fetched settings, so the
+// store is not asked again on every call.
+func (c *Client) fetchStoreSettings(ctx context.Context) (*StoreSettings, error) {
+	settings := &StoreSettings{
+		DecimalSeparator:  ".",
+		ThousandSeparator: ",",
+		Decimals:          2,
+	}
+
+	var apiSettings []APISetting
+	if err := c.getJSON(ctx, c.route("settings/general"), nil, &apiSettings); err != nil {
+		var apiErr *domain.WooCommerceAPIError
+		if !errors.As(err, &apiErr) || !apiErr.IsUnauthorized() {
+			return nil, fmt.Errorf("failed to fetch general settings: %w", err)
+		}
+		requestid.Logf(ctx, "Store %s does not let this API key read its general settings (status %d); using the default currency and price format",
+			c.config.BaseURL, apiErr.StatusCode)
+		settings.Defaulted = true
+	}
+	for _, setting := range apiSettings {
+		value := setting.StringValue()
+		switch setting.ID {
+		case "woocommerce_currency":
+			settings.Currency = value
+		case "woocommerce_price_decimal_sep":
+			settings.DecimalSeparator = value
+		case "woocommerce_price_thousand_sep":
+			settings.ThousandSeparator = value
+		case "woocommerce_price_num_decimals":
+			if decimals, err := strconv.Atoi(value); err == nil && decimals >= 0 {
+				settings.Decimals = decimals
+			}
+		}
+	}
+
+	// The symbol is not part of the general settings
+	var currency APICurrency
+	if err := c.getJSON(ctx, c.route("data/currencies/current"), nil, &currency); err == nil {
+		settings.CurrencySymbol = currency.Symbol
+		if settings.Currency == "" {
+			settings.Currency = currency.Code
+		}
+	}
+
+	if settings.Currency == "" {
+		settings.Currency = defaultCurrency
+	}
+
+	// The units are part of the product settings
+	var productSettings []APISetting
+	if err := c.getJSON(ctx, c.route("settings/products"), nil, &productSettings); err == nil {
+		for _, setting := range productSettings {
+			switch setting.ID {
+			case "woocommerce_weight_unit":
+				settings.WeightUnit = setting.StringValue()
+			case "woocommerce_dimension_unit":
+				settings.DimensionUnit = setting.StringValue()
+			}
+		}
+	}
+
+	return settings, nil
+}
+
+// getJSON performs an authenticated GET request against a REST route, with
+// optional query parameters, and decodes the JSON body
+func (c *Client) getJSON(ctx context.Context, route string, params url.Values, out interface{}) error {
+	u, err := c.buildURL(route)
+	if err != nil {
+		return err
+	}
+
+	query := u.Query()
+	for key, values := range params {
+		query[key] = values
+	}
+	c.addAuthParams(query)
+	u.RawQuery = query.Encode()
+
+	_, err = c.api().DoJSON(ctx, "GET", u.String(), out)
+	return err
+}
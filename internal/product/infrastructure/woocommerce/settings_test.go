@@ -0,0 +1,155 @@
+package woocommerce
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"woocommerce-mcp/internal/testutil/fakestore"
+)
+
+func TestStoreSettingsAreCachedPerCredentials(t *testing.T) {
+	server := fakestore.New().Start()
+	defer server.Close()
+
+	// A key that may not read the settings gets the defaults
+	denied, err := NewClient(NewConfig(server.URL, fakestore.ConsumerKey, "cs_wrong")).StoreSettings(context.Background())
+	if err != nil {
+		t.Fatalf("settings with a denied key: %v", err)
+	}
+	if !denied.Defaulted {
+		t.Fatalf("settings with a denied key = %+v, want the defaults", denied)
+	}
+
+	// A key that may read them still gets the store's settings
+	settings, err := NewClient(NewConfig(server.URL, fakestore.ConsumerKey, fakestore.ConsumerSecret)).StoreSettings(context.Background())
+	if err != nil {
+		t.Fatalf("settings with a valid key: %v", err)
+	}
+	if settings.Defaulted || settings.WeightUnit != "kg" {
+		t.Errorf("settings with a valid key = %+v, want the store's settings", settings)
+	}
+}
+
+func TestSettingsCacheEvictsExpiredEntries(t *testing.T) {
+	cache := &settingsCache{entries: make(map[string]*settingsCacheEntry)}
+	now := time.Now()
+
+	cache.set("old", &StoreSettings{Currency: "EUR"}, now, time.Minute)
+	if _, ok := cache.get("old", now.Add(30*time.Second)); !ok {
+		t.Fatal("entry expired before its TTL")
+	}
+
+	cache.set("new", &StoreSettings{Currency: "USD"}, now.Add(2*time.Minute), time.Minute)
+	if _, ok := cache.entries["old"]; ok {
+		t.Error("expired entry was kept after a later set")
+	}
+	if _, ok := cache.get("new", now.Add(2*time.Minute)); !ok {
+		t.Error("fresh entry is missing")
+	}
+}
+
+// settingsRequests counts the general settings requests a fake store received
+func settingsRequests(store *fakestore.Store) int {
+	count := 0
+	for _, request := range store.Requests() {
+		if strings.Contains(request, "/settings/general") {
+			count++
+		}
+	}
+	return count
+}
+
+func TestStoreSettingsAreCachedUntilTheyExpire(t *testing.T) {
+	store := fakestore.New()
+	server := store.Start()
+	defer server.Close()
+
+	config := NewConfig(server.URL, fakestore.ConsumerKey, fakestore.ConsumerSecret)
+	config.SettingsTTL = 50 * time.Millisecond
+	client := NewClient(config)
+
+	for i := 0; i < 2; i++ {
+		settings, err := client.StoreSettings(context.Background())
+		if err != nil {
+			t.Fatalf("lookup %d: %v", i+1, err)
+		}
+		if settings.Currency != "USD" || settings.Decimals != 2 {
+			t.Errorf("lookup %d = %+v", i+1, settings)
+		}
+	}
+	if got := settingsRequests(store); got != 1 {
+		t.Fatalf("settings were fetched %d times within the TTL, want once", got)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if _, err := client.StoreSettings(context.Background()); err != nil {
+		t.Fatalf("lookup after expiry: %v", err)
+	}
+	if got := settingsRequests(store); got != 2 {
+		t.Errorf("settings were fetched %d times after the TTL, want twice", got)
+	}
+}
+
+func TestStoreSettingsReadThePriceFormat(t *testing.T) {
+	_, baseURL := startStub(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/settings/general"):
+			w.Write([]byte(`[{"id":"woocommerce_currency","value":"EUR"},{"id":"woocommerce_price_decimal_sep","value":","},{"id":"woocommerce_price_thousand_sep","value":"."},{"id":"woocommerce_price_num_decimals","value":"3"}]`))
+		case strings.HasSuffix(r.URL.Path, "/data/currencies/current"):
+			w.Write([]byte(`{"code":"EUR","symbol":"€"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code":"rest_no_route","message":"No route"}`))
+		}
+	})
+
+	settings, err := NewClient(NewConfig(baseURL, "ck", "cs")).StoreSettings(context.Background())
+	if err != nil {
+		t.Fatalf("StoreSettings: %v", err)
+	}
+	want := StoreSettings{Currency: "EUR", CurrencySymbol: "€", DecimalSeparator: ",", ThousandSeparator: ".", Decimals: 3}
+	if *settings != want {
+		t.Errorf("StoreSettings() = %+v, want %+v", *settings, want)
+	}
+}
+
+func TestMeasurementUnitsComeFromTheProductSettings(t *testing.T) {
+	server := fakestore.New().Start()
+	defer server.Close()
+	repository := NewRepository(NewClient(NewConfig(server.URL, fakestore.ConsumerKey, fakestore.ConsumerSecret)))
+
+	units, err := repository.MeasurementUnits(context.Background())
+	if err != nil {
+		t.Fatalf("MeasurementUnits: %v", err)
+	}
+	if units.Weight != "kg" || units.Dimension != "cm" {
+		t.Errorf("units = %+v, want kg and cm", units)
+	}
+}
+
+func TestUnreadableProductSettingsLeaveTheUnitsEmpty(t *testing.T) {
+	_, baseURL := startStub(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/settings/general"):
+			w.Write([]byte(`[{"id":"woocommerce_currency","value":"EUR"}]`))
+		case strings.HasSuffix(r.URL.Path, "/settings/products"):
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"code":"woocommerce_rest_cannot_view","message":"Sorry, you cannot list resources."}`))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	})
+
+	settings, err := NewClient(NewConfig(baseURL, "ck", "cs")).StoreSettings(context.Background())
+	if err != nil {
+		t.Fatalf("StoreSettings: %v", err)
+	}
+	if settings.Currency != "EUR" || settings.WeightUnit != "" || settings.DimensionUnit != "" {
+		t.Errorf("settings = %+v, want the general settings without units", settings)
+	}
+}
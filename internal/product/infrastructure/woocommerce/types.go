@@ -1,5 +1,7 @@
 package woocommerce
 
+import "fmt"
+
 // APIProduct represents a product as returned by the WooCommerce API
 type APIProduct struct {
 	ID                int                   `json:"id"`
@@ -7,7 +9,9 @@ type APIProduct struct {
 	Slug              string                `json:"slug"`
 	Permalink         string                `json:"permalink"`
 	DateCreated       string                `json:"date_created"`
+	DateCreatedGMT    string                `json:"date_created_gmt"`
 	DateModified      string                `json:"date_modified"`
+	DateModifiedGMT   string                `json:"date_modified_gmt"`
 	Type              string                `json:"type"`
 	Status            string                `json:"status"`
 	Featured          bool                  `json:"featured"`
@@ -19,6 +23,10 @@ type APIProduct struct {
 	RegularPrice      string                `json:"regular_price"`
 	SalePrice         string                `json:"sale_price"`
 	OnSale            bool                  `json:"on_sale"`
+	DateOnSaleFrom    string                `json:"date_on_sale_from"`
+	DateOnSaleFromGMT string                `json:"date_on_sale_from_gmt"`
+	DateOnSaleTo      string                `json:"date_on_sale_to"`
+	DateOnSaleToGMT   string                `json:"date_on_sale_to_gmt"`
 	Purchasable       bool                  `json:"purchasable"`
 	TotalSales        int                   `json:"total_sales"`
 	Virtual           bool                  `json:"virtual"`
@@ -75,6 +83,10 @@ type APICategory struct {
 	ID   int    `json:"id"`
 	Name string `json:"name"`
 	Slug string `json:"slug"`
+	// Parent and Count are only set by the categories endpoint, not on
+	// products
+	Parent int `json:"parent"`
+	Count  int `json:"count"`
 }
 
 // APITag represents a product tag from the API
@@ -112,6 +124,23 @@ type APIDefaultAttribute struct {
 	Option string `json:"option"`
 }
 
+// APIVariation represents a product variation as returned by the WooCommerce API
+type APIVariation struct {
+	ID            int                   `json:"id"`
+	Status        string                `json:"status"`
+	SKU           string                `json:"sku"`
+	Permalink     string                `json:"permalink"`
+	Price         string                `json:"price"`
+	RegularPrice  string                `json:"regular_price"`
+	SalePrice     string                `json:"sale_price"`
+	OnSale        bool                  `json:"on_sale"`
+	Purchasable   bool                  `json:"purchasable"`
+	ManageStock   interface{}           `json:"manage_stock"`
+	StockQuantity *int                  `json:"stock_quantity"`
+	StockStatus   string                `json:"stock_status"`
+	Attributes    []APIDefaultAttribute `json:"attributes"`
+}
+
 // APIMetaData represents product metadata from the API
 type APIMetaData struct {
 	ID    int         `json:"id"`
@@ -119,6 +148,32 @@ type APIMetaData struct {
 	Value interface{} `json:"value"`
 }
 
+// APISetting represents a single setting from the WooCommerce settings API
+type APISetting struct {
+	ID    string      `json:"id"`
+	Label string      `json:"label"`
+	Value interface{} `json:"value"`
+}
+
+// StringValue returns the setting value as a string
+func (s APISetting) StringValue() string {
+	switch value := s.Value.(type) {
+	case string:
+		return value
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// APICurrency represents a currency from the WooCommerce data API
+type APICurrency struct {
+	Code   string `json:"code"`
+	Name   string `json:"name"`
+	Symbol string `json:"symbol"`
+}
+
 // APIErrorResponse represents an error response from the WooCommerce API
 type APIErrorResponse struct {
 	Code    string `json:"code"`
@@ -206,3 +261,10 @@ func (sp *SearchParams) ToMap() map[string]string {
 
 	return params
 }
+
+// APITopSeller represents an entry of the WooCommerce top sellers report
+type APITopSeller struct {
+	Name      string `json:"name"`
+	ProductID int    `json:"product_id"`
+	Quantity  int    `json:"quantity"`
+}
@@ -0,0 +1,101 @@
+package woocommerce
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"woocommerce-mcp/internal/product/domain"
+)
+
+// variationsPerPage is the page size used when fetching variations (the API maximum)
+const variationsPerPage = 100
+
+// maxVariationPages bounds how many pages of variations are fetched for one product
+const maxVariationPages = 10
+
+// GetVariations retrieves all variations of a variable product
+func (c *Client) GetVariations(ctx context.Context, parentID int) ([]*domain.Variation, error) {
+	route := c.route(fmt.Sprintf("products/%d/variations", parentID))
+
+	var variations []*domain.Variation
+	for page := 1; page <= maxVariationPages; page++ {
+		var apiVariations []APIVariation
+		params := url.Values{}
+		params.Set("per_page", strconv.Itoa(variationsPerPage))
+		params.Set("page", strconv.Itoa(page))
+		if err := c.getJSON(ctx, route, params, &apiVariations); err != nil {
+			return nil, err
+		}
+
+		for i := range apiVariations {
+			variation, err := c.apiVariationToDomain(&apiVariations[i], parentID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert variation %d: %w", apiVariations[i].ID, err)
+			}
+			variations = append(variations, variation)
+		}
+
+		if len(apiVariations) < variationsPerPage {
+			break
+		}
+	}
+
+	return variations, nil
+}
+
+// apiVariationToDomain converts an API variation to a domain variation
+func (c *Client) apiVariationToDomain(apiVariation *APIVariation, parentID int) (*domain.Variation, error) {
+	variationID, err := domain.NewProductID(apiVariation.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	variation := domain.NewVariation(variationID, parentID)
+	variation.Status = domain.ProductStatus(apiVariation.Status)
+	variation.SKU = apiVariation.SKU
+	variation.Permalink = apiVariation.Permalink
+	variation.OnSale = apiVariation.OnSale
+	variation.Purchasable = apiVariation.Purchasable
+	variation.StockQuantity = apiVariation.StockQuantity
+
+	// manage_stock is a boolean, or "parent" when stock is managed on the parent product
+	switch manageStock := apiVariation.ManageStock.(type) {
+	case bool:
+		variation.ManageStock = manageStock
+	case string:
+		variation.ManageStock = manageStock == "parent"
+	}
+
+	if apiVariation.StockStatus != "" {
+		stockStatus := domain.StockStatus(apiVariation.StockStatus)
+		if stockStatus.IsValid() {
+			variation.StockStatus = stockStatus
+		}
+	}
+
+	// Convert prices
+	if apiVariation.Price != "" {
+		if price, err := domain.NewMoneyFromString(apiVariation.Price, "USD"); err == nil {
+			variation.Price = price
+		}
+	}
+	if apiVariation.RegularPrice != "" {
+		if regularPrice, err := domain.NewMoneyFromString(apiVariation.RegularPrice, "USD"); err == nil {
+			variation.RegularPrice = regularPrice
+		}
+	}
+	if apiVariation.SalePrice != "" {
+		if salePrice, err := domain.NewMoneyFromString(apiVariation.SalePrice, "USD"); err == nil {
+			variation.SalePrice = salePrice
+		}
+	}
+
+	// Convert attributes
+	for _, apiAttribute := range apiVariation.Attributes {
+		attribute := domain.NewDefaultAttribute(apiAttribute.ID, apiAttribute.Name, apiAttribute.Option)
+		variation.Attributes = append(variation.Attributes, attribute)
+	}
+
+	return variation, nil
+}
@@ -0,0 +1,151 @@
+package presentation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"woocommerce-mcp/internal/product/application/get_category_menu"
+	"woocommerce-mcp/internal/product/infrastructure/woocommerce"
+	kitDomain "woocommerce-mcp/kit/domain"
+	"woocommerce-mcp/kit/storeconfig"
+	"woocommerce-mcp/kit/toolargs"
+	"woocommerce-mcp/kit/tooltimeout"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// CategoryMenuInput defines the input structure for the category_menu tool
+type CategoryMenuInput struct {
+	BaseURL        string `json:"base_url,omitempty" jsonschema:"WooCommerce store base URL (e.g., https://example.com); defaults to WC_BASE_URL"`
+	ConsumerKey    string `json:"consumer_key,omitempty" jsonschema:"WooCommerce REST API consumer key; defaults to WC_CONSUMER_KEY for the default store"`
+	ConsumerSecret string `json:"consumer_secret,omitempty" jsonschema:"WooCommerce REST API consumer secret; defaults to WC_CONSUMER_SECRET for the default store"`
+	Depth          string `json:"depth,omitempty" jsonschema:"Levels of categories to nest (1-10, default: 2 for top-level categories and their children)"`
+	HideEmpty      string `json:"hide_empty,omitempty" jsonschema:"Leave out categories without products unless a subcategory has some (true/false, default: false)"`
+}
+
+// UnmarshalJSON decodes the arguments, also accepting numbers and booleans
+// for the string fields
+func (in *CategoryMenuInput) UnmarshalJSON(data []byte) error {
+	type plain CategoryMenuInput
+	return toolargs.Unmarshal(data, (*plain)(in))
+}
+
+// CategoryMenuOutput defines the output structure for the category_menu tool
+type CategoryMenuOutput struct {
+	Message string `json:"message" jsonschema:"Human-readable message listing the top-level categories"`
+	Data    string `json:"data" jsonschema:"JSON-formatted category tree"`
+}
+
+// CategoryMenuHandler handles category_menu tool calls
+type CategoryMenuHandler struct{}
+
+// NewCategoryMenuHandler creates a new CategoryMenuHandler
+func NewCategoryMenuHandler() *CategoryMenuHandler {
+	return &CategoryMenuHandler{}
+}
+
+// CategoryMenuDescription describes the category_menu tool
+const CategoryMenuDescription = "Get the product categories of the store as a ready-to-render navigation menu: top-level categories with their product counts and their subcategories nested below them, in one call."
+
+// GetToolDefinition returns the MCP tool definition for category_menu
+func (h *CategoryMenuHandler) GetToolDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "category_menu",
+		Description: CategoryMenuDescription,
+		InputSchema: toolargs.InputSchema[CategoryMenuInput](),
+	}
+}
+
+// GetInputSchema returns the input schema for the JSON-RPC tools/list endpoint
+func (h *CategoryMenuHandler) GetInputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"base_url":        map[string]string{"type": "string", "description": "WooCommerce store base URL"},
+			"consumer_key":    map[string]string{"type": "string", "description": "WooCommerce REST API consumer key"},
+			"consumer_secret": map[string]string{"type": "string", "description": "WooCommerce REST API consumer secret"},
+			"depth":           map[string]string{"type": "string", "description": "Levels of categories to nest (1-10, default: 2)"},
+			"hide_empty":      map[string]string{"type": "string", "description": "Leave out categories without products (true/false)"},
+		},
+		"required": storeconfig.RequiredFields("base_url", "consumer_key", "consumer_secret"),
+	}
+}
+
+// ExecuteMCPTool implements the MCP tool execution
+func (h *CategoryMenuHandler) ExecuteMCPTool(ctx context.Context, req *mcp.CallToolRequest, input CategoryMenuInput) (*mcp.CallToolResult, CategoryMenuOutput, error) {
+	// Fall back to the default store configured through the environment
+	storeconfig.ApplyDefaults(&input.BaseURL, &input.ConsumerKey, &input.ConsumerSecret)
+
+	// Validate required fields
+	if input.BaseURL == "" {
+		return nil, CategoryMenuOutput{}, kitDomain.NewValidationError("base_url is required")
+	}
+	if input.ConsumerKey == "" {
+		return nil, CategoryMenuOutput{}, kitDomain.NewValidationError("consumer_key is required")
+	}
+	if input.ConsumerSecret == "" {
+		return nil, CategoryMenuOutput{}, kitDomain.NewValidationError("consumer_secret is required")
+	}
+
+	// Create WooCommerce client for the store of this call
+	ctx = storeconfig.WithStore(ctx, storeconfig.Store{
+		BaseURL:        input.BaseURL,
+		ConsumerKey:    input.ConsumerKey,
+		ConsumerSecret: input.ConsumerSecret,
+	})
+	config, err := woocommerce.NewConfigFromContext(ctx)
+	if err != nil {
+		return nil, CategoryMenuOutput{}, err
+	}
+	repo := woocommerce.NewRepository(woocommerce.NewCachedClient(config))
+
+	request := &get_category_menu.GetCategoryMenuRequest{
+		Depth:     input.Depth,
+		HideEmpty: input.HideEmpty,
+	}
+
+	// Bound the whole tool call, across all of its API requests
+	ctx, cancel := tooltimeout.WithTimeout(ctx)
+	defer cancel()
+
+	// Fetch the categories and build the tree
+	builder := get_category_menu.NewCategoryMenuBuilder(repo)
+	response, err := builder.Execute(ctx, request)
+	if err != nil {
+		return nil, CategoryMenuOutput{}, tooltimeout.Err(ctx, "category_menu", withAuthAdvice(fmt.Errorf("failed to get category menu: %w", err)))
+	}
+
+	// Convert response to JSON
+	jsonData, err := response.ToJSON()
+	if err != nil {
+		return nil, CategoryMenuOutput{}, fmt.Errorf("failed to serialize response: %w", err)
+	}
+
+	// Create human-readable message
+	message := categoryMenuMessage(response)
+
+	return nil, CategoryMenuOutput{
+		Message: message,
+		Data:    jsonData,
+	}, nil
+}
+
+// categoryMenuMessage summarizes the menu, listing the top-level categories
+// with their product counts
+func categoryMenuMessage(response *get_category_menu.GetCategoryMenuResponse) string {
+	if len(response.Categories) == 0 {
+		return "The store has no product categories"
+	}
+
+	names := make([]string, 0, len(response.Categories))
+	for _, item := range response.Categories {
+		names = append(names, fmt.Sprintf("%s (%d)", item.Name, item.Count))
+	}
+	message := fmt.Sprintf("Category menu with %d top-level category(ies) out of %d: %s",
+		len(response.Categories), response.TotalCategories, strings.Join(names, ", "))
+	if response.Truncated {
+		message += fmt.Sprintf(". Only the first %d categories were fetched", get_category_menu.MaxMenuCategories)
+	}
+	return message
+}
@@ -0,0 +1,44 @@
+package presentation
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"woocommerce-mcp/internal/product/application/get_category_menu"
+	"woocommerce-mcp/internal/testutil/fakestore"
+)
+
+func TestCategoryMenuNestsTheStoreCategories(t *testing.T) {
+	server := fakestore.New().Start()
+	defer server.Close()
+
+	_, output, err := NewCategoryMenuHandler().ExecuteMCPTool(context.Background(), nil, CategoryMenuInput{
+		BaseURL:        server.URL,
+		ConsumerKey:    fakestore.ConsumerKey,
+		ConsumerSecret: fakestore.ConsumerSecret,
+	})
+	if err != nil {
+		t.Fatalf("category_menu: %v", err)
+	}
+
+	var response get_category_menu.GetCategoryMenuResponse
+	if err := json.Unmarshal([]byte(output.Data), &response); err != nil {
+		t.Fatalf("decode data: %v", err)
+	}
+
+	// Apparel > Footwear > Sneakers, nested two levels deep by default
+	if len(response.Categories) != 1 || response.Categories[0].Name != "Apparel" {
+		t.Fatalf("top level = %+v, want Apparel only", response.Categories)
+	}
+	apparel := response.Categories[0]
+	if len(apparel.Children) != 1 || apparel.Children[0].Name != "Footwear" || apparel.Children[0].Count != 12 {
+		t.Fatalf("Apparel children = %+v, want Footwear with 12 products", apparel.Children)
+	}
+	if footwear := apparel.Children[0]; footwear.ChildCount != 1 || len(footwear.Children) != 0 {
+		t.Errorf("Footwear = %+v, want Sneakers counted but below the menu depth", footwear)
+	}
+	if response.TotalCategories != 3 {
+		t.Errorf("total_categories = %d, want 3", response.TotalCategories)
+	}
+}
@@ -0,0 +1,71 @@
+package presentation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"woocommerce-mcp/internal/testutil/fakestore"
+)
+
+// startPricedStore serves the fake store, finding nothing when a price,
+// stock status or on sale filter is set, which the fake store ignores
+func startPricedStore(t *testing.T) *httptest.Server {
+	t.Helper()
+	store := fakestore.New().Handler()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		for _, filter := range []string{"min_price", "max_price", "stock_status", "on_sale"} {
+			if query.Has(filter) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("X-WP-Total", "0")
+				w.Header().Set("X-WP-TotalPages", "0")
+				w.Write([]byte("[]"))
+				return
+			}
+		}
+		store.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// pricedSearchMessage runs search_products against startPricedStore
+func pricedSearchMessage(t *testing.T, input SearchProductsInput) string {
+	t.Helper()
+	server := startPricedStore(t)
+	input.BaseURL = server.URL
+	input.ConsumerKey = fakestore.ConsumerKey
+	input.ConsumerSecret = fakestore.ConsumerSecret
+	_, output, err := NewSearchProductsHandler().ExecuteMCPTool(context.Background(), nil, input)
+	if err != nil {
+		t.Fatalf("search_products: %v", err)
+	}
+	return output.Message
+}
+
+func TestEmptyResultSuggestsWideningThePriceRange(t *testing.T) {
+	message := pricedSearchMessage(t, SearchProductsInput{Search: "boots", MinPrice: "500", MaxPrice: "900", OnSale: "true"})
+
+	if want := "0 of 2 product(s) matched your filters — try widening the price range or dropping on_sale."; !strings.Contains(message, want) {
+		t.Errorf("message = %q, want %q", message, want)
+	}
+}
+
+func TestEmptyResultWithoutMatchesBlamesNoFilter(t *testing.T) {
+	message := pricedSearchMessage(t, SearchProductsInput{Search: "xyz", StockStatus: "instock"})
+
+	if want := "No products match even without stock_status"; !strings.Contains(message, want) {
+		t.Errorf("message = %q, want %q", message, want)
+	}
+}
+
+func TestEmptyResultWithoutRestrictiveFiltersHasNoHint(t *testing.T) {
+	message := pricedSearchMessage(t, SearchProductsInput{Search: "xyz"})
+
+	if strings.Contains(message, "matched your filters") || strings.Contains(message, "even without") {
+		t.Errorf("message = %q, want no filter hint", message)
+	}
+}
@@ -0,0 +1,28 @@
+package presentation
+
+import (
+	"errors"
+	"fmt"
+
+	"woocommerce-mcp/internal/product/domain"
+)
+
+// withAuthAdvice adds what to do about a store error rejecting the API key
+// to its message. A 401 means the keys are wrong, so the user should enter
+// them again; a 403 means they are right but lack permission, which new
+// keys with the same access would not fix.
+func withAuthAdvice(err error) error {
+	var apiErr *domain.WooCommerceAPIError
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+
+	switch {
+	case apiErr.IsAuthenticationFailure():
+		return fmt.Errorf("%w — the store did not accept the consumer key and secret: check that they were entered correctly and that the key has not been revoked", err)
+	case apiErr.IsPermissionDenied():
+		return fmt.Errorf("%w — the consumer key and secret are valid but do not have permission for this resource: give the key Read access under WooCommerce > Settings > Advanced > REST API", err)
+	default:
+		return err
+	}
+}
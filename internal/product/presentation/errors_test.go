@@ -0,0 +1,73 @@
+package presentation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"woocommerce-mcp/internal/product/domain"
+	"woocommerce-mcp/kit/retry"
+)
+
+func TestAuthAdviceTellsRejectedKeysFromMissingPermission(t *testing.T) {
+	tests := []struct {
+		status int
+		want   string
+		avoid  string
+	}{
+		{http.StatusUnauthorized, "check that they were entered correctly", "do not have permission"},
+		{http.StatusForbidden, "do not have permission for this resource", "entered correctly"},
+	}
+	for _, tt := range tests {
+		apiErr := domain.NewWooCommerceAPIError(tt.status, "Sorry, you cannot list resources.", "woocommerce_rest_cannot_view")
+		err := withAuthAdvice(fmt.Errorf("failed to search products: %w", apiErr))
+
+		if !strings.Contains(err.Error(), tt.want) || strings.Contains(err.Error(), tt.avoid) {
+			t.Errorf("%d: error = %q, want advice containing %q", tt.status, err, tt.want)
+		}
+		var unwrapped *domain.WooCommerceAPIError
+		if !errors.As(err, &unwrapped) || unwrapped.StatusCode != tt.status {
+			t.Errorf("%d: advice hides the store error", tt.status)
+		}
+	}
+}
+
+func TestAuthAdviceLeavesOtherErrorsAlone(t *testing.T) {
+	for _, err := range []error{
+		domain.NewWooCommerceAPIError(http.StatusNotFound, "No route", "rest_no_route"),
+		errors.New("connection refused"),
+	} {
+		if got := withAuthAdvice(err); got != err {
+			t.Errorf("withAuthAdvice(%v) = %v, want it unchanged", err, got)
+		}
+	}
+}
+
+func TestSearchProductsAdvisesOnAForbiddenKey(t *testing.T) {
+	t.Setenv(retry.MaxRetriesEnv, "0")
+	for status, want := range map[int]string{
+		http.StatusUnauthorized: "did not accept the consumer key and secret",
+		http.StatusForbidden:    "valid but do not have permission",
+	} {
+		store := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			w.Write([]byte(`{"code":"woocommerce_rest_cannot_view","message":"Sorry, you cannot list resources."}`))
+		}))
+
+		_, _, err := NewSearchProductsHandler().ExecuteMCPTool(context.Background(), nil, SearchProductsInput{
+			BaseURL:        store.URL,
+			ConsumerKey:    "ck_test",
+			ConsumerSecret: "cs_test",
+			Search:         "boots",
+		})
+		store.Close()
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%d: error = %v, want it to say %q", status, err, want)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package presentation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"woocommerce-mcp/internal/product/application/get_product_breadcrumb"
+	"woocommerce-mcp/internal/product/infrastructure/woocommerce"
+	kitDomain "woocommerce-mcp/kit/domain"
+	"woocommerce-mcp/kit/storeconfig"
+	"woocommerce-mcp/kit/toolargs"
+	"woocommerce-mcp/kit/tooltimeout"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// GetProductBreadcrumbInput defines the input structure for the get_product_breadcrumb tool
+type GetProductBreadcrumbInput struct {
+	BaseURL        string `json:"base_url,omitempty" jsonschema:"WooCommerce store base URL (e.g., https://example.com); defaults to WC_BASE_URL"`
+	ConsumerKey    string `json:"consumer_key,omitempty" jsonschema:"WooCommerce REST API consumer key; defaults to WC_CONSUMER_KEY for the default store"`
+	ConsumerSecret string `json:"consumer_secret,omitempty" jsonschema:"WooCommerce REST API consumer secret; defaults to WC_CONSUMER_SECRET for the default store"`
+	ProductID      string `json:"product_id" jsonschema:"ID of the product whose category breadcrumbs are returned"`
+}
+
+// UnmarshalJSON decodes the arguments, also accepting numbers and booleans
+// for the string fields
+func (in *GetProductBreadcrumbInput) UnmarshalJSON(data []byte) error {
+	type plain GetProductBreadcrumbInput
+	return toolargs.Unmarshal(data, (*plain)(in))
+}
+
+// GetProductBreadcrumbOutput defines the output structure for the get_product_breadcrumb tool
+type GetProductBreadcrumbOutput struct {
+	Message string `json:"message" jsonschema:"Human-readable message listing the breadcrumb paths"`
+	Data    string `json:"data" jsonschema:"JSON-formatted breadcrumb data"`
+}
+
+// GetProductBreadcrumbHandler handles get_product_breadcrumb tool calls
+type GetProductBreadcrumbHandler struct{}
+
+// NewGetProductBreadcrumbHandler creates a new GetProductBreadcrumbHandler
+func NewGetProductBreadcrumbHandler() *GetProductBreadcrumbHandler {
+	return &GetProductBreadcrumbHandler{}
+}
+
+// GetToolDefinition returns the MCP tool definition for get_product_breadcrumb
+func (h *GetProductBreadcrumbHandler) GetToolDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "get_product_breadcrumb",
+		Description: "Get the full category breadcrumb paths of a product, e.g. \"Apparel > Shoes > Running\", with one path per category the product is assigned to.",
+		InputSchema: toolargs.InputSchema[GetProductBreadcrumbInput](),
+	}
+}
+
+// GetInputSchema returns the input schema for the JSON-RPC tools/list endpoint
+func (h *GetProductBreadcrumbHandler) GetInputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"base_url":        map[string]string{"type": "string", "description": "WooCommerce store base URL"},
+			"consumer_key":    map[string]string{"type": "string", "description": "WooCommerce REST API consumer key"},
+			"consumer_secret": map[string]string{"type": "string", "description": "WooCommerce REST API consumer secret"},
+			"product_id":      map[string]string{"type": "string", "description": "Product ID"},
+		},
+		"required": storeconfig.RequiredFields("base_url", "consumer_key", "consumer_secret", "product_id"),
+	}
+}
+
+// ExecuteMCPTool implements the MCP tool execution
+func (h *GetProductBreadcrumbHandler) ExecuteMCPTool(ctx context.Context, req *mcp.CallToolRequest, input GetProductBreadcrumbInput) (*mcp.CallToolResult, GetProductBreadcrumbOutput, error) {
+	// Fall back to the default store configured through the environment
+	storeconfig.ApplyDefaults(&input.BaseURL, &input.ConsumerKey, &input.ConsumerSecret)
+
+	// Validate required fields
+	if input.BaseURL == "" {
+		return nil, GetProductBreadcrumbOutput{}, kitDomain.NewValidationError("base_url is required")
+	}
+	if input.ConsumerKey == "" {
+		return nil, GetProductBreadcrumbOutput{}, kitDomain.NewValidationError("consumer_key is required")
+	}
+	if input.ConsumerSecret == "" {
+		return nil, GetProductBreadcrumbOutput{}, kitDomain.NewValidationError("consumer_secret is required")
+	}
+
+	// Create WooCommerce client for the store of this call
+	ctx = storeconfig.WithStore(ctx, storeconfig.Store{
+		BaseURL:        input.BaseURL,
+		ConsumerKey:    input.ConsumerKey,
+		ConsumerSecret: input.ConsumerSecret,
+	})
+	config, err := woocommerce.NewConfigFromContext(ctx)
+	if err != nil {
+		return nil, GetProductBreadcrumbOutput{}, err
+	}
+	repo := woocommerce.NewRepository(woocommerce.NewCachedClient(config))
+
+	request := &get_product_breadcrumb.GetProductBreadcrumbRequest{
+		ProductID: input.ProductID,
+	}
+
+	// Bound the whole tool call, across all of its API requests
+	ctx, cancel := tooltimeout.WithTimeout(ctx)
+	defer cancel()
+
+	// Execute lookup
+	resolver := get_product_breadcrumb.NewBreadcrumbResolver(repo, repo)
+	response, err := resolver.Execute(ctx, request)
+	if err != nil {
+		return nil, GetProductBreadcrumbOutput{}, tooltimeout.Err(ctx, "get_product_breadcrumb", withAuthAdvice(fmt.Errorf("failed to get product breadcrumb: %w", err)))
+	}
+
+	// Convert response to JSON
+	jsonData, err := response.ToJSON()
+	if err != nil {
+		return nil, GetProductBreadcrumbOutput{}, fmt.Errorf("failed to serialize response: %w", err)
+	}
+
+	// Create human-readable message
+	var message string
+	if len(response.Breadcrumbs) == 0 {
+		message = fmt.Sprintf("Product %d has no categories", response.ProductID)
+	} else {
+		message = fmt.Sprintf("Product %d is in %d category path(s): %s",
+			response.ProductID, len(response.Breadcrumbs), strings.Join(response.Paths(), "; "))
+	}
+
+	return nil, GetProductBreadcrumbOutput{
+		Message: message,
+		Data:    jsonData,
+	}, nil
+}
@@ -0,0 +1,54 @@
+package presentation
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"woocommerce-mcp/internal/product/application/get_product_breadcrumb"
+	"woocommerce-mcp/internal/testutil/fakestore"
+)
+
+func TestBreadcrumbsFollowTheStoreHierarchy(t *testing.T) {
+	store := fakestore.New()
+	server := store.Start()
+	defer server.Close()
+
+	// Product 1 is a sneaker, assigned to both Footwear and Sneakers
+	_, output, err := NewGetProductBreadcrumbHandler().ExecuteMCPTool(context.Background(), nil, GetProductBreadcrumbInput{
+		BaseURL:        server.URL,
+		ConsumerKey:    fakestore.ConsumerKey,
+		ConsumerSecret: fakestore.ConsumerSecret,
+		ProductID:      "1",
+	})
+	if err != nil {
+		t.Fatalf("get_product_breadcrumb: %v", err)
+	}
+
+	var response get_product_breadcrumb.GetProductBreadcrumbResponse
+	if err := json.Unmarshal([]byte(output.Data), &response); err != nil {
+		t.Fatalf("decode data: %v", err)
+	}
+	paths := response.Paths()
+	if len(paths) != 2 || paths[0] != "Apparel > Footwear" || paths[1] != "Apparel > Footwear > Sneakers" {
+		t.Errorf("paths = %q, want Apparel > Footwear and Apparel > Footwear > Sneakers", paths)
+	}
+	if !strings.Contains(output.Data, "Apparel > Footwear > Sneakers") {
+		t.Errorf("data escapes the path separators: %s", output.Data)
+	}
+	if !strings.Contains(output.Message, "2 category path(s)") {
+		t.Errorf("message = %q, want both paths counted", output.Message)
+	}
+
+	// Footwear, the parent of Sneakers, is already among the assigned ones
+	lookups := 0
+	for _, request := range store.Requests() {
+		if strings.Contains(request, "/products/categories") {
+			lookups++
+		}
+	}
+	if lookups != 2 {
+		t.Errorf("category lookups = %d, want the assigned categories, then Apparel: %v", lookups, store.Requests())
+	}
+}
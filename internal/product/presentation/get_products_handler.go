@@ -0,0 +1,147 @@
+package presentation
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"woocommerce-mcp/internal/product/application/get_products"
+	"woocommerce-mcp/internal/product/application/search_products"
+	"woocommerce-mcp/internal/product/infrastructure/woocommerce"
+	kitDomain "woocommerce-mcp/kit/domain"
+	"woocommerce-mcp/kit/storeconfig"
+	"woocommerce-mcp/kit/toolargs"
+	"woocommerce-mcp/kit/tooltimeout"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// GetProductsInput defines the input structure for the get_products tool
+type GetProductsInput struct {
+	BaseURL        string `json:"base_url,omitempty" jsonschema:"WooCommerce store base URL (e.g., https://example.com); defaults to WC_BASE_URL"`
+	ConsumerKey    string `json:"consumer_key,omitempty" jsonschema:"WooCommerce REST API consumer key; defaults to WC_CONSUMER_KEY for the default store"`
+	ConsumerSecret string `json:"consumer_secret,omitempty" jsonschema:"WooCommerce REST API consumer secret; defaults to WC_CONSUMER_SECRET for the default store"`
+	ProductIDs     []int  `json:"product_ids" jsonschema:"IDs of the products to fetch (at most 500); products are returned in this order"`
+}
+
+// UnmarshalJSON decodes the arguments, also accepting numbers and booleans
+// for the string fields
+func (in *GetProductsInput) UnmarshalJSON(data []byte) error {
+	type plain GetProductsInput
+	return toolargs.Unmarshal(data, (*plain)(in))
+}
+
+// GetProductsOutput defines the output structure for the get_products tool
+type GetProductsOutput struct {
+	Message string `json:"message" jsonschema:"Human-readable message about the fetched products"`
+	Data    string `json:"data" jsonschema:"JSON-formatted product data"`
+}
+
+// GetProductsHandler handles get_products tool calls
+type GetProductsHandler struct{}
+
+// NewGetProductsHandler creates a new GetProductsHandler
+func NewGetProductsHandler() *GetProductsHandler {
+	return &GetProductsHandler{}
+}
+
+// GetToolDefinition returns the MCP tool definition for get_products
+func (h *GetProductsHandler) GetToolDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "get_products",
+		Description: "Get several products by ID at once as full product objects, e.g. to load the details of products found in earlier steps. Products are returned in the requested order and IDs that do not exist are listed as missing.",
+		InputSchema: toolargs.InputSchema[GetProductsInput](),
+	}
+}
+
+// GetInputSchema returns the input schema for the JSON-RPC tools/list endpoint
+func (h *GetProductsHandler) GetInputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"base_url":        map[string]string{"type": "string", "description": "WooCommerce store base URL"},
+			"consumer_key":    map[string]string{"type": "string", "description": "WooCommerce REST API consumer key"},
+			"consumer_secret": map[string]string{"type": "string", "description": "WooCommerce REST API consumer secret"},
+			"product_ids": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]string{"type": "integer"},
+				"description": "Product IDs, at most 500",
+			},
+		},
+		"required": storeconfig.RequiredFields("base_url", "consumer_key", "consumer_secret", "product_ids"),
+	}
+}
+
+// ExecuteMCPTool implements the MCP tool execution
+func (h *GetProductsHandler) ExecuteMCPTool(ctx context.Context, req *mcp.CallToolRequest, input GetProductsInput) (*mcp.CallToolResult, GetProductsOutput, error) {
+	// Fall back to the default store configured through the environment
+	storeconfig.ApplyDefaults(&input.BaseURL, &input.ConsumerKey, &input.ConsumerSecret)
+
+	// Validate required fields
+	if input.BaseURL == "" {
+		return nil, GetProductsOutput{}, kitDomain.NewValidationError("base_url is required")
+	}
+	if input.ConsumerKey == "" {
+		return nil, GetProductsOutput{}, kitDomain.NewValidationError("consumer_key is required")
+	}
+	if input.ConsumerSecret == "" {
+		return nil, GetProductsOutput{}, kitDomain.NewValidationError("consumer_secret is required")
+	}
+
+	// Create WooCommerce client for the store of this call
+	ctx = storeconfig.WithStore(ctx, storeconfig.Store{
+		BaseURL:        input.BaseURL,
+		ConsumerKey:    input.ConsumerKey,
+		ConsumerSecret: input.ConsumerSecret,
+	})
+	config, err := woocommerce.NewConfigFromContext(ctx)
+	if err != nil {
+		return nil, GetProductsOutput{}, err
+	}
+	repo := woocommerce.NewRepository(woocommerce.NewCachedClient(config))
+
+	request := &get_products.GetProductsRequest{
+		ProductIDs: input.ProductIDs,
+	}
+
+	// Bound the whole tool call, across all of its API requests
+	ctx, cancel := tooltimeout.WithTimeout(ctx)
+	defer cancel()
+
+	// Execute lookup
+	getter := get_products.NewProductsGetter(repo)
+	response, err := getter.Execute(ctx, request)
+	if err != nil {
+		return nil, GetProductsOutput{}, tooltimeout.Err(ctx, "get_products", withAuthAdvice(fmt.Errorf("failed to get products: %w", err)))
+	}
+
+	// Convert response to JSON
+	search_products.DescribeSales(response.Products, time.Now())
+	jsonData, err := response.ToJSON()
+	if err != nil {
+		return nil, GetProductsOutput{}, fmt.Errorf("failed to serialize response: %w", err)
+	}
+
+	// Create human-readable message
+	message := fmt.Sprintf("Found %d of %d requested product(s)",
+		len(response.Products), len(response.Products)+len(response.MissingIDs))
+	if len(response.MissingIDs) > 0 {
+		message += fmt.Sprintf("; not found: %s", joinIDs(response.MissingIDs))
+	}
+
+	return nil, GetProductsOutput{
+		Message: message,
+		Data:    jsonData,
+	}, nil
+}
+
+// joinIDs lists IDs separated by commas
+func joinIDs(ids []int) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.Itoa(id)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,139 @@
+package presentation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"woocommerce-mcp/internal/product/application/get_related_products"
+	"woocommerce-mcp/internal/product/application/search_products"
+	"woocommerce-mcp/internal/product/infrastructure/woocommerce"
+	kitDomain "woocommerce-mcp/kit/domain"
+	"woocommerce-mcp/kit/storeconfig"
+	"woocommerce-mcp/kit/toolargs"
+	"woocommerce-mcp/kit/tooltimeout"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// GetRelatedProductsInput defines the input structure for the get_related_products tool
+type GetRelatedProductsInput struct {
+	BaseURL        string `json:"base_url,omitempty" jsonschema:"WooCommerce store base URL (e.g., https://example.com); defaults to WC_BASE_URL"`
+	ConsumerKey    string `json:"consumer_key,omitempty" jsonschema:"WooCommerce REST API consumer key; defaults to WC_CONSUMER_KEY for the default store"`
+	ConsumerSecret string `json:"consumer_secret,omitempty" jsonschema:"WooCommerce REST API consumer secret; defaults to WC_CONSUMER_SECRET for the default store"`
+	ProductID      string `json:"product_id" jsonschema:"ID of the product whose linked products are returned"`
+	Relation       string `json:"relation,omitempty" jsonschema:"Relation type (related, upsell, cross_sell; default: related)"`
+	Limit          string `json:"limit,omitempty" jsonschema:"Maximum number of linked products to return (1-50, default: 10)"`
+}
+
+// UnmarshalJSON decodes the arguments, also accepting numbers and booleans
+// for the string fields
+func (in *GetRelatedProductsInput) UnmarshalJSON(data []byte) error {
+	type plain GetRelatedProductsInput
+	return toolargs.Unmarshal(data, (*plain)(in))
+}
+
+// GetRelatedProductsOutput defines the output structure for the get_related_products tool
+type GetRelatedProductsOutput struct {
+	Message string `json:"message" jsonschema:"Human-readable message about the linked products"`
+	Data    string `json:"data" jsonschema:"JSON-formatted product data"`
+}
+
+// GetRelatedProductsHandler handles get_related_products tool calls
+type GetRelatedProductsHandler struct{}
+
+// NewGetRelatedProductsHandler creates a new GetRelatedProductsHandler
+func NewGetRelatedProductsHandler() *GetRelatedProductsHandler {
+	return &GetRelatedProductsHandler{}
+}
+
+// GetToolDefinition returns the MCP tool definition for get_related_products
+func (h *GetRelatedProductsHandler) GetToolDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "get_related_products",
+		Description: "Get the related, upsell or cross-sell products of a product as full product objects, e.g. to recommend what customers also bought.",
+		InputSchema: toolargs.InputSchema[GetRelatedProductsInput](),
+	}
+}
+
+// GetInputSchema returns the input schema for the JSON-RPC tools/list endpoint
+func (h *GetRelatedProductsHandler) GetInputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"base_url":        map[string]string{"type": "string", "description": "WooCommerce store base URL"},
+			"consumer_key":    map[string]string{"type": "string", "description": "WooCommerce REST API consumer key"},
+			"consumer_secret": map[string]string{"type": "string", "description": "WooCommerce REST API consumer secret"},
+			"product_id":      map[string]string{"type": "string", "description": "Product ID"},
+			"relation":        map[string]string{"type": "string", "description": "Relation type (related, upsell, cross_sell)"},
+			"limit":           map[string]string{"type": "string", "description": "Maximum number of linked products"},
+		},
+		"required": storeconfig.RequiredFields("base_url", "consumer_key", "consumer_secret", "product_id"),
+	}
+}
+
+// ExecuteMCPTool implements the MCP tool execution
+func (h *GetRelatedProductsHandler) ExecuteMCPTool(ctx context.Context, req *mcp.CallToolRequest, input GetRelatedProductsInput) (*mcp.CallToolResult, GetRelatedProductsOutput, error) {
+	// Fall back to the default store configured through the environment
+	storeconfig.ApplyDefaults(&input.BaseURL, &input.ConsumerKey, &input.ConsumerSecret)
+
+	// Validate required fields
+	if input.BaseURL == "" {
+		return nil, GetRelatedProductsOutput{}, kitDomain.NewValidationError("base_url is required")
+	}
+	if input.ConsumerKey == "" {
+		return nil, GetRelatedProductsOutput{}, kitDomain.NewValidationError("consumer_key is required")
+	}
+	if input.ConsumerSecret == "" {
+		return nil, GetRelatedProductsOutput{}, kitDomain.NewValidationError("consumer_secret is required")
+	}
+
+	// Create WooCommerce client
+	config := woocommerce.NewConfig(input.BaseURL, input.ConsumerKey, input.ConsumerSecret)
+	client := woocommerce.NewCachedClient(config)
+	repo := woocommerce.NewRepository(client)
+
+	request := &get_related_products.RelatedProductsRequest{
+		BaseURL:        input.BaseURL,
+		ConsumerKey:    input.ConsumerKey,
+		ConsumerSecret: input.ConsumerSecret,
+		ProductID:      input.ProductID,
+		Relation:       input.Relation,
+		Limit:          input.Limit,
+	}
+
+	// Bound the whole tool call, across all of its API requests
+	ctx, cancel := tooltimeout.WithTimeout(ctx)
+	defer cancel()
+
+	// Execute lookup
+	finder := get_related_products.NewRelatedProductsFinder(repo)
+	response, err := finder.Execute(ctx, request)
+	if err != nil {
+		return nil, GetRelatedProductsOutput{}, tooltimeout.Err(ctx, "get_related_products", withAuthAdvice(fmt.Errorf("failed to get related products: %w", err)))
+	}
+
+	// Convert response to JSON
+	search_products.DescribeSales(response.Products, time.Now())
+	jsonData, err := response.ToJSON()
+	if err != nil {
+		return nil, GetRelatedProductsOutput{}, fmt.Errorf("failed to serialize response: %w", err)
+	}
+
+	// Create human-readable message
+	var message string
+	if response.TotalLinked == 0 {
+		message = fmt.Sprintf("Product %d has no %s products", response.ProductID, response.Relation)
+	} else {
+		message = fmt.Sprintf("Found %d %s product(s) for product %d (%d linked)",
+			len(response.Products), response.Relation, response.ProductID, response.TotalLinked)
+		if len(response.MissingIDs) > 0 {
+			message += fmt.Sprintf("; %d linked product(s) no longer exist or are unpublished", len(response.MissingIDs))
+		}
+	}
+
+	return nil, GetRelatedProductsOutput{
+		Message: message,
+		Data:    jsonData,
+	}, nil
+}
@@ -0,0 +1,153 @@
+package presentation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"woocommerce-mcp/internal/product/application/get_variation"
+	"woocommerce-mcp/internal/product/infrastructure/woocommerce"
+	kitDomain "woocommerce-mcp/kit/domain"
+	"woocommerce-mcp/kit/storeconfig"
+	"woocommerce-mcp/kit/toolargs"
+	"woocommerce-mcp/kit/tooltimeout"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// GetVariationInput defines the input structure for the get_variation tool
+type GetVariationInput struct {
+	BaseURL        string            `json:"base_url,omitempty" jsonschema:"WooCommerce store base URL (e.g., https://example.com); defaults to WC_BASE_URL"`
+	ConsumerKey    string            `json:"consumer_key,omitempty" jsonschema:"WooCommerce REST API consumer key; defaults to WC_CONSUMER_KEY for the default store"`
+	ConsumerSecret string            `json:"consumer_secret,omitempty" jsonschema:"WooCommerce REST API consumer secret; defaults to WC_CONSUMER_SECRET for the default store"`
+	ProductID      string            `json:"product_id" jsonschema:"ID of the parent variable product"`
+	Attributes     map[string]string `json:"attributes" jsonschema:"Attribute name to value map, e.g. color to red and size to L (case-insensitive)"`
+}
+
+// UnmarshalJSON decodes the arguments, also accepting numbers and booleans
+// for the string fields
+func (in *GetVariationInput) UnmarshalJSON(data []byte) error {
+	type plain GetVariationInput
+	return toolargs.Unmarshal(data, (*plain)(in))
+}
+
+// GetVariationOutput defines the output structure for the get_variation tool
+type GetVariationOutput struct {
+	Message string `json:"message" jsonschema:"Human-readable message about the matched variation"`
+	Data    string `json:"data" jsonschema:"JSON-formatted variation data"`
+}
+
+// GetVariationHandler handles get_variation tool calls
+type GetVariationHandler struct{}
+
+// NewGetVariationHandler creates a new GetVariationHandler
+func NewGetVariationHandler() *GetVariationHandler {
+	return &GetVariationHandler{}
+}
+
+// GetToolDefinition returns the MCP tool definition for get_variation
+func (h *GetVariationHandler) GetToolDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "get_variation",
+		Description: "Find the variation of a variable product matching attribute values (e.g. color red, size L) and return its SKU, price and stock.",
+		InputSchema: toolargs.InputSchema[GetVariationInput](),
+	}
+}
+
+// GetInputSchema returns the input schema for the JSON-RPC tools/list endpoint
+func (h *GetVariationHandler) GetInputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"base_url":        map[string]string{"type": "string", "description": "WooCommerce store base URL"},
+			"consumer_key":    map[string]string{"type": "string", "description": "WooCommerce REST API consumer key"},
+			"consumer_secret": map[string]string{"type": "string", "description": "WooCommerce REST API consumer secret"},
+			"product_id":      map[string]string{"type": "string", "description": "ID of the parent variable product"},
+			"attributes": map[string]interface{}{
+				"type":                 "object",
+				"description":          "Attribute name to value map, e.g. {\"color\": \"red\", \"size\": \"L\"} (case-insensitive)",
+				"additionalProperties": map[string]string{"type": "string"},
+			},
+		},
+		"required": storeconfig.RequiredFields("base_url", "consumer_key", "consumer_secret", "product_id", "attributes"),
+	}
+}
+
+// ExecuteMCPTool implements the MCP tool execution
+func (h *GetVariationHandler) ExecuteMCPTool(ctx context.Context, req *mcp.CallToolRequest, input GetVariationInput) (*mcp.CallToolResult, GetVariationOutput, error) {
+	// Fall back to the default store configured through the environment
+	storeconfig.ApplyDefaults(&input.BaseURL, &input.ConsumerKey, &input.ConsumerSecret)
+
+	// Validate required fields
+	if input.BaseURL == "" {
+		return nil, GetVariationOutput{}, kitDomain.NewValidationError("base_url is required")
+	}
+	if input.ConsumerKey == "" {
+		return nil, GetVariationOutput{}, kitDomain.NewValidationError("consumer_key is required")
+	}
+	if input.ConsumerSecret == "" {
+		return nil, GetVariationOutput{}, kitDomain.NewValidationError("consumer_secret is required")
+	}
+
+	// Create WooCommerce client
+	config := woocommerce.NewConfig(input.BaseURL, input.ConsumerKey, input.ConsumerSecret)
+	client := woocommerce.NewCachedClient(config)
+	repo := woocommerce.NewRepository(client)
+
+	request := &get_variation.GetVariationRequest{
+		BaseURL:        input.BaseURL,
+		ConsumerKey:    input.ConsumerKey,
+		ConsumerSecret: input.ConsumerSecret,
+		ProductID:      input.ProductID,
+		Attributes:     input.Attributes,
+	}
+
+	// Bound the whole tool call, across all of its API requests
+	ctx, cancel := tooltimeout.WithTimeout(ctx)
+	defer cancel()
+
+	// Execute lookup
+	finder := get_variation.NewVariationFinder(repo, repo)
+	response, err := finder.Execute(ctx, request)
+	if err != nil {
+		return nil, GetVariationOutput{}, tooltimeout.Err(ctx, "get_variation", withAuthAdvice(fmt.Errorf("failed to get variation: %w", err)))
+	}
+
+	// Convert response to JSON
+	jsonData, err := response.ToJSON()
+	if err != nil {
+		return nil, GetVariationOutput{}, fmt.Errorf("failed to serialize response: %w", err)
+	}
+
+	// Create human-readable message
+	var message string
+	wanted := describeAttributes(input.Attributes)
+	switch {
+	case response.HasMatch():
+		variation := response.Variation
+		message = fmt.Sprintf("Variation %d of product %d matches %s (SKU %q, price %s, stock status %s)",
+			variation.ID, response.ProductID, wanted, variation.SKU, variation.Price, variation.StockStatus)
+	case response.MatchCount == 0:
+		message = fmt.Sprintf("No matching variation for %s on product %d; see available_attributes for the offered values",
+			wanted, response.ProductID)
+	default:
+		message = fmt.Sprintf("%d variations of product %d match %s; specify more attributes to narrow it down",
+			response.MatchCount, response.ProductID, wanted)
+	}
+
+	return nil, GetVariationOutput{
+		Message: message,
+		Data:    jsonData,
+	}, nil
+}
+
+// describeAttributes formats the requested attributes in a stable order
+func describeAttributes(attributes map[string]string) string {
+	parts := make([]string, 0, len(attributes))
+	for name, value := range attributes {
+		parts = append(parts, fmt.Sprintf("%s='%s'", name, value))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,108 @@
+package presentation
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"woocommerce-mcp/internal/product/application/get_variation"
+	"woocommerce-mcp/internal/testutil/fakestore"
+)
+
+// variation builds a variation fixture distinguished by color and size
+func variation(id int, sku, color, size string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":           id,
+		"status":       "publish",
+		"sku":          sku,
+		"price":        "49.99",
+		"stock_status": "instock",
+		"attributes": []map[string]interface{}{
+			{"name": "Color", "option": color},
+			{"name": "Size", "option": size},
+		},
+	}
+}
+
+// variationOutput runs get_variation for product 2 of a fake store offering
+// red and blue shirts and returns the tool output and its decoded data
+func variationOutput(t *testing.T, attributes map[string]string) (GetVariationOutput, get_variation.GetVariationResponse) {
+	t.Helper()
+
+	store := fakestore.New()
+	store.SetVariations(2, []map[string]interface{}{
+		variation(21, "SHIRT-RED-M", "Red", "M"),
+		variation(22, "SHIRT-RED-L", "Red", "L"),
+		variation(23, "SHIRT-BLUE-L", "Blue", "L"),
+	})
+	server := store.Start()
+	t.Cleanup(server.Close)
+
+	_, output, err := NewGetVariationHandler().ExecuteMCPTool(context.Background(), nil, GetVariationInput{
+		BaseURL:        server.URL,
+		ConsumerKey:    fakestore.ConsumerKey,
+		ConsumerSecret: fakestore.ConsumerSecret,
+		ProductID:      "2",
+		Attributes:     attributes,
+	})
+	if err != nil {
+		t.Fatalf("get_variation: %v", err)
+	}
+
+	var response get_variation.GetVariationResponse
+	if err := json.Unmarshal([]byte(output.Data), &response); err != nil {
+		t.Fatalf("decode data: %v", err)
+	}
+	return output, response
+}
+
+func TestVariationMatchesBothAttributes(t *testing.T) {
+	output, response := variationOutput(t, map[string]string{"color": "red", "SIZE": " l "})
+
+	if response.MatchCount != 1 || response.Variation == nil {
+		t.Fatalf("match_count = %d, variation = %v, want a single match", response.MatchCount, response.Variation)
+	}
+	if response.Variation.SKU != "SHIRT-RED-L" {
+		t.Errorf("sku = %q, want SHIRT-RED-L", response.Variation.SKU)
+	}
+	if response.AvailableAttributes != nil {
+		t.Errorf("available_attributes = %v, want none for a single match", response.AvailableAttributes)
+	}
+	if !strings.Contains(output.Message, `SKU "SHIRT-RED-L"`) {
+		t.Errorf("message = %q, want the matched SKU", output.Message)
+	}
+}
+
+func TestVariationNeedsEveryAttributeToNarrowDown(t *testing.T) {
+	output, response := variationOutput(t, map[string]string{"color": "red"})
+
+	if response.MatchCount != 2 || response.Variation != nil {
+		t.Fatalf("match_count = %d, variation = %v, want two matches and no variation", response.MatchCount, response.Variation)
+	}
+	if !strings.Contains(output.Message, "specify more attributes") {
+		t.Errorf("message = %q, want a hint to narrow down", output.Message)
+	}
+	if len(response.AvailableAttributes) != 2 {
+		t.Errorf("available_attributes = %v, want color and size", response.AvailableAttributes)
+	}
+}
+
+func TestVariationWithoutMatchListsTheOfferedValues(t *testing.T) {
+	output, response := variationOutput(t, map[string]string{"color": "blue", "size": "m"})
+
+	if response.MatchCount != 0 || response.Variation != nil {
+		t.Fatalf("match_count = %d, variation = %v, want no match", response.MatchCount, response.Variation)
+	}
+	if !strings.HasPrefix(output.Message, "No matching variation") {
+		t.Errorf("message = %q, want the no-match message", output.Message)
+	}
+	if len(response.AvailableAttributes) != 2 {
+		t.Errorf("available_attributes = %v, want color and size", response.AvailableAttributes)
+	}
+	for name, values := range response.AvailableAttributes {
+		if len(values) != 2 {
+			t.Errorf("available_attributes[%s] = %v, want two values", name, values)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package presentation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"woocommerce-mcp/internal/product/domain"
+	"woocommerce-mcp/internal/testutil/fakestore"
+	"woocommerce-mcp/kit/safemode"
+)
+
+// startSafeModeStore serves the default products with Low Top Sneakers (1)
+// as a draft and High Top Sneakers (2) as a variable product with one
+// published and one private variation, and turns safe mode on
+func startSafeModeStore(t *testing.T) string {
+	t.Helper()
+	t.Setenv(safemode.Env, "true")
+
+	store := fakestore.New()
+	products := fakestore.DefaultProducts()
+	products[0]["status"] = "draft"
+	products[1]["type"] = "variable"
+	products[1]["related_ids"] = []int{3}
+	products[2]["related_ids"] = []int{1, 4}
+	store.SetProducts(products)
+	for _, id := range []int{1, 2} {
+		store.SetVariations(id, []map[string]interface{}{
+			{"id": id*100 + 1, "status": "publish", "sku": "PUBLIC", "price": "10.00", "attributes": []map[string]interface{}{{"name": "Size", "option": "M"}}},
+			{"id": id*100 + 2, "status": "private", "sku": "HIDDEN", "price": "9.00", "attributes": []map[string]interface{}{{"name": "Size", "option": "L"}}},
+		})
+	}
+
+	server := store.Start()
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestSafeModeRejectsDraftStatusSearch(t *testing.T) {
+	baseURL := startSafeModeStore(t)
+
+	_, _, err := NewSearchProductsHandler().ExecuteMCPTool(context.Background(), nil, SearchProductsInput{
+		BaseURL:        baseURL,
+		ConsumerKey:    fakestore.ConsumerKey,
+		ConsumerSecret: fakestore.ConsumerSecret,
+		Status:         "draft",
+	})
+	if err == nil || !strings.Contains(err.Error(), "safe mode") {
+		t.Fatalf("status=draft in safe mode: got error %v, want a safe mode rejection", err)
+	}
+}
+
+func TestSafeModeForcesPublishedSearch(t *testing.T) {
+	baseURL := startSafeModeStore(t)
+
+	_, output, err := NewSearchProductsHandler().ExecuteMCPTool(context.Background(), nil, SearchProductsInput{
+		BaseURL:        baseURL,
+		ConsumerKey:    fakestore.ConsumerKey,
+		ConsumerSecret: fakestore.ConsumerSecret,
+		Search:         "Sneakers",
+	})
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+
+	var data struct {
+		Products []struct {
+			ID     int    `json:"id"`
+			Status string `json:"status"`
+		} `json:"products"`
+	}
+	if err := json.Unmarshal([]byte(output.Data), &data); err != nil {
+		t.Fatalf("decode search data: %v", err)
+	}
+	if len(data.Products) == 0 {
+		t.Fatal("no published sneakers returned")
+	}
+	for _, product := range data.Products {
+		if product.ID == 1 || product.Status != "publish" {
+			t.Errorf("safe mode returned product %d with status %q", product.ID, product.Status)
+		}
+	}
+}
+
+func TestSafeModeReportsDraftProductsAsMissing(t *testing.T) {
+	baseURL := startSafeModeStore(t)
+
+	_, output, err := NewGetProductsHandler().ExecuteMCPTool(context.Background(), nil, GetProductsInput{
+		BaseURL:        baseURL,
+		ConsumerKey:    fakestore.ConsumerKey,
+		ConsumerSecret: fakestore.ConsumerSecret,
+		ProductIDs:     []int{1, 3},
+	})
+	if err != nil {
+		t.Fatalf("get_products: %v", err)
+	}
+
+	var data struct {
+		Products []struct {
+			ID int `json:"id"`
+		} `json:"products"`
+		MissingIDs []int `json:"missing_ids"`
+	}
+	if err := json.Unmarshal([]byte(output.Data), &data); err != nil {
+		t.Fatalf("decode get_products data: %v", err)
+	}
+	if len(data.Products) != 1 || data.Products[0].ID != 3 {
+		t.Errorf("products = %+v, want only product 3", data.Products)
+	}
+	if len(data.MissingIDs) != 1 || data.MissingIDs[0] != 1 {
+		t.Errorf("missing_ids = %v, want [1]", data.MissingIDs)
+	}
+}
+
+func TestSafeModeHidesVariationsOfDraftProducts(t *testing.T) {
+	baseURL := startSafeModeStore(t)
+
+	_, _, err := NewGetVariationHandler().ExecuteMCPTool(context.Background(), nil, GetVariationInput{
+		BaseURL:        baseURL,
+		ConsumerKey:    fakestore.ConsumerKey,
+		ConsumerSecret: fakestore.ConsumerSecret,
+		ProductID:      "1",
+		Attributes:     map[string]string{"size": "M"},
+	})
+	var notFound *domain.ProductNotFoundError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("variation of a draft product: got error %v, want ProductNotFoundError", err)
+	}
+}
+
+func TestSafeModeHidesPrivateVariations(t *testing.T) {
+	baseURL := startSafeModeStore(t)
+	handler := NewGetVariationHandler()
+	input := GetVariationInput{
+		BaseURL:        baseURL,
+		ConsumerKey:    fakestore.ConsumerKey,
+		ConsumerSecret: fakestore.ConsumerSecret,
+		ProductID:      "2",
+	}
+
+	input.Attributes = map[string]string{"size": "M"}
+	_, output, err := handler.ExecuteMCPTool(context.Background(), nil, input)
+	if err != nil {
+		t.Fatalf("published variation: %v", err)
+	}
+	if !strings.Contains(output.Data, "PUBLIC") {
+		t.Errorf("published variation not returned: %s", output.Data)
+	}
+
+	input.Attributes = map[string]string{"size": "L"}
+	_, output, err = handler.ExecuteMCPTool(context.Background(), nil, input)
+	if err != nil {
+		t.Fatalf("private variation: %v", err)
+	}
+	if strings.Contains(output.Data, "HIDDEN") || strings.Contains(output.Data, "9.00") {
+		t.Errorf("private variation leaked: %s", output.Data)
+	}
+}
+
+func TestSafeModeHidesLinksOfDraftProducts(t *testing.T) {
+	baseURL := startSafeModeStore(t)
+	handler := NewGetRelatedProductsHandler()
+	input := GetRelatedProductsInput{
+		BaseURL:        baseURL,
+		ConsumerKey:    fakestore.ConsumerKey,
+		ConsumerSecret: fakestore.ConsumerSecret,
+		ProductID:      "1",
+	}
+
+	_, _, err := handler.ExecuteMCPTool(context.Background(), nil, input)
+	var notFound *domain.ProductNotFoundError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("related products of a draft product: got error %v, want ProductNotFoundError", err)
+	}
+
+	// A published product's links to drafts are reported as missing
+	input.ProductID = "3"
+	_, output, err := handler.ExecuteMCPTool(context.Background(), nil, input)
+	if err != nil {
+		t.Fatalf("related products of a published product: %v", err)
+	}
+	var data struct {
+		MissingIDs []int `json:"missing_ids"`
+	}
+	if err := json.Unmarshal([]byte(output.Data), &data); err != nil {
+		t.Fatalf("decode related data: %v", err)
+	}
+	if len(data.MissingIDs) != 1 || data.MissingIDs[0] != 1 {
+		t.Errorf("missing_ids = %v, want the draft product [1]", data.MissingIDs)
+	}
+}
+
+func TestSafeModeHidesBreadcrumbsOfDraftProducts(t *testing.T) {
+	baseURL := startSafeModeStore(t)
+
+	_, _, err := NewGetProductBreadcrumbHandler().ExecuteMCPTool(context.Background(), nil, GetProductBreadcrumbInput{
+		BaseURL:        baseURL,
+		ConsumerKey:    fakestore.ConsumerKey,
+		ConsumerSecret: fakestore.ConsumerSecret,
+		ProductID:      "1",
+	})
+	var notFound *domain.ProductNotFoundError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("breadcrumb of a draft product: got error %v, want ProductNotFoundError", err)
+	}
+}
+
+func TestSafeModeHidesEmptyCategories(t *testing.T) {
+	t.Setenv(safemode.Env, "true")
+	store := fakestore.New()
+	// Clearance only holds unpublished products, which category counts leave out
+	store.SetCategories(append(fakestore.DefaultCategories(),
+		map[string]interface{}{"id": 17, "name": "Clearance", "slug": "clearance", "parent": 0, "count": 0}))
+	server := store.Start()
+	defer server.Close()
+
+	handler := NewCategoryMenuHandler()
+	input := CategoryMenuInput{
+		BaseURL:        server.URL,
+		ConsumerKey:    fakestore.ConsumerKey,
+		ConsumerSecret: fakestore.ConsumerSecret,
+	}
+	_, output, err := handler.ExecuteMCPTool(context.Background(), nil, input)
+	if err != nil {
+		t.Fatalf("category_menu: %v", err)
+	}
+	if strings.Contains(output.Data, "Clearance") || !strings.Contains(output.Data, "Footwear") {
+		t.Errorf("safe mode menu: %s, want Footwear without Clearance", output.Data)
+	}
+
+	input.HideEmpty = "false"
+	_, _, err = handler.ExecuteMCPTool(context.Background(), nil, input)
+	var validationErr *domain.ProductValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "hide_empty" || !strings.Contains(err.Error(), "safe mode") {
+		t.Errorf("hide_empty=false in safe mode: got error %v, want a safe mode rejection", err)
+	}
+}
@@ -0,0 +1,104 @@
+package presentation
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"testing"
+
+	"woocommerce-mcp/internal/testutil/fakestore"
+)
+
+// dataProductIDs returns the IDs of the products in search_products data
+func dataProductIDs(t *testing.T, data string) []int {
+	t.Helper()
+	var response struct {
+		Products []struct {
+			ID int `json:"id"`
+		} `json:"products"`
+	}
+	if err := json.Unmarshal([]byte(data), &response); err != nil {
+		t.Fatalf("data is not a search response: %v", err)
+	}
+	ids := make([]int, len(response.Products))
+	for i, product := range response.Products {
+		ids[i] = product.ID
+	}
+	return ids
+}
+
+func TestAutoSearchModeMatchesTheSKUFirst(t *testing.T) {
+	store := fakestore.New()
+	output := searchOutput(t, store, SearchProductsInput{Search: "FAKE-003", SearchMode: "auto"})
+
+	if ids := dataProductIDs(t, output.Data); len(ids) != 1 || ids[0] != 3 {
+		t.Errorf("got products %v, want the product with SKU FAKE-003", ids)
+	}
+	if !strings.Contains(output.Message, "search matched by sku") {
+		t.Errorf("message %q does not say the SKU matched", output.Message)
+	}
+	for _, request := range store.Requests() {
+		if strings.Contains(request, "search=") {
+			t.Errorf("a text search was run after the SKU matched: %s", request)
+		}
+	}
+}
+
+func TestAutoSearchModeFallsBackToText(t *testing.T) {
+	store := fakestore.New()
+	output := searchOutput(t, store, SearchProductsInput{Search: "boots", SearchMode: "auto"})
+
+	ids := dataProductIDs(t, output.Data)
+	sort.Ints(ids)
+	if len(ids) != 2 || ids[0] != 6 || ids[1] != 7 {
+		t.Errorf("got products %v, want the two boots", ids)
+	}
+	if !strings.Contains(output.Message, "search matched by text") {
+		t.Errorf("message %q does not say the text search matched", output.Message)
+	}
+	var sku, text bool
+	for _, request := range store.Requests() {
+		sku = sku || strings.Contains(request, "sku=boots")
+		text = text || strings.Contains(request, "search=boots")
+	}
+	if !sku || !text {
+		t.Errorf("requests %v, want an SKU lookup then a text search", store.Requests())
+	}
+}
+
+func TestAutoSearchModeWithoutMatchNamesBothModes(t *testing.T) {
+	output := searchOutput(t, fakestore.New(), SearchProductsInput{Search: "xyz", SearchMode: "auto"})
+
+	if ids := dataProductIDs(t, output.Data); len(ids) != 0 {
+		t.Errorf("got products %v, want none", ids)
+	}
+	if !strings.Contains(output.Message, "searched by sku, then text") {
+		t.Errorf("message %q does not name the modes tried", output.Message)
+	}
+}
+
+func TestSKUSearchModeOnlyMatchesWholeSKUs(t *testing.T) {
+	output := searchOutput(t, fakestore.New(), SearchProductsInput{Search: "FAKE-00", SearchMode: "sku"})
+
+	if ids := dataProductIDs(t, output.Data); len(ids) != 0 {
+		t.Errorf("got products %v for a partial SKU, want none", ids)
+	}
+	if !strings.Contains(output.Message, "searched by sku") {
+		t.Errorf("message %q does not name the mode", output.Message)
+	}
+}
+
+func TestSKUContainsMatchesPartialSKUsOnly(t *testing.T) {
+	store := fakestore.New()
+	products := fakestore.DefaultProducts()
+	products[3]["sku"] = "ABC-001"
+	products[4]["sku"] = "ABC-002"
+	products[5]["name"] = "ABC Boots"
+	store.SetProducts(products)
+
+	output := searchOutput(t, store, SearchProductsInput{SKUContains: "abc-"})
+
+	if ids := dataProductIDs(t, output.Data); len(ids) != 2 || ids[0]+ids[1] != 9 {
+		t.Errorf("got products %v, want 4 and 5 with an ABC- SKU", ids)
+	}
+}
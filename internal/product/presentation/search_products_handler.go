@@ -2,42 +2,87 @@ package presentation
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
+	"strings"
+	"time"
 
 	"woocommerce-mcp/internal/product/application/search_products"
 	"woocommerce-mcp/internal/product/infrastructure/woocommerce"
+	kitDomain "woocommerce-mcp/kit/domain"
+	"woocommerce-mcp/kit/jsonformat"
+	"woocommerce-mcp/kit/pagination"
+	"woocommerce-mcp/kit/retry"
+	"woocommerce-mcp/kit/storeconfig"
+	"woocommerce-mcp/kit/toolargs"
+	"woocommerce-mcp/kit/tooltimeout"
 
-	"github.com/gin-gonic/gin"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
 // SearchProductsInput defines the input structure for the search_products tool
 type SearchProductsInput struct {
-	BaseURL        string `json:"base_url" jsonschema:"WooCommerce store base URL (e.g., https://example.com)"`
-	ConsumerKey    string `json:"consumer_key" jsonschema:"WooCommerce REST API consumer key"`
-	ConsumerSecret string `json:"consumer_secret" jsonschema:"WooCommerce REST API consumer secret"`
-	Search         string `json:"search,omitempty" jsonschema:"Search term to filter products"`
-	Category       string `json:"category,omitempty" jsonschema:"Category ID or slug to filter products"`
-	Tag            string `json:"tag,omitempty" jsonschema:"Tag ID or slug to filter products"`
-	Status         string `json:"status,omitempty" jsonschema:"Product status filter (any, draft, pending, private, publish)"`
-	Type           string `json:"type,omitempty" jsonschema:"Product type filter (simple, grouped, external, variable)"`
-	Featured       string `json:"featured,omitempty" jsonschema:"Limit result set to featured products (true/false)"`
-	OnSale         string `json:"on_sale,omitempty" jsonschema:"Limit result set to products on sale (true/false)"`
-	MinPrice       string `json:"min_price,omitempty" jsonschema:"Limit result set to products with a minimum price"`
-	MaxPrice       string `json:"max_price,omitempty" jsonschema:"Limit result set to products with a maximum price"`
-	StockStatus    string `json:"stock_status,omitempty" jsonschema:"Limit result set to products with specified stock status"`
-	PerPage        string `json:"per_page,omitempty" jsonschema:"Number of products per page (1-100, default: 10)"`
-	Page           string `json:"page,omitempty" jsonschema:"Page number for pagination (default: 1)"`
-	Order          string `json:"order,omitempty" jsonschema:"Sort order (asc, desc)"`
-	OrderBy        string `json:"orderby,omitempty" jsonschema:"Sort by field (date, id, include, title, slug, price, popularity, rating, menu_order)"`
+	BaseURL         string `json:"base_url,omitempty" jsonschema:"WooCommerce store base URL (e.g., https://example.com); defaults to WC_BASE_URL"`
+	ConsumerKey     string `json:"consumer_key,omitempty" jsonschema:"WooCommerce REST API consumer key; defaults to WC_CONSUMER_KEY for the default store"`
+	ConsumerSecret  string `json:"consumer_secret,omitempty" jsonschema:"WooCommerce REST API consumer secret; defaults to WC_CONSUMER_SECRET for the default store"`
+	Search          string `json:"search,omitempty" jsonschema:"Search term to filter products"`
+	Category        string `json:"category,omitempty" jsonschema:"Category ID or slug to filter products"`
+	Tag             string `json:"tag,omitempty" jsonschema:"Tag ID or slug to filter products"`
+	Brand           string `json:"brand,omitempty" jsonschema:"Brand ID to filter products (see list_brands); comma-separate several IDs"`
+	Status          string `json:"status,omitempty" jsonschema:"Product status filter (any, draft, pending, private, publish)"`
+	Type            string `json:"type,omitempty" jsonschema:"Product type filter (simple, grouped, external, variable); comma-separate to match several types, e.g. simple,variable"`
+	Featured        string `json:"featured,omitempty" jsonschema:"Featured filter: true for featured only, false for non-featured only (filtered within each page), any for no filter"`
+	OnSale          string `json:"on_sale,omitempty" jsonschema:"On sale filter: true for products on sale, false for products not on sale, any for no filter"`
+	MinPrice        string `json:"min_price,omitempty" jsonschema:"Limit result set to products with a minimum price"`
+	MaxPrice        string `json:"max_price,omitempty" jsonschema:"Limit result set to products with a maximum price"`
+	StockStatus     string `json:"stock_status,omitempty" jsonschema:"Limit result set to products with specified stock status (instock, outofstock, onbackorder)"`
+	ModifiedAfter   string `json:"modified_after,omitempty" jsonschema:"Only products modified after this ISO 8601 date-time (GMT unless an offset is given); defaults ordering to oldest change first"`
+	ModifiedBefore  string `json:"modified_before,omitempty" jsonschema:"Only products modified before this ISO 8601 date-time (GMT unless an offset is given)"`
+	PerPage         string `json:"per_page,omitempty" jsonschema:"Number of products per page (1-100, default: 10; larger values are capped at 100)"`
+	Page            string `json:"page,omitempty" jsonschema:"Page number for pagination (default: 1)"`
+	Order           string `json:"order,omitempty" jsonschema:"Sort order (asc, desc)"`
+	OrderBy         string `json:"orderby,omitempty" jsonschema:"Sort by field (date, modified, id, include, title, slug, price, popularity, rating, menu_order)"`
+	StrictPriceSort string `json:"strict_price_sort,omitempty" jsonschema:"When ordering by price, re-sort each page by numeric price (true/false). Only orders within the returned page"`
+	Pretty          string `json:"pretty,omitempty" jsonschema:"Indent the JSON data for human readers (true/false, default: false)"`
+	Format          string `json:"format,omitempty" jsonschema:"Output format of the data (json, markdown, csv; default: json)"`
+	GroupBy         string `json:"group_by,omitempty" jsonschema:"Also group the returned products by tax_class, category or stock_status, with a count per group; the flat product list is kept"`
+	DateFormat      string `json:"date_format,omitempty" jsonschema:"Format of the product dates: iso (default), rfc3339, date_only or a Go time layout such as 'Jan 2, 2006'"`
+	Fields          string `json:"fields,omitempty" jsonschema:"Comma-separated product fields to keep in the JSON data, e.g. name,price,images.src,categories.name; a dotted name keeps one field of each image, category, tag, attribute or meta data entry. id is always kept; default: all fields"`
+	Facets          string `json:"facets,omitempty" jsonschema:"Comma-separated facets to count over the returned page (category, tag, stock_status, type, status, on_sale, featured), e.g. category,stock_status"`
+
+	IncludeMeta string  `json:"include_meta,omitempty" jsonschema:"Include the meta data of each product (true/false, default: false); meta_keys implies true"`
+	MetaKeys    *string `json:"meta_keys,omitempty" jsonschema:"Comma-separated meta data keys to include in each product, e.g. warranty,brand; an empty value drops all meta data. With include_meta=true and no meta_keys, every key except private ones starting with an underscore is included"`
+
+	CatalogVisibility string `json:"catalog_visibility,omitempty" jsonschema:"Catalog visibility filter (visible, catalog, search, hidden). Filtered within each returned page, so pages may hold fewer products and total_count is not reduced"`
+
+	PurchasableOnly string `json:"purchasable_only,omitempty" jsonschema:"Set to true to keep only products that can be bought, dropping external/affiliate products and products without a price. Filtered within each returned page, so pages may hold fewer products and total_count is not reduced"`
+
+	Parent string `json:"parent,omitempty" jsonschema:"Only products whose parent is one of these product IDs; comma-separate several IDs"`
+
+	CategoryOperator string `json:"category_operator,omitempty" jsonschema:"How several comma-separated categories match: or (any of them, default) or and (all of them, filtered within each returned page)"`
+	TagOperator      string `json:"tag_operator,omitempty" jsonschema:"How several comma-separated tags match: or (any of them, default) or and (all of them, filtered within each returned page)"`
+
+	SearchMode  string `json:"search_mode,omitempty" jsonschema:"How search is matched: text (titles and content, default), sku (exact SKU) or auto (exact SKU first, then text)"`
+	SKUContains string `json:"sku_contains,omitempty" jsonschema:"Only products whose SKU contains this text, ignoring case, e.g. a SKU prefix such as ABC-. Searched for and filtered within each returned page, so pages may hold fewer products; cannot be combined with search"`
+
+	ConfirmBroadQuery string `json:"confirm_broad_query,omitempty" jsonschema:"Set to true to get more than BROAD_QUERY_MAX_PER_PAGE (default 20) products per page from a search without any filter; without it per_page is lowered with a note"`
+
+	Stream string `json:"stream,omitempty" jsonschema:"Stream every page from page onward as one JSON array of products (true/false). Only supported by the legacy /call_tool endpoint"`
+
+	MaxRetries     string `json:"max_retries,omitempty" jsonschema:"Retries of each store request that fails transiently, for this call only (0-5; default: API_MAX_RETRIES or 0)"`
+	RetryBackoffMS string `json:"retry_backoff_ms,omitempty" jsonschema:"Wait before the first retry in milliseconds, doubling with each further retry, for this call only (0-10000; default: API_RETRY_BACKOFF_MS or 200)"`
+}
+
+// UnmarshalJSON decodes the arguments, also accepting numbers and booleans
+// for the string fields
+func (in *SearchProductsInput) UnmarshalJSON(data []byte) error {
+	type plain SearchProductsInput
+	return toolargs.Unmarshal(data, (*plain)(in))
 }
 
 // SearchProductsOutput defines the output structure for the search_products tool
 type SearchProductsOutput struct {
 	Message string `json:"message" jsonschema:"Human-readable message about the search results"`
-	Data    string `json:"data" jsonschema:"JSON-formatted product data"`
+	Data    string `json:"data" jsonschema:"Product data in the requested format (JSON by default)"`
 }
 
 // SearchProductsHandler handles search_products tool calls
@@ -53,6 +98,7 @@ func (h *SearchProductsHandler) GetToolDefinition() *mcp.Tool {
 	return &mcp.Tool{
 		Name:        "search_products",
 		Description: "Search for products in WooCommerce store. Supports various filters like search terms, categories, tags, status, and more.",
+		InputSchema: toolargs.InputSchema[SearchProductsInput](),
 	}
 }
 
@@ -61,213 +107,309 @@ func (h *SearchProductsHandler) GetInputSchema() map[string]interface{} {
 	return map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
-			"base_url":        map[string]string{"type": "string", "description": "WooCommerce store base URL"},
-			"consumer_key":    map[string]string{"type": "string", "description": "WooCommerce REST API consumer key"},
-			"consumer_secret": map[string]string{"type": "string", "description": "WooCommerce REST API consumer secret"},
-			"search":          map[string]string{"type": "string", "description": "Search term to filter products"},
-			"category":        map[string]string{"type": "string", "description": "Category filter"},
-			"tag":             map[string]string{"type": "string", "description": "Tag filter"},
-			"status":          map[string]string{"type": "string", "description": "Product status filter"},
-			"type":            map[string]string{"type": "string", "description": "Product type filter"},
-			"featured":        map[string]string{"type": "string", "description": "Featured products filter"},
-			"on_sale":         map[string]string{"type": "string", "description": "On sale products filter"},
-			"min_price":       map[string]string{"type": "string", "description": "Minimum price filter"},
-			"max_price":       map[string]string{"type": "string", "description": "Maximum price filter"},
-			"stock_status":    map[string]string{"type": "string", "description": "Stock status filter"},
-			"per_page":        map[string]string{"type": "string", "description": "Items per page"},
-			"page":            map[string]string{"type": "string", "description": "Page number"},
-			"order":           map[string]string{"type": "string", "description": "Sort order"},
-			"orderby":         map[string]string{"type": "string", "description": "Sort field"},
+			"base_url":            map[string]string{"type": "string", "description": "WooCommerce store base URL"},
+			"consumer_key":        map[string]string{"type": "string", "description": "WooCommerce REST API consumer key"},
+			"consumer_secret":     map[string]string{"type": "string", "description": "WooCommerce REST API consumer secret"},
+			"search":              map[string]string{"type": "string", "description": "Search term to filter products"},
+			"category":            map[string]string{"type": "string", "description": "Category filter"},
+			"tag":                 map[string]string{"type": "string", "description": "Tag filter"},
+			"brand":               map[string]string{"type": "string", "description": "Brand ID filter"},
+			"category_operator":   map[string]string{"type": "string", "description": "How several categories match (or, and; and is filtered within each page)"},
+			"tag_operator":        map[string]string{"type": "string", "description": "How several tags match (or, and; and is filtered within each page)"},
+			"parent":              map[string]string{"type": "string", "description": "Parent product ID filter (comma-separated IDs)"},
+			"search_mode":         map[string]string{"type": "string", "description": "How search is matched (text, sku, auto)"},
+			"sku_contains":        map[string]string{"type": "string", "description": "Only products whose SKU contains this text (filtered within each page)"},
+			"status":              map[string]string{"type": "string", "description": "Product status filter"},
+			"type":                map[string]string{"type": "string", "description": "Product type filter"},
+			"featured":            map[string]string{"type": "string", "description": "Featured filter (any, true, false)"},
+			"on_sale":             map[string]string{"type": "string", "description": "On sale filter (any, true, false)"},
+			"min_price":           map[string]string{"type": "string", "description": "Minimum price filter"},
+			"max_price":           map[string]string{"type": "string", "description": "Maximum price filter"},
+			"stock_status":        map[string]string{"type": "string", "description": "Stock status filter"},
+			"modified_after":      map[string]string{"type": "string", "description": "Only products modified after this ISO 8601 date-time (GMT)"},
+			"modified_before":     map[string]string{"type": "string", "description": "Only products modified before this ISO 8601 date-time (GMT)"},
+			"per_page":            map[string]string{"type": "string", "description": "Items per page"},
+			"page":                map[string]string{"type": "string", "description": "Page number"},
+			"order":               map[string]string{"type": "string", "description": "Sort order"},
+			"orderby":             map[string]string{"type": "string", "description": "Sort field"},
+			"strict_price_sort":   map[string]string{"type": "string", "description": "Re-sort each page by numeric price when ordering by price (true/false)"},
+			"pretty":              map[string]string{"type": "string", "description": "Indent the JSON data (true/false)"},
+			"format":              map[string]string{"type": "string", "description": "Output format of the data (json, markdown, csv)"},
+			"date_format":         map[string]string{"type": "string", "description": "Format of the product dates (iso, rfc3339, date_only or a Go time layout)"},
+			"include_meta":        map[string]string{"type": "string", "description": "Include product meta data (true/false, default: false; meta_keys implies true)"},
+			"meta_keys":           map[string]string{"type": "string", "description": "Comma-separated meta data keys to include; empty drops all meta data (with include_meta=true, default: all keys not starting with an underscore)"},
+			"group_by":            map[string]string{"type": "string", "description": "Group the returned products by tax_class, category or stock_status"},
+			"fields":              map[string]string{"type": "string", "description": "Comma-separated product fields to keep in the JSON data, with dotted names for nested fields (e.g. name,images.src)"},
+			"facets":              map[string]string{"type": "string", "description": "Comma-separated facets counted over the returned page (category, tag, stock_status, type, status, on_sale, featured)"},
+			"catalog_visibility":  map[string]string{"type": "string", "description": "Catalog visibility filter applied within each page (visible, catalog, search, hidden)"},
+			"purchasable_only":    map[string]string{"type": "string", "description": "Keep only purchasable products, filtered within each page (true/false)"},
+			"confirm_broad_query": map[string]string{"type": "string", "description": "Allow a large per_page for a search without filters (true/false)"},
+			"stream":              map[string]string{"type": "string", "description": "Stream all pages as one JSON array (true/false; legacy /call_tool only)"},
+			"max_retries":         map[string]string{"type": "string", "description": "Retries of transiently failing store requests for this call (0-5)"},
+			"retry_backoff_ms":    map[string]string{"type": "string", "description": "Wait before the first retry in milliseconds, doubling per retry (0-10000)"},
 		},
-		"required": []string{"base_url", "consumer_key", "consumer_secret"},
+		"required": storeconfig.RequiredFields("base_url", "consumer_key", "consumer_secret"),
 	}
 }
 
 // ExecuteMCPTool implements the MCP tool execution
 func (h *SearchProductsHandler) ExecuteMCPTool(ctx context.Context, req *mcp.CallToolRequest, input SearchProductsInput) (*mcp.CallToolResult, SearchProductsOutput, error) {
+	// Fall back to the default store configured through the environment
+	storeconfig.ApplyDefaults(&input.BaseURL, &input.ConsumerKey, &input.ConsumerSecret)
+
 	// Validate required fields
 	if input.BaseURL == "" {
-		return nil, SearchProductsOutput{}, fmt.Errorf("base_url is required")
+		return nil, SearchProductsOutput{}, kitDomain.NewValidationError("base_url is required")
 	}
 	if input.ConsumerKey == "" {
-		return nil, SearchProductsOutput{}, fmt.Errorf("consumer_key is required")
+		return nil, SearchProductsOutput{}, kitDomain.NewValidationError("consumer_key is required")
 	}
 	if input.ConsumerSecret == "" {
-		return nil, SearchProductsOutput{}, fmt.Errorf("consumer_secret is required")
-	}
-
-	// Create WooCommerce client
-	config := woocommerce.NewConfig(input.BaseURL, input.ConsumerKey, input.ConsumerSecret)
-	client := woocommerce.NewClient(config)
-	repo := woocommerce.NewRepository(client)
-
-	// Create search request
-	request := search_products.NewSearchRequest(input.BaseURL, input.ConsumerKey, input.ConsumerSecret)
-
-	// Set optional parameters
-	if input.Search != "" {
-		request.SetSearch(input.Search)
-	}
-	if input.Category != "" {
-		request.SetCategory(input.Category)
-	}
-	if input.Tag != "" {
-		request.SetTag(input.Tag)
-	}
-	if input.Status != "" {
-		request.SetStatus(input.Status)
+		return nil, SearchProductsOutput{}, kitDomain.NewValidationError("consumer_secret is required")
 	}
-	if input.Type != "" {
-		request.SetType(input.Type)
+	pretty, err := jsonformat.ParsePretty(input.Pretty)
+	if err != nil {
+		return nil, SearchProductsOutput{}, kitDomain.NewValidationError("pretty must be true or false")
 	}
-	if input.Featured != "" {
-		request.SetFeatured(input.Featured)
+	renderer, err := search_products.NewRenderer(input.Format, search_products.RenderOptions{Pretty: pretty, GroupBy: input.GroupBy, Fields: input.Fields})
+	if err != nil {
+		return nil, SearchProductsOutput{}, err
 	}
-	if input.OnSale != "" {
-		request.SetOnSale(input.OnSale)
+	stream, err := parseStream(input.Stream)
+	if err != nil {
+		return nil, SearchProductsOutput{}, kitDomain.NewValidationError("stream must be true or false")
 	}
-	if input.MinPrice != "" || input.MaxPrice != "" {
-		request.SetPriceRange(input.MinPrice, input.MaxPrice)
+	if stream {
+		return nil, SearchProductsOutput{}, kitDomain.NewValidationError("stream is only supported by the legacy /call_tool endpoint")
 	}
-	if input.StockStatus != "" {
-		request.SetStockStatus(input.StockStatus)
+	request, err := input.ToSearchRequest()
+	if err != nil {
+		return nil, SearchProductsOutput{}, err
 	}
-	if input.PerPage != "" || input.Page != "" {
-		request.SetPagination(input.Page, input.PerPage)
+
+	// Let this call tune the retries of its store requests
+	ctx, err = retry.Override(ctx, input.MaxRetries, input.RetryBackoffMS)
+	if err != nil {
+		return nil, SearchProductsOutput{}, err
 	}
-	if input.OrderBy != "" || input.Order != "" {
-		request.SetSorting(input.OrderBy, input.Order)
+
+	// Create WooCommerce client for the store of this call
+	ctx = withStore(ctx, input)
+	config, err := woocommerce.NewConfigFromContext(ctx)
+	if err != nil {
+		return nil, SearchProductsOutput{}, err
 	}
+	client := woocommerce.NewCachedClient(config)
+	repo := woocommerce.NewRepository(client)
+
+	// Bound the whole tool call, across all of its API requests
+	ctx, cancel := tooltimeout.WithTimeout(ctx)
+	defer cancel()
 
 	// Execute search
 	searcher := search_products.NewProductSearcher(repo)
 	response, err := searcher.Execute(ctx, request)
 	if err != nil {
-		return nil, SearchProductsOutput{}, fmt.Errorf("failed to search products: %w", err)
+		return nil, SearchProductsOutput{}, tooltimeout.Err(ctx, "search_products", withAuthAdvice(fmt.Errorf("failed to search products: %w", err)))
 	}
 
-	// Convert response to JSON
-	responseJSON, err := json.MarshalIndent(response, "", "  ")
+	// Render the response in the requested format
+	search_products.DescribeSales(response.Products, time.Now())
+	data, err := renderer.Render(response)
 	if err != nil {
 		return nil, SearchProductsOutput{}, fmt.Errorf("failed to serialize response: %w", err)
 	}
 
 	// Create human-readable message
-	message := fmt.Sprintf("Found %d product(s) out of %d total (page %d of %d)",
-		len(response.Products),
-		response.TotalCount,
-		response.CurrentPage,
-		response.TotalPages,
-	)
+	var message string
+	if response.IsEmpty() {
+		message = "No products found matching the search criteria"
+		if filters := request.FilterSummary(); filters != "" {
+			message = fmt.Sprintf("No products found for %s", filters)
+		}
+		if response.PageOutOfRange && response.TotalPages > 0 {
+			message += fmt.Sprintf(" on page %d, which is past the last page; %d product(s) match across pages 1-%d",
+				response.CurrentPage, response.TotalCount, response.TotalPages)
+		} else if response.TotalCount > 0 {
+			message += fmt.Sprintf(" on page %d (%d total across %d page(s))",
+				response.CurrentPage, response.TotalCount, response.TotalPages)
+		}
+		message += "."
+		if hint := unfilteredHint(response.Unfiltered); hint != "" {
+			message += " " + hint
+		}
+	} else {
+		message = fmt.Sprintf("Found %d product(s) out of %d total (page %d of %d)",
+			len(response.Products),
+			response.TotalCount,
+			response.CurrentPage,
+			response.TotalPages,
+		)
+	}
+	if summary := response.SaleSummary; summary != nil && summary.OnSaleCount > 0 {
+		message += fmt.Sprintf(" (%d on sale on this page", summary.OnSaleCount)
+		if summary.DiscountedCount > 0 {
+			message += fmt.Sprintf(", avg discount %g%%, up to %g%%", summary.AverageDiscountPercent, summary.MaxDiscountPercent)
+		}
+		message += ")"
+	}
+	if response.PerPageCapped {
+		message += fmt.Sprintf(" (per_page capped at %d; use page for more)", pagination.MaxPerPage)
+	}
+	if response.SkippedCount > 0 {
+		message += fmt.Sprintf(" (%d unreadable product(s) skipped)", response.SkippedCount)
+	}
+	if note := searchModeNote(input.SearchMode, response); note != "" {
+		message += fmt.Sprintf(" (%s)", note)
+	}
+	for _, note := range response.Notes {
+		message += fmt.Sprintf(" (note: %s)", note)
+	}
 
 	return nil, SearchProductsOutput{
 		Message: message,
-		Data:    string(responseJSON),
+		Data:    data,
 	}, nil
 }
 
-// HandleJSONRPC handles JSON-RPC tool calls
-func (h *SearchProductsHandler) HandleJSONRPC(c *gin.Context, requestID interface{}, arguments map[string]interface{}) {
-	// Convert arguments to SearchProductsInput
-	argsJSON, err := json.Marshal(arguments)
-	if err != nil {
-		h.sendJSONRPCError(c, requestID, -32602, "Invalid arguments", err.Error())
-		return
+// searchModeNote tells which search mode produced the results, or which
+// modes were tried when nothing matched
+func searchModeNote(requestedMode string, response *search_products.SearchResponse) string {
+	switch {
+	case response.SearchMode == "":
+		return ""
+	case response.TotalCount > 0:
+		return fmt.Sprintf("search matched by %s", response.SearchMode)
+	case strings.EqualFold(strings.TrimSpace(requestedMode), search_products.SearchModeAuto):
+		return "searched by sku, then text"
+	default:
+		return fmt.Sprintf("searched by %s", response.SearchMode)
 	}
+}
 
-	var input SearchProductsInput
-	if err := json.Unmarshal(argsJSON, &input); err != nil {
-		h.sendJSONRPCError(c, requestID, -32602, "Invalid input format", err.Error())
-		return
+// unfilteredHint tells whether the price, stock status and on sale filters
+// emptied the result, and which of them to relax
+func unfilteredHint(unfiltered *search_products.UnfilteredCount) string {
+	if unfiltered == nil {
+		return ""
 	}
-
-	// Call the MCP tool directly
-	_, output, err := h.ExecuteMCPTool(c.Request.Context(), nil, input)
-	if err != nil {
-		h.sendJSONRPCError(c, requestID, -32603, "Tool execution failed", err.Error())
-		return
+	if unfiltered.TotalCount == 0 {
+		return fmt.Sprintf("No products match even without %s, so those filters are not the reason.", strings.Join(unfiltered.RemovedFilters, ", "))
 	}
 
-	// Format response as expected by the message API
-	resultText := fmt.Sprintf("%s\n\n%s", output.Message, output.Data)
-	content := []map[string]interface{}{
-		{
-			"type": "text",
-			"text": resultText,
-		},
-	}
-
-	response := map[string]interface{}{
-		"jsonrpc": "2.0",
-		"result":  map[string]interface{}{"content": content},
-		"id":      requestID,
+	var suggestions []string
+	widenPrice := false
+	for _, filter := range unfiltered.RemovedFilters {
+		switch filter {
+		case "min_price", "max_price":
+			if !widenPrice {
+				widenPrice = true
+				suggestions = append(suggestions, "widening the price range")
+			}
+		default:
+			suggestions = append(suggestions, "dropping "+filter)
+		}
 	}
+	return fmt.Sprintf("0 of %d product(s) matched your filters — try %s.", unfiltered.TotalCount, strings.Join(suggestions, " or "))
+}
 
-	h.sendSSEResponse(c, response)
+// withStore returns a context carrying the store named by the tool input
+func withStore(ctx context.Context, input SearchProductsInput) context.Context {
+	return storeconfig.WithStore(ctx, storeconfig.Store{
+		BaseURL:        input.BaseURL,
+		ConsumerKey:    input.ConsumerKey,
+		ConsumerSecret: input.ConsumerSecret,
+	})
 }
 
-// HandleLegacyHTTP handles legacy HTTP tool calls
-func (h *SearchProductsHandler) HandleLegacyHTTP(c *gin.Context, arguments map[string]interface{}) {
-	// Convert arguments to SearchProductsInput
-	argsJSON, err := json.Marshal(arguments)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"content": []map[string]interface{}{{"type": "text", "text": fmt.Sprintf("Invalid arguments: %v", err)}},
-			"isError": true,
-		})
-		return
-	}
+// ToSearchRequest maps the search parameters of the tool input to a search
+// request and validates them, so every caller rejects the same malformed
+// input before contacting the store
+func (in SearchProductsInput) ToSearchRequest() (*search_products.SearchRequest, error) {
+	request := search_products.NewSearchRequest()
 
-	var input SearchProductsInput
-	if err := json.Unmarshal(argsJSON, &input); err != nil {
-		c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"content": []map[string]interface{}{{"type": "text", "text": fmt.Sprintf("Invalid input format: %v", err)}},
-			"isError": true,
-		})
-		return
+	// Set optional parameters
+	if in.Search != "" {
+		request.SetSearch(in.Search)
 	}
-
-	// Call the MCP tool directly
-	_, output, err := h.ExecuteMCPTool(c.Request.Context(), nil, input)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, map[string]interface{}{
-			"content": []map[string]interface{}{{"type": "text", "text": fmt.Sprintf("Tool execution failed: %v", err)}},
-			"isError": true,
-		})
-		return
+	if in.Category != "" {
+		request.SetCategory(in.Category)
 	}
-
-	// Return successful result
-	resultText := fmt.Sprintf("%s\n\n%s", output.Message, output.Data)
-	c.JSON(http.StatusOK, map[string]interface{}{
-		"content": []map[string]interface{}{{"type": "text", "text": resultText}},
-	})
-}
-
-// sendSSEResponse sends a JSON-RPC response as Server-Sent Event
-func (h *SearchProductsHandler) sendSSEResponse(c *gin.Context, response map[string]interface{}) {
-	responseData, err := json.Marshal(response)
-	if err != nil {
-		h.sendJSONRPCError(c, response["id"], -32603, "Internal error", err.Error())
-		return
+	if in.Tag != "" {
+		request.SetTag(in.Tag)
 	}
-
-	// Send as SSE format
-	c.String(http.StatusOK, "data: %s\n\n", string(responseData))
-}
-
-// sendJSONRPCError sends a JSON-RPC error response as SSE
-func (h *SearchProductsHandler) sendJSONRPCError(c *gin.Context, id interface{}, code int, message, data string) {
-	errorResponse := map[string]interface{}{
-		"jsonrpc": "2.0",
-		"error": map[string]interface{}{
-			"code":    code,
-			"message": message,
-			"data":    data,
-		},
-		"id": id,
+	if in.CategoryOperator != "" {
+		request.SetCategoryOperator(in.CategoryOperator)
+	}
+	if in.TagOperator != "" {
+		request.SetTagOperator(in.TagOperator)
+	}
+	if in.Brand != "" {
+		request.SetBrand(in.Brand)
+	}
+	if in.Parent != "" {
+		request.SetParent(in.Parent)
+	}
+	if in.SearchMode != "" {
+		request.SetSearchMode(in.SearchMode)
+	}
+	if in.SKUContains != "" {
+		request.SetSKUContains(in.SKUContains)
+	}
+	if in.Status != "" {
+		request.SetStatus(in.Status)
+	}
+	if in.Type != "" {
+		request.SetType(in.Type)
+	}
+	if in.Featured != "" {
+		request.SetFeatured(in.Featured)
+	}
+	if in.OnSale != "" {
+		request.SetOnSale(in.OnSale)
+	}
+	if in.MinPrice != "" || in.MaxPrice != "" {
+		request.SetPriceRange(in.MinPrice, in.MaxPrice)
+	}
+	if in.StockStatus != "" {
+		request.SetStockStatus(in.StockStatus)
+	}
+	if in.ModifiedAfter != "" || in.ModifiedBefore != "" {
+		request.SetModifiedRange(in.ModifiedAfter, in.ModifiedBefore)
+	}
+	if in.CatalogVisibility != "" {
+		request.SetCatalogVisibility(in.CatalogVisibility)
+	}
+	if in.PurchasableOnly != "" {
+		request.SetPurchasableOnly(in.PurchasableOnly)
+	}
+	if in.PerPage != "" || in.Page != "" {
+		request.SetPagination(in.Page, in.PerPage)
+	}
+	if in.OrderBy != "" || in.Order != "" {
+		request.SetSorting(in.OrderBy, in.Order)
+	}
+	if in.StrictPriceSort != "" {
+		request.SetStrictPriceSort(in.StrictPriceSort)
+	}
+	if in.DateFormat != "" {
+		request.SetDateFormat(in.DateFormat)
+	}
+	if in.Facets != "" {
+		request.SetFacets(in.Facets)
+	}
+	if in.IncludeMeta != "" {
+		request.SetIncludeMeta(in.IncludeMeta)
+	}
+	if in.MetaKeys != nil {
+		request.SetMetaKeys(*in.MetaKeys)
+	}
+	if in.ConfirmBroadQuery != "" {
+		request.SetConfirmBroadQuery(in.ConfirmBroadQuery)
 	}
 
-	responseData, _ := json.Marshal(errorResponse)
-	c.String(http.StatusOK, "data: %s\n\n", string(responseData))
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	return request, nil
 }
@@ -0,0 +1,343 @@
+package presentation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"woocommerce-mcp/internal/testutil/fakestore"
+	"woocommerce-mcp/kit/retry"
+	"woocommerce-mcp/kit/storeconfig"
+)
+
+// searchOutput runs search_products against a fake store and returns the
+// tool output
+func searchOutput(t *testing.T, store *fakestore.Store, input SearchProductsInput) SearchProductsOutput {
+	t.Helper()
+
+	server := store.Start()
+	t.Cleanup(server.Close)
+
+	input.BaseURL = server.URL
+	input.ConsumerKey = fakestore.ConsumerKey
+	input.ConsumerSecret = fakestore.ConsumerSecret
+	_, output, err := NewSearchProductsHandler().ExecuteMCPTool(context.Background(), nil, input)
+	if err != nil {
+		t.Fatalf("search_products: %v", err)
+	}
+	return output
+}
+
+func TestEmptySearchMessageEchoesTheFilters(t *testing.T) {
+	output := searchOutput(t, fakestore.New(), SearchProductsInput{
+		Search:      "  xyz  ",
+		Category:    "shoes",
+		StockStatus: "instock",
+	})
+
+	for _, want := range []string{"No products found for", "search='xyz'", "category='shoes'", "in stock only"} {
+		if !strings.Contains(output.Message, want) {
+			t.Errorf("message %q does not contain %q", output.Message, want)
+		}
+	}
+	if strings.Contains(output.Message, fakestore.ConsumerSecret) || strings.Contains(output.Message, fakestore.ConsumerKey) {
+		t.Errorf("message leaks the credentials: %q", output.Message)
+	}
+}
+
+func TestEmptySearchMessageWithoutFilters(t *testing.T) {
+	store := fakestore.New()
+	store.SetProducts(nil)
+	output := searchOutput(t, store, SearchProductsInput{})
+
+	if !strings.HasPrefix(output.Message, "No products found matching the search criteria.") {
+		t.Errorf("message = %q, want the generic empty-result message", output.Message)
+	}
+}
+
+func TestOversizedPerPageIsFlaggedAsCapped(t *testing.T) {
+	tests := []struct {
+		perPage string
+		capped  bool
+	}{
+		{"500", true},
+		{"100", false},
+	}
+	for _, tt := range tests {
+		output := searchOutput(t, fakestore.New(), SearchProductsInput{PerPage: tt.perPage, ConfirmBroadQuery: "true"})
+
+		if got := strings.Contains(output.Message, "per_page capped at 100; use page for more"); got != tt.capped {
+			t.Errorf("per_page=%s: message %q, want the cap note %v", tt.perPage, output.Message, tt.capped)
+		}
+		if got := strings.Contains(output.Data, `"per_page_capped":true`); got != tt.capped {
+			t.Errorf("per_page=%s: per_page_capped flag %v, want %v", tt.perPage, got, tt.capped)
+		}
+		if !strings.Contains(output.Data, `"per_page":100`) {
+			t.Errorf("per_page=%s: data does not report per_page 100", tt.perPage)
+		}
+	}
+}
+
+func TestDefaultStoreFillsTheCredentials(t *testing.T) {
+	server := fakestore.New().Start()
+	t.Cleanup(server.Close)
+	t.Setenv(storeconfig.BaseURLEnv, server.URL)
+	t.Setenv(storeconfig.ConsumerKeyEnv, fakestore.ConsumerKey)
+	t.Setenv(storeconfig.ConsumerSecretEnv, fakestore.ConsumerSecret)
+
+	_, output, err := NewSearchProductsHandler().ExecuteMCPTool(context.Background(), nil, SearchProductsInput{Search: "Sneakers"})
+	if err != nil {
+		t.Fatalf("search_products with the default store: %v", err)
+	}
+	if !strings.Contains(output.Data, "Sneakers") {
+		t.Errorf("data = %s, want the default store's products", output.Data)
+	}
+}
+
+func TestMissingStoreIsAValidationError(t *testing.T) {
+	t.Setenv(storeconfig.BaseURLEnv, "")
+
+	_, _, err := NewSearchProductsHandler().ExecuteMCPTool(context.Background(), nil, SearchProductsInput{Search: "Sneakers"})
+	if err == nil || !strings.Contains(err.Error(), "base_url is required") {
+		t.Errorf("got error %v, want base_url to be required", err)
+	}
+}
+
+func TestSkippedProductsAreReported(t *testing.T) {
+	store := fakestore.New()
+	products := fakestore.DefaultProducts()[:3]
+	products[1]["id"] = "not-a-number"
+	store.SetProducts(products)
+
+	output := searchOutput(t, store, SearchProductsInput{Search: "a"})
+	if !strings.Contains(output.Message, "(1 unreadable product(s) skipped)") {
+		t.Errorf("message %q does not report the skipped product", output.Message)
+	}
+	if !strings.Contains(output.Data, `"skipped_count":1`) {
+		t.Errorf("data does not count the skipped product:\n%s", output.Data)
+	}
+}
+
+func TestSearchDataIsCompactUnlessPretty(t *testing.T) {
+	compact := searchOutput(t, fakestore.New(), SearchProductsInput{Search: "a"})
+	if strings.Contains(compact.Data, "\n") || !json.Valid([]byte(compact.Data)) {
+		t.Errorf("default data is not compact JSON: %s", compact.Data)
+	}
+
+	pretty := searchOutput(t, fakestore.New(), SearchProductsInput{Search: "a", Pretty: "true"})
+	if !strings.Contains(pretty.Data, "\n  \"") || !json.Valid([]byte(pretty.Data)) {
+		t.Errorf("pretty data is not indented JSON: %s", pretty.Data)
+	}
+}
+
+func TestSearchDataIsRenderedInTheFormat(t *testing.T) {
+	output := searchOutput(t, fakestore.New(), SearchProductsInput{Search: "a", Format: "csv"})
+	if !strings.HasPrefix(output.Data, "id,name,sku,") {
+		t.Errorf("csv data = %q, want a header row first", output.Data)
+	}
+
+	server := fakestore.New().Start()
+	defer server.Close()
+	input := SearchProductsInput{BaseURL: server.URL, ConsumerKey: fakestore.ConsumerKey, ConsumerSecret: fakestore.ConsumerSecret, Format: "xml"}
+	if _, _, err := NewSearchProductsHandler().ExecuteMCPTool(context.Background(), nil, input); err == nil || !strings.Contains(err.Error(), "unsupported format") {
+		t.Errorf("got error %v, want an unsupported format error", err)
+	}
+}
+
+func TestMaxRetriesOverridesTheAttemptsOfOneCall(t *testing.T) {
+	t.Setenv(retry.MaxRetriesEnv, "0")
+
+	// the store fails twice before answering
+	store := fakestore.New().Handler()
+	var failures int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&failures, 1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		store.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	search := func(maxRetries string) error {
+		_, _, err := NewSearchProductsHandler().ExecuteMCPTool(context.Background(), nil, SearchProductsInput{
+			BaseURL:        server.URL,
+			ConsumerKey:    fakestore.ConsumerKey,
+			ConsumerSecret: fakestore.ConsumerSecret,
+			Search:         "a",
+			MaxRetries:     maxRetries,
+			RetryBackoffMS: "1",
+		})
+		return err
+	}
+
+	if err := search(""); err == nil {
+		t.Fatal("without retries the first failure succeeded")
+	}
+	atomic.StoreInt32(&failures, 0)
+	if err := search("2"); err != nil {
+		t.Errorf("max_retries=2: %v", err)
+	}
+	if err := search("9"); err == nil || !strings.Contains(err.Error(), "max_retries") {
+		t.Errorf("max_retries=9: got error %v, want a bounds error", err)
+	}
+}
+
+func TestPagePastTheLastSuggestsTheValidRange(t *testing.T) {
+	output := searchOutput(t, fakestore.New(), SearchProductsInput{Search: "s", PerPage: "5", Page: "50"})
+
+	if !strings.Contains(output.Data, `"page_out_of_range":true`) || !strings.Contains(output.Data, `"current_page":50`) {
+		t.Errorf("data %s does not flag page 50 as out of range", output.Data)
+	}
+	if !strings.Contains(output.Message, "past the last page") || !strings.Contains(output.Message, "pages 1-3") {
+		t.Errorf("message %q does not suggest pages 1-3", output.Message)
+	}
+
+	output = searchOutput(t, fakestore.New(), SearchProductsInput{Search: "s", PerPage: "5", Page: "3"})
+	if strings.Contains(output.Data, "page_out_of_range") || strings.Contains(output.Message, "past the last page") {
+		t.Errorf("last page flagged as out of range: %s", output.Message)
+	}
+}
+
+func TestFacetsAreCountedOverTheReturnedPage(t *testing.T) {
+	output := searchOutput(t, fakestore.New(), SearchProductsInput{
+		PerPage: "12",
+		Facets:  "category,stock_status",
+	})
+
+	var data struct {
+		Facets map[string][]struct {
+			Value string `json:"value"`
+			Count int    `json:"count"`
+		} `json:"facets"`
+	}
+	if err := json.Unmarshal([]byte(output.Data), &data); err != nil {
+		t.Fatalf("decode data: %v", err)
+	}
+
+	// All 12 products are footwear, the three sneakers also in Sneakers;
+	// the last two are out of stock
+	want := map[string]map[string]int{
+		"category":     {"footwear": 12, "sneakers": 3},
+		"stock_status": {"instock": 10, "outofstock": 2},
+	}
+	for facet, counts := range want {
+		got := make(map[string]int)
+		for _, value := range data.Facets[facet] {
+			got[value.Value] = value.Count
+		}
+		if len(got) != len(counts) {
+			t.Errorf("%s facet = %v, want %v", facet, got, counts)
+			continue
+		}
+		for value, count := range counts {
+			if got[value] != count {
+				t.Errorf("%s facet %s = %d, want %d", facet, value, got[value], count)
+			}
+		}
+	}
+}
+
+func TestWhitespaceCredentialsAreReportedAsMissing(t *testing.T) {
+	t.Setenv(storeconfig.BaseURLEnv, "")
+	tests := []struct {
+		input SearchProductsInput
+		want  string
+	}{
+		{SearchProductsInput{BaseURL: " \t\n", ConsumerKey: "ck", ConsumerSecret: "cs"}, "base_url is required"},
+		{SearchProductsInput{BaseURL: "https://shop.example", ConsumerKey: "   ", ConsumerSecret: "cs"}, "consumer_key is required"},
+		{SearchProductsInput{BaseURL: "https://shop.example", ConsumerKey: "ck", ConsumerSecret: "\t\r\n"}, "consumer_secret is required"},
+	}
+	for _, tt := range tests {
+		_, _, err := NewSearchProductsHandler().ExecuteMCPTool(context.Background(), nil, tt.input)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("input %+v: error = %v, want %q", tt.input, err, tt.want)
+		}
+	}
+}
+
+func TestPaddedCredentialsReachTheStore(t *testing.T) {
+	store := fakestore.New()
+	server := store.Start()
+	defer server.Close()
+
+	_, output, err := NewSearchProductsHandler().ExecuteMCPTool(context.Background(), nil, SearchProductsInput{
+		BaseURL:        "\t" + server.URL + "\n",
+		ConsumerKey:    " " + fakestore.ConsumerKey + "\r\n",
+		ConsumerSecret: "\t" + fakestore.ConsumerSecret + " ",
+		Search:         "boots",
+	})
+	if err != nil {
+		t.Fatalf("search_products with padded credentials: %v", err)
+	}
+	if !strings.Contains(output.Message, "Found") {
+		t.Errorf("message = %q, want products found", output.Message)
+	}
+}
+
+func TestOnSaleMessageSumsUpTheDiscounts(t *testing.T) {
+	store := fakestore.New()
+	products := fakestore.DefaultProducts()[:2]
+	// 25% and 10% off their regular prices
+	for i, sale := range []string{"15.00", "22.50"} {
+		products[i]["regular_price"] = []string{"20.00", "25.00"}[i]
+		products[i]["sale_price"] = sale
+		products[i]["price"] = sale
+		products[i]["on_sale"] = true
+	}
+	store.SetProducts(products)
+
+	output := searchOutput(t, store, SearchProductsInput{OnSale: "true"})
+
+	if !strings.Contains(output.Message, "2 on sale on this page, avg discount 17.5%, up to 25%") {
+		t.Errorf("message = %q, want the discounts summed up", output.Message)
+	}
+	if !strings.Contains(output.Data, `"discount_percent":25`) || !strings.Contains(output.Data, `"sale_price":"15.00"`) {
+		t.Errorf("data lacks the sale price and discount: %s", output.Data)
+	}
+}
+
+// storeWithMeta returns a fake store whose products carry a warranty
+func storeWithMeta() *fakestore.Store {
+	store := fakestore.New()
+	products := fakestore.DefaultProducts()
+	for _, product := range products {
+		product["meta_data"] = []map[string]interface{}{{"id": 1, "key": "warranty", "value": "2 years"}}
+	}
+	store.SetProducts(products)
+	return store
+}
+
+func TestMetaDataIsLeftOutUnlessIncluded(t *testing.T) {
+	output := searchOutput(t, storeWithMeta(), SearchProductsInput{Search: "boots"})
+	if strings.Contains(output.Data, "meta_data") || strings.Contains(output.Data, "warranty") {
+		t.Errorf("data carries meta data by default: %s", output.Data)
+	}
+
+	output = searchOutput(t, storeWithMeta(), SearchProductsInput{Search: "boots", IncludeMeta: "true"})
+	if !strings.Contains(output.Data, `"meta_data":[{"id":1,"key":"warranty","value":"2 years"}]`) {
+		t.Errorf("data lacks the meta data with include_meta=true: %s", output.Data)
+	}
+}
+
+func TestGetProductsKeepsMetaData(t *testing.T) {
+	server := storeWithMeta().Start()
+	defer server.Close()
+
+	_, output, err := NewGetProductsHandler().ExecuteMCPTool(context.Background(), nil, GetProductsInput{
+		BaseURL:        server.URL,
+		ConsumerKey:    fakestore.ConsumerKey,
+		ConsumerSecret: fakestore.ConsumerSecret,
+		ProductIDs:     []int{6},
+	})
+	if err != nil {
+		t.Fatalf("get_products: %v", err)
+	}
+	if !strings.Contains(output.Data, "warranty") {
+		t.Errorf("get_products left out the meta data: %s", output.Data)
+	}
+}
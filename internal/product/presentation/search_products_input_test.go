@@ -0,0 +1,118 @@
+package presentation
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"woocommerce-mcp/internal/product/application/search_products"
+)
+
+func TestInputWithEveryOptionMapsToTheRequest(t *testing.T) {
+	metaKeys := "_color,_size"
+	request, err := SearchProductsInput{
+		Search:            "boots",
+		Category:          "15",
+		Tag:               "3",
+		CategoryOperator:  "and",
+		TagOperator:       "or",
+		Brand:             "7",
+		Parent:            "12",
+		SearchMode:        "auto",
+		Status:            "publish",
+		Type:              "simple",
+		Featured:          "true",
+		OnSale:            "false",
+		MinPrice:          "10",
+		MaxPrice:          "50",
+		StockStatus:       "instock",
+		ModifiedAfter:     "2024-05-01",
+		ModifiedBefore:    "2024-05-02",
+		CatalogVisibility: "visible",
+		PurchasableOnly:   "true",
+		PerPage:           "20",
+		Page:              "2",
+		OrderBy:           "price",
+		Order:             "asc",
+		StrictPriceSort:   "true",
+		DateFormat:        "date_only",
+		Facets:            "category,tag",
+		IncludeMeta:       "true",
+		MetaKeys:          &metaKeys,
+		ConfirmBroadQuery: "true",
+	}.ToSearchRequest()
+	if err != nil {
+		t.Fatalf("ToSearchRequest: %v", err)
+	}
+
+	for field, got := range map[string][2]string{
+		"search":             {request.GetSearch(), "boots"},
+		"category":           {request.GetCategory(), "15"},
+		"tag":                {request.GetTag(), "3"},
+		"category_operator":  {request.GetCategoryOperator(), "and"},
+		"tag_operator":       {request.GetTagOperator(), "or"},
+		"brand":              {request.GetBrand(), "7"},
+		"parent":             {request.GetParent(), "12"},
+		"search_mode":        {request.GetSearchMode(), "auto"},
+		"status":             {request.GetStatus(), "publish"},
+		"type":               {request.GetType(), "simple"},
+		"featured":           {request.GetFeatured(), "true"},
+		"on_sale":            {request.GetOnSale(), "false"},
+		"min_price":          {request.GetMinPrice(), "10"},
+		"max_price":          {request.GetMaxPrice(), "50"},
+		"stock_status":       {request.GetStockStatus(), "instock"},
+		"modified_after":     {request.GetModifiedAfter(), "2024-05-01"},
+		"modified_before":    {request.GetModifiedBefore(), "2024-05-02"},
+		"catalog_visibility": {request.GetCatalogVisibility(), "visible"},
+		"purchasable_only":   {request.GetPurchasableOnly(), "true"},
+		"per_page":           {request.GetPerPage(), "20"},
+		"page":               {request.GetPage(), "2"},
+		"orderby":            {request.GetOrderBy(), "price"},
+		"order":              {request.GetOrder(), "asc"},
+		"strict_price_sort":  {request.GetStrictPriceSort(), "true"},
+		"date_format":        {request.GetDateFormat(), "date_only"},
+		"facets":             {request.GetFacets(), "category,tag"},
+		"meta_keys":          {request.GetMetaKeys(), "_color,_size"},
+		"confirm_broad":      {request.GetConfirmBroadQuery(), "true"},
+	} {
+		if got[0] != got[1] {
+			t.Errorf("%s = %q, want %q", field, got[0], got[1])
+		}
+	}
+	if request.IncludeMeta == nil || *request.IncludeMeta != "true" {
+		t.Errorf("include_meta = %v, want true", request.IncludeMeta)
+	}
+
+	// sku_contains excludes search, so it is mapped on its own
+	request, err = SearchProductsInput{SKUContains: "FAKE"}.ToSearchRequest()
+	if err != nil || request.GetSKUContains() != "FAKE" {
+		t.Errorf("sku_contains = %v, %v; want FAKE", request, err)
+	}
+}
+
+func TestInputWithoutOptionsMapsToADefaultRequest(t *testing.T) {
+	request, err := SearchProductsInput{}.ToSearchRequest()
+	if err != nil {
+		t.Fatalf("ToSearchRequest: %v", err)
+	}
+	if want := search_products.NewSearchRequest(); !reflect.DeepEqual(request, want) {
+		t.Errorf("request = %+v, want the default %+v", request, want)
+	}
+}
+
+func TestInvalidInputIsRejectedWhenMapped(t *testing.T) {
+	for _, tt := range []struct {
+		input SearchProductsInput
+		field string
+	}{
+		{SearchProductsInput{PerPage: "lots"}, "per_page"},
+		{SearchProductsInput{Search: "boots", SearchMode: "fuzzy"}, "search_mode"},
+		{SearchProductsInput{DateFormat: "soon"}, "date_format"},
+		{SearchProductsInput{Parent: "abc"}, "parent"},
+		{SearchProductsInput{Search: "boots", SKUContains: "FAKE"}, "sku_contains"},
+	} {
+		if _, err := tt.input.ToSearchRequest(); err == nil || !strings.Contains(err.Error(), tt.field) {
+			t.Errorf("%+v: got error %v, want a %s error", tt.input, err, tt.field)
+		}
+	}
+}
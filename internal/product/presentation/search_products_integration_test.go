@@ -0,0 +1,110 @@
+package presentation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"woocommerce-mcp/internal/product/application/search_products"
+	"woocommerce-mcp/internal/product/domain"
+	"woocommerce-mcp/internal/testutil/fakestore"
+)
+
+// searchFakeStore runs search_products against a fake store holding the
+// default fixtures and decodes the result
+func searchFakeStore(t *testing.T, input SearchProductsInput) *search_products.SearchResponse {
+	t.Helper()
+
+	server := fakestore.New().Start()
+	t.Cleanup(server.Close)
+
+	input.BaseURL = server.URL
+	input.ConsumerKey = fakestore.ConsumerKey
+	input.ConsumerSecret = fakestore.ConsumerSecret
+	_, output, err := NewSearchProductsHandler().ExecuteMCPTool(context.Background(), nil, input)
+	if err != nil {
+		t.Fatalf("search_products: %v", err)
+	}
+
+	var response search_products.SearchResponse
+	if err := json.Unmarshal([]byte(output.Data), &response); err != nil {
+		t.Fatalf("decode search data: %v", err)
+	}
+	return &response
+}
+
+func TestSearchProductsAgainstFakeStore(t *testing.T) {
+	response := searchFakeStore(t, SearchProductsInput{Search: "Sneakers"})
+
+	if response.TotalCount != 3 || len(response.Products) != 3 {
+		t.Fatalf("got %d of %d products, want 3 of 3", len(response.Products), response.TotalCount)
+	}
+	for _, product := range response.Products {
+		if product.Price == "" || product.Permalink == "" {
+			t.Errorf("product %d is missing its price or permalink: %+v", product.ID, product)
+		}
+	}
+	if response.HasNext || response.TotalPages != 1 {
+		t.Errorf("total_pages=%d has_next=%v, want a single page", response.TotalPages, response.HasNext)
+	}
+}
+
+func TestSearchProductsPaginatesFakeStore(t *testing.T) {
+	first := searchFakeStore(t, SearchProductsInput{Search: "s", PerPage: "5", Page: "1"})
+	if first.TotalCount != 12 || first.TotalPages != 3 {
+		t.Fatalf("total_count=%d total_pages=%d, want 12 and 3", first.TotalCount, first.TotalPages)
+	}
+	if len(first.Products) != 5 || !first.HasNext || first.HasPrev {
+		t.Errorf("page 1: %d products, has_next=%v has_prev=%v", len(first.Products), first.HasNext, first.HasPrev)
+	}
+
+	last := searchFakeStore(t, SearchProductsInput{Search: "s", PerPage: "5", Page: "3"})
+	if len(last.Products) != 2 || last.HasNext || !last.HasPrev {
+		t.Errorf("page 3: %d products, has_next=%v has_prev=%v", len(last.Products), last.HasNext, last.HasPrev)
+	}
+	if last.Products[0].ID != 11 || last.Products[1].ID != 12 {
+		t.Errorf("page 3 holds products %d and %d, want 11 and 12", last.Products[0].ID, last.Products[1].ID)
+	}
+}
+
+func TestSearchProductsWithWrongCredentials(t *testing.T) {
+	server := fakestore.New().Start()
+	defer server.Close()
+
+	_, _, err := NewSearchProductsHandler().ExecuteMCPTool(context.Background(), nil, SearchProductsInput{
+		BaseURL:        server.URL,
+		ConsumerKey:    fakestore.ConsumerKey,
+		ConsumerSecret: "cs_wrong",
+		Search:         "Sneakers",
+	})
+	var apiErr *domain.WooCommerceAPIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 401 {
+		t.Fatalf("got error %v, want a 401 WooCommerceAPIError", err)
+	}
+}
+
+func TestSearchProductsDescribesSalesWhenRendering(t *testing.T) {
+	store := fakestore.New()
+	products := fakestore.DefaultProducts()
+	products[0]["on_sale"] = true
+	products[0]["date_on_sale_to_gmt"] = time.Now().UTC().Add(3*24*time.Hour + time.Hour).Format("2006-01-02T15:04:05")
+	store.SetProducts(products)
+	server := store.Start()
+	defer server.Close()
+
+	_, output, err := NewSearchProductsHandler().ExecuteMCPTool(context.Background(), nil, SearchProductsInput{
+		BaseURL:        server.URL,
+		ConsumerKey:    fakestore.ConsumerKey,
+		ConsumerSecret: fakestore.ConsumerSecret,
+		Search:         "Low Top",
+	})
+	if err != nil {
+		t.Fatalf("search_products: %v", err)
+	}
+	if !strings.Contains(output.Data, `"sale_remaining":"ends in 3 days"`) {
+		t.Errorf("sale_remaining not rendered: %s", output.Data)
+	}
+}
@@ -0,0 +1,169 @@
+package presentation
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"woocommerce-mcp/internal/product/application/search_products"
+	"woocommerce-mcp/internal/product/infrastructure/woocommerce"
+	kitDomain "woocommerce-mcp/kit/domain"
+	"woocommerce-mcp/kit/retry"
+	"woocommerce-mcp/kit/storeconfig"
+	"woocommerce-mcp/kit/tooltimeout"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HTTP trailers of a streamed export
+const (
+	// StreamErrorTrailer names the error that interrupted the export after
+	// the response had started
+	StreamErrorTrailer = "X-Stream-Error"
+	// DuplicatesSkippedTrailer counts the products left out of a complete
+	// export because an earlier page already listed them
+	DuplicatesSkippedTrailer = "X-Duplicates-Skipped"
+)
+
+// parseStream parses a stream tool argument; an empty value means no streaming
+func parseStream(value string) (bool, error) {
+	if value = strings.TrimSpace(value); value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+// StreamLegacyHTTP answers a legacy call with stream=true and reports
+// whether it did; other calls are executed by the bridge as usual
+func (h *SearchProductsHandler) StreamLegacyHTTP(c *gin.Context, input SearchProductsInput) bool {
+	if stream, err := parseStream(input.Stream); err != nil || !stream {
+		return false
+	}
+	h.streamLegacyHTTP(c, input)
+	return true
+}
+
+// streamLegacyHTTP answers a legacy call with stream=true. Every page from
+// the requested one to the last is fetched in turn and its products are
+// written to a chunked JSON array as soon as the page arrives, so only one
+// page is held in memory. The total product count is sent in the
+// X-Total-Count header. Products repeated across pages are written once and
+// counted in the X-Duplicates-Skipped trailer. An error before the first
+// page is reported like any legacy tool error; a later one leaves the array
+// unterminated, so a partial export cannot pass for a complete one, and is
+// named in the X-Stream-Error trailer.
+func (h *SearchProductsHandler) streamLegacyHTTP(c *gin.Context, input SearchProductsInput) {
+	// Fall back to the default store configured through the environment
+	storeconfig.ApplyDefaults(&input.BaseURL, &input.ConsumerKey, &input.ConsumerSecret)
+
+	// Validate required fields
+	var err error
+	switch {
+	case input.BaseURL == "":
+		err = kitDomain.NewValidationError("base_url is required")
+	case input.ConsumerKey == "":
+		err = kitDomain.NewValidationError("consumer_key is required")
+	case input.ConsumerSecret == "":
+		err = kitDomain.NewValidationError("consumer_secret is required")
+	}
+	if err != nil {
+		h.sendLegacyError(c, err)
+		return
+	}
+	request, err := input.ToSearchRequest()
+	if err != nil {
+		h.sendLegacyError(c, err)
+		return
+	}
+	fields, err := search_products.ParseFields(input.Fields)
+	if err != nil {
+		h.sendLegacyError(c, err)
+		return
+	}
+
+	// Create WooCommerce client for the store of this call
+	ctx := withStore(c.Request.Context(), input)
+	config, err := woocommerce.NewConfigFromContext(ctx)
+	if err != nil {
+		h.sendLegacyError(c, err)
+		return
+	}
+	repo := woocommerce.NewRepository(woocommerce.NewCachedClient(config))
+	searcher := search_products.NewProductSearcher(repo)
+
+	// Let this call tune the retries of its store requests
+	ctx, err = retry.Override(ctx, input.MaxRetries, input.RetryBackoffMS)
+	if err != nil {
+		h.sendLegacyError(c, err)
+		return
+	}
+
+	// Bound the whole export, across all of its pages
+	ctx, cancel := tooltimeout.WithTimeout(ctx)
+	defer cancel()
+
+	written := 0
+	started := false
+	encoder := json.NewEncoder(c.Writer)
+	duplicatesSkipped, err := searcher.Stream(ctx, request, func(page *search_products.SearchResponse) error {
+		if !started {
+			started = true
+			c.Header("Content-Type", "application/json; charset=utf-8")
+			c.Header("X-Total-Count", strconv.Itoa(page.TotalCount))
+			c.Header("Trailer", StreamErrorTrailer+", "+DuplicatesSkippedTrailer)
+			c.Status(http.StatusOK)
+			if _, err := c.Writer.WriteString("["); err != nil {
+				return err
+			}
+		}
+
+		for _, product := range page.Products {
+			if written > 0 {
+				if _, err := c.Writer.WriteString(","); err != nil {
+					return err
+				}
+			}
+			product.DescribeSale(time.Now())
+			var value interface{} = product
+			if fields != nil {
+				projected, err := fields.Project(product)
+				if err != nil {
+					return err
+				}
+				value = projected
+			}
+			if err := encoder.Encode(value); err != nil {
+				return err
+			}
+			written++
+		}
+		c.Writer.Flush()
+		return nil
+	})
+
+	if err != nil {
+		err = tooltimeout.Err(ctx, "search_products", withAuthAdvice(fmt.Errorf("failed to search products: %w", err)))
+		if !started {
+			h.sendLegacyError(c, err)
+			return
+		}
+		c.Writer.Header().Set(StreamErrorTrailer, err.Error())
+		return
+	}
+
+	c.Writer.WriteString("]\n")
+	c.Writer.Header().Set(DuplicatesSkippedTrailer, strconv.Itoa(duplicatesSkipped))
+	c.Writer.Flush()
+}
+
+// sendLegacyError sends a failed tool result to a legacy HTTP caller
+func (h *SearchProductsHandler) sendLegacyError(c *gin.Context, err error) {
+	c.JSON(http.StatusInternalServerError, map[string]interface{}{
+		"content": []map[string]interface{}{{"type": "text", "text": fmt.Sprintf("Tool execution failed: %v", err)}},
+		"isError": true,
+		"error":   kitDomain.DescribeError(err),
+	})
+}
@@ -0,0 +1,132 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"woocommerce-mcp/internal/testutil/fakestore"
+
+	"github.com/gin-gonic/gin"
+)
+
+// streamProducts runs a streamed legacy search_products call against a fake
+// store holding the default fixtures and returns the response and its body
+func streamProducts(t *testing.T, arguments map[string]interface{}) (*http.Response, []byte) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	storeServer := fakestore.New().Start()
+	t.Cleanup(storeServer.Close)
+
+	handler := NewSearchProductsHandler()
+	router := gin.New()
+	router.POST("/call_tool", func(c *gin.Context) {
+		var call struct {
+			Arguments SearchProductsInput `json:"arguments"`
+		}
+		if err := c.ShouldBindJSON(&call); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		if !handler.StreamLegacyHTTP(c, call.Arguments) {
+			c.Status(http.StatusNotImplemented)
+		}
+	})
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	arguments["base_url"] = storeServer.URL
+	arguments["consumer_key"] = fakestore.ConsumerKey
+	arguments["consumer_secret"] = fakestore.ConsumerSecret
+	arguments["stream"] = "true"
+	body, _ := json.Marshal(map[string]interface{}{"name": "search_products", "arguments": arguments})
+
+	resp, err := http.Post(server.URL+"/call_tool", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("stream call: %v", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read stream: %v", err)
+	}
+	return resp, data
+}
+
+func TestStreamedSearchDecodesWithEveryProduct(t *testing.T) {
+	resp, body := streamProducts(t, map[string]interface{}{"search": "s", "per_page": "5"})
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("stream answered %d %s", resp.StatusCode, body)
+	}
+	var products []struct {
+		ID int `json:"id"`
+	}
+	if err := json.Unmarshal(body, &products); err != nil {
+		t.Fatalf("streamed body is not a JSON array: %v\n%s", err, body)
+	}
+	if len(products) != 12 {
+		t.Fatalf("streamed %d products, want all 12 across 3 pages", len(products))
+	}
+	for i, product := range products {
+		if product.ID != i+1 {
+			t.Errorf("product %d has id %d, want %d", i, product.ID, i+1)
+		}
+	}
+	if got := resp.Header.Get("X-Total-Count"); got != "12" {
+		t.Errorf("X-Total-Count = %q, want 12", got)
+	}
+	if got := resp.Trailer.Get("X-Stream-Error"); got != "" {
+		t.Errorf("complete export reported X-Stream-Error %q", got)
+	}
+	if got := resp.Trailer.Get(DuplicatesSkippedTrailer); got != "0" {
+		t.Errorf("%s = %q, want 0 for a catalog that did not change", DuplicatesSkippedTrailer, got)
+	}
+}
+
+func TestStreamedSearchStartsAtThePage(t *testing.T) {
+	_, body := streamProducts(t, map[string]interface{}{"search": "s", "per_page": "5", "page": "2"})
+
+	var products []struct {
+		ID int `json:"id"`
+	}
+	if err := json.Unmarshal(body, &products); err != nil {
+		t.Fatalf("streamed body is not a JSON array: %v\n%s", err, body)
+	}
+	if len(products) != 7 || products[0].ID != 6 {
+		t.Errorf("streamed %d products starting at %v, want 7 from product 6", len(products), products)
+	}
+}
+
+func TestStreamedSearchKeepsTheSelectedFields(t *testing.T) {
+	resp, body := streamProducts(t, map[string]interface{}{"search": "s", "per_page": "5", "fields": "name,categories.id"})
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("stream answered %d %s", resp.StatusCode, body)
+	}
+	var products []map[string]json.RawMessage
+	if err := json.Unmarshal(body, &products); err != nil {
+		t.Fatalf("streamed body is not a JSON array: %v\n%s", err, body)
+	}
+	if len(products) != 12 {
+		t.Fatalf("streamed %d products, want 12", len(products))
+	}
+	for _, product := range products {
+		if len(product) != 3 || product["id"] == nil || product["name"] == nil || bytes.Contains(product["categories"], []byte(`"name"`)) {
+			t.Errorf("streamed product %s, want only id, name and category ids", body)
+			break
+		}
+	}
+}
+
+func TestStreamedSearchRejectsUnknownFields(t *testing.T) {
+	resp, body := streamProducts(t, map[string]interface{}{"search": "s", "fields": "images.url"})
+
+	if resp.StatusCode == http.StatusOK {
+		t.Errorf("unknown field answered %d %s, want an error", resp.StatusCode, body)
+	}
+}
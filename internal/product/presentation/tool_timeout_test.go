@@ -0,0 +1,53 @@
+package presentation
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"woocommerce-mcp/internal/testutil/fakestore"
+	kitDomain "woocommerce-mcp/kit/domain"
+	"woocommerce-mcp/kit/retry"
+	"woocommerce-mcp/kit/tooltimeout"
+)
+
+func TestSlowPagingRunsIntoTheToolDeadline(t *testing.T) {
+	t.Setenv(tooltimeout.Env, "150ms")
+	t.Setenv(retry.MaxRetriesEnv, "0")
+
+	// every page answers well within the HTTP client timeout, but paging
+	// through all of them takes longer than the tool deadline
+	store := fakestore.New().Handler()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(100 * time.Millisecond):
+			store.ServeHTTP(w, r)
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	start := time.Now()
+	_, _, err := NewSearchProductsHandler().ExecuteMCPTool(context.Background(), nil, SearchProductsInput{
+		BaseURL:        server.URL,
+		ConsumerKey:    fakestore.ConsumerKey,
+		ConsumerSecret: fakestore.ConsumerSecret,
+		Type:           "simple,variable",
+		PerPage:        "2",
+	})
+
+	var timeoutErr *kitDomain.TimeoutError
+	if !errors.As(err, &timeoutErr) || timeoutErr.Tool != "search_products" {
+		t.Fatalf("got error %v, want a TimeoutError naming search_products", err)
+	}
+	if !strings.Contains(err.Error(), "150ms") {
+		t.Errorf("error %q does not name the deadline", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("call took %s, want it cut off near the deadline", elapsed)
+	}
+}
@@ -0,0 +1,138 @@
+package presentation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"woocommerce-mcp/internal/product/application/trending_products"
+	"woocommerce-mcp/internal/product/infrastructure/woocommerce"
+	kitDomain "woocommerce-mcp/kit/domain"
+	"woocommerce-mcp/kit/storeconfig"
+	"woocommerce-mcp/kit/toolargs"
+	"woocommerce-mcp/kit/tooltimeout"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// TrendingProductsInput defines the input structure for the trending_products tool
+type TrendingProductsInput struct {
+	BaseURL        string `json:"base_url,omitempty" jsonschema:"WooCommerce store base URL (e.g., https://example.com); defaults to WC_BASE_URL"`
+	ConsumerKey    string `json:"consumer_key,omitempty" jsonschema:"WooCommerce REST API consumer key; defaults to WC_CONSUMER_KEY for the default store"`
+	ConsumerSecret string `json:"consumer_secret,omitempty" jsonschema:"WooCommerce REST API consumer secret; defaults to WC_CONSUMER_SECRET for the default store"`
+	Period         string `json:"period,omitempty" jsonschema:"Sales period (week, month, last_month, year; default: week)"`
+	Limit          string `json:"limit,omitempty" jsonschema:"Maximum number of products to return (1-50, default: 10)"`
+}
+
+// UnmarshalJSON decodes the arguments, also accepting numbers and booleans
+// for the string fields
+func (in *TrendingProductsInput) UnmarshalJSON(data []byte) error {
+	type plain TrendingProductsInput
+	return toolargs.Unmarshal(data, (*plain)(in))
+}
+
+// TrendingProductsOutput defines the output structure for the trending_products tool
+type TrendingProductsOutput struct {
+	Message string `json:"message" jsonschema:"Human-readable message about the trending products"`
+	Data    string `json:"data" jsonschema:"JSON-formatted product data"`
+}
+
+// TrendingProductsHandler handles trending_products tool calls
+type TrendingProductsHandler struct{}
+
+// NewTrendingProductsHandler creates a new TrendingProductsHandler
+func NewTrendingProductsHandler() *TrendingProductsHandler {
+	return &TrendingProductsHandler{}
+}
+
+// GetToolDefinition returns the MCP tool definition for trending_products
+func (h *TrendingProductsHandler) GetToolDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "trending_products",
+		Description: "Get the best-selling products of a recent period (week, month, last_month, year) from the store's sales report, e.g. to answer what is trending this week. Falls back to lifetime popularity when the API key cannot read reports.",
+		InputSchema: toolargs.InputSchema[TrendingProductsInput](),
+	}
+}
+
+// GetInputSchema returns the input schema for the JSON-RPC tools/list endpoint
+func (h *TrendingProductsHandler) GetInputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"base_url":        map[string]string{"type": "string", "description": "WooCommerce store base URL"},
+			"consumer_key":    map[string]string{"type": "string", "description": "WooCommerce REST API consumer key"},
+			"consumer_secret": map[string]string{"type": "string", "description": "WooCommerce REST API consumer secret"},
+			"period":          map[string]string{"type": "string", "description": "Sales period (week, month, last_month, year)"},
+			"limit":           map[string]string{"type": "string", "description": "Maximum number of products"},
+		},
+		"required": storeconfig.RequiredFields("base_url", "consumer_key", "consumer_secret"),
+	}
+}
+
+// ExecuteMCPTool implements the MCP tool execution
+func (h *TrendingProductsHandler) ExecuteMCPTool(ctx context.Context, req *mcp.CallToolRequest, input TrendingProductsInput) (*mcp.CallToolResult, TrendingProductsOutput, error) {
+	// Fall back to the default store configured through the environment
+	storeconfig.ApplyDefaults(&input.BaseURL, &input.ConsumerKey, &input.ConsumerSecret)
+
+	// Validate required fields
+	if input.BaseURL == "" {
+		return nil, TrendingProductsOutput{}, kitDomain.NewValidationError("base_url is required")
+	}
+	if input.ConsumerKey == "" {
+		return nil, TrendingProductsOutput{}, kitDomain.NewValidationError("consumer_key is required")
+	}
+	if input.ConsumerSecret == "" {
+		return nil, TrendingProductsOutput{}, kitDomain.NewValidationError("consumer_secret is required")
+	}
+
+	// Create WooCommerce client
+	config := woocommerce.NewConfig(input.BaseURL, input.ConsumerKey, input.ConsumerSecret)
+	client := woocommerce.NewCachedClient(config)
+	repo := woocommerce.NewRepository(client)
+
+	request := &trending_products.TrendingProductsRequest{
+		BaseURL:        input.BaseURL,
+		ConsumerKey:    input.ConsumerKey,
+		ConsumerSecret: input.ConsumerSecret,
+		Period:         input.Period,
+		Limit:          input.Limit,
+	}
+
+	// Bound the whole tool call, across all of its API requests
+	ctx, cancel := tooltimeout.WithTimeout(ctx)
+	defer cancel()
+
+	// Execute lookup
+	finder := trending_products.NewTrendingProductsFinder(repo, repo)
+	response, err := finder.Execute(ctx, request)
+	if err != nil {
+		return nil, TrendingProductsOutput{}, tooltimeout.Err(ctx, "trending_products", withAuthAdvice(fmt.Errorf("failed to get trending products: %w", err)))
+	}
+
+	// Convert response to JSON
+	now := time.Now()
+	for _, product := range response.Products {
+		product.DescribeSale(now)
+	}
+	jsonData, err := response.ToJSON()
+	if err != nil {
+		return nil, TrendingProductsOutput{}, fmt.Errorf("failed to serialize response: %w", err)
+	}
+
+	// Create human-readable message
+	var message string
+	if len(response.Products) == 0 {
+		message = fmt.Sprintf("No products sold in the %s period", response.Period)
+	} else {
+		message = fmt.Sprintf("Found %d trending product(s) for the %s period", len(response.Products), response.Period)
+	}
+	if response.Degraded {
+		message = fmt.Sprintf("Sales reports are not available with this API key; showing %d product(s) ranked by lifetime popularity instead of %s sales",
+			len(response.Products), response.Period)
+	}
+
+	return nil, TrendingProductsOutput{
+		Message: message,
+		Data:    jsonData,
+	}, nil
+}
@@ -0,0 +1,31 @@
+package search_all
+
+import "strings"
+
+// SearchAllRequest represents a search across store products and blog posts
+type SearchAllRequest struct {
+	// Query is the search term sent to both sources
+	Query string `json:"query"`
+
+	// WooCommerce store; products are searched when all three are set
+	BaseURL        string `json:"base_url,omitempty"`
+	ConsumerKey    string `json:"consumer_key,omitempty"`
+	ConsumerSecret string `json:"consumer_secret,omitempty"`
+
+	// WordPressBaseURL is the blog site; posts are searched when it is set
+	WordPressBaseURL string `json:"wordpress_base_url,omitempty"`
+
+	// PerPage caps the results of each source
+	PerPage string `json:"per_page,omitempty"`
+}
+
+// SearchesProducts reports whether the request has the store credentials
+// needed to search products
+func (r *SearchAllRequest) SearchesProducts() bool {
+	return strings.TrimSpace(r.BaseURL) != "" && r.ConsumerKey != "" && r.ConsumerSecret != ""
+}
+
+// SearchesPosts reports whether the request names a WordPress site to search posts
+func (r *SearchAllRequest) SearchesPosts() bool {
+	return strings.TrimSpace(r.WordPressBaseURL) != ""
+}
@@ -0,0 +1,52 @@
+package search_all
+
+import (
+	"encoding/json"
+	"woocommerce-mcp/internal/post/application/search_posts"
+	"woocommerce-mcp/internal/product/application/search_products"
+)
+
+// Item types
+const (
+	ItemTypeProduct = "product"
+	ItemTypePost    = "post"
+)
+
+// SearchAllResponse represents the merged results of a search across
+// products and posts
+type SearchAllResponse struct {
+	Query   string     `json:"query"`
+	Items   []*ItemDTO `json:"items"`
+	Sources SourcesDTO `json:"sources"`
+}
+
+// ItemDTO is a single result; Type tells which of Product or Post is set
+type ItemDTO struct {
+	Type    string                      `json:"type"`
+	Product *search_products.ProductDTO `json:"product,omitempty"`
+	Post    *search_posts.PostDTO       `json:"post,omitempty"`
+}
+
+// SourcesDTO summarizes the search of each source
+type SourcesDTO struct {
+	Products SourceDTO `json:"products"`
+	Posts    SourceDTO `json:"posts"`
+}
+
+// SourceDTO reports whether a source was searched, how many of its results
+// were returned and how many match in total
+type SourceDTO struct {
+	Searched   bool   `json:"searched"`
+	Returned   int    `json:"returned"`
+	TotalCount int64  `json:"total_count"`
+	Error      string `json:"error,omitempty"`
+}
+
+// ToJSON converts the response to JSON string
+func (r *SearchAllResponse) ToJSON() (string, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
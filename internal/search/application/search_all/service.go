@@ -0,0 +1,113 @@
+package search_all
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"woocommerce-mcp/internal/post/application/search_posts"
+	"woocommerce-mcp/internal/product/application/search_products"
+	"woocommerce-mcp/kit/domain"
+)
+
+// ProductSearcher searches store products
+type ProductSearcher interface {
+	Execute(ctx context.Context, request *search_products.SearchRequest) (*search_products.SearchResponse, error)
+}
+
+// PostSearcher searches blog posts
+type PostSearcher interface {
+	Execute(ctx context.Context, request *search_posts.SearchRequest) (*search_posts.SearchResponse, error)
+}
+
+// CombinedSearcher searches products and posts concurrently and merges the results
+type CombinedSearcher struct {
+	productSearcher ProductSearcher
+	postSearcher    PostSearcher
+}
+
+// NewCombinedSearcher creates a new CombinedSearcher
+func NewCombinedSearcher(productSearcher ProductSearcher, postSearcher PostSearcher) *CombinedSearcher {
+	return &CombinedSearcher{
+		productSearcher: productSearcher,
+		postSearcher:    postSearcher,
+	}
+}
+
+// Execute searches every source the request is configured for. A failing
+// source is reported in its summary while the other's results are kept; the
+// search only fails when every searched source failed.
+func (s *CombinedSearcher) Execute(ctx context.Context, request *SearchAllRequest) (*SearchAllResponse, error) {
+	query := strings.TrimSpace(request.Query)
+	if query == "" {
+		return nil, domain.NewValidationError("query is required")
+	}
+	searchProducts, searchPosts := request.SearchesProducts(), request.SearchesPosts()
+	if !searchProducts && !searchPosts {
+		return nil, domain.NewValidationError("provide base_url with consumer_key and consumer_secret to search products, wordpress_base_url to search posts, or both")
+	}
+
+	perPage := strings.TrimSpace(request.PerPage)
+
+	var wg sync.WaitGroup
+	var products *search_products.SearchResponse
+	var posts *search_posts.SearchResponse
+	var productsErr, postsErr error
+	if searchProducts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			productRequest := search_products.NewSearchRequest()
+			productRequest.SetSearch(query)
+			productRequest.SetPagination("1", perPage)
+			products, productsErr = s.productSearcher.Execute(ctx, productRequest)
+		}()
+	}
+	if searchPosts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			posts, postsErr = s.postSearcher.Execute(ctx, &search_posts.SearchRequest{
+				BaseURL: request.WordPressBaseURL,
+				Search:  query,
+				PerPage: perPage,
+			})
+		}()
+	}
+	wg.Wait()
+
+	if (!searchProducts || productsErr != nil) && (!searchPosts || postsErr != nil) {
+		if productsErr != nil {
+			return nil, productsErr
+		}
+		return nil, postsErr
+	}
+
+	response := &SearchAllResponse{
+		Query: query,
+		Items: make([]*ItemDTO, 0),
+	}
+
+	response.Sources.Products.Searched = searchProducts
+	if productsErr != nil {
+		response.Sources.Products.Error = productsErr.Error()
+	} else if products != nil {
+		for _, product := range products.Products {
+			response.Items = append(response.Items, &ItemDTO{Type: ItemTypeProduct, Product: product})
+		}
+		response.Sources.Products.Returned = len(products.Products)
+		response.Sources.Products.TotalCount = int64(products.TotalCount)
+	}
+
+	response.Sources.Posts.Searched = searchPosts
+	if postsErr != nil {
+		response.Sources.Posts.Error = postsErr.Error()
+	} else if posts != nil {
+		for i := range posts.Posts {
+			response.Items = append(response.Items, &ItemDTO{Type: ItemTypePost, Post: &posts.Posts[i]})
+		}
+		response.Sources.Posts.Returned = len(posts.Posts)
+		response.Sources.Posts.TotalCount = posts.TotalCount
+	}
+
+	return response, nil
+}
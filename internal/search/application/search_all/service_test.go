@@ -0,0 +1,168 @@
+package search_all
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"woocommerce-mcp/internal/post/application/search_posts"
+	"woocommerce-mcp/internal/product/application/search_products"
+)
+
+// stubProductSearcher answers with its response or error and records the requests
+type stubProductSearcher struct {
+	response *search_products.SearchResponse
+	err      error
+	requests []*search_products.SearchRequest
+}
+
+func (s *stubProductSearcher) Execute(ctx context.Context, request *search_products.SearchRequest) (*search_products.SearchResponse, error) {
+	s.requests = append(s.requests, request)
+	return s.response, s.err
+}
+
+// stubPostSearcher answers with its response or error and records the requests
+type stubPostSearcher struct {
+	response *search_posts.SearchResponse
+	err      error
+	requests []*search_posts.SearchRequest
+}
+
+func (s *stubPostSearcher) Execute(ctx context.Context, request *search_posts.SearchRequest) (*search_posts.SearchResponse, error) {
+	s.requests = append(s.requests, request)
+	return s.response, s.err
+}
+
+// newSearchers returns searchers finding two of five products and one of
+// three posts
+func newSearchers() (*stubProductSearcher, *stubPostSearcher) {
+	products := &stubProductSearcher{response: &search_products.SearchResponse{
+		Products:   []*search_products.ProductDTO{{ID: 1, Name: "Running Shoes"}, {ID: 2, Name: "Running Socks"}},
+		TotalCount: 5,
+	}}
+	posts := &stubPostSearcher{response: &search_posts.SearchResponse{
+		Posts:      []search_posts.PostDTO{{ID: 103, Title: "Running Tips"}},
+		TotalCount: 3,
+	}}
+	return products, posts
+}
+
+// itemTypes returns the type of each item, in order
+func itemTypes(response *SearchAllResponse) []string {
+	types := make([]string, len(response.Items))
+	for i, item := range response.Items {
+		types[i] = item.Type
+	}
+	return types
+}
+
+func TestSearchAllMergesBothSources(t *testing.T) {
+	products, posts := newSearchers()
+	response, err := NewCombinedSearcher(products, posts).Execute(context.Background(), &SearchAllRequest{
+		Query:            " running ",
+		BaseURL:          "https://shop.example",
+		ConsumerKey:      "ck",
+		ConsumerSecret:   "cs",
+		WordPressBaseURL: "https://blog.example",
+		PerPage:          "5",
+	})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	if got := itemTypes(response); len(got) != 3 || got[0] != ItemTypeProduct || got[1] != ItemTypeProduct || got[2] != ItemTypePost {
+		t.Errorf("item types = %v, want two products then a post", got)
+	}
+	if response.Items[2].Post == nil || response.Items[2].Post.ID != 103 || response.Items[0].Product == nil {
+		t.Errorf("items do not carry their product or post")
+	}
+	if got := response.Sources.Products; !got.Searched || got.Returned != 2 || got.TotalCount != 5 {
+		t.Errorf("products source = %+v, want 2 returned of 5", got)
+	}
+	if got := response.Sources.Posts; !got.Searched || got.Returned != 1 || got.TotalCount != 3 {
+		t.Errorf("posts source = %+v, want 1 returned of 3", got)
+	}
+	if request := products.requests[0]; request.GetSearch() != "running" {
+		t.Errorf("product search = %q, want the trimmed query", request.GetSearch())
+	}
+	if request := posts.requests[0]; request.Search != "running" || request.BaseURL != "https://blog.example" || request.PerPage != "5" {
+		t.Errorf("post request = %+v, want the query, blog and page size", request)
+	}
+}
+
+func TestSearchAllRunsOnlyTheConfiguredSource(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  *SearchAllRequest
+		products bool
+		posts    bool
+	}{
+		{
+			name:     "products only",
+			request:  &SearchAllRequest{Query: "running", BaseURL: "https://shop.example", ConsumerKey: "ck", ConsumerSecret: "cs"},
+			products: true,
+		},
+		{
+			name:    "posts only",
+			request: &SearchAllRequest{Query: "running", WordPressBaseURL: "https://blog.example"},
+			posts:   true,
+		},
+		{
+			name:    "store without credentials",
+			request: &SearchAllRequest{Query: "running", BaseURL: "https://shop.example", WordPressBaseURL: "https://blog.example"},
+			posts:   true,
+		},
+	}
+	for _, tt := range tests {
+		products, posts := newSearchers()
+		response, err := NewCombinedSearcher(products, posts).Execute(context.Background(), tt.request)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if searched := len(products.requests) > 0; searched != tt.products || response.Sources.Products.Searched != tt.products {
+			t.Errorf("%s: products searched = %v, want %v", tt.name, searched, tt.products)
+		}
+		if searched := len(posts.requests) > 0; searched != tt.posts || response.Sources.Posts.Searched != tt.posts {
+			t.Errorf("%s: posts searched = %v, want %v", tt.name, searched, tt.posts)
+		}
+		for _, item := range response.Items {
+			if (item.Type == ItemTypeProduct && !tt.products) || (item.Type == ItemTypePost && !tt.posts) {
+				t.Errorf("%s: got a %s item from a source that was not searched", tt.name, item.Type)
+			}
+		}
+	}
+}
+
+func TestSearchAllKeepsResultsOfTheWorkingSource(t *testing.T) {
+	products, posts := newSearchers()
+	posts.err = errors.New("blog unreachable")
+	request := &SearchAllRequest{Query: "running", BaseURL: "https://shop.example", ConsumerKey: "ck", ConsumerSecret: "cs", WordPressBaseURL: "https://blog.example"}
+
+	response, err := NewCombinedSearcher(products, posts).Execute(context.Background(), request)
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if len(response.Items) != 2 || response.Sources.Posts.Error != "blog unreachable" {
+		t.Errorf("got %d items and posts error %q, want the products and the posts error", len(response.Items), response.Sources.Posts.Error)
+	}
+
+	products.err = errors.New("store unreachable")
+	if _, err := NewCombinedSearcher(products, posts).Execute(context.Background(), request); err == nil || err.Error() != "store unreachable" {
+		t.Errorf("got error %v, want the first failure when every source failed", err)
+	}
+}
+
+func TestSearchAllNeedsAQueryAndASource(t *testing.T) {
+	products, posts := newSearchers()
+	for name, request := range map[string]*SearchAllRequest{
+		"no query":  {Query: "  ", WordPressBaseURL: "https://blog.example"},
+		"no source": {Query: "running"},
+	} {
+		if _, err := NewCombinedSearcher(products, posts).Execute(context.Background(), request); err == nil {
+			t.Errorf("%s: succeeded, want a validation error", name)
+		}
+	}
+	if len(products.requests)+len(posts.requests) != 0 {
+		t.Error("an invalid request reached a source")
+	}
+}
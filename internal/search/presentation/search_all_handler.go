@@ -0,0 +1,159 @@
+package presentation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"woocommerce-mcp/internal/post/application/search_posts"
+	"woocommerce-mcp/internal/product/application/search_products"
+	"woocommerce-mcp/internal/product/infrastructure/woocommerce"
+	"woocommerce-mcp/internal/search/application/search_all"
+	"woocommerce-mcp/kit/storeconfig"
+	"woocommerce-mcp/kit/toolargs"
+	"woocommerce-mcp/kit/tooltimeout"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// SearchAllInput defines the input structure for the search_all tool
+type SearchAllInput struct {
+	Query            string `json:"query" jsonschema:"Search term matched against product and post titles and content"`
+	BaseURL          string `json:"base_url,omitempty" jsonschema:"WooCommerce store base URL; products are searched when it and the API keys are set; defaults to WC_BASE_URL"`
+	ConsumerKey      string `json:"consumer_key,omitempty" jsonschema:"WooCommerce REST API consumer key; defaults to WC_CONSUMER_KEY for the default store"`
+	ConsumerSecret   string `json:"consumer_secret,omitempty" jsonschema:"WooCommerce REST API consumer secret; defaults to WC_CONSUMER_SECRET for the default store"`
+	WordPressBaseURL string `json:"wordpress_base_url,omitempty" jsonschema:"WordPress site base URL; posts are searched when it is set; defaults to WC_BASE_URL"`
+	PerPage          string `json:"per_page,omitempty" jsonschema:"Maximum results per source (default: 10, max: 100)"`
+}
+
+// UnmarshalJSON decodes the arguments, also accepting numbers and booleans
+// for the string fields
+func (in *SearchAllInput) UnmarshalJSON(data []byte) error {
+	type plain SearchAllInput
+	return toolargs.Unmarshal(data, (*plain)(in))
+}
+
+// SearchAllOutput defines the output structure for the search_all tool
+type SearchAllOutput struct {
+	Message string `json:"message" jsonschema:"Human-readable message about the search results"`
+	Data    string `json:"data" jsonschema:"JSON-formatted product and post results"`
+}
+
+// SearchAllHandler handles search_all tool calls
+type SearchAllHandler struct{}
+
+// NewSearchAllHandler creates a new SearchAllHandler
+func NewSearchAllHandler() *SearchAllHandler {
+	return &SearchAllHandler{}
+}
+
+// GetToolDefinition returns the MCP tool definition for search_all
+func (h *SearchAllHandler) GetToolDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "search_all",
+		Description: "Search store products and blog posts at once, for when it is unclear whether the user wants a product or an article. Each result has a type (product or post). Products are searched when store credentials are given, posts when a WordPress base URL is given.",
+		InputSchema: toolargs.InputSchema[SearchAllInput](),
+	}
+}
+
+// GetInputSchema returns the input schema for the JSON-RPC tools/list endpoint
+func (h *SearchAllHandler) GetInputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"query":              map[string]string{"type": "string", "description": "Search term"},
+			"base_url":           map[string]string{"type": "string", "description": "WooCommerce store base URL"},
+			"consumer_key":       map[string]string{"type": "string", "description": "WooCommerce REST API consumer key"},
+			"consumer_secret":    map[string]string{"type": "string", "description": "WooCommerce REST API consumer secret"},
+			"wordpress_base_url": map[string]string{"type": "string", "description": "WordPress site base URL"},
+			"per_page":           map[string]string{"type": "string", "description": "Maximum results per source"},
+		},
+		"required": []string{"query"},
+	}
+}
+
+// ExecuteMCPTool implements the MCP tool execution
+func (h *SearchAllHandler) ExecuteMCPTool(ctx context.Context, req *mcp.CallToolRequest, input SearchAllInput) (*mcp.CallToolResult, SearchAllOutput, error) {
+	// Fall back to the default store configured through the environment
+	storeconfig.ApplyDefaults(&input.BaseURL, &input.ConsumerKey, &input.ConsumerSecret)
+	storeconfig.ApplyDefaultBaseURL(&input.WordPressBaseURL)
+
+	request := &search_all.SearchAllRequest{
+		Query:            input.Query,
+		BaseURL:          input.BaseURL,
+		ConsumerKey:      input.ConsumerKey,
+		ConsumerSecret:   input.ConsumerSecret,
+		WordPressBaseURL: input.WordPressBaseURL,
+		PerPage:          input.PerPage,
+	}
+
+	// The product searcher needs a client for the store; without
+	// credentials products are not searched and it stays unused
+	var productSearcher search_all.ProductSearcher
+	if request.SearchesProducts() {
+		ctx = storeconfig.WithStore(ctx, storeconfig.Store{
+			BaseURL:        input.BaseURL,
+			ConsumerKey:    input.ConsumerKey,
+			ConsumerSecret: input.ConsumerSecret,
+		})
+		config, err := woocommerce.NewConfigFromContext(ctx)
+		if err != nil {
+			return nil, SearchAllOutput{}, err
+		}
+		productSearcher = search_products.NewProductSearcher(woocommerce.NewRepository(woocommerce.NewCachedClient(config)))
+	}
+	postSearcher := search_posts.NewPostSearcher(nil) // The post searcher creates its own repository
+
+	// Bound the whole tool call, across all of its API requests
+	ctx, cancel := tooltimeout.WithTimeout(ctx)
+	defer cancel()
+
+	// Execute search
+	searcher := search_all.NewCombinedSearcher(productSearcher, postSearcher)
+	response, err := searcher.Execute(ctx, request)
+	if err != nil {
+		return nil, SearchAllOutput{}, tooltimeout.Err(ctx, "search_all", fmt.Errorf("failed to search: %w", err))
+	}
+
+	// Convert response to JSON
+	now := time.Now()
+	for _, item := range response.Items {
+		if item.Product != nil {
+			item.Product.DescribeSale(now)
+		}
+	}
+	jsonData, err := response.ToJSON()
+	if err != nil {
+		return nil, SearchAllOutput{}, fmt.Errorf("failed to serialize response: %w", err)
+	}
+
+	// Create human-readable message
+	var found []string
+	var notes []string
+	for _, source := range []struct {
+		name    string
+		summary search_all.SourceDTO
+	}{
+		{"product", response.Sources.Products},
+		{"post", response.Sources.Posts},
+	} {
+		switch {
+		case !source.summary.Searched:
+			notes = append(notes, fmt.Sprintf("%ss not searched", source.name))
+		case source.summary.Error != "":
+			notes = append(notes, fmt.Sprintf("%s search failed: %s", source.name, source.summary.Error))
+		default:
+			found = append(found, fmt.Sprintf("%d %s(s) (%d total)", source.summary.Returned, source.name, source.summary.TotalCount))
+		}
+	}
+	message := fmt.Sprintf("Found %s for '%s'", strings.Join(found, " and "), response.Query)
+	if len(notes) > 0 {
+		message += fmt.Sprintf(" (%s)", strings.Join(notes, "; "))
+	}
+
+	return nil, SearchAllOutput{
+		Message: message,
+		Data:    jsonData,
+	}, nil
+}
@@ -0,0 +1,33 @@
+package server_status
+
+import "encoding/json"
+
+// ServerStatusResponse describes the running MCP server
+type ServerStatusResponse struct {
+	Name    string    `json:"name"`
+	Version string    `json:"version"`
+	Build   *BuildDTO `json:"build,omitempty"`
+	Tools   []string  `json:"tools"`
+
+	// SafeMode is set when the tools only return published content
+	SafeMode bool `json:"safe_mode"`
+}
+
+// BuildDTO holds the build information embedded in the binary. VCS fields
+// are empty when the binary was built outside a checkout.
+type BuildDTO struct {
+	GoVersion     string `json:"go_version"`
+	ModuleVersion string `json:"module_version,omitempty"`
+	Commit        string `json:"commit,omitempty"`
+	CommitTime    string `json:"commit_time,omitempty"`
+	Modified      bool   `json:"modified,omitempty"`
+}
+
+// ToJSON converts the response to JSON string
+func (r *ServerStatusResponse) ToJSON() (string, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,70 @@
+package server_status
+
+import (
+	"runtime/debug"
+	"sort"
+	"woocommerce-mcp/kit/safemode"
+)
+
+// ToolLister returns the names of the tools the server currently exposes
+type ToolLister func() []string
+
+// StatusReporter reports the version, build and tools of the server. It
+// needs no store, so it also works when no store is reachable.
+type StatusReporter struct {
+	name    string
+	version string
+	tools   ToolLister
+}
+
+// NewStatusReporter creates a new StatusReporter for the server with the
+// given name and version
+func NewStatusReporter(name, version string, tools ToolLister) *StatusReporter {
+	return &StatusReporter{
+		name:    name,
+		version: version,
+		tools:   tools,
+	}
+}
+
+// Execute builds the status of the server. Tool names are sorted.
+func (r *StatusReporter) Execute() *ServerStatusResponse {
+	tools := []string{}
+	if r.tools != nil {
+		tools = append(tools, r.tools()...)
+	}
+	sort.Strings(tools)
+
+	return &ServerStatusResponse{
+		Name:    r.name,
+		Version: r.version,
+		Build:   readBuild(),
+		Tools:   tools,
+
+		SafeMode: safemode.Enabled(),
+	}
+}
+
+// build reads the build information of the binary, if it has any
+func readBuild() *BuildDTO {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+
+	build := &BuildDTO{
+		GoVersion:     info.GoVersion,
+		ModuleVersion: info.Main.Version,
+	}
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			build.Commit = setting.Value
+		case "vcs.time":
+			build.CommitTime = setting.Value
+		case "vcs.modified":
+			build.Modified = setting.Value == "true"
+		}
+	}
+	return build
+}
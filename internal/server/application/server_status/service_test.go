@@ -0,0 +1,44 @@
+package server_status
+
+import (
+	"encoding/json"
+	"testing"
+
+	"woocommerce-mcp/kit/safemode"
+)
+
+func TestStatusReportsVersionAndSortedTools(t *testing.T) {
+	t.Setenv(safemode.Env, "true")
+	reporter := NewStatusReporter("woocommerce-mcp", "1.2.3", func() []string {
+		return []string{"search_products", "search_posts", "get_product"}
+	})
+
+	status := reporter.Execute()
+	if status.Name != "woocommerce-mcp" || status.Version != "1.2.3" {
+		t.Errorf("server = %s %s, want woocommerce-mcp 1.2.3", status.Name, status.Version)
+	}
+	if want := []string{"get_product", "search_posts", "search_products"}; len(status.Tools) != 3 || status.Tools[0] != want[0] || status.Tools[2] != want[2] {
+		t.Errorf("tools = %v, want %v", status.Tools, want)
+	}
+	if !status.SafeMode {
+		t.Error("safe mode is not reported")
+	}
+	// test binaries carry build information
+	if status.Build == nil || status.Build.GoVersion == "" {
+		t.Errorf("build = %+v, want the Go version at least", status.Build)
+	}
+}
+
+func TestStatusWithoutToolsHasAnEmptyList(t *testing.T) {
+	data, err := NewStatusReporter("woocommerce-mcp", "1.2.3", nil).Execute().ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON: %v", err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal([]byte(data), &decoded); err != nil {
+		t.Fatalf("status is not valid JSON: %v", err)
+	}
+	if tools, ok := decoded["tools"].([]interface{}); !ok || len(tools) != 0 {
+		t.Errorf("tools = %v, want an empty list rather than null", decoded["tools"])
+	}
+}
@@ -0,0 +1,68 @@
+package presentation
+
+import (
+	"context"
+	"fmt"
+
+	"woocommerce-mcp/internal/server/application/server_status"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ServerStatusInput defines the input structure for the server_status tool,
+// which takes no arguments
+type ServerStatusInput struct{}
+
+// ServerStatusOutput defines the output structure for the server_status tool
+type ServerStatusOutput struct {
+	Message string `json:"message" jsonschema:"Human-readable message about the server"`
+	Data    string `json:"data" jsonschema:"JSON-formatted server status"`
+}
+
+// ServerStatusHandler handles server_status tool calls
+type ServerStatusHandler struct {
+	reporter *server_status.StatusReporter
+}
+
+// NewServerStatusHandler creates a new ServerStatusHandler for the server
+// implementation; tools lists the names of the tools it exposes
+func NewServerStatusHandler(implementation *mcp.Implementation, tools server_status.ToolLister) *ServerStatusHandler {
+	return &ServerStatusHandler{
+		reporter: server_status.NewStatusReporter(implementation.Name, implementation.Version, tools),
+	}
+}
+
+// ServerStatusDescription describes the server_status tool
+const ServerStatusDescription = "Report the name, version and build (commit and date) of this MCP server and the names of the tools it exposes, e.g. to confirm a deployment. Needs no store credentials."
+
+// GetToolDefinition returns the MCP tool definition for server_status
+func (h *ServerStatusHandler) GetToolDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "server_status",
+		Description: ServerStatusDescription,
+	}
+}
+
+// GetInputSchema returns the input schema for the JSON-RPC tools/list endpoint
+func (h *ServerStatusHandler) GetInputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{},
+	}
+}
+
+// ExecuteMCPTool implements the MCP tool execution
+func (h *ServerStatusHandler) ExecuteMCPTool(ctx context.Context, req *mcp.CallToolRequest, input ServerStatusInput) (*mcp.CallToolResult, ServerStatusOutput, error) {
+	response := h.reporter.Execute()
+
+	// Convert response to JSON
+	jsonData, err := response.ToJSON()
+	if err != nil {
+		return nil, ServerStatusOutput{}, fmt.Errorf("failed to serialize response: %w", err)
+	}
+
+	return nil, ServerStatusOutput{
+		Message: fmt.Sprintf("%s %s exposes %d tool(s)", response.Name, response.Version, len(response.Tools)),
+		Data:    jsonData,
+	}, nil
+}
@@ -0,0 +1,8 @@
+package get_store_config
+
+// GetRequest represents a request for the checkout configuration of a store
+type GetRequest struct {
+	BaseURL        string `json:"base_url"`
+	ConsumerKey    string `json:"consumer_key"`
+	ConsumerSecret string `json:"consumer_secret"`
+}
@@ -0,0 +1,65 @@
+package get_store_config
+
+import (
+	"encoding/json"
+	"woocommerce-mcp/internal/store/domain"
+)
+
+// StoreConfigResponse represents the enabled payment gateways and the
+// shipping methods of a store
+type StoreConfigResponse struct {
+	PaymentGateways []*PaymentGatewayDTO `json:"payment_gateways"`
+	ShippingMethods []*ShippingMethodDTO `json:"shipping_methods"`
+}
+
+// PaymentGatewayDTO represents a payment gateway in the response
+type PaymentGatewayDTO struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Enabled     bool   `json:"enabled"`
+}
+
+// ShippingMethodDTO represents a shipping method definition in the response
+type ShippingMethodDTO struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+}
+
+// ToJSON converts the response to JSON string
+func (r *StoreConfigResponse) ToJSON() (string, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// FromDomainStoreConfig converts a domain store config to a response DTO,
+// keeping only the gateways customers can pay with
+func FromDomainStoreConfig(config *domain.StoreConfig) *StoreConfigResponse {
+	enabled := config.EnabledPaymentGateways()
+	response := &StoreConfigResponse{
+		PaymentGateways: make([]*PaymentGatewayDTO, 0, len(enabled)),
+		ShippingMethods: make([]*ShippingMethodDTO, 0, len(config.ShippingMethods)),
+	}
+
+	for _, gateway := range enabled {
+		response.PaymentGateways = append(response.PaymentGateways, &PaymentGatewayDTO{
+			ID:          gateway.ID,
+			Title:       gateway.Title,
+			Description: gateway.Description,
+			Enabled:     gateway.Enabled,
+		})
+	}
+	for _, method := range config.ShippingMethods {
+		response.ShippingMethods = append(response.ShippingMethods, &ShippingMethodDTO{
+			ID:          method.ID,
+			Title:       method.Title,
+			Description: method.Description,
+		})
+	}
+
+	return response
+}
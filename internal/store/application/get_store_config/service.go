@@ -0,0 +1,47 @@
+package get_store_config
+
+import (
+	"context"
+	"fmt"
+	"woocommerce-mcp/internal/store/domain"
+)
+
+// StoreConfigGetter handles store checkout configuration lookups
+type StoreConfigGetter struct {
+	repository domain.StoreConfigRepository
+}
+
+// NewStoreConfigGetter creates a new StoreConfigGetter
+func NewStoreConfigGetter(repository domain.StoreConfigRepository) *StoreConfigGetter {
+	return &StoreConfigGetter{
+		repository: repository,
+	}
+}
+
+// Execute returns the enabled payment gateways and the shipping methods of the store
+func (g *StoreConfigGetter) Execute(ctx context.Context, req *GetRequest) (*StoreConfigResponse, error) {
+	if err := validateRequest(req); err != nil {
+		return nil, err
+	}
+
+	config, err := g.repository.GetStoreConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get store config: %w", err)
+	}
+
+	return FromDomainStoreConfig(config), nil
+}
+
+// validateRequest checks that the store credentials are present
+func validateRequest(req *GetRequest) error {
+	if req.BaseURL == "" {
+		return domain.NewValidationError("base_url is required")
+	}
+	if req.ConsumerKey == "" {
+		return domain.NewValidationError("consumer_key is required")
+	}
+	if req.ConsumerSecret == "" {
+		return domain.NewValidationError("consumer_secret is required")
+	}
+	return nil
+}
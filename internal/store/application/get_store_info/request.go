@@ -0,0 +1,8 @@
+package get_store_info
+
+// GetRequest represents a request for general store information
+type GetRequest struct {
+	BaseURL        string `json:"base_url"`
+	ConsumerKey    string `json:"consumer_key"`
+	ConsumerSecret string `json:"consumer_secret"`
+}
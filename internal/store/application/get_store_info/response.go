@@ -0,0 +1,55 @@
+package get_store_info
+
+import (
+	"encoding/json"
+	"woocommerce-mcp/internal/store/domain"
+)
+
+// StoreInfoResponse represents general information about a store
+type StoreInfoResponse struct {
+	Source             string `json:"source"`
+	WooCommerceVersion string `json:"woocommerce_version,omitempty"`
+	WordPressVersion   string `json:"wordpress_version,omitempty"`
+	HomeURL            string `json:"home_url,omitempty"`
+	ThemeName          string `json:"theme_name,omitempty"`
+	ThemeVersion       string `json:"theme_version,omitempty"`
+	Currency           string `json:"currency"`
+	CurrencySymbol     string `json:"currency_symbol,omitempty"`
+	CurrencyPosition   string `json:"currency_position,omitempty"`
+	DecimalSeparator   string `json:"decimal_separator"`
+	ThousandSeparator  string `json:"thousand_separator"`
+	Decimals           int    `json:"decimals"`
+	DefaultCountry     string `json:"default_country,omitempty"`
+	CalcTaxes          string `json:"calc_taxes,omitempty"`
+	Degraded           bool   `json:"degraded"`
+}
+
+// ToJSON converts the response to JSON string
+func (r *StoreInfoResponse) ToJSON() (string, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// FromDomainStoreInfo converts domain store info to a response DTO
+func FromDomainStoreInfo(info *domain.StoreInfo) *StoreInfoResponse {
+	return &StoreInfoResponse{
+		Source:             string(info.Source),
+		WooCommerceVersion: info.WooCommerceVersion,
+		WordPressVersion:   info.WordPressVersion,
+		HomeURL:            info.HomeURL,
+		ThemeName:          info.ThemeName,
+		ThemeVersion:       info.ThemeVersion,
+		Currency:           info.Currency,
+		CurrencySymbol:     info.CurrencySymbol,
+		CurrencyPosition:   info.CurrencyPosition,
+		DecimalSeparator:   info.DecimalSeparator,
+		ThousandSeparator:  info.ThousandSeparator,
+		Decimals:           info.Decimals,
+		DefaultCountry:     info.DefaultCountry,
+		CalcTaxes:          info.CalcTaxes,
+		Degraded:           info.IsDegraded(),
+	}
+}
@@ -0,0 +1,47 @@
+package get_store_info
+
+import (
+	"context"
+	"fmt"
+	"woocommerce-mcp/internal/store/domain"
+)
+
+// StoreInfoGetter handles store info lookups
+type StoreInfoGetter struct {
+	repository domain.StoreInfoRepository
+}
+
+// NewStoreInfoGetter creates a new StoreInfoGetter
+func NewStoreInfoGetter(repository domain.StoreInfoRepository) *StoreInfoGetter {
+	return &StoreInfoGetter{
+		repository: repository,
+	}
+}
+
+// Execute returns general information about the store
+func (g *StoreInfoGetter) Execute(ctx context.Context, req *GetRequest) (*StoreInfoResponse, error) {
+	if err := validateRequest(req); err != nil {
+		return nil, err
+	}
+
+	info, err := g.repository.GetStoreInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get store info: %w", err)
+	}
+
+	return FromDomainStoreInfo(info), nil
+}
+
+// validateRequest checks that the store credentials are present
+func validateRequest(req *GetRequest) error {
+	if req.BaseURL == "" {
+		return domain.NewValidationError("base_url is required")
+	}
+	if req.ConsumerKey == "" {
+		return domain.NewValidationError("consumer_key is required")
+	}
+	if req.ConsumerSecret == "" {
+		return domain.NewValidationError("consumer_secret is required")
+	}
+	return nil
+}
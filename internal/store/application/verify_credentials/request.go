@@ -0,0 +1,8 @@
+package verify_credentials
+
+// VerifyRequest represents a request to verify store credentials
+type VerifyRequest struct {
+	BaseURL        string `json:"base_url"`
+	ConsumerKey    string `json:"consumer_key"`
+	ConsumerSecret string `json:"consumer_secret"`
+}
@@ -0,0 +1,33 @@
+package verify_credentials
+
+import (
+	"encoding/json"
+	"woocommerce-mcp/internal/store/domain"
+)
+
+// VerifyResponse represents the outcome of a credential check
+type VerifyResponse struct {
+	Valid      bool   `json:"valid"`
+	Reason     string `json:"reason,omitempty"`
+	StatusCode int    `json:"status_code,omitempty"`
+	Detail     string `json:"detail,omitempty"`
+}
+
+// ToJSON converts the response to JSON string
+func (r *VerifyResponse) ToJSON() (string, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// FromDomainCredentialCheck converts a domain credential check to a response DTO
+func FromDomainCredentialCheck(check *domain.CredentialCheck) *VerifyResponse {
+	return &VerifyResponse{
+		Valid:      check.Valid,
+		Reason:     string(check.Reason),
+		StatusCode: check.StatusCode,
+		Detail:     check.Detail,
+	}
+}
@@ -0,0 +1,39 @@
+package verify_credentials
+
+import (
+	"context"
+	"fmt"
+	"woocommerce-mcp/internal/store/domain"
+)
+
+// CredentialChecker handles credential verification
+type CredentialChecker struct {
+	verifier domain.CredentialVerifier
+}
+
+// NewCredentialChecker creates a new CredentialChecker
+func NewCredentialChecker(verifier domain.CredentialVerifier) *CredentialChecker {
+	return &CredentialChecker{
+		verifier: verifier,
+	}
+}
+
+// Execute verifies the credentials of the request
+func (c *CredentialChecker) Execute(ctx context.Context, req *VerifyRequest) (*VerifyResponse, error) {
+	if req.BaseURL == "" {
+		return nil, domain.NewValidationError("base_url is required")
+	}
+	if req.ConsumerKey == "" {
+		return nil, domain.NewValidationError("consumer_key is required")
+	}
+	if req.ConsumerSecret == "" {
+		return nil, domain.NewValidationError("consumer_secret is required")
+	}
+
+	check, err := c.verifier.VerifyCredentials(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify credentials: %w", err)
+	}
+
+	return FromDomainCredentialCheck(check), nil
+}
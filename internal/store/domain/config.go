@@ -0,0 +1,35 @@
+package domain
+
+// PaymentGateway represents a payment gateway installed on the store
+type PaymentGateway struct {
+	ID          string
+	Title       string
+	Description string
+	Enabled     bool
+}
+
+// ShippingMethod represents a shipping method type the store offers. It is
+// a definition only; whether it applies to an order depends on the shipping
+// zones it has been added to.
+type ShippingMethod struct {
+	ID          string
+	Title       string
+	Description string
+}
+
+// StoreConfig represents the checkout configuration of a store
+type StoreConfig struct {
+	PaymentGateways []*PaymentGateway
+	ShippingMethods []*ShippingMethod
+}
+
+// EnabledPaymentGateways returns the gateways customers can pay with
+func (c *StoreConfig) EnabledPaymentGateways() []*PaymentGateway {
+	enabled := make([]*PaymentGateway, 0, len(c.PaymentGateways))
+	for _, gateway := range c.PaymentGateways {
+		if gateway.Enabled {
+			enabled = append(enabled, gateway)
+		}
+	}
+	return enabled
+}
@@ -0,0 +1,38 @@
+package domain
+
+// CredentialFailureReason explains why a credential check failed
+type CredentialFailureReason string
+
+const (
+	// ReasonInvalidKey means the consumer key or secret was rejected
+	ReasonInvalidKey CredentialFailureReason = "invalid_key"
+	// ReasonInsufficientPermissions means the key is valid but may not read products
+	ReasonInsufficientPermissions CredentialFailureReason = "insufficient_permissions"
+	// ReasonStoreUnreachable means the store or its REST API could not be reached
+	ReasonStoreUnreachable CredentialFailureReason = "store_unreachable"
+)
+
+// CredentialCheck represents the outcome of verifying store credentials
+type CredentialCheck struct {
+	Valid      bool
+	Reason     CredentialFailureReason
+	StatusCode int
+	Detail     string
+}
+
+// NewValidCredentialCheck creates a successful credential check
+func NewValidCredentialCheck(statusCode int) *CredentialCheck {
+	return &CredentialCheck{
+		Valid:      true,
+		StatusCode: statusCode,
+	}
+}
+
+// NewFailedCredentialCheck creates a failed credential check
+func NewFailedCredentialCheck(reason CredentialFailureReason, statusCode int, detail string) *CredentialCheck {
+	return &CredentialCheck{
+		Reason:     reason,
+		StatusCode: statusCode,
+		Detail:     detail,
+	}
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// StoreError represents a domain error for store info
+type StoreError struct {
+	Code    string
+	Message string
+	Type    string
+	// StatusCode is the upstream HTTP status of API errors
+	StatusCode int
+}
+
+func (e *StoreError) Error() string {
+	return fmt.Sprintf("[%s] %s: %s", e.Type, e.Code, e.Message)
+}
+
+// ErrorCode returns the machine-readable error code
+func (e *StoreError) ErrorCode() string {
+	return e.Code
+}
+
+// ErrorType returns the error type
+func (e *StoreError) ErrorType() string {
+	return e.Type
+}
+
+// HTTPStatus returns the upstream HTTP status, or 0 when there is none
+func (e *StoreError) HTTPStatus() int {
+	return e.StatusCode
+}
+
+// NewValidationError creates a new validation error
+func NewValidationError(message string) *StoreError {
+	return &StoreError{
+		Code:    "VALIDATION_ERROR",
+		Message: message,
+		Type:    "ValidationError",
+	}
+}
+
+// NewWooCommerceAPIError creates a new WooCommerce API error
+func NewWooCommerceAPIError(statusCode int, message, code string) *StoreError {
+	return &StoreError{
+		Code:       fmt.Sprintf("WOOCOMMERCE_API_ERROR_%d", statusCode),
+		Message:    fmt.Sprintf("WooCommerce API error (status %d): %s", statusCode, message),
+		Type:       "WooCommerceAPIError",
+		StatusCode: statusCode,
+	}
+}
+
+// NewConnectionError creates a new connection error
+func NewConnectionError(url, message string) *StoreError {
+	return &StoreError{
+		Code:    "CONNECTION_ERROR",
+		Message: fmt.Sprintf("connection error to %s: %s", url, message),
+		Type:    "ConnectionError",
+	}
+}
+
+// NewForbiddenError creates an error for API keys lacking the permissions a route requires
+func NewForbiddenError(route string) *StoreError {
+	return &StoreError{
+		Code:    "FORBIDDEN",
+		Message: fmt.Sprintf("the API key is not allowed to read %s; it must belong to an administrator and have read access", route),
+		Type:    "ForbiddenError",
+	}
+}
+
+// IsForbiddenError reports whether err is a ForbiddenError
+func IsForbiddenError(err error) bool {
+	var storeErr *StoreError
+	return errors.As(err, &storeErr) && storeErr.Type == "ForbiddenError"
+}
@@ -0,0 +1,24 @@
+package domain
+
+import "context"
+
+// StoreInfoRepository defines the interface for store info data access
+type StoreInfoRepository interface {
+	// GetStoreInfo returns general information about the store
+	GetStoreInfo(ctx context.Context) (*StoreInfo, error)
+}
+
+// CredentialVerifier defines the interface for checking store credentials
+type CredentialVerifier interface {
+	// VerifyCredentials makes a minimal authenticated request and reports
+	// whether the credentials are accepted. Failures to verify are reported
+	// in the returned check rather than as an error.
+	VerifyCredentials(ctx context.Context) (*CredentialCheck, error)
+}
+
+// StoreConfigRepository defines the interface for checkout configuration data access
+type StoreConfigRepository interface {
+	// GetStoreConfig returns the payment gateways and shipping methods of
+	// the store. Both routes require an API key owned by an administrator.
+	GetStoreConfig(ctx context.Context) (*StoreConfig, error)
+}
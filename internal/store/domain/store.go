@@ -0,0 +1,41 @@
+package domain
+
+// InfoSource identifies which endpoint the store info was read from
+type InfoSource string
+
+const (
+	// SourceSystemStatus means the full system status report was available
+	SourceSystemStatus InfoSource = "system_status"
+	// SourceGeneralSettings means only the general settings could be read
+	SourceGeneralSettings InfoSource = "settings_general"
+)
+
+// StoreInfo represents general information about a WooCommerce store
+type StoreInfo struct {
+	Source InfoSource
+
+	// Environment (only available from the system status report)
+	WooCommerceVersion string
+	WordPressVersion   string
+	HomeURL            string
+	ThemeName          string
+	ThemeVersion       string
+
+	// Currency and price formatting
+	Currency          string
+	CurrencySymbol    string
+	CurrencyPosition  string
+	DecimalSeparator  string
+	ThousandSeparator string
+	Decimals          int
+
+	// Location and taxes
+	DefaultCountry string
+	CalcTaxes      string
+}
+
+// IsDegraded reports whether the info was read from the general settings
+// fallback and therefore lacks version and theme details
+func (s *StoreInfo) IsDegraded() bool {
+	return s.Source != SourceSystemStatus
+}
@@ -0,0 +1,218 @@
+package woocommerce
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+	"woocommerce-mcp/internal/store/domain"
+	"woocommerce-mcp/kit/contentencoding"
+	"woocommerce-mcp/kit/httpjson"
+	"woocommerce-mcp/kit/requestid"
+	"woocommerce-mcp/kit/storehttp"
+)
+
+const (
+	// systemStatusRoute is the REST route of the system status report
+	systemStatusRoute = "wc/v3/system_status"
+	// generalSettingsRoute is the REST route of the general settings group
+	generalSettingsRoute = "wc/v3/settings/general"
+	// productsRoute is the REST route used to probe credentials
+	productsRoute = "wc/v3/products"
+	// paymentGatewaysRoute is the REST route of the payment gateways
+	paymentGatewaysRoute = "wc/v3/payment_gateways"
+	// shippingMethodsRoute is the REST route of the shipping method definitions
+	shippingMethodsRoute = "wc/v3/shipping_methods"
+)
+
+// Config represents WooCommerce API configuration
+type Config struct {
+	BaseURL        string
+	ConsumerKey    string
+	ConsumerSecret string
+	Timeout        time.Duration
+	// ProxyURL routes outbound requests through an HTTP proxy. When empty,
+	// the standard HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment is used.
+	ProxyURL string
+}
+
+// NewConfig creates a new WooCommerce configuration
+func NewConfig(baseURL, consumerKey, consumerSecret string) *Config {
+	return &Config{
+		BaseURL:        storehttp.NormalizeBaseURL(baseURL),
+		ConsumerKey:    consumerKey,
+		ConsumerSecret: consumerSecret,
+		Timeout:        30 * time.Second,
+		ProxyURL:       storehttp.ProxyURL(),
+	}
+}
+
+// Client represents a WooCommerce API client for store-level information
+type Client struct {
+	config     *Config
+	httpClient *http.Client
+}
+
+// NewClient creates a new WooCommerce store client
+func NewClient(config *Config) *Client {
+	return &Client{
+		config: config,
+		httpClient: &http.Client{
+			Timeout:   config.Timeout,
+			Transport: requestid.NewTransport(contentencoding.NewTransport(storehttp.NewTransport(config.ProxyURL))),
+		},
+	}
+}
+
+// GetSystemStatus retrieves the system status report. The route requires an
+// API key owned by an administrator.
+func (c *Client) GetSystemStatus(ctx context.Context) (*APISystemStatus, error) {
+	var status APISystemStatus
+	if err := c.getJSON(ctx, systemStatusRoute, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// GetGeneralSettings retrieves the general settings group
+func (c *Client) GetGeneralSettings(ctx context.Context) ([]APISetting, error) {
+	var settings []APISetting
+	if err := c.getJSON(ctx, generalSettingsRoute, &settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// GetPaymentGateways retrieves every installed payment gateway, enabled or
+// not. The route requires an API key owned by an administrator.
+func (c *Client) GetPaymentGateways(ctx context.Context) ([]APIPaymentGateway, error) {
+	var gateways []APIPaymentGateway
+	if err := c.getJSON(ctx, paymentGatewaysRoute, &gateways); err != nil {
+		return nil, err
+	}
+	return gateways, nil
+}
+
+// GetShippingMethods retrieves the shipping method definitions. The route
+// requires an API key owned by an administrator.
+func (c *Client) GetShippingMethods(ctx context.Context) ([]APIShippingMethod, error) {
+	var methods []APIShippingMethod
+	if err := c.getJSON(ctx, shippingMethodsRoute, &methods); err != nil {
+		return nil, err
+	}
+	return methods, nil
+}
+
+// VerifyCredentials requests a single product ID, the cheapest authenticated
+// read, and maps the response status to a credential check
+func (c *Client) VerifyCredentials(ctx context.Context) (*domain.CredentialCheck, error) {
+	u, err := c.buildURL(productsRoute)
+	if err != nil {
+		return nil, err
+	}
+
+	query := u.Query()
+	c.addAuthParams(query)
+	query.Set("per_page", "1")
+	query.Set("_fields", "id")
+	u.RawQuery = query.Encode()
+
+	// Any status is an answer; only a request that got none fails the check
+	_, err = httpjson.Client{Send: c.httpClient.Do}.DoJSON(ctx, "GET", u.String(), nil)
+	var statusErr *httpjson.StatusError
+	switch {
+	case err == nil:
+		return credentialCheckFromStatus(http.StatusOK, nil), nil
+	case errors.As(err, &statusErr):
+		return credentialCheckFromStatus(statusErr.StatusCode, statusErr.Body), nil
+	case ctx.Err() != nil:
+		return nil, ctx.Err()
+	default:
+		return domain.NewFailedCredentialCheck(domain.ReasonStoreUnreachable, 0, err.Error()), nil
+	}
+}
+
+// credentialCheckFromStatus maps the status of the probe request to a credential check
+func credentialCheckFromStatus(statusCode int, body []byte) *domain.CredentialCheck {
+	var apiError struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+	detail := http.StatusText(statusCode)
+	if err := json.Unmarshal(body, &apiError); err == nil && apiError.Message != "" {
+		detail = apiError.Message
+	}
+
+	switch {
+	case statusCode == http.StatusOK:
+		return domain.NewValidCredentialCheck(statusCode)
+	case statusCode == http.StatusUnauthorized:
+		return domain.NewFailedCredentialCheck(domain.ReasonInvalidKey, statusCode, detail)
+	case statusCode == http.StatusForbidden:
+		return domain.NewFailedCredentialCheck(domain.ReasonInsufficientPermissions, statusCode, detail)
+	case statusCode == http.StatusNotFound:
+		return domain.NewFailedCredentialCheck(domain.ReasonStoreUnreachable, statusCode,
+			"WooCommerce REST API not found at this URL — verify WooCommerce is active and pretty permalinks are enabled")
+	default:
+		return domain.NewFailedCredentialCheck(domain.ReasonStoreUnreachable, statusCode, detail)
+	}
+}
+
+// getJSON performs an authenticated GET request against a REST route and decodes the JSON body
+func (c *Client) getJSON(ctx context.Context, route string, out interface{}) error {
+	u, err := c.buildURL(route)
+	if err != nil {
+		return err
+	}
+
+	query := u.Query()
+	c.addAuthParams(query)
+	u.RawQuery = query.Encode()
+
+	_, err = c.api(route).DoJSON(ctx, "GET", u.String(), out)
+	return err
+}
+
+// api returns the JSON request helper of the client for a REST route.
+// Errors name the endpoint without its query string, which holds the
+// credentials.
+func (c *Client) api(route string) httpjson.Client {
+	return httpjson.Client{
+		Send: c.httpClient.Do,
+		ConnectionError: func(url string, err error) error {
+			return domain.NewConnectionError(url, fmt.Sprintf("HTTP request failed: %v", err))
+		},
+		StatusError: func(statusCode int, _ http.Header, body []byte) error {
+			return c.handleAPIError(route, statusCode, body)
+		},
+	}
+}
+
+// buildURL resolves a REST route against the base URL, appending it under
+// wp-json relative to any path prefix of the store
+func (c *Client) buildURL(route string) (*url.URL, error) {
+	u, err := storehttp.RouteURL(c.config.BaseURL, route)
+	if err != nil {
+		return nil, domain.NewConnectionError(c.config.BaseURL, err.Error())
+	}
+	return u, nil
+}
+
+// addAuthParams adds authentication parameters to the query
+func (c *Client) addAuthParams(query url.Values) {
+	query.Set("consumer_key", c.config.ConsumerKey)
+	query.Set("consumer_secret", c.config.ConsumerSecret)
+}
+
+// handleAPIError handles API errors and converts them to domain errors
+func (c *Client) handleAPIError(route string, statusCode int, body []byte) error {
+	if statusCode == http.StatusForbidden {
+		return domain.NewForbiddenError(route)
+	}
+
+	message, code := httpjson.ErrorMessage(statusCode, body)
+	return domain.NewWooCommerceAPIError(statusCode, message, code)
+}
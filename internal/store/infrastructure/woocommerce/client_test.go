@@ -0,0 +1,91 @@
+package woocommerce
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"woocommerce-mcp/internal/store/domain"
+	"woocommerce-mcp/kit/httpjson"
+)
+
+func TestConnectionErrorsHideTheCredentials(t *testing.T) {
+	// A closed server refuses the connection
+	server := httptest.NewServer(nil)
+	server.Close()
+
+	client := NewClient(NewConfig(server.URL, "ck_visible", "cs_secret"))
+	if _, err := client.GetGeneralSettings(context.Background()); err == nil || strings.Contains(err.Error(), "cs_secret") {
+		t.Errorf("settings error = %v, want one without the credentials", err)
+	}
+
+	check, err := client.VerifyCredentials(context.Background())
+	if err != nil {
+		t.Fatalf("verify credentials: %v", err)
+	}
+	if check.Valid || check.Reason != domain.ReasonStoreUnreachable {
+		t.Errorf("closed store: got %+v, want store_unreachable", check)
+	}
+	if detail := check.Detail; strings.Contains(detail, "cs_secret") {
+		t.Errorf("credential check detail leaks the credentials: %s", detail)
+	}
+}
+
+// answering starts a store answering every request with the status and body given
+func answering(t *testing.T, status int, contentType, body string) *Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return NewClient(NewConfig(server.URL, "ck", "cs"))
+}
+
+func TestForbiddenRouteNamesTheRoute(t *testing.T) {
+	client := answering(t, http.StatusForbidden, "application/json", `{"code":"woocommerce_rest_cannot_view","message":"Sorry, you cannot list resources."}`)
+
+	_, err := client.GetSystemStatus(context.Background())
+	if !domain.IsForbiddenError(err) || !strings.Contains(err.Error(), "system_status") {
+		t.Errorf("got error %v, want a forbidden error naming system_status", err)
+	}
+}
+
+func TestNonJSONResponseIsReported(t *testing.T) {
+	client := answering(t, http.StatusOK, "text/html; charset=UTF-8", "<html><body>Maintenance</body></html>")
+
+	_, err := client.GetGeneralSettings(context.Background())
+	var notJSON *httpjson.NotJSONError
+	if !errors.As(err, &notJSON) {
+		t.Errorf("got error %v, want a NotJSONError", err)
+	}
+}
+
+func TestVerifyCredentialsMapsStatus(t *testing.T) {
+	tests := []struct {
+		status int
+		valid  bool
+		reason domain.CredentialFailureReason
+	}{
+		{http.StatusOK, true, ""},
+		{http.StatusUnauthorized, false, domain.ReasonInvalidKey},
+		{http.StatusForbidden, false, domain.ReasonInsufficientPermissions},
+		{http.StatusNotFound, false, domain.ReasonStoreUnreachable},
+		{http.StatusInternalServerError, false, domain.ReasonStoreUnreachable},
+		{http.StatusServiceUnavailable, false, domain.ReasonStoreUnreachable},
+	}
+	for _, tt := range tests {
+		client := answering(t, tt.status, "application/json", `[]`)
+		check, err := client.VerifyCredentials(context.Background())
+		if err != nil {
+			t.Fatalf("status %d: %v", tt.status, err)
+		}
+		if check.Valid != tt.valid || check.Reason != tt.reason {
+			t.Errorf("status %d: got %+v, want valid %v reason %q", tt.status, check, tt.valid, tt.reason)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package woocommerce
+
+import (
+	"context"
+	"strconv"
+	"woocommerce-mcp/internal/store/domain"
+)
+
+// Repository implements the domain StoreInfoRepository, CredentialVerifier
+// and StoreConfigRepository interfaces
+type Repository struct {
+	client *Client
+}
+
+// NewRepository creates a new WooCommerce store info repository
+func NewRepository(client *Client) *Repository {
+	return &Repository{
+		client: client,
+	}
+}
+
+// GetStoreInfo reads the system status report, falling back to the general
+// settings when the API key is not allowed to read the report
+func (r *Repository) GetStoreInfo(ctx context.Context) (*domain.StoreInfo, error) {
+	status, err := r.client.GetSystemStatus(ctx)
+	if err == nil {
+		return systemStatusToDomain(status), nil
+	}
+	if !domain.IsForbiddenError(err) {
+		return nil, err
+	}
+
+	settings, err := r.client.GetGeneralSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return generalSettingsToDomain(settings), nil
+}
+
+// VerifyCredentials checks the credentials against the products route
+func (r *Repository) VerifyCredentials(ctx context.Context) (*domain.CredentialCheck, error) {
+	return r.client.VerifyCredentials(ctx)
+}
+
+// GetStoreConfig reads the payment gateways and the shipping method definitions
+func (r *Repository) GetStoreConfig(ctx context.Context) (*domain.StoreConfig, error) {
+	gateways, err := r.client.GetPaymentGateways(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	methods, err := r.client.GetShippingMethods(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &domain.StoreConfig{
+		PaymentGateways: make([]*domain.PaymentGateway, 0, len(gateways)),
+		ShippingMethods: make([]*domain.ShippingMethod, 0, len(methods)),
+	}
+	for _, gateway := range gateways {
+		title := gateway.Title
+		if title == "" {
+			// Gateways that were never configured have no customer-facing title
+			title = gateway.MethodTitle
+		}
+		config.PaymentGateways = append(config.PaymentGateways, &domain.PaymentGateway{
+			ID:          gateway.ID,
+			Title:       title,
+			Description: gateway.Description,
+			Enabled:     gateway.Enabled,
+		})
+	}
+	for _, method := range methods {
+		config.ShippingMethods = append(config.ShippingMethods, &domain.ShippingMethod{
+			ID:          method.ID,
+			Title:       method.Title,
+			Description: method.Description,
+		})
+	}
+
+	return config, nil
+}
+
+// systemStatusToDomain converts a system status report to store info
+func systemStatusToDomain(status *APISystemStatus) *domain.StoreInfo {
+	calcTaxes := "no"
+	if status.Settings.TaxesEnabled {
+		calcTaxes = "yes"
+	}
+
+	return &domain.StoreInfo{
+		Source:             domain.SourceSystemStatus,
+		WooCommerceVersion: status.Environment.Version,
+		WordPressVersion:   status.Environment.WPVersion,
+		HomeURL:            status.Environment.HomeURL,
+		ThemeName:          status.Theme.Name,
+		ThemeVersion:       status.Theme.Version,
+		Currency:           status.Settings.Currency,
+		CurrencySymbol:     status.Settings.CurrencySymbol,
+		CurrencyPosition:   status.Settings.CurrencyPosition,
+		DecimalSeparator:   status.Settings.DecimalSeparator,
+		ThousandSeparator:  status.Settings.ThousandSeparator,
+		Decimals:           status.Settings.NumberOfDecimals,
+		CalcTaxes:          calcTaxes,
+	}
+}
+
+// generalSettingsToDomain converts the general settings group to store info
+func generalSettingsToDomain(settings []APISetting) *domain.StoreInfo {
+	info := &domain.StoreInfo{
+		Source:            domain.SourceGeneralSettings,
+		DecimalSeparator:  ".",
+		ThousandSeparator: ",",
+		Decimals:          2,
+	}
+
+	for _, setting := range settings {
+		value := setting.StringValue()
+		switch setting.ID {
+		case "woocommerce_currency":
+			info.Currency = value
+		case "woocommerce_currency_pos":
+			info.CurrencyPosition = value
+		case "woocommerce_price_decimal_sep":
+			info.DecimalSeparator = value
+		case "woocommerce_price_thousand_sep":
+			info.ThousandSeparator = value
+		case "woocommerce_price_num_decimals":
+			if decimals, err := strconv.Atoi(value); err == nil && decimals >= 0 {
+				info.Decimals = decimals
+			}
+		case "woocommerce_default_country":
+			info.DefaultCountry = value
+		case "woocommerce_calc_taxes":
+			info.CalcTaxes = value
+		}
+	}
+
+	return info
+}
@@ -0,0 +1,139 @@
+package woocommerce
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"woocommerce-mcp/internal/store/domain"
+)
+
+// routedStore starts a store answering the routes given, by path suffix,
+// with a JSON body; other routes are forbidden
+func routedStore(t *testing.T, routes map[string]string) *Repository {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		for suffix, body := range routes {
+			if strings.HasSuffix(r.URL.Path, suffix) {
+				w.Write([]byte(body))
+				return
+			}
+		}
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"code":"woocommerce_rest_cannot_view","message":"Sorry, you cannot list resources."}`))
+	}))
+	t.Cleanup(server.Close)
+	return NewRepository(NewClient(NewConfig(server.URL, "ck", "cs")))
+}
+
+func TestStoreInfoFromSystemStatus(t *testing.T) {
+	repository := routedStore(t, map[string]string{
+		"/system_status": `{
+			"environment": {"home_url": "https://shop.example", "version": "8.9.1", "wp_version": "6.5.3"},
+			"theme": {"name": "Storefront", "version": "4.5.0"},
+			"settings": {"currency": "EUR", "currency_symbol": "€", "currency_position": "right_space",
+				"thousand_separator": ".", "decimal_separator": ",", "number_of_decimals": 2, "taxes_enabled": true}
+		}`,
+	})
+
+	info, err := repository.GetStoreInfo(context.Background())
+	if err != nil {
+		t.Fatalf("GetStoreInfo: %v", err)
+	}
+	want := domain.StoreInfo{
+		Source:             domain.SourceSystemStatus,
+		WooCommerceVersion: "8.9.1",
+		WordPressVersion:   "6.5.3",
+		HomeURL:            "https://shop.example",
+		ThemeName:          "Storefront",
+		ThemeVersion:       "4.5.0",
+		Currency:           "EUR",
+		CurrencySymbol:     "€",
+		CurrencyPosition:   "right_space",
+		DecimalSeparator:   ",",
+		ThousandSeparator:  ".",
+		Decimals:           2,
+		CalcTaxes:          "yes",
+	}
+	if *info != want {
+		t.Errorf("GetStoreInfo() = %+v, want %+v", *info, want)
+	}
+}
+
+func TestStoreInfoFallsBackToGeneralSettings(t *testing.T) {
+	repository := routedStore(t, map[string]string{
+		"/settings/general": `[
+			{"id": "woocommerce_currency", "value": "GBP"},
+			{"id": "woocommerce_default_country", "value": "GB:LND"},
+			{"id": "woocommerce_price_num_decimals", "value": "0"},
+			{"id": "woocommerce_calc_taxes", "value": "no"}
+		]`,
+	})
+
+	info, err := repository.GetStoreInfo(context.Background())
+	if err != nil {
+		t.Fatalf("GetStoreInfo: %v", err)
+	}
+	if info.Source != domain.SourceGeneralSettings || info.Currency != "GBP" || info.DefaultCountry != "GB:LND" || info.Decimals != 0 {
+		t.Errorf("GetStoreInfo() = %+v, want the general settings", info)
+	}
+	if info.WooCommerceVersion != "" {
+		t.Errorf("degraded info reports version %q", info.WooCommerceVersion)
+	}
+}
+
+func TestStoreInfoForbiddenNeedsAnAdministrator(t *testing.T) {
+	repository := routedStore(t, nil)
+
+	_, err := repository.GetStoreInfo(context.Background())
+	if !domain.IsForbiddenError(err) || !strings.Contains(err.Error(), "administrator") {
+		t.Errorf("got error %v, want a forbidden error asking for an administrator key", err)
+	}
+}
+
+func TestStoreConfigListsGatewaysAndShippingMethods(t *testing.T) {
+	repository := routedStore(t, map[string]string{
+		"/payment_gateways": `[
+			{"id": "bacs", "title": "Direct bank transfer", "description": "Pay by bank transfer.", "enabled": true, "method_title": "BACS"},
+			{"id": "cheque", "title": "Check payments", "enabled": false, "method_title": "Check payments"},
+			{"id": "cod", "title": "", "enabled": true, "method_title": "Cash on delivery"}
+		]`,
+		"/shipping_methods": `[
+			{"id": "flat_rate", "title": "Flat rate", "description": "A fixed rate."},
+			{"id": "local_pickup", "title": "Local pickup"}
+		]`,
+	})
+
+	config, err := repository.GetStoreConfig(context.Background())
+	if err != nil {
+		t.Fatalf("GetStoreConfig: %v", err)
+	}
+	if len(config.PaymentGateways) != 3 || len(config.ShippingMethods) != 2 {
+		t.Fatalf("config = %d gateways, %d methods; want 3 and 2", len(config.PaymentGateways), len(config.ShippingMethods))
+	}
+	if got := *config.PaymentGateways[0]; got != (domain.PaymentGateway{ID: "bacs", Title: "Direct bank transfer", Description: "Pay by bank transfer.", Enabled: true}) {
+		t.Errorf("first gateway = %+v", got)
+	}
+	if title := config.PaymentGateways[2].Title; title != "Cash on delivery" {
+		t.Errorf("untitled gateway title = %q, want its method title", title)
+	}
+	if enabled := config.EnabledPaymentGateways(); len(enabled) != 2 || enabled[1].ID != "cod" {
+		t.Errorf("enabled gateways = %+v, want bacs and cod", enabled)
+	}
+	if got := *config.ShippingMethods[1]; got != (domain.ShippingMethod{ID: "local_pickup", Title: "Local pickup"}) {
+		t.Errorf("second shipping method = %+v", got)
+	}
+}
+
+func TestStoreConfigForbiddenNeedsAnAdministrator(t *testing.T) {
+	// A shop manager key may list the gateways but not the shipping methods
+	repository := routedStore(t, map[string]string{"/payment_gateways": `[]`})
+
+	_, err := repository.GetStoreConfig(context.Background())
+	if !domain.IsForbiddenError(err) || !strings.Contains(err.Error(), "shipping_methods") || !strings.Contains(err.Error(), "administrator") {
+		t.Errorf("got error %v, want a forbidden error naming shipping_methods and asking for an administrator key", err)
+	}
+}
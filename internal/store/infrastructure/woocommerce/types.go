@@ -0,0 +1,70 @@
+package woocommerce
+
+import "fmt"
+
+// APISystemStatus represents the parts of the system status report used by store_info
+type APISystemStatus struct {
+	Environment APIEnvironment    `json:"environment"`
+	Theme       APITheme          `json:"theme"`
+	Settings    APIStatusSettings `json:"settings"`
+}
+
+// APIEnvironment represents the environment section of the system status report
+type APIEnvironment struct {
+	HomeURL   string `json:"home_url"`
+	SiteURL   string `json:"site_url"`
+	Version   string `json:"version"`
+	WPVersion string `json:"wp_version"`
+}
+
+// APITheme represents the active theme section of the system status report
+type APITheme struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// APIStatusSettings represents the settings section of the system status report
+type APIStatusSettings struct {
+	Currency          string `json:"currency"`
+	CurrencySymbol    string `json:"currency_symbol"`
+	CurrencyPosition  string `json:"currency_position"`
+	ThousandSeparator string `json:"thousand_separator"`
+	DecimalSeparator  string `json:"decimal_separator"`
+	NumberOfDecimals  int    `json:"number_of_decimals"`
+	TaxesEnabled      bool   `json:"taxes_enabled"`
+}
+
+// APISetting represents a single setting from a WooCommerce settings group
+type APISetting struct {
+	ID    string      `json:"id"`
+	Label string      `json:"label"`
+	Value interface{} `json:"value"`
+}
+
+// StringValue returns the setting value as a string
+func (s APISetting) StringValue() string {
+	switch value := s.Value.(type) {
+	case string:
+		return value
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// APIPaymentGateway represents a payment gateway from the WooCommerce API
+type APIPaymentGateway struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+	MethodTitle string `json:"method_title"`
+}
+
+// APIShippingMethod represents a shipping method definition from the WooCommerce API
+type APIShippingMethod struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
@@ -0,0 +1,119 @@
+package presentation
+
+import (
+	"context"
+	"fmt"
+
+	"woocommerce-mcp/internal/store/application/get_store_config"
+	"woocommerce-mcp/internal/store/infrastructure/woocommerce"
+	kitDomain "woocommerce-mcp/kit/domain"
+	"woocommerce-mcp/kit/storeconfig"
+	"woocommerce-mcp/kit/toolargs"
+	"woocommerce-mcp/kit/tooltimeout"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// StoreConfigInput defines the input structure for the store_config tool
+type StoreConfigInput struct {
+	BaseURL        string `json:"base_url,omitempty" jsonschema:"WooCommerce store base URL (e.g., https://example.com); defaults to WC_BASE_URL"`
+	ConsumerKey    string `json:"consumer_key,omitempty" jsonschema:"WooCommerce REST API consumer key; defaults to WC_CONSUMER_KEY for the default store"`
+	ConsumerSecret string `json:"consumer_secret,omitempty" jsonschema:"WooCommerce REST API consumer secret; defaults to WC_CONSUMER_SECRET for the default store"`
+}
+
+// UnmarshalJSON decodes the arguments, also accepting numbers and booleans
+// for the string fields
+func (in *StoreConfigInput) UnmarshalJSON(data []byte) error {
+	type plain StoreConfigInput
+	return toolargs.Unmarshal(data, (*plain)(in))
+}
+
+// StoreConfigOutput defines the output structure for the store_config tool
+type StoreConfigOutput struct {
+	Message string `json:"message" jsonschema:"Human-readable summary of the payment and shipping options"`
+	Data    string `json:"data" jsonschema:"JSON-formatted payment gateways and shipping methods"`
+}
+
+// StoreConfigHandler handles store_config tool calls
+type StoreConfigHandler struct{}
+
+// NewStoreConfigHandler creates a new StoreConfigHandler
+func NewStoreConfigHandler() *StoreConfigHandler {
+	return &StoreConfigHandler{}
+}
+
+// GetToolDefinition returns the MCP tool definition for store_config
+func (h *StoreConfigHandler) GetToolDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "store_config",
+		Description: "List how customers can pay and how orders can ship: the enabled payment gateways (title, description) and the shipping method definitions of a WooCommerce store. Requires an API key owned by an administrator.",
+		InputSchema: toolargs.InputSchema[StoreConfigInput](),
+	}
+}
+
+// GetInputSchema returns the input schema for the JSON-RPC tools/list endpoint
+func (h *StoreConfigHandler) GetInputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"base_url":        map[string]string{"type": "string", "description": "WooCommerce store base URL"},
+			"consumer_key":    map[string]string{"type": "string", "description": "WooCommerce REST API consumer key"},
+			"consumer_secret": map[string]string{"type": "string", "description": "WooCommerce REST API consumer secret"},
+		},
+		"required": storeconfig.RequiredFields("base_url", "consumer_key", "consumer_secret"),
+	}
+}
+
+// ExecuteMCPTool implements the MCP tool execution
+func (h *StoreConfigHandler) ExecuteMCPTool(ctx context.Context, req *mcp.CallToolRequest, input StoreConfigInput) (*mcp.CallToolResult, StoreConfigOutput, error) {
+	// Fall back to the default store configured through the environment
+	storeconfig.ApplyDefaults(&input.BaseURL, &input.ConsumerKey, &input.ConsumerSecret)
+
+	// Validate required fields
+	if input.BaseURL == "" {
+		return nil, StoreConfigOutput{}, kitDomain.NewValidationError("base_url is required")
+	}
+	if input.ConsumerKey == "" {
+		return nil, StoreConfigOutput{}, kitDomain.NewValidationError("consumer_key is required")
+	}
+	if input.ConsumerSecret == "" {
+		return nil, StoreConfigOutput{}, kitDomain.NewValidationError("consumer_secret is required")
+	}
+
+	// Create WooCommerce client
+	config := woocommerce.NewConfig(input.BaseURL, input.ConsumerKey, input.ConsumerSecret)
+	client := woocommerce.NewClient(config)
+	repo := woocommerce.NewRepository(client)
+
+	request := &get_store_config.GetRequest{
+		BaseURL:        input.BaseURL,
+		ConsumerKey:    input.ConsumerKey,
+		ConsumerSecret: input.ConsumerSecret,
+	}
+
+	// Bound the whole tool call, across all of its API requests
+	ctx, cancel := tooltimeout.WithTimeout(ctx)
+	defer cancel()
+
+	// Execute lookup
+	getter := get_store_config.NewStoreConfigGetter(repo)
+	response, err := getter.Execute(ctx, request)
+	if err != nil {
+		return nil, StoreConfigOutput{}, tooltimeout.Err(ctx, "store_config", fmt.Errorf("failed to get store config: %w", err))
+	}
+
+	// Convert response to JSON
+	jsonData, err := response.ToJSON()
+	if err != nil {
+		return nil, StoreConfigOutput{}, fmt.Errorf("failed to serialize response: %w", err)
+	}
+
+	// Create human-readable message
+	message := fmt.Sprintf("%d enabled payment gateway(s), %d shipping method(s)",
+		len(response.PaymentGateways), len(response.ShippingMethods))
+
+	return nil, StoreConfigOutput{
+		Message: message,
+		Data:    jsonData,
+	}, nil
+}
@@ -0,0 +1,125 @@
+package presentation
+
+import (
+	"context"
+	"fmt"
+
+	"woocommerce-mcp/internal/store/application/get_store_info"
+	"woocommerce-mcp/internal/store/infrastructure/woocommerce"
+	kitDomain "woocommerce-mcp/kit/domain"
+	"woocommerce-mcp/kit/storeconfig"
+	"woocommerce-mcp/kit/toolargs"
+	"woocommerce-mcp/kit/tooltimeout"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// StoreInfoInput defines the input structure for the store_info tool
+type StoreInfoInput struct {
+	BaseURL        string `json:"base_url,omitempty" jsonschema:"WooCommerce store base URL (e.g., https://example.com); defaults to WC_BASE_URL"`
+	ConsumerKey    string `json:"consumer_key,omitempty" jsonschema:"WooCommerce REST API consumer key; defaults to WC_CONSUMER_KEY for the default store"`
+	ConsumerSecret string `json:"consumer_secret,omitempty" jsonschema:"WooCommerce REST API consumer secret; defaults to WC_CONSUMER_SECRET for the default store"`
+}
+
+// UnmarshalJSON decodes the arguments, also accepting numbers and booleans
+// for the string fields
+func (in *StoreInfoInput) UnmarshalJSON(data []byte) error {
+	type plain StoreInfoInput
+	return toolargs.Unmarshal(data, (*plain)(in))
+}
+
+// StoreInfoOutput defines the output structure for the store_info tool
+type StoreInfoOutput struct {
+	Message string `json:"message" jsonschema:"Human-readable summary of the store"`
+	Data    string `json:"data" jsonschema:"JSON-formatted store info"`
+}
+
+// StoreInfoHandler handles store_info tool calls
+type StoreInfoHandler struct{}
+
+// NewStoreInfoHandler creates a new StoreInfoHandler
+func NewStoreInfoHandler() *StoreInfoHandler {
+	return &StoreInfoHandler{}
+}
+
+// GetToolDefinition returns the MCP tool definition for store_info
+func (h *StoreInfoHandler) GetToolDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "store_info",
+		Description: "Get general information about a WooCommerce store: WooCommerce and WordPress versions, active theme, currency and price formatting.",
+		InputSchema: toolargs.InputSchema[StoreInfoInput](),
+	}
+}
+
+// GetInputSchema returns the input schema for the JSON-RPC tools/list endpoint
+func (h *StoreInfoHandler) GetInputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"base_url":        map[string]string{"type": "string", "description": "WooCommerce store base URL"},
+			"consumer_key":    map[string]string{"type": "string", "description": "WooCommerce REST API consumer key"},
+			"consumer_secret": map[string]string{"type": "string", "description": "WooCommerce REST API consumer secret"},
+		},
+		"required": storeconfig.RequiredFields("base_url", "consumer_key", "consumer_secret"),
+	}
+}
+
+// ExecuteMCPTool implements the MCP tool execution
+func (h *StoreInfoHandler) ExecuteMCPTool(ctx context.Context, req *mcp.CallToolRequest, input StoreInfoInput) (*mcp.CallToolResult, StoreInfoOutput, error) {
+	// Fall back to the default store configured through the environment
+	storeconfig.ApplyDefaults(&input.BaseURL, &input.ConsumerKey, &input.ConsumerSecret)
+
+	// Validate required fields
+	if input.BaseURL == "" {
+		return nil, StoreInfoOutput{}, kitDomain.NewValidationError("base_url is required")
+	}
+	if input.ConsumerKey == "" {
+		return nil, StoreInfoOutput{}, kitDomain.NewValidationError("consumer_key is required")
+	}
+	if input.ConsumerSecret == "" {
+		return nil, StoreInfoOutput{}, kitDomain.NewValidationError("consumer_secret is required")
+	}
+
+	// Create WooCommerce client
+	config := woocommerce.NewConfig(input.BaseURL, input.ConsumerKey, input.ConsumerSecret)
+	client := woocommerce.NewClient(config)
+	repo := woocommerce.NewRepository(client)
+
+	request := &get_store_info.GetRequest{
+		BaseURL:        input.BaseURL,
+		ConsumerKey:    input.ConsumerKey,
+		ConsumerSecret: input.ConsumerSecret,
+	}
+
+	// Bound the whole tool call, across all of its API requests
+	ctx, cancel := tooltimeout.WithTimeout(ctx)
+	defer cancel()
+
+	// Execute lookup
+	getter := get_store_info.NewStoreInfoGetter(repo)
+	response, err := getter.Execute(ctx, request)
+	if err != nil {
+		return nil, StoreInfoOutput{}, tooltimeout.Err(ctx, "store_info", fmt.Errorf("failed to get store info: %w", err))
+	}
+
+	// Convert response to JSON
+	jsonData, err := response.ToJSON()
+	if err != nil {
+		return nil, StoreInfoOutput{}, fmt.Errorf("failed to serialize response: %w", err)
+	}
+
+	// Create human-readable message
+	var message string
+	if response.Degraded {
+		message = fmt.Sprintf("Store currency is %s. Version and theme details are unavailable because the API key cannot read the system status report (administrator access required).",
+			response.Currency)
+	} else {
+		message = fmt.Sprintf("WooCommerce %s on WordPress %s, theme %s, currency %s",
+			response.WooCommerceVersion, response.WordPressVersion, response.ThemeName, response.Currency)
+	}
+
+	return nil, StoreInfoOutput{
+		Message: message,
+		Data:    jsonData,
+	}, nil
+}
@@ -0,0 +1,123 @@
+package presentation
+
+import (
+	"context"
+	"fmt"
+
+	"woocommerce-mcp/internal/store/application/verify_credentials"
+	"woocommerce-mcp/internal/store/infrastructure/woocommerce"
+	kitDomain "woocommerce-mcp/kit/domain"
+	"woocommerce-mcp/kit/storeconfig"
+	"woocommerce-mcp/kit/toolargs"
+	"woocommerce-mcp/kit/tooltimeout"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// VerifyCredentialsInput defines the input structure for the verify_credentials tool
+type VerifyCredentialsInput struct {
+	BaseURL        string `json:"base_url,omitempty" jsonschema:"WooCommerce store base URL (e.g., https://example.com); defaults to WC_BASE_URL"`
+	ConsumerKey    string `json:"consumer_key,omitempty" jsonschema:"WooCommerce REST API consumer key; defaults to WC_CONSUMER_KEY for the default store"`
+	ConsumerSecret string `json:"consumer_secret,omitempty" jsonschema:"WooCommerce REST API consumer secret; defaults to WC_CONSUMER_SECRET for the default store"`
+}
+
+// UnmarshalJSON decodes the arguments, also accepting numbers and booleans
+// for the string fields
+func (in *VerifyCredentialsInput) UnmarshalJSON(data []byte) error {
+	type plain VerifyCredentialsInput
+	return toolargs.Unmarshal(data, (*plain)(in))
+}
+
+// VerifyCredentialsOutput defines the output structure for the verify_credentials tool
+type VerifyCredentialsOutput struct {
+	Message string `json:"message" jsonschema:"Human-readable outcome of the credential check"`
+	Data    string `json:"data" jsonschema:"JSON-formatted credential check result"`
+}
+
+// VerifyCredentialsHandler handles verify_credentials tool calls
+type VerifyCredentialsHandler struct{}
+
+// NewVerifyCredentialsHandler creates a new VerifyCredentialsHandler
+func NewVerifyCredentialsHandler() *VerifyCredentialsHandler {
+	return &VerifyCredentialsHandler{}
+}
+
+// GetToolDefinition returns the MCP tool definition for verify_credentials
+func (h *VerifyCredentialsHandler) GetToolDefinition() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "verify_credentials",
+		Description: "Check that WooCommerce REST API credentials are valid without running a search. Reports invalid_key, insufficient_permissions or store_unreachable on failure.",
+		InputSchema: toolargs.InputSchema[VerifyCredentialsInput](),
+	}
+}
+
+// GetInputSchema returns the input schema for the JSON-RPC tools/list endpoint
+func (h *VerifyCredentialsHandler) GetInputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"base_url":        map[string]string{"type": "string", "description": "WooCommerce store base URL"},
+			"consumer_key":    map[string]string{"type": "string", "description": "WooCommerce REST API consumer key"},
+			"consumer_secret": map[string]string{"type": "string", "description": "WooCommerce REST API consumer secret"},
+		},
+		"required": storeconfig.RequiredFields("base_url", "consumer_key", "consumer_secret"),
+	}
+}
+
+// ExecuteMCPTool implements the MCP tool execution
+func (h *VerifyCredentialsHandler) ExecuteMCPTool(ctx context.Context, req *mcp.CallToolRequest, input VerifyCredentialsInput) (*mcp.CallToolResult, VerifyCredentialsOutput, error) {
+	// Fall back to the default store configured through the environment
+	storeconfig.ApplyDefaults(&input.BaseURL, &input.ConsumerKey, &input.ConsumerSecret)
+
+	// Validate required fields
+	if input.BaseURL == "" {
+		return nil, VerifyCredentialsOutput{}, kitDomain.NewValidationError("base_url is required")
+	}
+	if input.ConsumerKey == "" {
+		return nil, VerifyCredentialsOutput{}, kitDomain.NewValidationError("consumer_key is required")
+	}
+	if input.ConsumerSecret == "" {
+		return nil, VerifyCredentialsOutput{}, kitDomain.NewValidationError("consumer_secret is required")
+	}
+
+	// Create WooCommerce client
+	config := woocommerce.NewConfig(input.BaseURL, input.ConsumerKey, input.ConsumerSecret)
+	client := woocommerce.NewClient(config)
+	repo := woocommerce.NewRepository(client)
+
+	request := &verify_credentials.VerifyRequest{
+		BaseURL:        input.BaseURL,
+		ConsumerKey:    input.ConsumerKey,
+		ConsumerSecret: input.ConsumerSecret,
+	}
+
+	// Bound the whole tool call, across all of its API requests
+	ctx, cancel := tooltimeout.WithTimeout(ctx)
+	defer cancel()
+
+	// Execute verification
+	checker := verify_credentials.NewCredentialChecker(repo)
+	response, err := checker.Execute(ctx, request)
+	if err != nil {
+		return nil, VerifyCredentialsOutput{}, tooltimeout.Err(ctx, "verify_credentials", fmt.Errorf("failed to verify credentials: %w", err))
+	}
+
+	// Convert response to JSON
+	jsonData, err := response.ToJSON()
+	if err != nil {
+		return nil, VerifyCredentialsOutput{}, fmt.Errorf("failed to serialize response: %w", err)
+	}
+
+	// Create human-readable message
+	var message string
+	if response.Valid {
+		message = "Credentials are valid"
+	} else {
+		message = fmt.Sprintf("Credentials could not be verified (%s): %s", response.Reason, response.Detail)
+	}
+
+	return nil, VerifyCredentialsOutput{
+		Message: message,
+		Data:    jsonData,
+	}, nil
+}
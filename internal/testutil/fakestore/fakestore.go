@@ -0,0 +1,489 @@
+package fakestore
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Credentials the fake store accepts for the WooCommerce API
+const (
+	ConsumerKey    = "ck_fake"
+	ConsumerSecret = "cs_fake"
+)
+
+// Credentials the fake store accepts for the WordPress API
+const (
+	Username            = "editor"
+	ApplicationPassword = "abcd efgh"
+)
+
+// Store is a fake WooCommerce/WordPress store serving canned products,
+// product categories, product variations, posts, customers, orders,
+// payment gateways and shipping methods over the REST API. It paginates like WordPress, setting
+// the X-WP-Total and X-WP-TotalPages headers, answers HEAD count requests
+// and rejects WooCommerce calls with wrong credentials, and WordPress calls
+// with a wrong application password, with a 401.
+type Store struct {
+	mu         sync.Mutex
+	products   []map[string]interface{}
+	variations map[int][]map[string]interface{}
+	categories []map[string]interface{}
+	posts      []map[string]interface{}
+	typedPosts map[string][]map[string]interface{}
+	customers  []map[string]interface{}
+	orders     []map[string]interface{}
+	requests   []string
+}
+
+// New creates a fake store holding the default fixtures
+func New() *Store {
+	return &Store{
+		products:   DefaultProducts(),
+		categories: DefaultCategories(),
+		posts:      DefaultPosts(),
+		customers:  DefaultCustomers(),
+		orders:     DefaultOrders(),
+	}
+}
+
+// Start serves the store on a local test server; the caller must close it
+func (s *Store) Start() *httptest.Server {
+	return httptest.NewServer(s.Handler())
+}
+
+// SetProducts replaces the product fixtures
+func (s *Store) SetProducts(products []map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.products = products
+}
+
+// SetVariations serves the variations of a variable product under
+// /products/{parentID}/variations
+func (s *Store) SetVariations(parentID int, variations []map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.variations == nil {
+		s.variations = make(map[int][]map[string]interface{})
+	}
+	s.variations[parentID] = variations
+}
+
+// SetCategories replaces the product category fixtures
+func (s *Store) SetCategories(categories []map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.categories = categories
+}
+
+// SetPosts replaces the post fixtures
+func (s *Store) SetPosts(posts []map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.posts = posts
+}
+
+// SetPostsOfType serves posts of a custom post type under its REST base,
+// e.g. /wp-json/wp/v2/portfolio. Unknown REST bases answer rest_no_route.
+func (s *Store) SetPostsOfType(restBase string, posts []map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.typedPosts == nil {
+		s.typedPosts = make(map[string][]map[string]interface{})
+	}
+	s.typedPosts[restBase] = posts
+}
+
+// SetCustomers replaces the customer fixtures
+func (s *Store) SetCustomers(customers []map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.customers = customers
+}
+
+// SetOrders replaces the order fixtures
+func (s *Store) SetOrders(orders []map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.orders = orders
+}
+
+// Requests returns the method and request URI of every request served so far
+func (s *Store) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+// Handler returns the HTTP handler of the store's REST API
+func (s *Store) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/wp-json/wc/v3/products", s.requireCredentials(s.handleProducts))
+	mux.HandleFunc("/wp-json/wc/v3/products/categories", s.requireCredentials(s.handleCategories))
+	mux.HandleFunc("/wp-json/wc/v3/products/", s.requireCredentials(s.handleVariations))
+	mux.HandleFunc("/wp-json/wc/v3/settings/general", s.requireCredentials(s.handleGeneralSettings))
+	mux.HandleFunc("/wp-json/wc/v3/settings/products", s.requireCredentials(s.handleProductSettings))
+	mux.HandleFunc("/wp-json/wc/v3/customers", s.requireCredentials(s.handleCustomers))
+	mux.HandleFunc("/wp-json/wc/v3/customers/", s.requireCredentials(s.handleCustomer))
+	mux.HandleFunc("/wp-json/wc/v3/orders", s.requireCredentials(s.handleOrders))
+	mux.HandleFunc("/wp-json/wc/v3/payment_gateways", s.requireCredentials(s.handlePaymentGateways))
+	mux.HandleFunc("/wp-json/wc/v3/shipping_methods", s.requireCredentials(s.handleShippingMethods))
+	mux.HandleFunc("/wp-json/wp/v2/posts", s.checkApplicationPassword(s.handlePosts))
+	mux.HandleFunc("/wp-json/wp/v2/", s.checkApplicationPassword(s.handleCustomPosts))
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "rest_no_route", "No route was found matching the URL and request method.")
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, r.Method+" "+r.URL.RequestURI())
+		s.mu.Unlock()
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// requireCredentials rejects requests without the fake store's API keys
+func (s *Store) requireCredentials(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("consumer_key") != ConsumerKey || query.Get("consumer_secret") != ConsumerSecret {
+			writeError(w, http.StatusUnauthorized, "woocommerce_rest_cannot_view", "Sorry, you cannot list resources.")
+			return
+		}
+		next(w, r)
+	}
+}
+
+// checkApplicationPassword rejects requests authenticating with other than
+// the fake store's application password. Like WordPress, anonymous requests
+// are let through.
+func (s *Store) checkApplicationPassword(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); ok && (username != Username || password != ApplicationPassword) {
+			writeError(w, http.StatusUnauthorized, "incorrect_password", "The provided password is an invalid application password.")
+			return
+		}
+		next(w, r)
+	}
+}
+
+// handleProducts lists products, filtered by search (over names and SKUs,
+// as on stores whose search covers SKUs), exact sku, include, status, type
+// and featured. Like WooCommerce, featured=false does not filter.
+// Like WooCommerce, products of every status are listed by default.
+func (s *Store) handleProducts(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	products := s.products
+	s.mu.Unlock()
+
+	query := r.URL.Query()
+	var matching []map[string]interface{}
+	include := idSet(query.Get("include"))
+	for _, product := range products {
+		if include != nil && !include[idOf(product)] {
+			continue
+		}
+		if !matchesSearch(query.Get("search"), product["name"]) && !matchesSearch(query.Get("search"), product["sku"]) {
+			continue
+		}
+		if sku := query.Get("sku"); sku != "" && sku != product["sku"] {
+			continue
+		}
+		if status := query.Get("status"); status != "" && status != "any" && status != product["status"] {
+			continue
+		}
+		if productType := query.Get("type"); productType != "" && productType != product["type"] {
+			continue
+		}
+		if query.Get("featured") == "true" && product["featured"] != true {
+			continue
+		}
+		matching = append(matching, product)
+	}
+
+	writePage(w, r, matching)
+}
+
+// handleVariations lists the variations of a product set with SetVariations
+func (s *Store) handleVariations(w http.ResponseWriter, r *http.Request) {
+	idText, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/wp-json/wc/v3/products/"), "/variations")
+	parentID, err := strconv.Atoi(idText)
+	if !ok || err != nil {
+		writeError(w, http.StatusNotFound, "rest_no_route", "No route was found matching the URL and request method.")
+		return
+	}
+
+	s.mu.Lock()
+	variations := s.variations[parentID]
+	s.mu.Unlock()
+
+	writePage(w, r, variations)
+}
+
+// handleCategories lists product categories, filtered by include and parent
+func (s *Store) handleCategories(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	categories := s.categories
+	s.mu.Unlock()
+
+	query := r.URL.Query()
+	var matching []map[string]interface{}
+	include := idSet(query.Get("include"))
+	for _, category := range categories {
+		if include != nil && !include[idOf(category)] {
+			continue
+		}
+		if parent := query.Get("parent"); parent != "" && parent != fmt.Sprint(category["parent"]) {
+			continue
+		}
+		matching = append(matching, category)
+	}
+
+	writePage(w, r, matching)
+}
+
+// handlePosts lists posts, filtered by search and status. Like WordPress,
+// only published posts are listed by default.
+func (s *Store) handlePosts(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	posts := s.posts
+	s.mu.Unlock()
+
+	statuses := r.URL.Query().Get("status")
+	if statuses == "" {
+		statuses = "publish"
+	}
+	var matching []map[string]interface{}
+	for _, post := range postsMatching(r.URL.Query().Get("search"), posts) {
+		for _, status := range strings.Split(statuses, ",") {
+			if status == post["status"] {
+				matching = append(matching, post)
+				break
+			}
+		}
+	}
+
+	writePage(w, r, matching)
+}
+
+// handleCustomPosts lists the posts of a custom post type, filtered by search
+func (s *Store) handleCustomPosts(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	posts, ok := s.typedPosts[strings.TrimPrefix(r.URL.Path, "/wp-json/wp/v2/")]
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "rest_no_route", "No route was found matching the URL and request method.")
+		return
+	}
+
+	writePage(w, r, postsMatching(r.URL.Query().Get("search"), posts))
+}
+
+// handleCustomers lists customers, filtered by email, search and role. Like
+// WooCommerce, only customers are listed unless another role is asked for.
+func (s *Store) handleCustomers(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	customers := s.customers
+	s.mu.Unlock()
+
+	query := r.URL.Query()
+	role := query.Get("role")
+	if role == "" {
+		role = "customer"
+	}
+
+	var matching []map[string]interface{}
+	for _, customer := range customers {
+		if email := query.Get("email"); email != "" && !strings.EqualFold(email, customer["email"].(string)) {
+			continue
+		}
+		if role != "all" && role != customer["role"] {
+			continue
+		}
+		search := query.Get("search")
+		if !matchesSearch(search, customer["email"]) && !matchesSearch(search, customer["first_name"]) && !matchesSearch(search, customer["last_name"]) {
+			continue
+		}
+		matching = append(matching, customer)
+	}
+
+	writePage(w, r, matching)
+}
+
+// handleCustomer serves a single customer by ID
+func (s *Store) handleCustomer(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	customers := s.customers
+	s.mu.Unlock()
+
+	id, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/wp-json/wc/v3/customers/"))
+	if err == nil {
+		for _, customer := range customers {
+			if idOf(customer) == id {
+				writeJSON(w, http.StatusOK, customer)
+				return
+			}
+		}
+	}
+	writeError(w, http.StatusNotFound, "woocommerce_rest_invalid_id", "Invalid resource ID.")
+}
+
+// handleOrders lists orders, filtered by customer
+func (s *Store) handleOrders(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	orders := s.orders
+	s.mu.Unlock()
+
+	customer := r.URL.Query().Get("customer")
+	var matching []map[string]interface{}
+	for _, order := range orders {
+		if customer != "" && customer != fmt.Sprint(order["customer_id"]) {
+			continue
+		}
+		matching = append(matching, order)
+	}
+
+	writePage(w, r, matching)
+}
+
+// handleGeneralSettings serves the currency settings
+func (s *Store) handleGeneralSettings(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, []map[string]interface{}{
+		{"id": "woocommerce_currency", "value": "USD"},
+		{"id": "woocommerce_price_num_decimals", "value": "2"},
+	})
+}
+
+// handlePaymentGateways serves the installed payment gateways
+func (s *Store) handlePaymentGateways(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, DefaultPaymentGateways())
+}
+
+// handleShippingMethods serves the shipping method definitions
+func (s *Store) handleShippingMethods(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, DefaultShippingMethods())
+}
+
+// handleProductSettings serves the measurement unit settings
+func (s *Store) handleProductSettings(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, []map[string]interface{}{
+		{"id": "woocommerce_weight_unit", "value": "kg"},
+		{"id": "woocommerce_dimension_unit", "value": "cm"},
+	})
+}
+
+// postsMatching returns the posts whose rendered title contains the search term
+func postsMatching(search string, posts []map[string]interface{}) []map[string]interface{} {
+	var matching []map[string]interface{}
+	for _, post := range posts {
+		var title interface{}
+		if rendered, ok := post["title"].(map[string]interface{}); ok {
+			title = rendered["rendered"]
+		}
+		if matchesSearch(search, title) {
+			matching = append(matching, post)
+		}
+	}
+	return matching
+}
+
+// writePage writes one page of items with the WordPress pagination headers.
+// HEAD requests only get the headers.
+func writePage(w http.ResponseWriter, r *http.Request, items []map[string]interface{}) {
+	query := r.URL.Query()
+	page := positiveInt(query.Get("page"), 1)
+	perPage := positiveInt(query.Get("per_page"), 10)
+	if perPage > 100 {
+		writeError(w, http.StatusBadRequest, "rest_invalid_param", "Invalid parameter(s): per_page")
+		return
+	}
+
+	total := len(items)
+	totalPages := (total + perPage - 1) / perPage
+	w.Header().Set("X-WP-Total", strconv.Itoa(total))
+	w.Header().Set("X-WP-TotalPages", strconv.Itoa(totalPages))
+
+	start := (page - 1) * perPage
+	if start > total {
+		start = total
+	}
+	end := start + perPage
+	if end > total {
+		end = total
+	}
+
+	if r.Method == http.MethodHead {
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	pageItems := items[start:end]
+	if pageItems == nil {
+		pageItems = []map[string]interface{}{}
+	}
+	writeJSON(w, http.StatusOK, pageItems)
+}
+
+// writeError writes a WordPress REST error response
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"code":    code,
+		"message": message,
+		"data":    map[string]interface{}{"status": status},
+	})
+}
+
+// writeJSON writes a JSON response
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// matchesSearch reports whether a value contains the search term, ignoring case
+func matchesSearch(search string, value interface{}) bool {
+	if search == "" {
+		return true
+	}
+	text, _ := value.(string)
+	return strings.Contains(strings.ToLower(text), strings.ToLower(search))
+}
+
+// idSet parses a comma-separated ID list; it returns nil for an empty list
+func idSet(value string) map[int]bool {
+	if value == "" {
+		return nil
+	}
+	ids := make(map[int]bool)
+	for _, part := range strings.Split(value, ",") {
+		if id, err := strconv.Atoi(strings.TrimSpace(part)); err == nil {
+			ids[id] = true
+		}
+	}
+	return ids
+}
+
+// idOf returns the numeric ID of a fixture
+func idOf(item map[string]interface{}) int {
+	switch id := item["id"].(type) {
+	case int:
+		return id
+	case float64:
+		return int(id)
+	default:
+		return 0
+	}
+}
+
+// positiveInt parses a positive integer, falling back to a default
+func positiveInt(value string, fallback int) int {
+	if parsed, err := strconv.Atoi(value); err == nil && parsed > 0 {
+		return parsed
+	}
+	return fallback
+}
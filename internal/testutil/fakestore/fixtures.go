@@ -0,0 +1,160 @@
+package fakestore
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultProducts returns the canned products: twelve simple products, so
+// listings span more than one page at the default page size. All of them
+// are in Footwear, and the sneakers are in Sneakers as well.
+func DefaultProducts() []map[string]interface{} {
+	names := []string{
+		"Low Top Sneakers", "High Top Sneakers", "Running Shoes", "Trail Shoes",
+		"Canvas Sneakers", "Leather Boots", "Rain Boots", "Sandals",
+		"Slippers", "Sport Socks", "Wool Socks", "Shoe Laces",
+	}
+
+	products := make([]map[string]interface{}, len(names))
+	for i, name := range names {
+		id := i + 1
+		stockQuantity := 10 - i
+		stockStatus := "instock"
+		if stockQuantity <= 0 {
+			stockStatus = "outofstock"
+		}
+		categories := []map[string]interface{}{
+			{"id": 15, "name": "Footwear", "slug": "footwear"},
+		}
+		if strings.HasSuffix(name, "Sneakers") {
+			categories = append(categories, map[string]interface{}{"id": 16, "name": "Sneakers", "slug": "sneakers"})
+		}
+		products[i] = map[string]interface{}{
+			"id":                 id,
+			"name":               name,
+			"slug":               slugify(name),
+			"permalink":          fmt.Sprintf("https://store.example/product/%s/", slugify(name)),
+			"date_created":       "2024-01-15T10:00:00",
+			"date_created_gmt":   "2024-01-15T09:00:00",
+			"date_modified":      "2024-02-01T09:30:00",
+			"date_modified_gmt":  "2024-02-01T08:30:00",
+			"type":               "simple",
+			"status":             "publish",
+			"catalog_visibility": "visible",
+			"sku":                fmt.Sprintf("FAKE-%03d", id),
+			"price":              fmt.Sprintf("%d.99", 20+i*5),
+			"regular_price":      fmt.Sprintf("%d.99", 20+i*5),
+			"purchasable":        true,
+			"manage_stock":       true,
+			"stock_quantity":     stockQuantity,
+			"stock_status":       stockStatus,
+			"backorders":         "no",
+			"weight":             "0.5",
+			"categories":         categories,
+		}
+	}
+	return products
+}
+
+// DefaultCategories returns the canned product categories: a three-level
+// hierarchy, Apparel > Footwear > Sneakers
+func DefaultCategories() []map[string]interface{} {
+	return []map[string]interface{}{
+		{"id": 14, "name": "Apparel", "slug": "apparel", "parent": 0, "count": 0},
+		{"id": 15, "name": "Footwear", "slug": "footwear", "parent": 14, "count": 12},
+		{"id": 16, "name": "Sneakers", "slug": "sneakers", "parent": 15, "count": 3},
+	}
+}
+
+// DefaultPosts returns the canned posts: three published posts
+func DefaultPosts() []map[string]interface{} {
+	titles := []string{"Spring Collection", "Caring for Leather &amp; Suede", "Running Tips"}
+
+	posts := make([]map[string]interface{}, len(titles))
+	for i, title := range titles {
+		id := 101 + i
+		posts[i] = map[string]interface{}{
+			"id":             id,
+			"date":           fmt.Sprintf("2024-03-%02dT08:00:00", i+1),
+			"date_gmt":       fmt.Sprintf("2024-03-%02dT08:00:00", i+1),
+			"modified":       fmt.Sprintf("2024-03-%02dT08:00:00", i+1),
+			"modified_gmt":   fmt.Sprintf("2024-03-%02dT08:00:00", i+1),
+			"slug":           fmt.Sprintf("post-%d", id),
+			"status":         "publish",
+			"type":           "post",
+			"link":           fmt.Sprintf("https://store.example/post-%d/", id),
+			"title":          map[string]interface{}{"rendered": title},
+			"content":        map[string]interface{}{"rendered": "<p>" + title + "</p>", "protected": false},
+			"excerpt":        map[string]interface{}{"rendered": "<p>" + title + "</p>", "protected": false},
+			"author":         1,
+			"comment_status": "open",
+			"ping_status":    "open",
+			"format":         "standard",
+			"categories":     []int{1},
+			"tags":           []int{},
+		}
+	}
+	return posts
+}
+
+// DefaultCustomers returns the canned customers: two customers and a shop
+// manager
+func DefaultCustomers() []map[string]interface{} {
+	return []map[string]interface{}{
+		{"id": 201, "email": "jane.doe@example.com", "first_name": "Jane", "last_name": "Doe", "username": "janedoe", "role": "customer"},
+		{"id": 202, "email": "john.smith@example.com", "first_name": "John", "last_name": "Smith", "username": "johnsmith", "role": "customer"},
+		{"id": 203, "email": "manager@store.example", "first_name": "Shop", "last_name": "Manager", "username": "manager", "role": "shop_manager"},
+	}
+}
+
+// DefaultOrders returns the canned orders: three by the first customer and
+// one by the second
+func DefaultOrders() []map[string]interface{} {
+	customerIDs := []int{201, 201, 201, 202}
+
+	orders := make([]map[string]interface{}, len(customerIDs))
+	for i, customerID := range customerIDs {
+		orders[i] = map[string]interface{}{
+			"id":          301 + i,
+			"status":      "completed",
+			"customer_id": customerID,
+			"total":       fmt.Sprintf("%d.00", 40+i*10),
+		}
+	}
+	return orders
+}
+
+// DefaultPaymentGateways returns the canned payment gateways: bank transfer
+// and cash on delivery are enabled, check payments are not
+func DefaultPaymentGateways() []map[string]interface{} {
+	return []map[string]interface{}{
+		{"id": "bacs", "title": "Direct bank transfer", "description": "Make your payment directly into our bank account.", "enabled": true, "method_title": "Direct bank transfer"},
+		{"id": "cheque", "title": "Check payments", "description": "Please send a check to our store.", "enabled": false, "method_title": "Check payments"},
+		{"id": "cod", "title": "Cash on delivery", "description": "Pay with cash upon delivery.", "enabled": true, "method_title": "Cash on delivery"},
+	}
+}
+
+// DefaultShippingMethods returns the canned shipping method definitions
+func DefaultShippingMethods() []map[string]interface{} {
+	return []map[string]interface{}{
+		{"id": "flat_rate", "title": "Flat rate", "description": "Lets you charge a fixed rate for shipping."},
+		{"id": "free_shipping", "title": "Free shipping", "description": "Free shipping is a special method which can be triggered with coupons and minimum spends."},
+		{"id": "local_pickup", "title": "Local pickup", "description": "Allow customers to pick up orders themselves."},
+	}
+}
+
+// slugify derives a URL slug from a fixture name
+func slugify(name string) string {
+	slug := make([]rune, 0, len(name))
+	for _, r := range name {
+		switch {
+		case r >= 'A' && r <= 'Z':
+			slug = append(slug, r+'a'-'A')
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			slug = append(slug, r)
+		case r == ' ':
+			slug = append(slug, '-')
+		}
+	}
+	return string(slug)
+}
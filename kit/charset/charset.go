@@ -0,0 +1,37 @@
+package charset
+
+import (
+	"fmt"
+	"mime"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+// ToUTF8 transcodes a response body to UTF-8 according to the charset of its
+// Content-Type header. A body without a charset, or already in UTF-8, is
+// returned as is. So is a body that is valid UTF-8 despite declaring another
+// charset: JSON is UTF-8 by definition, and misconfigured hosts often label
+// correct UTF-8 output with their default charset.
+func ToUTF8(contentType string, body []byte) ([]byte, error) {
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return body, nil
+	}
+
+	name := strings.ToLower(strings.TrimSpace(params["charset"]))
+	if name == "" || name == "utf-8" || name == "utf8" || utf8.Valid(body) {
+		return body, nil
+	}
+
+	encoding, err := htmlindex.Get(name)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported response charset %q", name)
+	}
+	decoded, err := encoding.NewDecoder().Bytes(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s response: %w", name, err)
+	}
+	return decoded, nil
+}
@@ -0,0 +1,37 @@
+package charset
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestToUTF8(t *testing.T) {
+	latin1 := []byte("{\"title\":\"Caf\xe9 cr\xe8me\"}")
+	utf8Body := []byte(`{"title":"Café crème"}`)
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        []byte
+		want        []byte
+		wantErr     bool
+	}{
+		{"iso-8859-1", "application/json; charset=ISO-8859-1", latin1, utf8Body, false},
+		{"windows-1252", "application/json; charset=windows-1252", latin1, utf8Body, false},
+		{"utf-8", "application/json; charset=UTF-8", utf8Body, utf8Body, false},
+		{"no charset", "application/json", utf8Body, utf8Body, false},
+		{"no content type", "", latin1, latin1, false},
+		{"mislabelled utf-8", "application/json; charset=iso-8859-1", utf8Body, utf8Body, false},
+		{"unknown charset", "application/json; charset=x-klingon", latin1, nil, true},
+	}
+	for _, tt := range tests {
+		got, err := ToUTF8(tt.contentType, tt.body)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: got error %v, want error %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !bytes.Equal(got, tt.want) {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package contentencoding
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Transport decodes response bodies that still carry a gzip or deflate
+// Content-Encoding. The standard transport only decodes gzip when it asked
+// for it itself, so a request with its own Accept-Encoding header, or a host
+// compressing responses unasked (including with deflate), would otherwise
+// hand compressed bytes to the JSON parser.
+type Transport struct {
+	Base http.RoundTripper
+}
+
+// NewTransport wraps a transport with response decoding
+func NewTransport(base http.RoundTripper) *Transport {
+	return &Transport{Base: base}
+}
+
+// RoundTrip sends the request and decodes the response body if needed
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.Base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding")))
+	if encoding == "" || encoding == "identity" || req.Method == http.MethodHead || resp.StatusCode == http.StatusNoContent {
+		return resp, nil
+	}
+
+	var decoded io.Reader
+	switch encoding {
+	case "gzip", "x-gzip":
+		decoded, err = gzip.NewReader(resp.Body)
+	case "deflate":
+		decoded, err = newDeflateReader(resp.Body)
+	default:
+		// Leave unknown encodings alone; parsing will report them
+		return resp, nil
+	}
+	if err != nil {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to decode %s response: %w", encoding, err)
+	}
+
+	resp.Body = &decodedBody{Reader: decoded, body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return resp, nil
+}
+
+// newDeflateReader decodes an HTTP deflate body. The encoding is meant to be
+// zlib-wrapped, but some servers send raw deflate data, so the zlib header
+// is detected rather than assumed.
+func newDeflateReader(body io.Reader) (io.Reader, error) {
+	buffered := bufio.NewReader(body)
+	header, err := buffered.Peek(2)
+	if err != nil {
+		if err == io.EOF {
+			return buffered, nil
+		}
+		return nil, err
+	}
+
+	if isZlibHeader(header[0], header[1]) {
+		return zlib.NewReader(buffered)
+	}
+	return flate.NewReader(buffered), nil
+}
+
+// isZlibHeader reports whether two bytes form a zlib header: the deflate
+// method with a header checksum that is a multiple of 31
+func isZlibHeader(cmf, flg byte) bool {
+	return cmf&0x0f == 8 && (uint16(cmf)<<8|uint16(flg))%31 == 0
+}
+
+// decodedBody reads the decoded stream and closes the original body
+type decodedBody struct {
+	io.Reader
+	body io.ReadCloser
+}
+
+// Close closes the decoder, when it has a Close method, and the original body
+func (b *decodedBody) Close() error {
+	if closer, ok := b.Reader.(io.Closer); ok {
+		closer.Close()
+	}
+	return b.body.Close()
+}
+
+// CloseIdleConnections closes the idle connections of the base transport, if
+// it keeps any, so http.Client.CloseIdleConnections reaches through the wrapper
+func (t *Transport) CloseIdleConnections() {
+	if closer, ok := t.Base.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+}
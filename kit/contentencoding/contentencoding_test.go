@@ -0,0 +1,124 @@
+package contentencoding
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const payload = `[{"id":1,"name":"Running Shoes"}]`
+
+// encode compresses the payload with an HTTP content encoding
+func encode(t *testing.T, encoding string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	var writer io.WriteCloser
+	switch encoding {
+	case "gzip":
+		writer = gzip.NewWriter(&buf)
+	case "deflate":
+		writer = zlib.NewWriter(&buf)
+	case "raw deflate":
+		writer, _ = flate.NewWriter(&buf, flate.DefaultCompression)
+	default:
+		buf.WriteString(payload)
+		return buf.Bytes()
+	}
+	writer.Write([]byte(payload))
+	writer.Close()
+	return buf.Bytes()
+}
+
+// fetch requests a server answering with the body and Content-Encoding
+// given, through a Transport whose base transport never decodes on its own
+func fetch(t *testing.T, method, contentEncoding string, body []byte) (*http.Response, string) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if contentEncoding != "" {
+			w.Header().Set("Content-Encoding", contentEncoding)
+		}
+		w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+
+	client := &http.Client{Transport: NewTransport(&http.Transport{DisableCompression: true})}
+	req, _ := http.NewRequest(method, server.URL, nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, contentEncoding, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read %s body: %v", contentEncoding, err)
+	}
+	return resp, string(data)
+}
+
+func TestEncodedBodiesAreDecoded(t *testing.T) {
+	for _, tt := range []struct{ header, encoding string }{
+		{"gzip", "gzip"},
+		{"x-gzip", "gzip"},
+		{" GZIP ", "gzip"},
+		{"deflate", "deflate"},
+		{"deflate", "raw deflate"},
+		{"identity", ""},
+		{"", ""},
+	} {
+		resp, body := fetch(t, http.MethodGet, tt.header, encode(t, tt.encoding))
+		if body != payload {
+			t.Errorf("%s body (%s): got %q, want the decoded payload", tt.header, tt.encoding, body)
+		}
+		if tt.encoding != "" && (resp.Header.Get("Content-Encoding") != "" || !resp.Uncompressed) {
+			t.Errorf("%s: response still marked as encoded", tt.header)
+		}
+	}
+}
+
+func TestUnknownEncodingsAndHeadRequestsAreLeftAlone(t *testing.T) {
+	if resp, body := fetch(t, http.MethodGet, "br", []byte("brotli bytes")); body != "brotli bytes" || resp.Header.Get("Content-Encoding") != "br" {
+		t.Errorf("br: got %q with encoding %q, want the body untouched", body, resp.Header.Get("Content-Encoding"))
+	}
+	if resp, _ := fetch(t, http.MethodHead, "gzip", encode(t, "gzip")); resp.Header.Get("Content-Encoding") != "gzip" {
+		t.Error("HEAD: Content-Encoding was removed from a response without a body")
+	}
+}
+
+func TestCorruptGzipIsAnError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write([]byte("not gzip"))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewTransport(&http.Transport{DisableCompression: true})}
+	if _, err := client.Get(server.URL); err == nil {
+		t.Error("corrupt gzip body: succeeded, want a decoding error")
+	}
+}
+
+// idleCloser records whether its idle connections were closed
+type idleCloser struct {
+	http.RoundTripper
+	closed bool
+}
+
+func (c *idleCloser) CloseIdleConnections() {
+	c.closed = true
+}
+
+func TestCloseIdleConnectionsReachesTheBaseTransport(t *testing.T) {
+	base := &idleCloser{RoundTripper: http.DefaultTransport}
+	(&http.Client{Transport: NewTransport(base)}).CloseIdleConnections()
+	if !base.closed {
+		t.Error("closing the client's idle connections did not reach the base transport")
+	}
+
+	// A base transport without idle connections is left alone
+	NewTransport(http.NewFileTransport(http.Dir("."))).CloseIdleConnections()
+}
@@ -0,0 +1,48 @@
+package dateformat
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Named date formats a date_format tool argument accepts besides a Go layout
+const (
+	// ISO keeps each tool's default date output
+	ISO = "iso"
+	// RFC3339 formats dates with their offset, e.g. 2024-01-15T10:00:00+01:00
+	RFC3339 = "rfc3339"
+	// DateOnly formats the calendar date alone, e.g. 2024-01-15
+	DateOnly = "date_only"
+)
+
+// presets maps each named format to its layout
+var presets = map[string]string{
+	RFC3339:  time.RFC3339,
+	DateOnly: time.DateOnly,
+}
+
+// reference is the time a layout is checked against
+var reference = time.Date(2024, time.January, 15, 10, 30, 45, 0, time.UTC)
+
+// ParseLayout parses a date_format tool argument into a Go time layout. An
+// empty value or iso returns "", meaning the default output. Any other value
+// is a named format or a Go layout such as "Jan 2, 2006", which must hold at
+// least one date or time element.
+func ParseLayout(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	switch name := strings.ToLower(value); name {
+	case "", ISO:
+		return "", nil
+	default:
+		if layout, ok := presets[name]; ok {
+			return layout, nil
+		}
+	}
+
+	// A layout without elements formats every date as the same text
+	if reference.Format(value) == value {
+		return "", fmt.Errorf("%q is neither iso, rfc3339, date_only nor a Go time layout such as \"2006-01-02 15:04\"", value)
+	}
+	return value, nil
+}
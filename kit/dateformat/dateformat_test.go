@@ -0,0 +1,33 @@
+package dateformat
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseLayout(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{value: "", want: ""},
+		{value: " ISO ", want: ""},
+		{value: "rfc3339", want: time.RFC3339},
+		{value: "Date_Only", want: time.DateOnly},
+		{value: "Jan 2, 2006", want: "Jan 2, 2006"},
+		{value: "02/01/2006 15:04", want: "02/01/2006 15:04"},
+		{value: "relative", wantErr: true},
+		{value: "yyyy-mm-dd", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseLayout(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseLayout(%q) = %q, %v; want %q, error %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+		if err != nil && !strings.Contains(err.Error(), "rfc3339") {
+			t.Errorf("ParseLayout(%q) error %q does not list the presets", tt.value, err)
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package domain
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 // ValidationError represents a domain validation error
 type ValidationError struct {
@@ -108,3 +112,157 @@ func (e *ConflictError) Is(target error) bool {
 	_, ok := target.(*ConflictError)
 	return ok
 }
+
+// TimeoutError represents a tool call that ran past its deadline
+type TimeoutError struct {
+	Tool    string
+	Timeout time.Duration
+}
+
+// NewTimeoutError creates a new TimeoutError
+func NewTimeoutError(tool string, timeout time.Duration) *TimeoutError {
+	return &TimeoutError{
+		Tool:    tool,
+		Timeout: timeout,
+	}
+}
+
+// Error returns the error message
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("timeout error: tool %s did not finish within %s", e.Tool, e.Timeout)
+}
+
+// Is checks if the error is of the same type
+func (e *TimeoutError) Is(target error) bool {
+	_, ok := target.(*TimeoutError)
+	return ok
+}
+
+// CodedError is implemented by domain errors that carry a machine-readable
+// code and type
+type CodedError interface {
+	error
+	ErrorCode() string
+	ErrorType() string
+}
+
+// HTTPStatusError is implemented by errors caused by an upstream HTTP response
+type HTTPStatusError interface {
+	error
+	HTTPStatus() int
+}
+
+// RateLimitedError is implemented by errors of a rate-limited upstream
+// request that say how long to wait before retrying
+type RateLimitedError interface {
+	error
+	RetryAfterDelay() time.Duration
+}
+
+// ErrorDetail is the machine-readable form of an error returned to clients
+type ErrorDetail struct {
+	Code    string `json:"code"`
+	Type    string `json:"type"`
+	Message string `json:"message"`
+	// Status is the upstream HTTP status, when the error came from the store
+	Status int `json:"status,omitempty"`
+	// RetryAfterSeconds is how long a rate-limited caller should wait
+	RetryAfterSeconds int `json:"retry_after_seconds,omitempty"`
+}
+
+// NewErrorDetail creates an ErrorDetail
+func NewErrorDetail(code, errorType, message string) *ErrorDetail {
+	return &ErrorDetail{
+		Code:    code,
+		Type:    errorType,
+		Message: message,
+	}
+}
+
+// DescribeError maps an error to its ErrorDetail using the first CodedError
+// in its chain. Errors without a code are reported as internal errors.
+func DescribeError(err error) *ErrorDetail {
+	detail := NewErrorDetail("INTERNAL_ERROR", "InternalError", err.Error())
+
+	var coded CodedError
+	if errors.As(err, &coded) {
+		detail.Code = coded.ErrorCode()
+		detail.Type = coded.ErrorType()
+	}
+
+	var statusErr HTTPStatusError
+	if errors.As(err, &statusErr) && statusErr.HTTPStatus() > 0 {
+		detail.Status = statusErr.HTTPStatus()
+	}
+
+	var rateLimitedErr RateLimitedError
+	if errors.As(err, &rateLimitedErr) && rateLimitedErr.RetryAfterDelay() > 0 {
+		// Round up, so waiting the reported seconds is always enough
+		detail.RetryAfterSeconds = int((rateLimitedErr.RetryAfterDelay() + time.Second - 1) / time.Second)
+	}
+
+	return detail
+}
+
+// JSONRPCErrorData returns the data of a JSON-RPC error for a failed tool
+// call: the error message, or its ErrorDetail when the caller was rate
+// limited, so it can read retry_after_seconds
+func JSONRPCErrorData(err error) interface{} {
+	if detail := DescribeError(err); detail.RetryAfterSeconds > 0 {
+		return detail
+	}
+	return err.Error()
+}
+
+// ErrorCode returns the machine-readable error code
+func (e *ValidationError) ErrorCode() string {
+	return "VALIDATION_ERROR"
+}
+
+// ErrorType returns the error type
+func (e *ValidationError) ErrorType() string {
+	return "ValidationError"
+}
+
+// ErrorCode returns the machine-readable error code
+func (e *DomainError) ErrorCode() string {
+	if e.Code != "" {
+		return e.Code
+	}
+	return "DOMAIN_ERROR"
+}
+
+// ErrorType returns the error type
+func (e *DomainError) ErrorType() string {
+	return "DomainError"
+}
+
+// ErrorCode returns the machine-readable error code
+func (e *NotFoundError) ErrorCode() string {
+	return "NOT_FOUND"
+}
+
+// ErrorType returns the error type
+func (e *NotFoundError) ErrorType() string {
+	return "NotFoundError"
+}
+
+// ErrorCode returns the machine-readable error code
+func (e *ConflictError) ErrorCode() string {
+	return "CONFLICT"
+}
+
+// ErrorType returns the error type
+func (e *ConflictError) ErrorType() string {
+	return "ConflictError"
+}
+
+// ErrorCode returns the machine-readable error code
+func (e *TimeoutError) ErrorCode() string {
+	return "TOOL_TIMEOUT"
+}
+
+// ErrorType returns the error type
+func (e *TimeoutError) ErrorType() string {
+	return "TimeoutError"
+}
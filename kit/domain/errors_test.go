@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// upstreamError is a store error with a code, an HTTP status and a retry delay
+type upstreamError struct {
+	status     int
+	retryAfter time.Duration
+}
+
+func (e *upstreamError) Error() string                  { return fmt.Sprintf("store answered %d", e.status) }
+func (e *upstreamError) ErrorCode() string              { return "rest_too_many_requests" }
+func (e *upstreamError) ErrorType() string              { return "UpstreamError" }
+func (e *upstreamError) HTTPStatus() int                { return e.status }
+func (e *upstreamError) RetryAfterDelay() time.Duration { return e.retryAfter }
+
+func TestDescribeError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want ErrorDetail
+	}{
+		{
+			name: "validation",
+			err:  NewValidationError("base_url is required"),
+			want: ErrorDetail{Code: "VALIDATION_ERROR", Type: "ValidationError", Message: "validation error: base_url is required"},
+		},
+		{
+			name: "wrapped upstream",
+			err:  fmt.Errorf("failed to search products: %w", &upstreamError{status: 429, retryAfter: 1500 * time.Millisecond}),
+			want: ErrorDetail{Code: "rest_too_many_requests", Type: "UpstreamError", Message: "failed to search products: store answered 429", Status: 429, RetryAfterSeconds: 2},
+		},
+		{
+			name: "uncoded",
+			err:  errors.New("boom"),
+			want: ErrorDetail{Code: "INTERNAL_ERROR", Type: "InternalError", Message: "boom"},
+		},
+	}
+	for _, tt := range tests {
+		if got := DescribeError(tt.err); *got != tt.want {
+			t.Errorf("%s: got %+v, want %+v", tt.name, *got, tt.want)
+		}
+	}
+}
+
+func TestJSONRPCErrorDataOnlyDetailsRateLimits(t *testing.T) {
+	if data := JSONRPCErrorData(errors.New("boom")); data != "boom" {
+		t.Errorf("data = %v, want the plain message", data)
+	}
+	data := JSONRPCErrorData(&upstreamError{status: 429, retryAfter: 3 * time.Second})
+	if detail, ok := data.(*ErrorDetail); !ok || detail.RetryAfterSeconds != 3 {
+		t.Errorf("data = %+v, want an ErrorDetail with retry_after_seconds 3", data)
+	}
+}
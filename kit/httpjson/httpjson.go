@@ -0,0 +1,172 @@
+package httpjson
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"woocommerce-mcp/kit/charset"
+	"woocommerce-mcp/kit/storehttp"
+)
+
+// Client sends REST API requests and decodes their JSON responses, so every
+// store client reads bodies, checks statuses and reports malformed
+// responses the same way. Compressed bodies are decoded by the
+// contentencoding transport the clients send through; bodies in another
+// charset are transcoded to UTF-8 here. The hooks let each client keep its
+// own error types.
+type Client struct {
+	// Send sends a request, e.g. the Do method of an http.Client
+	Send func(req *http.Request) (*http.Response, error)
+
+	// Prepare adjusts each request before it is sent, e.g. to authenticate
+	// it; nil sends requests as they are
+	Prepare func(req *http.Request)
+
+	// ConnectionError reports a request that got no response, given the URL
+	// without its query string, which holds the credentials, and the cause
+	// of the failure; nil returns the cause wrapped
+	ConnectionError func(url string, err error) error
+
+	// StatusError reports a response with a status outside 2xx, given its
+	// UTF-8 body (empty for HEAD requests); nil returns a *StatusError
+	StatusError func(statusCode int, header http.Header, body []byte) error
+}
+
+// StatusError is a response with a status outside 2xx
+type StatusError struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+func (e *StatusError) Error() string {
+	message := strings.TrimSpace(string(e.Body))
+	if message == "" {
+		message = http.StatusText(e.StatusCode)
+	}
+	return fmt.Sprintf("API error (status %d): %s", e.StatusCode, message)
+}
+
+// ErrorMessage returns the message and code of a REST API error response,
+// read from a body such as {"code":"rest_forbidden","message":"..."}. A
+// body without a message is returned as it is, and an empty one as the
+// status text.
+func ErrorMessage(statusCode int, body []byte) (message, code string) {
+	message = string(body)
+	if len(body) == 0 {
+		message = http.StatusText(statusCode)
+	}
+
+	var apiError struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &apiError); err == nil && apiError.Message != "" {
+			message = apiError.Message
+		}
+	}
+	return message, apiError.Code
+}
+
+// NotJSONError is a successful response whose body is not JSON, typically
+// an HTML page a host or security plugin serves in place of the API
+type NotJSONError struct {
+	ContentType string
+	// Snippet is the start of the body, for the error message
+	Snippet string
+}
+
+func (e *NotJSONError) Error() string {
+	contentType := e.ContentType
+	if contentType == "" {
+		contentType = "no Content-Type"
+	}
+	return fmt.Sprintf("response is not JSON (%s): %q", contentType, e.Snippet)
+}
+
+// snippetLength bounds the body excerpt of a NotJSONError
+const snippetLength = 120
+
+// DoJSON sends a request without a body and decodes the JSON response into
+// out, returning the response headers (e.g. X-WP-Total). With a nil out,
+// typically for HEAD requests, the body is not decoded.
+func (c Client) DoJSON(ctx context.Context, method, url string, out interface{}) (http.Header, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", storehttp.RequestError(err))
+	}
+	if c.Prepare != nil {
+		c.Prepare(req)
+	}
+
+	resp, err := c.Send(req)
+	if err != nil {
+		err = storehttp.RequestError(err)
+		if c.ConnectionError != nil {
+			return nil, c.ConnectionError(storehttp.RedactedURL(url), err)
+		}
+		return nil, fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.Header, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// Hosts may serve the API in another charset; the JSON parser needs UTF-8
+	contentType := resp.Header.Get("Content-Type")
+	body, err = charset.ToUTF8(contentType, body)
+	if err != nil {
+		return resp.Header, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		if c.StatusError != nil {
+			return resp.Header, c.StatusError(resp.StatusCode, resp.Header, body)
+		}
+		return resp.Header, &StatusError{StatusCode: resp.StatusCode, Header: resp.Header, Body: body}
+	}
+
+	if out == nil {
+		return resp.Header, nil
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		if !looksLikeJSON(contentType, body) {
+			return resp.Header, &NotJSONError{ContentType: contentType, Snippet: snippet(body)}
+		}
+		return resp.Header, fmt.Errorf("failed to parse JSON response: %w", err)
+	}
+	return resp.Header, nil
+}
+
+// looksLikeJSON tells a malformed JSON body from one that is something else
+// entirely. Markup never is JSON; otherwise the Content-Type decides.
+func looksLikeJSON(contentType string, body []byte) bool {
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '<' {
+		return false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		// A missing or garbled Content-Type says nothing either way
+		return true
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// snippet returns the start of a body on one line
+func snippet(body []byte) string {
+	text := strings.Join(strings.Fields(string(body)), " ")
+	if len(text) > snippetLength {
+		text = text[:snippetLength] + "..."
+	}
+	return text
+}
@@ -0,0 +1,147 @@
+package httpjson
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// serve starts a server answering every request with the status, Content-Type
+// and body given
+func serve(t *testing.T, status int, contentType string, body []byte) string {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if contentType != "" {
+			w.Header().Set("Content-Type", contentType)
+		}
+		w.Header().Set("X-WP-Total", "3")
+		w.WriteHeader(status)
+		w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestDoJSONDecodesResponse(t *testing.T) {
+	url := serve(t, http.StatusOK, "application/json; charset=UTF-8", []byte(`{"name":"Café"}`))
+
+	var out struct{ Name string }
+	header, err := Client{Send: http.DefaultClient.Do}.DoJSON(context.Background(), "GET", url, &out)
+	if err != nil {
+		t.Fatalf("DoJSON: %v", err)
+	}
+	if out.Name != "Café" || header.Get("X-WP-Total") != "3" {
+		t.Errorf("got %q with X-WP-Total %q", out.Name, header.Get("X-WP-Total"))
+	}
+}
+
+func TestDoJSONTranscodesCharset(t *testing.T) {
+	// "Café" in ISO-8859-1
+	url := serve(t, http.StatusOK, "application/json; charset=ISO-8859-1", []byte("{\"name\":\"Caf\xe9\"}"))
+
+	var out struct{ Name string }
+	if _, err := (Client{Send: http.DefaultClient.Do}).DoJSON(context.Background(), "GET", url, &out); err != nil {
+		t.Fatalf("DoJSON: %v", err)
+	}
+	if out.Name != "Café" {
+		t.Errorf("got name %q, want Café", out.Name)
+	}
+}
+
+func TestDoJSONReportsNonJSONBody(t *testing.T) {
+	url := serve(t, http.StatusOK, "text/html", []byte("<html>\n  <body>Checking your browser</body>\n</html>"))
+
+	var out []interface{}
+	_, err := Client{Send: http.DefaultClient.Do}.DoJSON(context.Background(), "GET", url, &out)
+	var notJSON *NotJSONError
+	if !errors.As(err, &notJSON) {
+		t.Fatalf("got error %v, want a NotJSONError", err)
+	}
+	if notJSON.ContentType != "text/html" || notJSON.Snippet != "<html> <body>Checking your browser</body> </html>" {
+		t.Errorf("got %+v", notJSON)
+	}
+}
+
+func TestDoJSONReportsMalformedJSON(t *testing.T) {
+	url := serve(t, http.StatusOK, "application/json", []byte(`{"name":`))
+
+	var out map[string]interface{}
+	_, err := Client{Send: http.DefaultClient.Do}.DoJSON(context.Background(), "GET", url, &out)
+	var notJSON *NotJSONError
+	if err == nil || errors.As(err, &notJSON) || !strings.Contains(err.Error(), "failed to parse JSON response") {
+		t.Errorf("got error %v, want a parse error", err)
+	}
+}
+
+func TestDoJSONReportsStatus(t *testing.T) {
+	url := serve(t, http.StatusForbidden, "application/json", []byte(`{"code":"rest_forbidden","message":"Sorry, you cannot list resources."}`))
+
+	_, err := Client{Send: http.DefaultClient.Do}.DoJSON(context.Background(), "GET", url, nil)
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusForbidden {
+		t.Fatalf("got error %v, want a 403 StatusError", err)
+	}
+
+	// The client hook replaces the default error
+	hooked := Client{
+		Send: http.DefaultClient.Do,
+		StatusError: func(statusCode int, _ http.Header, body []byte) error {
+			message, code := ErrorMessage(statusCode, body)
+			return errors.New(code + ": " + message)
+		},
+	}
+	if _, err := hooked.DoJSON(context.Background(), "GET", url, nil); err == nil || err.Error() != "rest_forbidden: Sorry, you cannot list resources." {
+		t.Errorf("got error %v from the hook", err)
+	}
+}
+
+func TestDoJSONRedactsConnectionErrors(t *testing.T) {
+	// A closed server refuses the connection
+	server := httptest.NewServer(nil)
+	server.Close()
+	url := server.URL + "/wp-json/wc/v3/products?consumer_secret=cs_secret"
+
+	_, err := Client{Send: http.DefaultClient.Do}.DoJSON(context.Background(), "GET", url, nil)
+	if err == nil || strings.Contains(err.Error(), "cs_secret") {
+		t.Errorf("got error %v, want one without the credentials", err)
+	}
+
+	var reported string
+	client := Client{
+		Send: http.DefaultClient.Do,
+		ConnectionError: func(url string, err error) error {
+			reported = url
+			return err
+		},
+	}
+	client.DoJSON(context.Background(), "GET", url, nil)
+	if reported != server.URL+"/wp-json/wc/v3/products" {
+		t.Errorf("ConnectionError got URL %q, want it without the query", reported)
+	}
+}
+
+func TestErrorMessage(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantMessage string
+		wantCode    string
+	}{
+		{"rest error", 401, `{"code":"woocommerce_rest_cannot_view","message":"Sorry, you cannot list resources."}`, "Sorry, you cannot list resources.", "woocommerce_rest_cannot_view"},
+		{"no message", 500, `{"code":"internal"}`, `{"code":"internal"}`, "internal"},
+		{"plain text", 502, "Bad Gateway from upstream", "Bad Gateway from upstream", ""},
+		{"empty body", 503, "", "Service Unavailable", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message, code := ErrorMessage(tt.status, []byte(tt.body))
+			if message != tt.wantMessage || code != tt.wantCode {
+				t.Errorf("ErrorMessage() = %q, %q; want %q, %q", message, code, tt.wantMessage, tt.wantCode)
+			}
+		})
+	}
+}
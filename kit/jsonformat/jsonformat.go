@@ -0,0 +1,32 @@
+package jsonformat
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// Marshal serializes a tool response as compact JSON, or indented with two
+// spaces when pretty is set. Compact output is about half the size, which
+// matters when the data is passed to a language model.
+func Marshal(v interface{}, pretty bool) (string, error) {
+	var data []byte
+	var err error
+	if pretty {
+		data, err = json.MarshalIndent(v, "", "  ")
+	} else {
+		data, err = json.Marshal(v)
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// ParsePretty parses a pretty tool argument; an empty value means compact output
+func ParsePretty(value string) (bool, error) {
+	if value = strings.TrimSpace(value); value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
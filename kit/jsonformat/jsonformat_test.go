@@ -0,0 +1,65 @@
+package jsonformat
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var sample = map[string]interface{}{
+	"query":    "shoes",
+	"products": []map[string]interface{}{{"id": 1, "name": "Running Shoes"}, {"id": 2, "name": "Trail Shoes"}},
+}
+
+func TestCompactOutputHasNoIndentation(t *testing.T) {
+	compact, err := Marshal(sample, false)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if strings.ContainsAny(compact, "\n\t") || strings.Contains(compact, ": ") {
+		t.Errorf("compact output is indented: %s", compact)
+	}
+	if !json.Valid([]byte(compact)) {
+		t.Errorf("compact output is not valid JSON: %s", compact)
+	}
+
+	pretty, err := Marshal(sample, true)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if !strings.Contains(pretty, "\n  \"") {
+		t.Errorf("pretty output is not indented with two spaces: %s", pretty)
+	}
+	if len(compact) >= len(pretty) {
+		t.Errorf("compact output (%d bytes) is not smaller than pretty output (%d bytes)", len(compact), len(pretty))
+	}
+
+	var fromCompact, fromPretty interface{}
+	json.Unmarshal([]byte(compact), &fromCompact)
+	json.Unmarshal([]byte(pretty), &fromPretty)
+	if !reflect.DeepEqual(fromCompact, fromPretty) {
+		t.Error("compact and pretty output hold different values")
+	}
+}
+
+func TestParsePretty(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    bool
+		wantErr bool
+	}{
+		{value: "", want: false},
+		{value: "  ", want: false},
+		{value: "true", want: true},
+		{value: " false ", want: false},
+		{value: "1", want: true},
+		{value: "yes", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParsePretty(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParsePretty(%q) = %v, %v; want %v, error %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package pagination
+
+import "context"
+
+// PageFunc fetches one page of a result set and reports whether another
+// page follows it
+type PageFunc[T any] func(ctx context.Context, page int) (T, bool, error)
+
+// Iterator walks a paginated result set lazily, fetching one page per call
+// to Next, so aggregations share one paging loop:
+//
+//	pages := pagination.NewIterator(1, fetch)
+//	for pages.Next(ctx) {
+//		use(pages.Page())
+//	}
+//	if err := pages.Err(); err != nil { ... }
+//
+// A caller that has what it needs simply stops calling Next. Iteration ends
+// after the last page, at the first fetch error, or once the context is done;
+// Err then tells which.
+type Iterator[T any] struct {
+	fetch PageFunc[T]
+	next  int
+	done  bool
+	page  T
+	err   error
+}
+
+// NewIterator creates an iterator starting at the given page
+func NewIterator[T any](first int, fetch PageFunc[T]) *Iterator[T] {
+	if first < 1 {
+		first = 1
+	}
+	return &Iterator[T]{fetch: fetch, next: first}
+}
+
+// Next fetches the next page and reports whether there was one
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	if it.done {
+		return false
+	}
+	if err := ctx.Err(); err != nil {
+		it.done, it.err = true, err
+		return false
+	}
+
+	page, more, err := it.fetch(ctx, it.next)
+	if err != nil {
+		it.done, it.err = true, err
+		return false
+	}
+
+	it.page = page
+	it.next++
+	it.done = !more
+	return true
+}
+
+// Page returns the page fetched by the last successful call to Next
+func (it *Iterator[T]) Page() T {
+	return it.page
+}
+
+// PageNumber returns the number of the page fetched by the last successful
+// call to Next
+func (it *Iterator[T]) PageNumber() int {
+	return it.next - 1
+}
+
+// Err returns the error that ended the iteration, or nil when it ended after
+// the last page or has not ended yet
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
@@ -0,0 +1,119 @@
+package pagination
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// pagesOf serves the given pages, recording the page numbers fetched
+func pagesOf(pages [][]int, fetched *[]int) PageFunc[[]int] {
+	return func(ctx context.Context, page int) ([]int, bool, error) {
+		*fetched = append(*fetched, page)
+		return pages[page-1], page < len(pages), nil
+	}
+}
+
+func TestIteratorCrawlsEveryPage(t *testing.T) {
+	var fetched []int
+	pages := NewIterator(1, pagesOf([][]int{{1, 2}, {3, 4}, {5}}, &fetched))
+
+	var items []int
+	for pages.Next(context.Background()) {
+		if pages.PageNumber() != len(fetched) {
+			t.Errorf("page number = %d, want %d", pages.PageNumber(), len(fetched))
+		}
+		items = append(items, pages.Page()...)
+	}
+	if err := pages.Err(); err != nil {
+		t.Fatalf("crawl: %v", err)
+	}
+	if len(items) != 5 || items[0] != 1 || items[4] != 5 {
+		t.Errorf("items = %v, want 1 to 5", items)
+	}
+	if len(fetched) != 3 {
+		t.Errorf("fetched pages %v, want 3", fetched)
+	}
+	if pages.Next(context.Background()) {
+		t.Error("Next after the last page reported another page")
+	}
+}
+
+func TestIteratorStartsAtTheGivenPage(t *testing.T) {
+	var fetched []int
+	pages := NewIterator(2, pagesOf([][]int{{1}, {2}, {3}}, &fetched))
+	for pages.Next(context.Background()) {
+	}
+	if len(fetched) != 2 || fetched[0] != 2 {
+		t.Errorf("fetched pages %v, want 2 and 3", fetched)
+	}
+
+	fetched = nil
+	pages = NewIterator(0, pagesOf([][]int{{1}}, &fetched))
+	for pages.Next(context.Background()) {
+	}
+	if len(fetched) != 1 || fetched[0] != 1 {
+		t.Errorf("fetched pages %v from page 0, want page 1", fetched)
+	}
+}
+
+func TestIteratorFetchesNothingPastWhereTheCallerStops(t *testing.T) {
+	var fetched []int
+	pages := NewIterator(1, pagesOf([][]int{{1}, {2}, {3}, {4}}, &fetched))
+
+	if !pages.Next(context.Background()) {
+		t.Fatalf("first page: %v", pages.Err())
+	}
+	if len(fetched) != 1 {
+		t.Errorf("fetched pages %v after one Next, want only page 1", fetched)
+	}
+	if pages.Err() != nil {
+		t.Errorf("Err = %v before the iteration ended", pages.Err())
+	}
+}
+
+func TestIteratorStopsAtTheFirstError(t *testing.T) {
+	failure := errors.New("store unavailable")
+	var fetched []int
+	pages := NewIterator(1, func(ctx context.Context, page int) ([]int, bool, error) {
+		fetched = append(fetched, page)
+		if page == 2 {
+			return nil, false, failure
+		}
+		return []int{page}, true, nil
+	})
+
+	var items []int
+	for pages.Next(context.Background()) {
+		items = append(items, pages.Page()...)
+	}
+	if !errors.Is(pages.Err(), failure) {
+		t.Errorf("Err = %v, want %v", pages.Err(), failure)
+	}
+	if len(items) != 1 || items[0] != 1 {
+		t.Errorf("items = %v, want only the page before the error", items)
+	}
+	if pages.Next(context.Background()) || len(fetched) != 2 {
+		t.Errorf("fetched pages %v, want no fetch after the error", fetched)
+	}
+}
+
+func TestIteratorStopsWhenTheContextIsDone(t *testing.T) {
+	var fetched []int
+	pages := NewIterator(1, pagesOf([][]int{{1}, {2}, {3}}, &fetched))
+	ctx, cancel := context.WithCancel(context.Background())
+
+	if !pages.Next(ctx) {
+		t.Fatalf("first page: %v", pages.Err())
+	}
+	cancel()
+	if pages.Next(ctx) {
+		t.Error("Next after cancellation reported another page")
+	}
+	if !errors.Is(pages.Err(), context.Canceled) {
+		t.Errorf("Err = %v, want context.Canceled", pages.Err())
+	}
+	if len(fetched) != 1 {
+		t.Errorf("fetched pages %v, want none after cancellation", fetched)
+	}
+}
@@ -0,0 +1,42 @@
+package pagination
+
+// MaxPerPage is the largest page size the WooCommerce and WordPress REST APIs
+// accept; larger requests are clamped to it.
+const MaxPerPage = 100
+
+// Pagination describes the position of a page within a paginated result set.
+// NextPage and PrevPage are nil at the boundaries so callers can request the
+// adjacent page without recomputing it.
+type Pagination struct {
+	CurrentPage int   `json:"current_page"`
+	PerPage     int   `json:"per_page"`
+	Total       int64 `json:"total"`
+	TotalPages  int   `json:"total_pages"`
+	NextPage    *int  `json:"next_page"`
+	PrevPage    *int  `json:"prev_page"`
+}
+
+// New creates the pagination for a page of a result set
+func New(total int64, currentPage, perPage, totalPages int) *Pagination {
+	p := &Pagination{
+		CurrentPage: currentPage,
+		PerPage:     perPage,
+		Total:       total,
+		TotalPages:  totalPages,
+	}
+
+	if currentPage < totalPages {
+		next := currentPage + 1
+		p.NextPage = &next
+	}
+	if currentPage > 1 {
+		// Past the last page, point back at the last page that has results
+		prev := currentPage - 1
+		if totalPages > 0 && prev > totalPages {
+			prev = totalPages
+		}
+		p.PrevPage = &prev
+	}
+
+	return p
+}
@@ -0,0 +1,43 @@
+package pagination
+
+import "testing"
+
+// pageNumber renders an optional page number for test messages
+func pageNumber(page *int) interface{} {
+	if page == nil {
+		return nil
+	}
+	return *page
+}
+
+func TestNextAndPrevPagesAreNilAtTheBoundaries(t *testing.T) {
+	tests := []struct {
+		name       string
+		current    int
+		totalPages int
+		next       interface{}
+		prev       interface{}
+	}{
+		{name: "only page", current: 1, totalPages: 1, next: nil, prev: nil},
+		{name: "first page", current: 1, totalPages: 3, next: 2, prev: nil},
+		{name: "middle page", current: 2, totalPages: 3, next: 3, prev: 1},
+		{name: "last page", current: 3, totalPages: 3, next: nil, prev: 2},
+		{name: "past the last page", current: 7, totalPages: 3, next: nil, prev: 3},
+		{name: "no results", current: 2, totalPages: 0, next: nil, prev: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(25, tt.current, 10, tt.totalPages)
+			if got := pageNumber(p.NextPage); got != tt.next {
+				t.Errorf("next_page = %v, want %v", got, tt.next)
+			}
+			if got := pageNumber(p.PrevPage); got != tt.prev {
+				t.Errorf("prev_page = %v, want %v", got, tt.prev)
+			}
+			if p.CurrentPage != tt.current || p.PerPage != 10 || p.Total != 25 || p.TotalPages != tt.totalPages {
+				t.Errorf("pagination = %+v, want the given position", p)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package pagination
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParsePage parses a page tool argument. The error describes what is wrong
+// without naming the argument, e.g. "must be a positive integer, got '0'",
+// so callers can report it in their own validation error.
+func ParsePage(value string) (int, error) {
+	return parsePositive(value, "a positive integer")
+}
+
+// ParsePerPage parses a per_page tool argument like ParsePage. Values above
+// MaxPerPage are returned as is for the caller to clamp.
+func ParsePerPage(value string) (int, error) {
+	return parsePositive(value, fmt.Sprintf("a positive integer (1-%d)", MaxPerPage))
+}
+
+// parsePositive parses a positive integer, telling a non-numeric value, zero
+// and a negative value apart in its error
+func parsePositive(value, want string) (int, error) {
+	trimmed := strings.TrimSpace(value)
+	n, err := strconv.Atoi(trimmed)
+	switch {
+	case errors.Is(err, strconv.ErrRange):
+		return 0, fmt.Errorf("must be %s, got out-of-range '%s'", want, trimmed)
+	case err != nil:
+		return 0, fmt.Errorf("must be %s, got non-numeric '%s'", want, trimmed)
+	case n == 0:
+		return 0, fmt.Errorf("must be %s, got '0'", want)
+	case n < 0:
+		return 0, fmt.Errorf("must be %s, got negative '%s'", want, trimmed)
+	}
+	return n, nil
+}
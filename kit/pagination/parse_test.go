@@ -0,0 +1,69 @@
+package pagination
+
+import "testing"
+
+func TestParsePerPage(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int
+		wantErr string
+	}{
+		{value: "0", wantErr: "must be a positive integer (1-100), got '0'"},
+		{value: "-1", wantErr: "must be a positive integer (1-100), got negative '-1'"},
+		{value: "abc", wantErr: "must be a positive integer (1-100), got non-numeric 'abc'"},
+		{value: "99999999999999999999", wantErr: "must be a positive integer (1-100), got out-of-range '99999999999999999999'"},
+		{value: "10", want: 10},
+		{value: " 10 ", want: 10},
+		{value: "101", want: 101},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParsePerPage(tt.value)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParsePerPage(%q): %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParsePerPage(%q) = %d, want %d", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePage(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int
+		wantErr string
+	}{
+		{value: "0", wantErr: "must be a positive integer, got '0'"},
+		{value: "-1", wantErr: "must be a positive integer, got negative '-1'"},
+		{value: "abc", wantErr: "must be a positive integer, got non-numeric 'abc'"},
+		{value: "10", want: 10},
+		{value: "101", want: 101},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParsePage(tt.value)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParsePage(%q): %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParsePage(%q) = %d, want %d", tt.value, got, tt.want)
+			}
+		})
+	}
+}
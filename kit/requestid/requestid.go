@@ -0,0 +1,90 @@
+package requestid
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// Header is the HTTP header carrying the request ID, on incoming calls,
+// their responses and the upstream requests they make
+const Header = "X-Request-ID"
+
+// maxLength bounds the length of an incoming request ID
+const maxLength = 128
+
+// New generates a request ID
+func New() string {
+	return uuid.NewString()
+}
+
+// Valid reports whether an incoming request ID can be used as is: it must be
+// non-empty, at most 128 characters and printable ASCII without spaces, so it
+// is safe to echo in headers and log lines
+func Valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// idKey is the context key of the request ID
+type idKey struct{}
+
+// WithID returns a context carrying the request ID
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, idKey{}, id)
+}
+
+// FromContext returns the request ID of a context, or "" when it has none
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(idKey{}).(string)
+	return id
+}
+
+// Logf logs a line of the call a context belongs to, prefixed with its
+// request ID when it has one
+func Logf(ctx context.Context, format string, args ...interface{}) {
+	if id := FromContext(ctx); id != "" {
+		log.Printf("[request_id=%s] %s", id, fmt.Sprintf(format, args...))
+		return
+	}
+	log.Printf(format, args...)
+}
+
+// Transport sends the request ID of the request context upstream in the
+// X-Request-ID header, so a tool call can be traced into the store's logs
+type Transport struct {
+	Base http.RoundTripper
+}
+
+// NewTransport wraps a transport with request ID propagation
+func NewTransport(base http.RoundTripper) *Transport {
+	return &Transport{Base: base}
+}
+
+// RoundTrip adds the request ID header, if any, and sends the request
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if id := FromContext(req.Context()); id != "" && req.Header.Get(Header) == "" {
+		// A RoundTripper must not modify the caller's request
+		req = req.Clone(req.Context())
+		req.Header.Set(Header, id)
+	}
+	return t.Base.RoundTrip(req)
+}
+
+// CloseIdleConnections closes the idle connections of the base transport, if
+// it keeps any, so http.Client.CloseIdleConnections reaches through the wrapper
+func (t *Transport) CloseIdleConnections() {
+	if closer, ok := t.Base.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+}
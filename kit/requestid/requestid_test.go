@@ -0,0 +1,101 @@
+package requestid
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestValid(t *testing.T) {
+	for id, want := range map[string]bool{
+		"":                       false,
+		"trace-123":              true,
+		New():                    true,
+		"has spaces":             false,
+		"tab\there":              false,
+		"café":                   false,
+		strings.Repeat("a", 128): true,
+		strings.Repeat("a", 129): false,
+	} {
+		if got := Valid(id); got != want {
+			t.Errorf("Valid(%q) = %v, want %v", id, got, want)
+		}
+	}
+}
+
+func TestTransportSendsTheContextID(t *testing.T) {
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get(Header))
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: NewTransport(http.DefaultTransport)}
+
+	send := func(ctx context.Context, header string) *http.Request {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		if header != "" {
+			req.Header.Set(Header, header)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return req
+	}
+
+	req := send(WithID(context.Background(), "trace-123"), "")
+	send(context.Background(), "")
+	send(WithID(context.Background(), "trace-123"), "explicit")
+
+	if want := []string{"trace-123", "", "explicit"}; strings.Join(received, ",") != strings.Join(want, ",") {
+		t.Errorf("server received IDs %q, want %q", received, want)
+	}
+	if req.Header.Get(Header) != "" {
+		t.Error("the transport modified the caller's request")
+	}
+}
+
+func TestLogfPrefixesTheID(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	flags := log.Flags()
+	log.SetFlags(0)
+	defer func() {
+		log.SetOutput(os.Stderr)
+		log.SetFlags(flags)
+	}()
+
+	Logf(WithID(context.Background(), "trace-123"), "searched %d products", 3)
+	Logf(context.Background(), "no id")
+
+	if want := "[request_id=trace-123] searched 3 products\nno id\n"; buf.String() != want {
+		t.Errorf("logged %q, want %q", buf.String(), want)
+	}
+}
+
+// idleCloser records whether its idle connections were closed
+type idleCloser struct {
+	http.RoundTripper
+	closed bool
+}
+
+func (c *idleCloser) CloseIdleConnections() {
+	c.closed = true
+}
+
+func TestCloseIdleConnectionsReachesTheBaseTransport(t *testing.T) {
+	base := &idleCloser{RoundTripper: http.DefaultTransport}
+	(&http.Client{Transport: NewTransport(base)}).CloseIdleConnections()
+	if !base.closed {
+		t.Error("closing the client's idle connections did not reach the base transport")
+	}
+
+	// A base transport without idle connections is left alone
+	NewTransport(http.NewFileTransport(http.Dir("."))).CloseIdleConnections()
+}
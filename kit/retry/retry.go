@@ -0,0 +1,235 @@
+package retry
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	kitDomain "woocommerce-mcp/kit/domain"
+)
+
+// Environment variables configuring the default retry policy of API requests
+const (
+	MaxRetriesEnv = "API_MAX_RETRIES"
+	BackoffEnv    = "API_RETRY_BACKOFF_MS"
+)
+
+// Retry policy defaults. Requests are not retried unless configured.
+const (
+	DefaultMaxRetries = 0
+	DefaultBackoff    = 200 * time.Millisecond
+)
+
+// Bounds of the retry policy, for the environment and per-call overrides alike
+const (
+	MaxRetriesLimit = 5
+	BackoffLimit    = 10 * time.Second
+)
+
+// Policy is how often a failed request is retried and how long to wait
+// before the first retry; the wait doubles with each further retry
+type Policy struct {
+	MaxRetries int
+	Backoff    time.Duration
+}
+
+// DefaultPolicy returns the policy configured through the environment,
+// falling back to the defaults for unset, invalid or out-of-range values
+func DefaultPolicy() Policy {
+	policy := Policy{MaxRetries: DefaultMaxRetries, Backoff: DefaultBackoff}
+
+	if value := os.Getenv(MaxRetriesEnv); value != "" {
+		if maxRetries, err := parseMaxRetries(value); err == nil {
+			policy.MaxRetries = maxRetries
+		}
+	}
+	if value := os.Getenv(BackoffEnv); value != "" {
+		if backoff, err := parseBackoff(value); err == nil {
+			policy.Backoff = backoff
+		}
+	}
+
+	return policy
+}
+
+// policyKey is the context key of a per-call policy
+type policyKey struct{}
+
+// WithPolicy returns a context whose requests use the given policy
+func WithPolicy(ctx context.Context, policy Policy) context.Context {
+	return context.WithValue(ctx, policyKey{}, policy)
+}
+
+// PolicyFrom returns the policy of a context, or the default policy
+func PolicyFrom(ctx context.Context) Policy {
+	if policy, ok := ctx.Value(policyKey{}).(Policy); ok {
+		return policy
+	}
+	return DefaultPolicy()
+}
+
+// Override applies the max_retries and retry_backoff_ms tool arguments to
+// the policy of a context. Empty arguments keep the current value; values
+// outside the bounds are rejected with a validation error.
+func Override(ctx context.Context, maxRetries, backoffMS string) (context.Context, error) {
+	maxRetries, backoffMS = strings.TrimSpace(maxRetries), strings.TrimSpace(backoffMS)
+	if maxRetries == "" && backoffMS == "" {
+		return ctx, nil
+	}
+
+	policy := PolicyFrom(ctx)
+	if maxRetries != "" {
+		parsed, err := parseMaxRetries(maxRetries)
+		if err != nil {
+			return ctx, kitDomain.NewValidationError(fmt.Sprintf("max_retries must be an integer between 0 and %d", MaxRetriesLimit))
+		}
+		policy.MaxRetries = parsed
+	}
+	if backoffMS != "" {
+		parsed, err := parseBackoff(backoffMS)
+		if err != nil {
+			return ctx, kitDomain.NewValidationError(fmt.Sprintf("retry_backoff_ms must be an integer between 0 and %d", BackoffLimit.Milliseconds()))
+		}
+		policy.Backoff = parsed
+	}
+
+	return WithPolicy(ctx, policy), nil
+}
+
+// parseMaxRetries parses a retry count within the bounds
+func parseMaxRetries(value string) (int, error) {
+	maxRetries, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0, err
+	}
+	if maxRetries < 0 || maxRetries > MaxRetriesLimit {
+		return 0, fmt.Errorf("max retries %d out of range", maxRetries)
+	}
+	return maxRetries, nil
+}
+
+// parseBackoff parses a backoff in milliseconds within the bounds
+func parseBackoff(value string) (time.Duration, error) {
+	ms, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0, err
+	}
+	backoff := time.Duration(ms) * time.Millisecond
+	if backoff < 0 || backoff > BackoffLimit {
+		return 0, fmt.Errorf("backoff %dms out of range", ms)
+	}
+	return backoff, nil
+}
+
+// ParseRetryAfter parses a Retry-After header, given either as seconds or as
+// an HTTP date. ok is false when the header is missing or invalid; dates in
+// the past mean no wait.
+func ParseRetryAfter(value string, now time.Time) (wait time.Duration, ok bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if wait = date.Sub(now); wait < 0 {
+		wait = 0
+	}
+	return wait, true
+}
+
+// Transport retries requests that failed transiently, following the policy
+// of the request context. Only GET and HEAD requests without a body are
+// retried; connection errors and 429, 500, 502, 503 and 504 responses count
+// as transient. A Retry-After header longer than the backoff is waited out,
+// unless it exceeds BackoffLimit, in which case the response is returned so
+// the caller can report it. Waits between attempts end early when the
+// context is done.
+type Transport struct {
+	Base http.RoundTripper
+}
+
+// NewTransport wraps a transport with retries
+func NewTransport(base http.RoundTripper) *Transport {
+	return &Transport{Base: base}
+}
+
+// RoundTrip sends the request, retrying it as the policy allows
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	policy := PolicyFrom(req.Context())
+	if !replayable(req) {
+		policy.MaxRetries = 0
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := t.Base.RoundTrip(req)
+		if attempt >= policy.MaxRetries || req.Context().Err() != nil || !transient(resp, err) {
+			return resp, err
+		}
+
+		wait := policy.Backoff << attempt
+		if resp != nil {
+			if retryAfter, ok := ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				if retryAfter > BackoffLimit {
+					return resp, nil
+				}
+				if retryAfter > wait {
+					wait = retryAfter
+				}
+			}
+
+			// Drain the failed response so its connection can be reused
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// replayable reports whether a request can safely be sent again
+func replayable(req *http.Request) bool {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return false
+	}
+	return req.Body == nil || req.Body == http.NoBody
+}
+
+// transient reports whether a request outcome is worth retrying
+func transient(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// CloseIdleConnections closes the idle connections of the base transport, if
+// it keeps any, so http.Client.CloseIdleConnections reaches through the wrapper
+func (t *Transport) CloseIdleConnections() {
+	if closer, ok := t.Base.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+}
@@ -0,0 +1,162 @@
+package retry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakyServer fails the first failures requests with the status given and
+// answers the rest; it returns the URL and the number of requests received
+func flakyServer(t *testing.T, failures int, status int) (string, *int32) {
+	t.Helper()
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if int(atomic.AddInt32(&attempts, 1)) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(server.Close)
+	return server.URL, &attempts
+}
+
+// send sends a request through a retrying transport with the given policy
+func send(t *testing.T, method, url string, policy Policy) *http.Response {
+	t.Helper()
+	req, _ := http.NewRequestWithContext(WithPolicy(context.Background(), policy), method, url, nil)
+	resp, err := (&http.Client{Transport: NewTransport(http.DefaultTransport)}).Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, url, err)
+	}
+	resp.Body.Close()
+	return resp
+}
+
+func TestPolicyChangesTheNumberOfAttempts(t *testing.T) {
+	for _, tt := range []struct {
+		maxRetries int
+		status     int
+		attempts   int32
+	}{
+		{maxRetries: 0, status: http.StatusServiceUnavailable, attempts: 1},
+		{maxRetries: 1, status: http.StatusServiceUnavailable, attempts: 2},
+		{maxRetries: 3, status: http.StatusOK, attempts: 3},
+	} {
+		url, attempts := flakyServer(t, 2, http.StatusServiceUnavailable)
+		resp := send(t, http.MethodGet, url, Policy{MaxRetries: tt.maxRetries, Backoff: time.Millisecond})
+		if resp.StatusCode != tt.status || atomic.LoadInt32(attempts) != tt.attempts {
+			t.Errorf("max retries %d: got %d after %d attempt(s), want %d after %d", tt.maxRetries, resp.StatusCode, *attempts, tt.status, tt.attempts)
+		}
+	}
+}
+
+func TestOnlyTransientFailuresOfReplayableRequestsAreRetried(t *testing.T) {
+	policy := Policy{MaxRetries: 3, Backoff: time.Millisecond}
+
+	url, attempts := flakyServer(t, 1, http.StatusNotFound)
+	if send(t, http.MethodGet, url, policy); atomic.LoadInt32(attempts) != 1 {
+		t.Errorf("404: %d attempts, want 1", *attempts)
+	}
+
+	url, attempts = flakyServer(t, 1, http.StatusServiceUnavailable)
+	if send(t, http.MethodPost, url, policy); atomic.LoadInt32(attempts) != 1 {
+		t.Errorf("POST: %d attempts, want 1", *attempts)
+	}
+}
+
+func TestLongRetryAfterIsReturnedRatherThanWaited(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	resp := send(t, http.MethodGet, server.URL, Policy{MaxRetries: 3, Backoff: time.Millisecond})
+	if resp.StatusCode != http.StatusTooManyRequests || atomic.LoadInt32(&attempts) != 1 {
+		t.Errorf("got %d after %d attempt(s), want the 429 at once", resp.StatusCode, attempts)
+	}
+}
+
+func TestOverrideIsBounded(t *testing.T) {
+	t.Setenv(MaxRetriesEnv, "2")
+	t.Setenv(BackoffEnv, "50")
+
+	ctx, err := Override(context.Background(), "", "")
+	if err != nil || PolicyFrom(ctx) != (Policy{MaxRetries: 2, Backoff: 50 * time.Millisecond}) {
+		t.Errorf("no override: policy %+v, %v; want the environment policy", PolicyFrom(ctx), err)
+	}
+
+	ctx, err = Override(context.Background(), " 4 ", "")
+	if err != nil || PolicyFrom(ctx) != (Policy{MaxRetries: 4, Backoff: 50 * time.Millisecond}) {
+		t.Errorf("max_retries=4: policy %+v, %v", PolicyFrom(ctx), err)
+	}
+
+	for _, tt := range []struct{ maxRetries, backoffMS, field string }{
+		{"6", "", "max_retries"},
+		{"-1", "", "max_retries"},
+		{"many", "", "max_retries"},
+		{"", "10001", "retry_backoff_ms"},
+		{"", "-5", "retry_backoff_ms"},
+	} {
+		if _, err := Override(context.Background(), tt.maxRetries, tt.backoffMS); err == nil || !strings.Contains(err.Error(), tt.field) {
+			t.Errorf("max_retries=%q retry_backoff_ms=%q: got error %v, want a %s error", tt.maxRetries, tt.backoffMS, err, tt.field)
+		}
+	}
+}
+
+func TestDefaultPolicyIgnoresOutOfRangeValues(t *testing.T) {
+	t.Setenv(MaxRetriesEnv, "50")
+	t.Setenv(BackoffEnv, "soon")
+	if got := DefaultPolicy(); got != (Policy{MaxRetries: DefaultMaxRetries, Backoff: DefaultBackoff}) {
+		t.Errorf("policy = %+v, want the defaults", got)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		value string
+		wait  time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"120", 2 * time.Minute, true},
+		{"-1", 0, false},
+		{"Wed, 01 May 2024 12:00:30 GMT", 30 * time.Second, true},
+		{"Wed, 01 May 2024 11:00:00 GMT", 0, true},
+		{"later", 0, false},
+	} {
+		if wait, ok := ParseRetryAfter(tt.value, now); wait != tt.wait || ok != tt.ok {
+			t.Errorf("ParseRetryAfter(%q) = %s, %v; want %s, %v", tt.value, wait, ok, tt.wait, tt.ok)
+		}
+	}
+}
+
+// idleCloser records whether its idle connections were closed
+type idleCloser struct {
+	http.RoundTripper
+	closed bool
+}
+
+func (c *idleCloser) CloseIdleConnections() {
+	c.closed = true
+}
+
+func TestCloseIdleConnectionsReachesTheBaseTransport(t *testing.T) {
+	base := &idleCloser{RoundTripper: http.DefaultTransport}
+	(&http.Client{Transport: NewTransport(base)}).CloseIdleConnections()
+	if !base.closed {
+		t.Error("closing the client's idle connections did not reach the base transport")
+	}
+
+	// A base transport without idle connections is left alone
+	NewTransport(http.NewFileTransport(http.Dir("."))).CloseIdleConnections()
+}
@@ -0,0 +1,41 @@
+package safemode
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Env turns on safe mode, for servers behind a public-facing chatbot: the
+// tools only return published content, whatever statuses or credentials a
+// caller passes
+const Env = "SAFE_MODE"
+
+// PublishStatus is the only product and post status safe mode returns
+const PublishStatus = "publish"
+
+// Enabled reports whether safe mode is on; an unset or invalid value keeps
+// it off
+func Enabled() bool {
+	enabled, err := strconv.ParseBool(strings.TrimSpace(os.Getenv(Env)))
+	return err == nil && enabled
+}
+
+// UnpublishedStatus returns the first status of a comma-separated list other
+// than publish, or "" when the list only asks for published content
+func UnpublishedStatus(statuses string) string {
+	for _, status := range strings.Split(statuses, ",") {
+		status = strings.ToLower(strings.TrimSpace(status))
+		if status != "" && status != PublishStatus {
+			return status
+		}
+	}
+	return ""
+}
+
+// Rejection explains that safe mode refuses what a request asked for, e.g.
+// Rejection("status 'draft'")
+func Rejection(what string) string {
+	return fmt.Sprintf("%s is not available: the server runs in safe mode (%s) and only returns published content", what, Env)
+}
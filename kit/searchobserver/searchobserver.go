@@ -0,0 +1,94 @@
+package searchobserver
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Kinds of observed searches
+const (
+	KindProducts = "products"
+	KindPosts    = "posts"
+)
+
+// Search describes a search to observers. It only carries the filter
+// summary, never the store URL or credentials.
+type Search struct {
+	// Kind is KindProducts or KindPosts
+	Kind string
+	// Summary describes the effective filters, e.g. "search='shoes', in
+	// stock only"; it is empty for an unfiltered listing
+	Summary string
+}
+
+// Result describes the outcome of an observed search
+type Result struct {
+	// Count is the number of results returned, 0 when the search failed
+	Count int
+	// TotalCount is the number of results across all pages
+	TotalCount int
+	// Duration is how long the search took
+	Duration time.Duration
+	// Err is the error the search failed with, or nil
+	Err error
+}
+
+// SearchObserver is notified of product and post searches, e.g. to record
+// metrics or track popular search terms. Observers are called synchronously
+// on the search path, so they should return quickly.
+type SearchObserver interface {
+	// OnSearch is called before a search is sent to the store
+	OnSearch(ctx context.Context, search Search)
+	// OnResult is called once the search succeeded or failed
+	OnResult(ctx context.Context, search Search, result Result)
+}
+
+// Nop is a SearchObserver that ignores every search
+type Nop struct{}
+
+// OnSearch does nothing
+func (Nop) OnSearch(context.Context, Search) {}
+
+// OnResult does nothing
+func (Nop) OnResult(context.Context, Search, Result) {}
+
+var defaultObserver struct {
+	sync.RWMutex
+	observer SearchObserver
+}
+
+// SetDefault sets the observer given to searchers created afterwards; nil
+// restores the no-op observer. Embedders call it once at startup.
+func SetDefault(observer SearchObserver) {
+	defaultObserver.Lock()
+	defer defaultObserver.Unlock()
+	defaultObserver.observer = observer
+}
+
+// Default returns the observer set with SetDefault, or Nop
+func Default() SearchObserver {
+	defaultObserver.RLock()
+	defer defaultObserver.RUnlock()
+	if defaultObserver.observer == nil {
+		return Nop{}
+	}
+	return defaultObserver.observer
+}
+
+// Observe notifies an observer of a search, runs it and notifies the
+// observer of its result. count reports the returned and total number of
+// results of a successful search.
+func Observe[T any](ctx context.Context, observer SearchObserver, search Search, run func() (T, error), count func(T) (int, int)) (T, error) {
+	observer.OnSearch(ctx, search)
+
+	start := time.Now()
+	response, err := run()
+	result := Result{Duration: time.Since(start), Err: err}
+	if err == nil {
+		result.Count, result.TotalCount = count(response)
+	}
+
+	observer.OnResult(ctx, search, result)
+	return response, err
+}
@@ -0,0 +1,84 @@
+package searchobserver
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// recorder records the callbacks it receives
+type recorder struct {
+	searches []Search
+	results  []Result
+}
+
+func (r *recorder) OnSearch(ctx context.Context, search Search) {
+	r.searches = append(r.searches, search)
+}
+
+func (r *recorder) OnResult(ctx context.Context, search Search, result Result) {
+	r.results = append(r.results, result)
+}
+
+func TestObserveReportsTheSearchAndItsResult(t *testing.T) {
+	observer := &recorder{}
+	search := Search{Kind: KindProducts, Summary: "search='shoes'"}
+
+	got, err := Observe(context.Background(), observer, search, func() ([]int, error) {
+		if len(observer.searches) != 1 {
+			t.Error("OnSearch was not called before the search ran")
+		}
+		return []int{1, 2}, nil
+	}, func(ids []int) (int, int) {
+		return len(ids), 7
+	})
+	if err != nil || len(got) != 2 {
+		t.Fatalf("Observe = %v, %v; want the search response", got, err)
+	}
+
+	if len(observer.searches) != 1 || observer.searches[0] != search {
+		t.Errorf("searches = %+v, want %+v", observer.searches, search)
+	}
+	if len(observer.results) != 1 {
+		t.Fatalf("results = %+v, want one", observer.results)
+	}
+	if result := observer.results[0]; result.Count != 2 || result.TotalCount != 7 || result.Err != nil || result.Duration < 0 {
+		t.Errorf("result = %+v, want 2 of 7 without error", result)
+	}
+}
+
+func TestObserveReportsAFailedSearch(t *testing.T) {
+	observer := &recorder{}
+	failure := errors.New("store unavailable")
+
+	_, err := Observe(context.Background(), observer, Search{Kind: KindPosts}, func() ([]int, error) {
+		return nil, failure
+	}, func(ids []int) (int, int) {
+		t.Error("a failed search was counted")
+		return 0, 0
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("Observe error = %v, want %v", err, failure)
+	}
+	if len(observer.results) != 1 || !errors.Is(observer.results[0].Err, failure) || observer.results[0].Count != 0 {
+		t.Errorf("results = %+v, want the failure", observer.results)
+	}
+}
+
+func TestDefaultIsNopUntilSet(t *testing.T) {
+	if _, ok := Default().(Nop); !ok {
+		t.Fatalf("Default = %T, want Nop", Default())
+	}
+
+	observer := &recorder{}
+	SetDefault(observer)
+	defer SetDefault(nil)
+	if Default() != SearchObserver(observer) {
+		t.Errorf("Default = %T, want the observer set", Default())
+	}
+
+	SetDefault(nil)
+	if _, ok := Default().(Nop); !ok {
+		t.Errorf("Default = %T after SetDefault(nil), want Nop", Default())
+	}
+}
@@ -0,0 +1,31 @@
+package searchterm
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Sanitize cleans a search term before it is sent upstream. Control
+// characters and invalid UTF-8 bytes are dropped, whitespace of any kind
+// (tabs, newlines, non-breaking spaces) counts as a space, and runs of
+// spaces are collapsed and trimmed. Punctuation such as & or # is kept;
+// query encoding takes care of it.
+func Sanitize(term string) string {
+	var b strings.Builder
+	b.Grow(len(term))
+	for i, r := range term {
+		switch {
+		case r == utf8.RuneError && !strings.HasPrefix(term[i:], string(utf8.RuneError)):
+			// An invalid byte, not a literal replacement character
+			continue
+		case unicode.IsSpace(r):
+			b.WriteRune(' ')
+		case unicode.IsControl(r):
+			continue
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
@@ -0,0 +1,29 @@
+package searchterm
+
+import "testing"
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name string
+		term string
+		want string
+	}{
+		{"plain", "running shoes", "running shoes"},
+		{"padded", "  \t running shoes \n", "running shoes"},
+		{"multiple spaces", "running    shoes", "running shoes"},
+		{"tabs and newlines inside", "running\t\tshoes\r\nsale", "running shoes sale"},
+		{"non-breaking space", "running\u00a0shoes", "running shoes"},
+		{"control characters", "run\x00ning\x07 sho\x1bes\x7f", "running shoes"},
+		{"invalid UTF-8", "caf\xe9 latte", "caf latte"},
+		{"replacement character kept", "a \ufffd b", "a \ufffd b"},
+		{"punctuation kept", " R&D #1 \"quoted\" 50% off? ", "R&D #1 \"quoted\" 50% off?"},
+		{"unicode kept", "crème brûlée", "crème brûlée"},
+		{"only whitespace and controls", " \t\x00\n ", ""},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		if got := Sanitize(tt.term); got != tt.want {
+			t.Errorf("%s: Sanitize(%q) = %q, want %q", tt.name, tt.term, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package storeconfig
+
+import "context"
+
+// storeKey is the context key of the store a tool call targets
+type storeKey struct{}
+
+// WithStore returns a context carrying the store, with its credentials, that
+// a tool call targets. Repositories are built from it, so search requests
+// and criteria need not carry credentials.
+func WithStore(ctx context.Context, store Store) context.Context {
+	return context.WithValue(ctx, storeKey{}, store)
+}
+
+// FromContext returns the store carried by a context; ok is false when the
+// context carries none
+func FromContext(ctx context.Context) (store Store, ok bool) {
+	store, ok = ctx.Value(storeKey{}).(Store)
+	return store, ok
+}
@@ -0,0 +1,29 @@
+package storeconfig
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStoreRoundTripsThroughTheContext(t *testing.T) {
+	if _, ok := FromContext(context.Background()); ok {
+		t.Error("a bare context carries a store")
+	}
+
+	store := Store{BaseURL: "https://shop.example", ConsumerKey: "ck_1", ConsumerSecret: "cs_1"}
+	ctx := WithStore(context.Background(), store)
+	if got, ok := FromContext(ctx); !ok || got != store {
+		t.Errorf("FromContext = %+v, %v; want %+v", got, ok, store)
+	}
+
+	// a derived context keeps the store, and a nested store replaces it
+	derived, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if got, _ := FromContext(derived); got != store {
+		t.Errorf("derived context carries %+v, want %+v", got, store)
+	}
+	other := Store{BaseURL: "https://other.example", ConsumerKey: "ck_2", ConsumerSecret: "cs_2"}
+	if got, _ := FromContext(WithStore(derived, other)); got != other {
+		t.Errorf("nested store = %+v, want %+v", got, other)
+	}
+}
@@ -0,0 +1,111 @@
+package storeconfig
+
+import (
+	"os"
+	"strings"
+)
+
+// Environment variables configuring the default store
+const (
+	BaseURLEnv        = "WC_BASE_URL"
+	ConsumerKeyEnv    = "WC_CONSUMER_KEY"
+	ConsumerSecretEnv = "WC_CONSUMER_SECRET"
+)
+
+// Store holds the location and credentials of a store
+type Store struct {
+	BaseURL        string
+	ConsumerKey    string
+	ConsumerSecret string
+}
+
+// Default returns the default store configured by the environment. Fields
+// that are not configured are empty.
+func Default() Store {
+	return Store{
+		BaseURL:        strings.TrimSpace(os.Getenv(BaseURLEnv)),
+		ConsumerKey:    strings.TrimSpace(os.Getenv(ConsumerKeyEnv)),
+		ConsumerSecret: strings.TrimSpace(os.Getenv(ConsumerSecretEnv)),
+	}
+}
+
+// HasCredentials reports whether the default store is fully configured
+func (s Store) HasCredentials() bool {
+	return s.BaseURL != "" && s.ConsumerKey != "" && s.ConsumerSecret != ""
+}
+
+// ApplyDefaults trims the tool arguments, so a pasted credential padded
+// with whitespace or a newline still works and a blank one counts as
+// missing, then fills empty ones from the default store; explicit arguments
+// always win. The default credentials are only used for the default store,
+// so they are never sent to another base URL.
+func ApplyDefaults(baseURL, consumerKey, consumerSecret *string) {
+	trimSpace(baseURL, consumerKey, consumerSecret)
+
+	store := Default()
+	if store.BaseURL == "" {
+		return
+	}
+
+	if *baseURL == "" {
+		*baseURL = store.BaseURL
+	}
+	if !sameStore(*baseURL, store.BaseURL) {
+		return
+	}
+
+	if *consumerKey == "" {
+		*consumerKey = store.ConsumerKey
+	}
+	if *consumerSecret == "" {
+		*consumerSecret = store.ConsumerSecret
+	}
+}
+
+// ApplyDefaultBaseURL trims the base URL and fills it from the default
+// store when empty, for tools that need no credentials
+func ApplyDefaultBaseURL(baseURL *string) {
+	trimSpace(baseURL)
+	if *baseURL == "" {
+		*baseURL = Default().BaseURL
+	}
+}
+
+// trimSpace trims surrounding whitespace, including tabs and newlines, from
+// each argument in place
+func trimSpace(values ...*string) {
+	for _, value := range values {
+		*value = strings.TrimSpace(*value)
+	}
+}
+
+// RequiredFields drops the store fields the default store provides from a
+// tool's list of required input fields
+func RequiredFields(fields ...string) []string {
+	store := Default()
+
+	required := make([]string, 0, len(fields))
+	for _, field := range fields {
+		switch field {
+		case "base_url":
+			if store.BaseURL != "" {
+				continue
+			}
+		case "consumer_key", "consumer_secret":
+			if store.HasCredentials() {
+				continue
+			}
+		}
+		required = append(required, field)
+	}
+	return required
+}
+
+// sameStore compares base URLs ignoring case, surrounding whitespace and
+// trailing slashes
+func sameStore(a, b string) bool {
+	normalize := func(baseURL string) string {
+		return strings.TrimRight(strings.ToLower(strings.TrimSpace(baseURL)), "/")
+	}
+	return normalize(a) == normalize(b)
+}
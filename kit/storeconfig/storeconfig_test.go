@@ -0,0 +1,98 @@
+package storeconfig
+
+import (
+	"strings"
+	"testing"
+)
+
+// setDefaultStore configures the default store for the test
+func setDefaultStore(t *testing.T, baseURL, consumerKey, consumerSecret string) {
+	t.Helper()
+	t.Setenv(BaseURLEnv, baseURL)
+	t.Setenv(ConsumerKeyEnv, consumerKey)
+	t.Setenv(ConsumerSecretEnv, consumerSecret)
+}
+
+func TestApplyDefaults(t *testing.T) {
+	tests := []struct {
+		name       string
+		env        [3]string
+		args, want [3]string
+	}{
+		{
+			name: "env only",
+			env:  [3]string{"https://shop.example", "ck_env", "cs_env"},
+			args: [3]string{"", "", ""},
+			want: [3]string{"https://shop.example", "ck_env", "cs_env"},
+		},
+		{
+			name: "explicit credentials override",
+			env:  [3]string{"https://shop.example", "ck_env", "cs_env"},
+			args: [3]string{"https://SHOP.example/", " ck_arg\n", "cs_arg"},
+			want: [3]string{"https://SHOP.example/", "ck_arg", "cs_arg"},
+		},
+		{
+			name: "another store never gets the default credentials",
+			env:  [3]string{"https://shop.example", "ck_env", "cs_env"},
+			args: [3]string{"https://other.example", "", ""},
+			want: [3]string{"https://other.example", "", ""},
+		},
+		{
+			name: "whitespace-only credentials count as missing",
+			env:  [3]string{"https://shop.example", "ck_env", "cs_env"},
+			args: [3]string{" \t", "   ", "\n"},
+			want: [3]string{"https://shop.example", "ck_env", "cs_env"},
+		},
+		{
+			name: "tab and newline padding is trimmed",
+			args: [3]string{"\thttps://other.example\n", "\tck_arg\r\n", " cs_arg\t"},
+			want: [3]string{"https://other.example", "ck_arg", "cs_arg"},
+		},
+		{
+			name: "nothing configured",
+			args: [3]string{"  ", "", "\t"},
+			want: [3]string{"", "", ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setDefaultStore(t, tt.env[0], tt.env[1], tt.env[2])
+			baseURL, consumerKey, consumerSecret := tt.args[0], tt.args[1], tt.args[2]
+			ApplyDefaults(&baseURL, &consumerKey, &consumerSecret)
+			if got := [3]string{baseURL, consumerKey, consumerSecret}; got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyDefaultBaseURL(t *testing.T) {
+	setDefaultStore(t, "https://shop.example", "", "")
+
+	baseURL := " "
+	ApplyDefaultBaseURL(&baseURL)
+	if baseURL != "https://shop.example" {
+		t.Errorf("base URL = %q, want the default store", baseURL)
+	}
+}
+
+func TestRequiredFields(t *testing.T) {
+	tests := []struct {
+		name string
+		env  [3]string
+		want string
+	}{
+		{name: "nothing configured", want: "base_url,consumer_key,consumer_secret,product_id"},
+		{name: "base URL only", env: [3]string{"https://shop.example", "", ""}, want: "consumer_key,consumer_secret,product_id"},
+		{name: "full store", env: [3]string{"https://shop.example", "ck_env", "cs_env"}, want: "product_id"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setDefaultStore(t, tt.env[0], tt.env[1], tt.env[2])
+			got := strings.Join(RequiredFields("base_url", "consumer_key", "consumer_secret", "product_id"), ",")
+			if got != tt.want {
+				t.Errorf("required = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
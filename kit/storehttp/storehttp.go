@@ -0,0 +1,95 @@
+// Package storehttp holds the HTTP plumbing every store API client shares:
+// normalizing the store base URL and resolving REST routes against it, the
+// outbound transport with its proxy and TLS settings, and keeping
+// credentials sent in query strings out of error messages.
+package storehttp
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"woocommerce-mcp/kit/tlsconfig"
+)
+
+// ProxyURLEnv is the environment variable holding the outbound proxy URL
+const ProxyURLEnv = "HTTP_PROXY_URL"
+
+// ProxyURL returns the outbound proxy URL configured by the environment
+func ProxyURL() string {
+	return os.Getenv(ProxyURLEnv)
+}
+
+// NormalizeBaseURL trims trailing slashes and an accidental "/wp-json" suffix
+// while keeping any path prefix (e.g. a multisite subsite like /shop)
+func NormalizeBaseURL(baseURL string) string {
+	normalized := strings.TrimRight(strings.TrimSpace(baseURL), "/")
+	normalized = strings.TrimSuffix(normalized, "/wp-json")
+	return strings.TrimRight(normalized, "/")
+}
+
+// RouteURL resolves a REST route against a store base URL, appending it
+// under wp-json relative to any path prefix of the store, e.g.
+// https://example.com/shop/wp-json/wc/v3/products. The error describes an
+// invalid base URL, for the client to wrap in its own error type.
+func RouteURL(baseURL, route string) (*url.URL, error) {
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %v", err)
+	}
+	if base.Scheme == "" || base.Host == "" {
+		return nil, errors.New("invalid base URL: scheme and host are required")
+	}
+	return base.JoinPath("wp-json", route), nil
+}
+
+// NewTransport creates an HTTP transport that uses the given proxy URL, or
+// the proxy environment variables when it is empty
+func NewTransport(proxyURL string) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+
+	if proxyURL = strings.TrimSpace(proxyURL); proxyURL != "" {
+		parsed, err := url.Parse(proxyURL)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			// Fail requests loudly rather than silently bypassing the proxy
+			transport.Proxy = func(*http.Request) (*url.URL, error) {
+				return nil, fmt.Errorf("invalid %s %q", ProxyURLEnv, proxyURL)
+			}
+		} else {
+			transport.Proxy = http.ProxyURL(parsed)
+		}
+	}
+
+	// Development stores may use self-signed certificates
+	tlsconfig.Apply(transport)
+
+	return transport
+}
+
+// RedactedURL returns a URL without its query string, which holds the
+// WooCommerce credentials and possibly search terms such as an email
+// address. A URL that does not parse is left out altogether.
+func RedactedURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "(invalid URL)"
+	}
+	u.RawQuery = ""
+	u.Fragment = ""
+	u.User = nil
+	return u.String()
+}
+
+// RequestError returns the cause of a failed request without the full URL
+// a *url.Error embeds
+func RequestError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
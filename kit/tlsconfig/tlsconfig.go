@@ -0,0 +1,94 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// Environment variables relaxing TLS verification for development stores.
+// Neither is meant for production.
+const (
+	// InsecureSkipVerifyEnv disables certificate verification altogether
+	InsecureSkipVerifyEnv = "INSECURE_SKIP_TLS_VERIFY"
+	// CACertFileEnv names a PEM file of extra CA certificates to trust,
+	// e.g. the CA that signed a staging store's certificate
+	CACertFileEnv = "CA_CERT_FILE"
+)
+
+// insecureWarning makes sure disabled verification is reported, once
+var insecureWarning sync.Once
+
+// InsecureSkipVerify reports whether certificate verification is disabled;
+// only an explicit true value disables it
+func InsecureSkipVerify() bool {
+	insecure, err := strconv.ParseBool(os.Getenv(InsecureSkipVerifyEnv))
+	return err == nil && insecure
+}
+
+// WarnIfInsecure logs a warning, once per process, when certificate
+// verification is disabled
+func WarnIfInsecure() {
+	if !InsecureSkipVerify() {
+		return
+	}
+	insecureWarning.Do(func() {
+		log.Printf("WARNING: %s is set; TLS certificates of stores are NOT verified. Never use this in production.", InsecureSkipVerifyEnv)
+	})
+}
+
+// Apply configures the TLS settings of an outbound transport from the
+// environment. Without either variable the transport is left untouched.
+func Apply(transport *http.Transport) {
+	insecure := InsecureSkipVerify()
+	caFile := os.Getenv(CACertFileEnv)
+	if !insecure && caFile == "" {
+		return
+	}
+
+	config := transport.TLSClientConfig
+	if config == nil {
+		config = &tls.Config{}
+	} else {
+		config = config.Clone()
+	}
+
+	if insecure {
+		WarnIfInsecure()
+		config.InsecureSkipVerify = true
+	}
+
+	if caFile != "" {
+		pool, err := certPool(caFile)
+		if err != nil {
+			// Keep the system roots; requests to the store then fail verification
+			log.Printf("Ignoring %s: %v", CACertFileEnv, err)
+		} else {
+			config.RootCAs = pool
+		}
+	}
+
+	transport.TLSClientConfig = config
+}
+
+// certPool returns the system roots extended with the certificates of a PEM file
+func certPool(caFile string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no PEM certificates found in %s", caFile)
+	}
+	return pool, nil
+}
@@ -0,0 +1,75 @@
+package tlsconfig
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// get requests a URL through a transport configured from the environment
+func get(url string) error {
+	transport := &http.Transport{}
+	Apply(transport)
+	resp, err := (&http.Client{Transport: transport}).Get(url)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func TestSelfSignedStoreNeedsTheFlag(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	t.Setenv(InsecureSkipVerifyEnv, "")
+	if err := get(server.URL); err == nil {
+		t.Error("verification on: a self-signed certificate was accepted")
+	}
+
+	for _, value := range []string{"yes", "false"} {
+		t.Setenv(InsecureSkipVerifyEnv, value)
+		if InsecureSkipVerify() {
+			t.Errorf("%s=%s disables verification, want only true to", InsecureSkipVerifyEnv, value)
+		}
+	}
+
+	t.Setenv(InsecureSkipVerifyEnv, "true")
+	if err := get(server.URL); err != nil {
+		t.Errorf("verification off: %v", err)
+	}
+}
+
+func TestCACertFileTrustsTheStoreCertificate(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	certificate := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, certificate, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(CACertFileEnv, caFile)
+	if err := get(server.URL); err != nil {
+		t.Errorf("%s trusting the store: %v", CACertFileEnv, err)
+	}
+
+	// An unusable file keeps verification against the system roots
+	t.Setenv(CACertFileEnv, filepath.Join(t.TempDir(), "missing.pem"))
+	if err := get(server.URL); err == nil {
+		t.Errorf("missing %s: a self-signed certificate was accepted", CACertFileEnv)
+	}
+}
+
+func TestTransportIsUntouchedByDefault(t *testing.T) {
+	t.Setenv(InsecureSkipVerifyEnv, "")
+	t.Setenv(CACertFileEnv, "")
+	transport := &http.Transport{}
+	Apply(transport)
+	if transport.TLSClientConfig != nil {
+		t.Errorf("TLS config = %+v, want none", transport.TLSClientConfig)
+	}
+}
@@ -0,0 +1,137 @@
+// Package toolargs lets tool inputs whose fields are strings also accept
+// the JSON numbers and booleans MCP clients and language models often send,
+// e.g. {"per_page": 10} or {"on_sale": true} instead of "10" and "true".
+package toolargs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+)
+
+// Unmarshal decodes a JSON object into out, a pointer to a struct, turning
+// numbers and booleans given for its string fields into their JSON text,
+// e.g. 10 into "10". Other values are decoded as encoding/json would.
+//
+// Inputs call it from their UnmarshalJSON through a type without that
+// method, so it does not recurse:
+//
+//	func (in *Input) UnmarshalJSON(data []byte) error {
+//		type plain Input
+//		return toolargs.Unmarshal(data, (*plain)(in))
+//	}
+func Unmarshal(data []byte, out interface{}) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil || fields == nil {
+		// Not an object; let encoding/json report it
+		return json.Unmarshal(data, out)
+	}
+
+	stringFields := stringFieldNames(reflect.TypeOf(out).Elem())
+	for name, raw := range fields {
+		if !stringFields[name] {
+			continue
+		}
+		if scalar, ok := scalarText(raw); ok {
+			quoted, err := json.Marshal(scalar)
+			if err != nil {
+				return err
+			}
+			fields[name] = quoted
+		}
+	}
+
+	coerced, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(coerced, out)
+}
+
+// scalarText returns the text of a JSON number or boolean
+func scalarText(raw json.RawMessage) (string, bool) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return "", false
+	}
+	switch c := raw[0]; {
+	case c == 't' || c == 'f':
+		return string(raw), string(raw) == "true" || string(raw) == "false"
+	case c == '-' || (c >= '0' && c <= '9'):
+		var number json.Number
+		if err := json.Unmarshal(raw, &number); err != nil {
+			return "", false
+		}
+		return number.String(), true
+	default:
+		return "", false
+	}
+}
+
+// stringFieldNames returns the JSON names of the string and *string fields
+// of a struct type
+func stringFieldNames(t reflect.Type) map[string]bool {
+	names := make(map[string]bool)
+	if t.Kind() != reflect.Struct {
+		return names
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if !field.IsExported() || fieldType.Kind() != reflect.String {
+			continue
+		}
+
+		name := field.Name
+		if tag := field.Tag.Get("json"); tag != "" {
+			tagName, _, _ := strings.Cut(tag, ",")
+			if tagName == "-" {
+				continue
+			}
+			if tagName != "" {
+				name = tagName
+			}
+		}
+		names[name] = true
+	}
+	return names
+}
+
+// InputSchema infers the input schema of a tool like the MCP server does,
+// then lets its string properties also be numbers and booleans, so the
+// server accepts what Unmarshal can decode. It panics when the type has no
+// schema, as registering the tool would.
+func InputSchema[T any]() *jsonschema.Schema {
+	schema, err := jsonschema.For[T](&jsonschema.ForOptions{})
+	if err != nil {
+		panic(fmt.Sprintf("toolargs: input schema of %T: %v", *new(T), err))
+	}
+
+	for _, property := range schema.Properties {
+		switch {
+		case property.Type == "string":
+			property.Type = ""
+			property.Types = []string{"string", "number", "boolean"}
+		case containsString(property.Types, "string"):
+			property.Types = append(property.Types, "number", "boolean")
+		}
+	}
+	return schema
+}
+
+// containsString reports whether a list holds a value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
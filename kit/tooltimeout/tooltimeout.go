@@ -0,0 +1,49 @@
+package tooltimeout
+
+import (
+	"context"
+	"errors"
+	"os"
+	"time"
+
+	kitDomain "woocommerce-mcp/kit/domain"
+)
+
+// Env is the environment variable holding the tool call deadline, as a Go
+// duration (e.g. "90s")
+const Env = "TOOL_TIMEOUT"
+
+// Default is the deadline of a tool call when none is configured
+const Default = 60 * time.Second
+
+// Timeout returns the configured tool call deadline. Invalid or non-positive
+// values fall back to the default.
+func Timeout() time.Duration {
+	value := os.Getenv(Env)
+	if value == "" {
+		return Default
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		return Default
+	}
+	return timeout
+}
+
+// WithTimeout derives the context of a whole tool call. The HTTP client
+// timeout only bounds each request, while a tool may page through many; the
+// deadline bounds all of them together.
+func WithTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, Timeout())
+}
+
+// Err replaces the error of a tool call whose deadline passed with a
+// TimeoutError naming the tool; other errors are returned unchanged. The
+// context is checked rather than the error chain, since API clients report
+// aborted requests as connection errors.
+func Err(ctx context.Context, tool string, err error) error {
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return kitDomain.NewTimeoutError(tool, Timeout())
+	}
+	return err
+}
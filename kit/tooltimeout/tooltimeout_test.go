@@ -0,0 +1,50 @@
+package tooltimeout
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	kitDomain "woocommerce-mcp/kit/domain"
+)
+
+func TestTimeoutFallsBackToTheDefault(t *testing.T) {
+	for value, want := range map[string]time.Duration{
+		"":      Default,
+		"90s":   90 * time.Second,
+		"500ms": 500 * time.Millisecond,
+		"soon":  Default,
+		"0s":    Default,
+		"-1s":   Default,
+	} {
+		t.Setenv(Env, value)
+		if got := Timeout(); got != want {
+			t.Errorf("%s=%q: timeout = %s, want %s", Env, value, got, want)
+		}
+	}
+}
+
+func TestErrNamesTheToolOnlyPastTheDeadline(t *testing.T) {
+	t.Setenv(Env, "10ms")
+	upstream := errors.New("connection error: context deadline exceeded")
+
+	ctx, cancel := WithTimeout(context.Background())
+	defer cancel()
+	if err := Err(ctx, "search_products", upstream); err != upstream {
+		t.Errorf("before the deadline: got %v, want the error unchanged", err)
+	}
+	if err := Err(ctx, "search_products", nil); err != nil {
+		t.Errorf("no error: got %v", err)
+	}
+
+	<-ctx.Done()
+	err := Err(ctx, "search_products", upstream)
+	var timeoutErr *kitDomain.TimeoutError
+	if !errors.As(err, &timeoutErr) || timeoutErr.Tool != "search_products" || timeoutErr.Timeout != 10*time.Millisecond {
+		t.Errorf("past the deadline: got %v, want a TimeoutError naming search_products", err)
+	}
+	if err := Err(ctx, "search_products", nil); err != nil {
+		t.Errorf("past the deadline without an error: got %v", err)
+	}
+}
@@ -0,0 +1,135 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"woocommerce-mcp/internal/post/application/search_posts"
+	post_presentation "woocommerce-mcp/internal/post/presentation"
+	"woocommerce-mcp/internal/product/application/search_products"
+	"woocommerce-mcp/internal/product/domain"
+	product_presentation "woocommerce-mcp/internal/product/presentation"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// SearchProductsInput is the input of the search_products tool
+type SearchProductsInput = product_presentation.SearchProductsInput
+
+// SearchPostsInput is the input of the search_posts tool
+type SearchPostsInput = post_presentation.SearchPostsInput
+
+// Client is a typed client for the WooCommerce MCP tools. It wraps an
+// established MCP client session and takes care of marshaling tool
+// arguments and decoding tool results into the response DTOs.
+type Client struct {
+	session *mcp.ClientSession
+}
+
+// New creates a new Client on top of a connected MCP client session
+func New(session *mcp.ClientSession) *Client {
+	return &Client{
+		session: session,
+	}
+}
+
+// Close closes the underlying MCP session
+func (c *Client) Close() error {
+	return c.session.Close()
+}
+
+// SearchProducts calls the search_products tool. The data is decoded into a
+// SearchResponse, so inputs that change its shape (a format other than json,
+// fields or group_by) are rejected; call the tool directly to use them.
+func (c *Client) SearchProducts(ctx context.Context, input SearchProductsInput) (*search_products.SearchResponse, error) {
+	if err := checkDecodable(input); err != nil {
+		return nil, err
+	}
+
+	var output product_presentation.SearchProductsOutput
+	if err := c.callTool(ctx, "search_products", input, &output); err != nil {
+		return nil, err
+	}
+
+	var response search_products.SearchResponse
+	if err := json.Unmarshal([]byte(output.Data), &response); err != nil {
+		return nil, fmt.Errorf("failed to decode search_products data: %w", err)
+	}
+
+	return &response, nil
+}
+
+// SearchPosts calls the search_posts tool
+func (c *Client) SearchPosts(ctx context.Context, input SearchPostsInput) (*search_posts.SearchResponse, error) {
+	var output post_presentation.SearchPostsOutput
+	if err := c.callTool(ctx, "search_posts", input, &output); err != nil {
+		return nil, err
+	}
+
+	var response search_posts.SearchResponse
+	if err := json.Unmarshal([]byte(output.Data), &response); err != nil {
+		return nil, fmt.Errorf("failed to decode search_posts data: %w", err)
+	}
+
+	return &response, nil
+}
+
+// checkDecodable rejects search_products inputs whose data does not decode
+// into a complete SearchResponse
+func checkDecodable(input SearchProductsInput) error {
+	if format := strings.ToLower(strings.TrimSpace(input.Format)); format != "" && format != search_products.FormatJSON {
+		return domain.NewProductValidationError("format", fmt.Sprintf("format %q is not supported by SearchProducts, which decodes JSON data", input.Format))
+	}
+	if strings.TrimSpace(input.Fields) != "" {
+		return domain.NewProductValidationError("fields", "fields is not supported by SearchProducts, which decodes complete products")
+	}
+	if strings.TrimSpace(input.GroupBy) != "" {
+		return domain.NewProductValidationError("group_by", "group_by is not supported by SearchProducts, which decodes the flat product list")
+	}
+	return nil
+}
+
+// callTool calls a tool and decodes its structured output
+func (c *Client) callTool(ctx context.Context, name string, input interface{}, output interface{}) error {
+	result, err := c.session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      name,
+		Arguments: input,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to call %s tool: %w", name, err)
+	}
+
+	if result.IsError {
+		return fmt.Errorf("%s tool returned an error: %s", name, resultText(result))
+	}
+
+	// Prefer the structured output, falling back to the JSON text content
+	var raw []byte
+	if result.StructuredContent != nil {
+		raw, err = json.Marshal(result.StructuredContent)
+		if err != nil {
+			return fmt.Errorf("failed to read %s result: %w", name, err)
+		}
+	} else {
+		raw = []byte(resultText(result))
+	}
+
+	if err := json.Unmarshal(raw, output); err != nil {
+		return fmt.Errorf("failed to decode %s result: %w", name, err)
+	}
+
+	return nil
+}
+
+// resultText concatenates the text content of a tool result
+func resultText(result *mcp.CallToolResult) string {
+	var text strings.Builder
+	for _, content := range result.Content {
+		if textContent, ok := content.(*mcp.TextContent); ok {
+			text.WriteString(textContent.Text)
+		}
+	}
+	return text.String()
+}
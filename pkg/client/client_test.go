@@ -0,0 +1,135 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	post_presentation "woocommerce-mcp/internal/post/presentation"
+	"woocommerce-mcp/internal/product/domain"
+	product_presentation "woocommerce-mcp/internal/product/presentation"
+	"woocommerce-mcp/internal/testutil/fakestore"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// connectInProcess serves the search tools from an in-process MCP server
+// and returns a typed client connected to it
+func connectInProcess(t *testing.T) *Client {
+	t.Helper()
+	ctx := context.Background()
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "woocommerce-mcp", Version: "test"}, nil)
+	productHandler := product_presentation.NewSearchProductsHandler()
+	mcp.AddTool(server, productHandler.GetToolDefinition(), productHandler.ExecuteMCPTool)
+	postHandler := post_presentation.NewSearchPostsHandler()
+	mcp.AddTool(server, postHandler.GetToolDefinition(), postHandler.ExecuteMCPTool)
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatalf("connect server: %v", err)
+	}
+	t.Cleanup(func() { serverSession.Close() })
+
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "client-test", Version: "test"}, nil).Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("connect client: %v", err)
+	}
+	client := New(session)
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+// startStore starts a fake store holding the default fixtures
+func startStore(t *testing.T) (*fakestore.Store, string) {
+	t.Helper()
+	store := fakestore.New()
+	server := store.Start()
+	t.Cleanup(server.Close)
+	return store, server.URL
+}
+
+func TestSearchProductsDecodesResponse(t *testing.T) {
+	client := connectInProcess(t)
+	_, storeURL := startStore(t)
+
+	response, err := client.SearchProducts(context.Background(), SearchProductsInput{
+		BaseURL:        storeURL,
+		ConsumerKey:    fakestore.ConsumerKey,
+		ConsumerSecret: fakestore.ConsumerSecret,
+		Search:         "Sneakers",
+	})
+	if err != nil {
+		t.Fatalf("SearchProducts: %v", err)
+	}
+	if len(response.Products) == 0 || !strings.Contains(response.Products[0].Name, "Sneakers") {
+		t.Errorf("got products %+v, want the sneakers", response.Products)
+	}
+}
+
+func TestSearchPostsDecodesResponse(t *testing.T) {
+	client := connectInProcess(t)
+	_, storeURL := startStore(t)
+
+	response, err := client.SearchPosts(context.Background(), SearchPostsInput{
+		BaseURL: storeURL,
+		Search:  "Running",
+	})
+	if err != nil {
+		t.Fatalf("SearchPosts: %v", err)
+	}
+	if len(response.Posts) != 1 || response.Posts[0].Title != "Running Tips" {
+		t.Errorf("got posts %+v, want Running Tips", response.Posts)
+	}
+}
+
+func TestSearchProductsRejectsReshapedData(t *testing.T) {
+	client := connectInProcess(t)
+	store, storeURL := startStore(t)
+
+	inputs := map[string]SearchProductsInput{
+		"format":   {Format: "markdown"},
+		"fields":   {Fields: "name,price"},
+		"group_by": {GroupBy: "category"},
+	}
+	for field, input := range inputs {
+		input.BaseURL = storeURL
+		input.ConsumerKey = fakestore.ConsumerKey
+		input.ConsumerSecret = fakestore.ConsumerSecret
+
+		_, err := client.SearchProducts(context.Background(), input)
+		var validationErr *domain.ProductValidationError
+		if !errors.As(err, &validationErr) || validationErr.Field != field {
+			t.Errorf("%s: got error %v, want a validation error for %s", field, err, field)
+		}
+	}
+	if requests := store.Requests(); len(requests) != 0 {
+		t.Errorf("rejected calls reached the store: %v", requests)
+	}
+
+	// An explicit json format is what SearchProducts decodes
+	if _, err := client.SearchProducts(context.Background(), SearchProductsInput{
+		BaseURL:        storeURL,
+		ConsumerKey:    fakestore.ConsumerKey,
+		ConsumerSecret: fakestore.ConsumerSecret,
+		Format:         "JSON",
+	}); err != nil {
+		t.Errorf("SearchProducts with format JSON: %v", err)
+	}
+}
+
+func TestSearchProductsReportsToolErrors(t *testing.T) {
+	client := connectInProcess(t)
+	_, storeURL := startStore(t)
+
+	_, err := client.SearchProducts(context.Background(), SearchProductsInput{
+		BaseURL:        storeURL,
+		ConsumerKey:    fakestore.ConsumerKey,
+		ConsumerSecret: "cs_wrong",
+	})
+	if err == nil || !strings.Contains(err.Error(), "search_products tool returned an error") {
+		t.Errorf("got error %v, want the tool error", err)
+	}
+}